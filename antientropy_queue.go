@@ -0,0 +1,188 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/stats"
+)
+
+// Policies of the anti-entropy queue, which decide what happens to a sync
+// requested while the queue is full.
+const (
+	// AntiEntropyQueueBlock waits up to the queue timeout for room in the
+	// queue before rescheduling the sync.
+	AntiEntropyQueueBlock = "block"
+
+	// AntiEntropyQueueDrop reschedules the sync immediately.
+	AntiEntropyQueueDrop = "drop"
+)
+
+// Defaults of the anti-entropy queue.
+const (
+	defaultAntiEntropyQueueSize    = 16
+	defaultAntiEntropyQueueTimeout = time.Minute
+	defaultAntiEntropyRetryDelay   = time.Minute
+)
+
+// syncQueue bounds the number of anti-entropy syncs which are running or
+// waiting to run. Syncs which do not fit are rescheduled: they are merged
+// into a set of retries which is run later, so that data still converges.
+type syncQueue struct {
+	slots   chan struct{} // holds a token per queued sync, nil if unbounded
+	policy  string
+	timeout time.Duration // zero blocks without a limit
+
+	mu      sync.Mutex
+	depth   int
+	retries map[string]*syncRetry // by index, "" for every index
+
+	stats stats.StatsClient
+}
+
+// syncRetry is a rescheduled sync of an index.
+type syncRetry struct {
+	shards      map[uint64]struct{} // nil for every shard
+	primaryOnly bool
+}
+
+// syncRequest is a sync taken from the retries of a syncQueue.
+type syncRequest struct {
+	index       string
+	shards      []uint64
+	primaryOnly bool
+}
+
+// newSyncQueue returns a syncQueue holding up to size syncs, or any number of
+// syncs if size is not positive.
+func newSyncQueue(size int, policy string, timeout time.Duration) *syncQueue {
+	q := &syncQueue{
+		policy:  policy,
+		timeout: timeout,
+		retries: make(map[string]*syncRetry),
+		stats:   stats.NopStatsClient,
+	}
+	if size > 0 {
+		q.slots = make(chan struct{}, size)
+	}
+	return q
+}
+
+// acquire adds a sync to the queue. It returns false if the queue is full, in
+// which case the sync must be rescheduled. Under the block policy it waits up
+// to the timeout, or until closing is closed, for room in the queue.
+func (q *syncQueue) acquire(closing <-chan struct{}) bool {
+	if q.slots != nil {
+		select {
+		case q.slots <- struct{}{}:
+		default:
+			if q.policy != AntiEntropyQueueBlock {
+				return false
+			}
+			var timeoutC <-chan time.Time
+			if q.timeout > 0 {
+				timer := time.NewTimer(q.timeout)
+				defer timer.Stop()
+				timeoutC = timer.C
+			}
+			select {
+			case q.slots <- struct{}{}:
+			case <-timeoutC:
+				return false
+			case <-closing:
+				return false
+			}
+		}
+	}
+	q.updateDepth(1)
+	return true
+}
+
+// release removes a sync added by acquire from the queue.
+func (q *syncQueue) release() {
+	q.updateDepth(-1)
+	if q.slots != nil {
+		<-q.slots
+	}
+}
+
+func (q *syncQueue) updateDepth(delta int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.depth += delta
+	q.stats.Gauge("AntiEntropyQueueDepth", float64(q.depth), 1.0)
+}
+
+// Depth returns the number of syncs which are running or waiting to run.
+func (q *syncQueue) Depth() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.depth
+}
+
+// reschedule merges a sync which did not fit in the queue into the retries.
+func (q *syncQueue) reschedule(index string, shards []uint64, primaryOnly bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.stats.Count("AntiEntropyRescheduled", 1, 1.0)
+
+	// A retry of every index covers every other retry.
+	if r := q.retries[""]; r != nil {
+		r.primaryOnly = r.primaryOnly && primaryOnly
+		return
+	}
+	if index == "" {
+		for _, r := range q.retries {
+			primaryOnly = primaryOnly && r.primaryOnly
+		}
+		q.retries = map[string]*syncRetry{"": {primaryOnly: primaryOnly}}
+		return
+	}
+
+	r := q.retries[index]
+	if r == nil {
+		r = &syncRetry{shards: make(map[uint64]struct{}), primaryOnly: primaryOnly}
+		q.retries[index] = r
+	}
+	r.primaryOnly = r.primaryOnly && primaryOnly
+	if len(shards) == 0 {
+		r.shards = nil
+	} else if r.shards != nil {
+		for _, shard := range shards {
+			r.shards[shard] = struct{}{}
+		}
+	}
+}
+
+// takeRetries removes and returns the rescheduled syncs, sorted by index.
+func (q *syncQueue) takeRetries() []syncRequest {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	reqs := make([]syncRequest, 0, len(q.retries))
+	for index, r := range q.retries {
+		req := syncRequest{index: index, primaryOnly: r.primaryOnly}
+		for shard := range r.shards {
+			req.shards = append(req.shards, shard)
+		}
+		sort.Slice(req.shards, func(i, j int) bool { return req.shards[i] < req.shards[j] })
+		reqs = append(reqs, req)
+	}
+	q.retries = make(map[string]*syncRetry)
+	sort.Slice(reqs, func(i, j int) bool { return reqs[i].index < reqs[j].index })
+	return reqs
+}
@@ -0,0 +1,124 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSyncQueue(t *testing.T) {
+	t.Run("Drop", func(t *testing.T) {
+		q := newSyncQueue(2, AntiEntropyQueueDrop, time.Minute)
+		if !q.acquire(nil) || !q.acquire(nil) {
+			t.Fatal("expected room in the queue")
+		} else if q.acquire(nil) {
+			t.Fatal("expected full queue")
+		} else if n := q.Depth(); n != 2 {
+			t.Fatalf("unexpected depth: %d", n)
+		}
+
+		q.release()
+		if n := q.Depth(); n != 1 {
+			t.Fatalf("unexpected depth: %d", n)
+		} else if !q.acquire(nil) {
+			t.Fatal("expected room in the queue after release")
+		}
+	})
+
+	t.Run("Block", func(t *testing.T) {
+		q := newSyncQueue(1, AntiEntropyQueueBlock, 10*time.Millisecond)
+		if !q.acquire(nil) {
+			t.Fatal("expected room in the queue")
+		} else if q.acquire(nil) {
+			t.Fatal("expected timeout")
+		}
+
+		// A blocked sync runs once the running sync is released.
+		go func() {
+			time.Sleep(time.Millisecond)
+			q.release()
+		}()
+		q.timeout = time.Minute
+		if !q.acquire(nil) {
+			t.Fatal("expected room in the queue after release")
+		}
+
+		// Closing stops waiting.
+		closing := make(chan struct{})
+		close(closing)
+		if q.acquire(closing) {
+			t.Fatal("expected full queue")
+		}
+	})
+
+	t.Run("Unbounded", func(t *testing.T) {
+		q := newSyncQueue(0, AntiEntropyQueueDrop, 0)
+		for i := 0; i < 100; i++ {
+			if !q.acquire(nil) {
+				t.Fatal("expected room in the queue")
+			}
+		}
+		if n := q.Depth(); n != 100 {
+			t.Fatalf("unexpected depth: %d", n)
+		}
+	})
+
+	t.Run("Reschedule", func(t *testing.T) {
+		q := newSyncQueue(1, AntiEntropyQueueDrop, 0)
+		q.reschedule("i", []uint64{3, 1}, true)
+		q.reschedule("i", []uint64{2, 3}, true)
+		q.reschedule("j", []uint64{1}, true)
+		q.reschedule("j", nil, false)
+		q.reschedule("j", []uint64{4}, true)
+
+		exp := []syncRequest{
+			{index: "i", shards: []uint64{1, 2, 3}, primaryOnly: true},
+			{index: "j", primaryOnly: false},
+		}
+		if reqs := q.takeRetries(); !reflect.DeepEqual(reqs, exp) {
+			t.Fatalf("unexpected retries: %+v", reqs)
+		} else if reqs := q.takeRetries(); len(reqs) != 0 {
+			t.Fatalf("expected retries to be taken: %+v", reqs)
+		}
+
+		// A sync of every index replaces the other retries.
+		q.reschedule("i", []uint64{1}, true)
+		q.reschedule("", nil, true)
+		q.reschedule("j", nil, true)
+		exp = []syncRequest{{index: "", primaryOnly: true}}
+		if reqs := q.takeRetries(); !reflect.DeepEqual(reqs, exp) {
+			t.Fatalf("unexpected retries: %+v", reqs)
+		}
+	})
+}
+
+// Ensure a sync which does not fit in the anti-entropy queue is rescheduled.
+func TestHolderSyncer_QueueFull(t *testing.T) {
+	q := newSyncQueue(1, AntiEntropyQueueDrop, 0)
+	s := &holderSyncer{Queue: q}
+	if !q.acquire(nil) {
+		t.Fatal("expected room in the queue")
+	}
+
+	if _, err := s.syncHolder("i", []uint64{5}, true); err != ErrAntiEntropyQueueFull {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exp := []syncRequest{{index: "i", shards: []uint64{5}, primaryOnly: true}}
+	if reqs := q.takeRetries(); !reflect.DeepEqual(reqs, exp) {
+		t.Fatalf("unexpected retries: %+v", reqs)
+	}
+}
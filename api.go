@@ -24,6 +24,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -48,6 +49,12 @@ type API struct {
 	importWorkerPoolSize int
 	importWork           chan importJob
 
+	queries *runningQueries
+
+	// The writes and read queries in flight, drained on shutdown.
+	writes drainGroup
+	reads  drainGroup
+
 	Serializer Serializer
 }
 
@@ -75,6 +82,7 @@ func OptAPIImportWorkerPoolSize(size int) apiOption {
 func NewAPI(opts ...apiOption) (*API, error) {
 	api := &API{
 		importWorkerPoolSize: 2,
+		queries:              newRunningQueries(),
 	}
 
 	for _, opt := range opts {
@@ -118,10 +126,23 @@ func appendMap(a, b map[apiMethod]struct{}) map[apiMethod]struct{} {
 
 func (api *API) validate(f apiMethod) error {
 	state := api.cluster.State()
-	if _, ok := validAPIMethods[state][f]; ok {
-		return nil
+	if _, ok := validAPIMethods[state][f]; !ok {
+		return newAPIMethodNotAllowedError(errors.Errorf("api method %s not allowed in state %s", f, state))
+	}
+	if _, ok := methodsWrite[f]; ok {
+		return api.validateWritable()
+	}
+	return nil
+}
+
+// validateWritable returns a ConflictError if the node is a standby which
+// has not been promoted, and ErrNoQuorum if it is waiting for a quorum of
+// the cluster's nodes.
+func (api *API) validateWritable() error {
+	if api.server.replicator.standby() {
+		return newConflictError(ErrReadOnlyStandby)
 	}
-	return newAPIMethodNotAllowedError(errors.Errorf("api method %s not allowed in state %s", f, state))
+	return api.server.quorum.validate()
 }
 
 // Close closes the api and waits for it to shutdown.
@@ -140,40 +161,145 @@ func (api *API) Query(ctx context.Context, req *QueryRequest) (QueryResponse, er
 		return QueryResponse{}, errors.Wrap(err, "validating api method")
 	}
 
-	q, err := pql.NewParser(strings.NewReader(req.Query)).Parse()
+	// Queries from other nodes are parts of queries already checked
+	// against the limits. Any client can mark a query as remote though, so
+	// the depth limit, which guards the parser's stack, is always applied.
+	limits := api.server.queryLimits
+	if req.Remote {
+		limits = pql.Limits{MaxDepth: limits.MaxDepth}
+	}
+	q, err := pql.NewParserWithLimits(strings.NewReader(req.Query), limits).Parse()
 	if err != nil {
 		return QueryResponse{}, errors.Wrap(err, "parsing")
 	}
+	if !req.Remote && isWriteQuery(q) {
+		if err := api.validateWritable(); err != nil {
+			return QueryResponse{}, err
+		}
+	} else if !req.Remote {
+		if err := api.server.memory.validate(); err != nil {
+			return QueryResponse{}, err
+		}
+	}
+	inflight := &api.reads
+	if isWriteQuery(q) {
+		inflight = &api.writes
+	}
+	if err := inflight.enter(); err != nil {
+		return QueryResponse{}, err
+	}
+	defer inflight.leave()
+	var autoCreated bool
+	if !req.Remote && isWriteQuery(q) {
+		if autoCreated, err = api.autoCreateQuery(ctx, req.Index, q); err != nil {
+			return QueryResponse{}, err
+		}
+	}
+	if api.server.safeMode != nil && !req.Remote && !req.AllowUnbounded {
+		if idx := api.holder.Index(api.holder.resolveIndexAlias(req.Index)); idx != nil {
+			if err := api.server.safeMode.check(idx, q, req.Shards); err != nil {
+				return QueryResponse{}, err
+			}
+		}
+	}
+	var cursor *queryCursor
+	if isPaginated(req) {
+		if err := validatePaginatedQuery(q); err != nil {
+			return QueryResponse{}, err
+		}
+		if req.Cursor != "" {
+			if cursor, err = decodeQueryCursor(req.Cursor); err != nil {
+				return QueryResponse{}, err
+			}
+		}
+	}
+
+	// Serve read-only queries from the cache when possible. The key is built
+	// before executing since execution translates the calls in place.
+	var cacheKey string
+	var cacheIdx *Index
+	var cacheGen uint64
+	if idx := api.holder.Index(api.holder.resolveIndexAlias(req.Index)); idx != nil {
+		if key, ok := api.cacheableQuery(idx, q, req); ok {
+			indexTag := fmt.Sprintf("index:%s", idx.Name())
+			if resp, ok := api.server.queryCache.get(key, idx, time.Now()); ok {
+				api.holder.Stats.CountWithCustomTags("queryCacheHit", 1, 1.0, []string{indexTag})
+				if isPaginated(req) {
+					return paginate(resp, req.Limit, cursor)
+				}
+				return resp, nil
+			}
+			api.holder.Stats.CountWithCustomTags("queryCacheMiss", 1, 1.0, []string{indexTag})
+			cacheKey, cacheIdx, cacheGen = key, idx, idx.generation.load()
+		}
+	}
+
 	execOpts := &execOptions{
 		Remote:          req.Remote,
 		ExcludeRowAttrs: req.ExcludeRowAttrs, // NOTE: Kept for Pilosa 1.x compat.
 		ExcludeColumns:  req.ExcludeColumns,  // NOTE: Kept for Pilosa 1.x compat.
 		ColumnAttrs:     req.ColumnAttrs,     // NOTE: Kept for Pilosa 1.x compat.
+		MaxResultRows:   req.MaxResultRows,
 	}
-	resp, err := api.server.executor.Execute(ctx, req.Index, q, req.Shards, execOpts)
-	if err != nil {
+	ctx, running := api.queries.register(ctx, req)
+	resp, err := api.server.executor.Execute(ctx, api.holder.resolveIndexAlias(req.Index), q, req.Shards, execOpts)
+	if cancelled := api.queries.deregister(running); cancelled {
+		return QueryResponse{}, ErrQueryCancelledByOperator
+	} else if err != nil {
 		return QueryResponse{}, errors.Wrap(err, "executing")
 	}
+	resp.AutoCreated = autoCreated
+	if !req.Remote && resp.Err == nil {
+		if err := api.server.warmup.record(api.holder.resolveIndexAlias(req.Index), q, req.Shards); err != nil {
+			api.server.logger.Printf("recording warmup query: %v", err)
+		}
+	}
+	if cacheIdx != nil && resp.Err == nil && !resp.Partial() {
+		api.server.queryCache.add(cacheKey, cacheIdx, cacheGen, resp, time.Now())
+	}
+	if isPaginated(req) && resp.Err == nil {
+		return paginate(resp, req.Limit, cursor)
+	}
 
 	return resp, nil
 }
 
 // CreateIndex makes a new Pilosa index.
 func (api *API) CreateIndex(ctx context.Context, indexName string, options IndexOptions) (*Index, error) {
-	span, _ := tracing.StartSpanFromContext(ctx, "API.CreateIndex")
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.CreateIndex")
 	defer span.Finish()
 
 	if err := api.validate(apiCreateIndex); err != nil {
 		return nil, errors.Wrap(err, "validating api method")
 	}
 
+	api.holder.schemaMu.Lock()
+	defer api.holder.schemaMu.Unlock()
+	return api.createIndex(ctx, indexName, options)
+}
+
+// createIndex is CreateIndex for callers holding the schema lock.
+func (api *API) createIndex(ctx context.Context, indexName string, options IndexOptions) (*Index, error) {
+	if !options.TimeQuantum.Valid() {
+		return nil, NewBadRequestError(ErrInvalidTimeQuantum)
+	} else if !ValidCompression(options.Compression) {
+		return nil, NewBadRequestError(ErrInvalidCompression)
+	} else if !ValidDurability(options.Durability) {
+		return nil, NewBadRequestError(ErrInvalidDurability)
+	}
+	if api.holder.Index(indexName) == nil {
+		if err := api.server.limits.checkIndex(api.holder); err != nil {
+			return nil, NewBadRequestError(err)
+		}
+	}
+
 	// Create index.
 	index, err := api.holder.CreateIndex(indexName, options)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating index")
 	}
 	// Send the create index message to all nodes.
-	err = api.server.SendSync(
+	err = api.sendSchemaChange(ctx,
 		&CreateIndexMessage{
 			Index: indexName,
 			Meta:  &options,
@@ -204,20 +330,23 @@ func (api *API) Index(ctx context.Context, indexName string) (*Index, error) {
 // DeleteIndex removes the named index. If the index is not found it does
 // nothing and returns no error.
 func (api *API) DeleteIndex(ctx context.Context, indexName string) error {
-	span, _ := tracing.StartSpanFromContext(ctx, "API.DeleteIndex")
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.DeleteIndex")
 	defer span.Finish()
 
 	if err := api.validate(apiDeleteIndex); err != nil {
 		return errors.Wrap(err, "validating api method")
 	}
 
+	api.holder.schemaMu.Lock()
+	defer api.holder.schemaMu.Unlock()
+
 	// Delete index from the holder.
 	err := api.holder.DeleteIndex(indexName)
 	if err != nil {
 		return errors.Wrap(err, "deleting index")
 	}
 	// Send the delete index message to all nodes.
-	err = api.server.SendSync(
+	err = api.sendSchemaChange(ctx,
 		&DeleteIndexMessage{
 			Index: indexName,
 		})
@@ -229,17 +358,315 @@ func (api *API) DeleteIndex(ctx context.Context, indexName string) error {
 	return nil
 }
 
+// UndeleteIndex restores a deleted index whose tombstone has not yet expired
+// across the cluster.
+func (api *API) UndeleteIndex(ctx context.Context, indexName string) (*Index, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.UndeleteIndex")
+	defer span.Finish()
+
+	if err := api.validate(apiUndeleteIndex); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	} else if err := api.cluster.checkMessageProtocol(messageTypeUndeleteIndex); err != nil {
+		return nil, err
+	}
+
+	api.holder.schemaMu.Lock()
+	defer api.holder.schemaMu.Unlock()
+
+	if err := api.server.limits.checkIndex(api.holder); err != nil {
+		return nil, NewBadRequestError(err)
+	}
+	index, err := api.holder.UndeleteIndex(indexName)
+	if err != nil {
+		return nil, errors.Wrap(err, "undeleting index")
+	}
+	// Send the undelete index message to all nodes.
+	err = api.sendSchemaChange(ctx,
+		&UndeleteIndexMessage{
+			Index: indexName,
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "sending UndeleteIndex message")
+	}
+	api.holder.Stats.Count("undeleteIndex", 1, 1.0)
+	return index, nil
+}
+
+// IndexTombstones returns the deleted indexes on this node which can still be
+// undeleted.
+func (api *API) IndexTombstones(ctx context.Context) ([]IndexTombstone, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.IndexTombstones")
+	defer span.Finish()
+	return api.holder.Tombstones()
+}
+
+// SetIndexTimeQuantum changes the default time quantum of the named index
+// across the cluster. Only time fields created afterwards are affected.
+func (api *API) SetIndexTimeQuantum(ctx context.Context, indexName string, q TimeQuantum) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.SetIndexTimeQuantum")
+	defer span.Finish()
+
+	if err := api.validate(apiUpdateIndex); err != nil {
+		return errors.Wrap(err, "validating api method")
+	} else if err := api.cluster.checkMessageProtocol(messageTypeUpdateIndex); err != nil {
+		return err
+	}
+
+	if !q.Valid() {
+		return NewBadRequestError(ErrInvalidTimeQuantum)
+	}
+
+	index := api.holder.Index(indexName)
+	if index == nil {
+		return newNotFoundError(ErrIndexNotFound, indexName)
+	}
+	options := index.Options()
+	options.TimeQuantum = q
+	if err := index.setOptions(options); err != nil {
+		return errors.Wrap(err, "updating index")
+	}
+	// Send the updated options to all nodes.
+	err := api.sendSchemaChange(ctx,
+		&UpdateIndexMessage{
+			Index: indexName,
+			Meta:  &options,
+		})
+	if err != nil {
+		api.server.logger.Printf("problem sending UpdateIndex message: %s", err)
+		return errors.Wrap(err, "sending UpdateIndex message")
+	}
+	return nil
+}
+
+// SetIndexCompression changes the compression of the data files of the named
+// index across the cluster. Each fragment is converted the next time its data
+// file is rewritten.
+func (api *API) SetIndexCompression(ctx context.Context, indexName string, compression string) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.SetIndexCompression")
+	defer span.Finish()
+
+	if err := api.validate(apiUpdateIndex); err != nil {
+		return errors.Wrap(err, "validating api method")
+	} else if err := api.cluster.checkMessageProtocol(messageTypeUpdateIndex); err != nil {
+		return err
+	}
+
+	if !ValidCompression(compression) {
+		return NewBadRequestError(ErrInvalidCompression)
+	}
+
+	index := api.holder.Index(indexName)
+	if index == nil {
+		return newNotFoundError(ErrIndexNotFound, indexName)
+	}
+	options := index.Options()
+	options.Compression = compression
+	if err := index.setOptions(options); err != nil {
+		return errors.Wrap(err, "updating index")
+	}
+	// Send the updated options to all nodes.
+	err := api.sendSchemaChange(ctx,
+		&UpdateIndexMessage{
+			Index: indexName,
+			Meta:  &options,
+		})
+	if err != nil {
+		api.server.logger.Printf("problem sending UpdateIndex message: %s", err)
+		return errors.Wrap(err, "sending UpdateIndex message")
+	}
+	return nil
+}
+
+// IndexAliases returns the mapping of index aliases to index names.
+func (api *API) IndexAliases(ctx context.Context) map[string]string {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.IndexAliases")
+	defer span.Finish()
+	return api.holder.IndexAliases()
+}
+
+// SetIndexAlias points alias at the named index across the cluster. Queries
+// against the alias are executed against the index. Repointing an existing
+// alias takes effect atomically for subsequent queries.
+func (api *API) SetIndexAlias(ctx context.Context, alias, indexName string) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.SetIndexAlias")
+	defer span.Finish()
+
+	if err := api.validate(apiSetIndexAlias); err != nil {
+		return errors.Wrap(err, "validating api method")
+	} else if err := api.cluster.checkMessageProtocol(messageTypeSetIndexAlias); err != nil {
+		return err
+	}
+
+	api.holder.schemaMu.Lock()
+	defer api.holder.schemaMu.Unlock()
+
+	if err := api.holder.SetIndexAlias(alias, indexName); err != nil {
+		return errors.Wrap(err, "setting index alias")
+	}
+	// Send the alias to all nodes.
+	err := api.sendSchemaChange(ctx,
+		&SetIndexAliasMessage{
+			Alias: alias,
+			Index: indexName,
+		})
+	if err != nil {
+		api.server.logger.Printf("problem sending SetIndexAlias message: %s", err)
+		return errors.Wrap(err, "sending SetIndexAlias message")
+	}
+	return nil
+}
+
+// DeleteIndexAlias removes an index alias across the cluster.
+func (api *API) DeleteIndexAlias(ctx context.Context, alias string) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.DeleteIndexAlias")
+	defer span.Finish()
+
+	if err := api.validate(apiDeleteIndexAlias); err != nil {
+		return errors.Wrap(err, "validating api method")
+	} else if err := api.cluster.checkMessageProtocol(messageTypeSetIndexAlias); err != nil {
+		return err
+	}
+
+	api.holder.schemaMu.Lock()
+	defer api.holder.schemaMu.Unlock()
+
+	if err := api.holder.DeleteIndexAlias(alias); err != nil {
+		return errors.Wrap(err, "deleting index alias")
+	}
+	// Send the removal to all nodes.
+	err := api.sendSchemaChange(ctx,
+		&SetIndexAliasMessage{
+			Alias: alias,
+		})
+	if err != nil {
+		api.server.logger.Printf("problem sending SetIndexAlias message: %s", err)
+		return errors.Wrap(err, "sending SetIndexAlias message")
+	}
+	return nil
+}
+
+// RenameIndex renames an index across the cluster. Renames are made on the
+// coordinator, which serializes them with the other schema changes made
+// through it; other nodes return a BadRequestError naming the coordinator. A
+// new name which is taken by an index or an alias is rejected. If a node
+// fails to apply the rename, it is rolled back on the coordinator and on the
+// nodes which applied it.
+func (api *API) RenameIndex(ctx context.Context, indexName, newName string) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.RenameIndex")
+	defer span.Finish()
+
+	if err := api.validate(apiRename); err != nil {
+		return errors.Wrap(err, "validating api method")
+	} else if err := api.checkRenameCoordinator(); err != nil {
+		return err
+	} else if err := api.cluster.checkMessageProtocol(messageTypeRename); err != nil {
+		return err
+	}
+
+	api.holder.schemaMu.Lock()
+	defer api.holder.schemaMu.Unlock()
+
+	if err := api.holder.RenameIndex(indexName, newName); err != nil {
+		return errors.Wrap(err, "renaming index")
+	}
+	// Send the rename to all nodes.
+	err := api.sendSchemaChange(ctx,
+		&RenameMessage{
+			Index:   indexName,
+			NewName: newName,
+		})
+	if err != nil {
+		api.server.logger.Printf("problem sending Rename message: %s", err)
+		if rerr := api.holder.RenameIndex(newName, indexName); rerr != nil {
+			api.server.logger.Printf("rolling back rename of index %s: %s", indexName, rerr)
+		}
+		api.rollbackRename(ctx, &RenameMessage{Index: newName, NewName: indexName})
+		return errors.Wrap(err, "sending Rename message")
+	}
+	return nil
+}
+
+// RenameField renames a field of an index across the cluster, like
+// RenameIndex.
+func (api *API) RenameField(ctx context.Context, indexName, fieldName, newName string) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.RenameField")
+	defer span.Finish()
+
+	if err := api.validate(apiRename); err != nil {
+		return errors.Wrap(err, "validating api method")
+	} else if err := api.checkRenameCoordinator(); err != nil {
+		return err
+	} else if err := api.cluster.checkMessageProtocol(messageTypeRename); err != nil {
+		return err
+	}
+
+	api.holder.schemaMu.Lock()
+	defer api.holder.schemaMu.Unlock()
+
+	index := api.holder.Index(indexName)
+	if index == nil {
+		return newNotFoundError(ErrIndexNotFound, indexName)
+	}
+	if err := index.RenameField(fieldName, newName); err != nil {
+		return errors.Wrap(err, "renaming field")
+	}
+	// Send the rename to all nodes.
+	err := api.sendSchemaChange(ctx,
+		&RenameMessage{
+			Index:   indexName,
+			Field:   fieldName,
+			NewName: newName,
+		})
+	if err != nil {
+		api.server.logger.Printf("problem sending Rename message: %s", err)
+		if rerr := index.RenameField(newName, fieldName); rerr != nil {
+			api.server.logger.Printf("rolling back rename of field %s/%s: %s", indexName, fieldName, rerr)
+		}
+		api.rollbackRename(ctx, &RenameMessage{Index: indexName, Field: newName, NewName: fieldName})
+		return errors.Wrap(err, "sending Rename message")
+	}
+	return nil
+}
+
+// rollbackRename sends m, the reverse of a rename which some nodes failed to
+// apply, to all nodes. Nodes which did not apply the rename ignore it. Nodes
+// which cannot be reached keep the rename until they pull the schema.
+func (api *API) rollbackRename(ctx context.Context, m *RenameMessage) {
+	if err := api.sendSchemaChange(ctx, m); err != nil {
+		api.server.logger.Printf("problem sending Rename rollback message: %s", err)
+	}
+}
+
+// checkRenameCoordinator returns a BadRequestError wrapping
+// ErrNodeNotCoordinator unless this node is the coordinator.
+func (api *API) checkRenameCoordinator() error {
+	if api.cluster.isCoordinator() {
+		return nil
+	}
+	if node := api.cluster.coordinatorNode(); node != nil {
+		return NewBadRequestError(errors.Wrapf(ErrNodeNotCoordinator, "renames are made on the coordinator %s", node.URI))
+	}
+	return NewBadRequestError(ErrNodeNotCoordinator)
+}
+
 // CreateField makes the named field in the named index with the given options.
 // This method currently only takes a single functional option, but that may be
 // changed in the future to support multiple options.
 func (api *API) CreateField(ctx context.Context, indexName string, fieldName string, opts ...FieldOption) (*Field, error) {
-	span, _ := tracing.StartSpanFromContext(ctx, "API.CreateField")
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.CreateField")
 	defer span.Finish()
 
 	if err := api.validate(apiCreateField); err != nil {
 		return nil, errors.Wrap(err, "validating api method")
 	}
 
+	api.holder.schemaMu.Lock()
+	defer api.holder.schemaMu.Unlock()
+	return api.createField(ctx, indexName, fieldName, opts...)
+}
+
+// createField is CreateField for callers holding the schema lock.
+func (api *API) createField(ctx context.Context, indexName string, fieldName string, opts ...FieldOption) (*Field, error) {
 	// Apply functional options.
 	fo := FieldOptions{}
 	for _, opt := range opts {
@@ -255,14 +682,22 @@ func (api *API) CreateField(ctx context.Context, indexName string, fieldName str
 		return nil, newNotFoundError(ErrIndexNotFound, indexName)
 	}
 
+	if index.Field(fieldName) == nil {
+		if err := api.server.limits.checkField(index); err != nil {
+			return nil, NewBadRequestError(err)
+		}
+	}
+
 	// Create field.
 	field, err := index.CreateField(fieldName, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating field")
 	}
-
-	// Send the create field message to all nodes.
-	err = api.server.SendSync(
+	// Send the create field message to all nodes. The field's own options
+	// are sent, rather than those requested, so that any inherited from the
+	// index are fixed at creation.
+	fo = field.Options()
+	err = api.sendSchemaChange(ctx,
 		&CreateFieldMessage{
 			Index: indexName,
 			Field: fieldName,
@@ -373,13 +808,30 @@ func (api *API) ImportRoaring(ctx context.Context, indexName, fieldName string,
 	if err = api.validate(apiField); err != nil {
 		return errors.Wrap(err, "validating api method")
 	}
+	if !remote {
+		if err := api.validateWritable(); err != nil {
+			return err
+		}
+	}
+	if err := api.writes.enter(); err != nil {
+		return err
+	}
+	defer api.writes.leave()
 
+	// Tag forwarded imports with the epoch of the topology the replicas
+	// are chosen from.
+	ctx = WithTopologyEpoch(ctx, api.cluster.Epoch())
 	nodes := api.cluster.shardNodes(indexName, shard)
 
-	field := api.holder.Field(indexName, fieldName)
-	if field == nil {
-		return newNotFoundError(ErrFieldNotFound, fieldName)
+	ctx, _, field, end, err := api.importIndexField(ctx, indexName, fieldName, shard)
+	if err != nil {
+		return err
 	}
+	defer func() {
+		if end() && err != nil {
+			err = ErrIndexRenaming
+		}
+	}()
 
 	// only set and time fields are supported
 	if field.Type() != FieldTypeSet && field.Type() != FieldTypeTime {
@@ -431,13 +883,16 @@ func (api *API) ImportRoaring(ctx context.Context, indexName, fieldName string,
 // found, an error is returned. If the field is not found, it is ignored and no
 // action is taken.
 func (api *API) DeleteField(ctx context.Context, indexName string, fieldName string) error {
-	span, _ := tracing.StartSpanFromContext(ctx, "API.DeleteField")
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.DeleteField")
 	defer span.Finish()
 
 	if err := api.validate(apiDeleteField); err != nil {
 		return errors.Wrap(err, "validating api method")
 	}
 
+	api.holder.schemaMu.Lock()
+	defer api.holder.schemaMu.Unlock()
+
 	// Find index.
 	index := api.holder.Index(indexName)
 	if index == nil {
@@ -448,9 +903,8 @@ func (api *API) DeleteField(ctx context.Context, indexName string, fieldName str
 	if err := index.DeleteField(fieldName); err != nil {
 		return errors.Wrap(err, "deleting field")
 	}
-
 	// Send the delete field message to all nodes.
-	err := api.server.SendSync(
+	err := api.sendSchemaChange(ctx,
 		&DeleteFieldMessage{
 			Index: indexName,
 			Field: fieldName,
@@ -464,7 +918,7 @@ func (api *API) DeleteField(ctx context.Context, indexName string, fieldName str
 }
 
 // DeleteAvailableShard a shard ID from the available shard set cache.
-func (api *API) DeleteAvailableShard(_ context.Context, indexName, fieldName string, shardID uint64) error {
+func (api *API) DeleteAvailableShard(ctx context.Context, indexName, fieldName string, shardID uint64) error {
 	if err := api.validate(apiDeleteAvailableShard); err != nil {
 		return errors.Wrap(err, "validating api method")
 	}
@@ -481,7 +935,7 @@ func (api *API) DeleteAvailableShard(_ context.Context, indexName, fieldName str
 	}
 
 	// Send the delete shard message to all nodes.
-	err := api.server.SendSync(
+	err := api.server.SendSyncContext(ctx,
 		&DeleteAvailableShardMessage{
 			Index:   indexName,
 			Field:   fieldName,
@@ -573,74 +1027,319 @@ func (api *API) ExportCSV(ctx context.Context, indexName string, fieldName strin
 	return nil
 }
 
-// ShardNodes returns the node and all replicas which should contain a shard's data.
-func (api *API) ShardNodes(ctx context.Context, indexName string, shard uint64) ([]*Node, error) {
-	span, _ := tracing.StartSpanFromContext(ctx, "API.ShardNodes")
+// RowColumns calls fn with the IDs of the columns set in a row of a field, in
+// ascending order, one shard at a time so that the columns of the whole row
+// are never held in memory at once. The row is an ID, or a key if the field
+// uses keys. Only columns in [start, end) are included; an end of zero
+// includes all columns from start.
+func (api *API) RowColumns(ctx context.Context, indexName, fieldName string, row interface{}, start, end uint64, fn func(columns []uint64) error) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.RowColumns")
 	defer span.Finish()
 
-	if err := api.validate(apiShardNodes); err != nil {
-		return nil, errors.Wrap(err, "validating api method")
+	if err := api.validate(apiRowColumns); err != nil {
+		return errors.Wrap(err, "validating api method")
 	}
 
-	return api.cluster.shardNodes(indexName, shard), nil
-}
-
-// FragmentBlockData is an endpoint for internal usage. It is not guaranteed to
-// return anything useful. Currently it returns protobuf encoded row and column
-// ids from a "block" which is a subdivision of a fragment.
-func (api *API) FragmentBlockData(ctx context.Context, body io.Reader) ([]byte, error) {
-	span, _ := tracing.StartSpanFromContext(ctx, "API.FragmentBlockData")
-	defer span.Finish()
-
-	if err := api.validate(apiFragmentBlockData); err != nil {
-		return nil, errors.Wrap(err, "validating api method")
+	index := api.holder.Index(indexName)
+	if index == nil {
+		return newNotFoundError(ErrIndexNotFound, indexName)
+	} else if index.Keys() {
+		return NewBadRequestError(errors.New("columns of an index with keys cannot be streamed"))
 	}
-
-	reqBytes, err := ioutil.ReadAll(body)
-	if err != nil {
-		return nil, NewBadRequestError(errors.Wrap(err, "read body error"))
+	field := index.Field(fieldName)
+	if field == nil {
+		return newNotFoundError(ErrFieldNotFound, fieldName)
+	} else if field.Type() == FieldTypeInt || field.Type() == FieldTypeTimestamp {
+		return NewBadRequestError(errors.Errorf("field %s is an int field", fieldName))
+	}
+	switch row.(type) {
+	case uint64:
+	case string:
+		if !field.keys() {
+			return NewBadRequestError(errors.Errorf("field %s does not use keys", fieldName))
+		}
+	default:
+		return NewBadRequestError(errors.Errorf("invalid row: %v", row))
 	}
-	var req BlockDataRequest
-	if err := api.Serializer.Unmarshal(reqBytes, &req); err != nil {
-		return nil, NewBadRequestError(errors.Wrap(err, "unmarshal body error"))
+	if end != 0 && end <= start {
+		return NewBadRequestError(errors.Errorf("invalid column range: [%d, %d)", start, end))
 	}
 
-	// Retrieve fragment from holder.
-	f := api.holder.fragment(req.Index, req.Field, req.View, req.Shard)
-	if f == nil {
-		return nil, ErrFragmentNotFound
-	}
+	for _, shard := range index.AvailableShards().Slice() {
+		if shard < start/ShardWidth {
+			continue
+		} else if end != 0 && shard > (end-1)/ShardWidth {
+			break
+		}
 
-	var resp = BlockDataResponse{}
-	resp.RowIDs, resp.ColumnIDs = f.blockData(int(req.Block))
+		q := &pql.Query{Calls: []*pql.Call{{Name: "Row", Args: map[string]interface{}{fieldName: row}}}}
+		resp, err := api.server.executor.Execute(ctx, indexName, q, []uint64{shard}, &execOptions{ExcludeRowAttrs: true})
+		if err != nil {
+			return errors.Wrapf(err, "reading shard %d", shard)
+		}
 
-	// Encode response.
-	buf, err := api.Serializer.Marshal(&resp)
-	if err != nil {
-		return nil, errors.Wrap(err, "merge block response encoding error")
+		columns := resp.Results[0].(*Row).Columns()
+		columns = columns[sort.Search(len(columns), func(i int) bool { return columns[i] >= start }):]
+		if end != 0 {
+			n := sort.Search(len(columns), func(i int) bool { return columns[i] >= end })
+			columns = columns[:n]
+		}
+		if len(columns) == 0 {
+			continue
+		}
+		if err := fn(columns); err != nil {
+			return err
+		}
 	}
-
-	return buf, nil
+	return nil
 }
 
-// FragmentBlocks returns the checksums and block ids for all blocks in the specified fragment.
-func (api *API) FragmentBlocks(ctx context.Context, indexName, fieldName, viewName string, shard uint64) ([]FragmentBlock, error) {
-	span, _ := tracing.StartSpanFromContext(ctx, "API.FragmentBlocks")
+// Changes calls fn with the bits set and cleared on the node as they are
+// applied, in batches, starting at the event with sequence from. A from of
+// zero starts at the next event. Only the events of the given index and
+// field are passed, unless these are empty. fn is first called with no
+// events once from is known to be held, so that callers can start their
+// response. Changes returns once ctx is done, or a ResyncRequiredError if
+// from is not held or the events are not read fast enough to stay in the
+// feed.
+func (api *API) Changes(ctx context.Context, from uint64, indexName, fieldName string, fn func(events []ChangeEvent) error) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.Changes")
 	defer span.Finish()
 
-	if err := api.validate(apiFragmentBlocks); err != nil {
-		return nil, errors.Wrap(err, "validating api method")
+	if err := api.validate(apiChanges); err != nil {
+		return errors.Wrap(err, "validating api method")
 	}
 
-	// Retrieve fragment from holder.
-	f := api.holder.fragment(indexName, fieldName, viewName, shard)
-	if f == nil {
-		return nil, ErrFragmentNotFound
+	feed := api.holder.changes
+	if feed == nil {
+		return NewBadRequestError(ErrChangeFeedDisabled)
+	}
+	if indexName != "" {
+		indexName = api.holder.resolveIndexAlias(indexName)
+	}
+	if from == 0 {
+		from = feed.nextSeq()
+	}
+	if _, _, err := feed.read(from, 0); err != nil {
+		return err
+	}
+	if err := fn(nil); err != nil {
+		return err
+	}
+
+	for {
+		events, wait, err := feed.read(from, changeBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			select {
+			case <-wait:
+				continue
+			case <-ctx.Done():
+				return nil
+			case <-api.holder.closing:
+				return nil
+			}
+		}
+		from = events[len(events)-1].Seq + 1
+
+		if indexName != "" || fieldName != "" {
+			n := 0
+			for _, e := range events {
+				if (indexName == "" || e.Index == indexName) && (fieldName == "" || e.Field == fieldName) {
+					events[n] = e
+					n++
+				}
+			}
+			events = events[:n]
+		}
+		if len(events) == 0 {
+			continue
+		}
+		if err := fn(events); err != nil {
+			return err
+		}
+	}
+}
+
+// ShardNodes returns the node and all replicas which should contain a shard's data.
+func (api *API) ShardNodes(ctx context.Context, indexName string, shard uint64) ([]*Node, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.ShardNodes")
+	defer span.Finish()
+
+	if err := api.validate(apiShardNodes); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	return api.cluster.shardNodes(indexName, shard), nil
+}
+
+// ShardOwners describes the nodes which own a shard of an index, for clients
+// routing requests directly to them. Nodes are only valid at Epoch; clients
+// should look them up again once the topology epoch changes.
+type ShardOwners struct {
+	Index string `json:"index"`
+	Shard uint64 `json:"shard"`
+
+	// Column and Key are the column, or column key, of which the shard was
+	// looked up, if any.
+	Column *uint64 `json:"column,omitempty"`
+	Key    string  `json:"key,omitempty"`
+
+	Epoch    uint64  `json:"epoch"`
+	Primary  *Node   `json:"primary"`
+	Replicas []*Node `json:"replicas"`
+}
+
+// ShardOwners returns the primary and replica nodes of a shard of the named
+// index. It returns a NotFoundError if the index does not exist.
+func (api *API) ShardOwners(ctx context.Context, indexName string, shard uint64) (*ShardOwners, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.ShardOwners")
+	defer span.Finish()
+
+	if err := api.validate(apiShardNodes); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	indexName = api.holder.resolveIndexAlias(indexName)
+	if api.holder.Index(indexName) == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, indexName)
+	}
+	nodes, epoch := api.cluster.shardOwners(indexName, shard)
+	o := &ShardOwners{Index: indexName, Shard: shard, Epoch: epoch, Replicas: []*Node{}}
+	if len(nodes) > 0 {
+		o.Primary, o.Replicas = nodes[0], nodes[1:]
+	}
+	return o, nil
+}
+
+// ColumnOwners returns the primary and replica nodes of the shard of a column
+// of the named index. If key is set, the shard is that of the column with the
+// key. The key is looked up without being created, so a key which was never
+// written returns a NotFoundError.
+func (api *API) ColumnOwners(ctx context.Context, indexName string, column uint64, key string) (*ShardOwners, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.ColumnOwners")
+	defer span.Finish()
+
+	if err := api.validate(apiShardNodes); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	if key != "" {
+		index := api.holder.Index(api.holder.resolveIndexAlias(indexName))
+		if index == nil {
+			return nil, newNotFoundError(ErrIndexNotFound, indexName)
+		} else if !index.Keys() {
+			return nil, NewBadRequestError(errors.Errorf("index %s does not use column keys", index.Name()))
+		}
+		id, err := index.TranslateStore().FindKey(key)
+		if err != nil {
+			return nil, errors.Wrap(err, "finding column key")
+		} else if id == 0 {
+			return nil, newNotFoundError(ErrColumnKeyNotFound, key)
+		}
+		column = id
+	}
+
+	o, err := api.ShardOwners(ctx, indexName, column/ShardWidth)
+	if err != nil {
+		return nil, err
+	}
+	o.Column, o.Key = &column, key
+	return o, nil
+}
+
+// ShardDistribution returns the number of shards each node owns, as primary
+// and as replica, for every index, along with an overall balance score.
+func (api *API) ShardDistribution(ctx context.Context) (*ShardDistribution, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.ShardDistribution")
+	defer span.Finish()
+
+	if err := api.validate(apiShardDistribution); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	return api.cluster.shardDistribution(api.holder.availableShardsByIndex()), nil
+}
+
+// ClusterTopology returns the membership and shard placement of the cluster
+// as a portable document, which bootstraps a new cluster with the same
+// topology.
+func (api *API) ClusterTopology(ctx context.Context) (*ClusterTopology, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.ClusterTopology")
+	defer span.Finish()
+
+	if err := api.validate(apiClusterTopology); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	return api.cluster.exportTopology(), nil
+}
+
+// FragmentBlockData is an endpoint for internal usage. It is not guaranteed to
+// return anything useful. Currently it returns protobuf encoded row and column
+// ids from a "block" which is a subdivision of a fragment.
+func (api *API) FragmentBlockData(ctx context.Context, body io.Reader) ([]byte, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.FragmentBlockData")
+	defer span.Finish()
+
+	if err := api.validate(apiFragmentBlockData); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	reqBytes, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "read body error"))
+	}
+	var req BlockDataRequest
+	if err := api.Serializer.Unmarshal(reqBytes, &req); err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "unmarshal body error"))
+	}
+
+	// Retrieve fragment from holder.
+	f := api.holder.fragment(req.Index, req.Field, req.View, req.Shard)
+	if f == nil {
+		return nil, ErrFragmentNotFound
+	}
+
+	var resp = BlockDataResponse{}
+	resp.RowIDs, resp.ColumnIDs = f.blockData(int(req.Block))
+
+	// Encode response.
+	buf, err := api.Serializer.Marshal(&resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "merge block response encoding error")
+	}
+
+	return buf, nil
+}
+
+// FragmentBlocks returns the checksums and block ids for all blocks in the
+// specified fragment. The checksums are computed with the requested algorithm
+// if this node's anti-entropy uses it too, otherwise with ChecksumStandard,
+// so that both sides of a sync agree. The algorithm used is returned.
+func (api *API) FragmentBlocks(ctx context.Context, indexName, fieldName, viewName string, shard uint64, checksum string) ([]FragmentBlock, string, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.FragmentBlocks")
+	defer span.Finish()
+
+	if err := api.validate(apiFragmentBlocks); err != nil {
+		return nil, "", errors.Wrap(err, "validating api method")
+	}
+
+	// Retrieve fragment from holder.
+	f := api.holder.fragment(indexName, fieldName, viewName, shard)
+	if f == nil {
+		return nil, "", ErrFragmentNotFound
+	}
+
+	if checksum != api.server.antiEntropyChecksum {
+		checksum = ChecksumStandard
 	}
 
 	// Retrieve blocks.
-	blocks := f.Blocks()
-	return blocks, nil
+	blocks := f.BlocksWithChecksum(checksum)
+	return blocks, checksum, nil
 }
 
 // FragmentData returns all data in the specified fragment.
@@ -668,6 +1367,46 @@ func (api *API) Hosts(ctx context.Context) []*Node {
 	return api.cluster.Nodes()
 }
 
+// TopologyEpoch returns the epoch of the cluster topology known to this node.
+// It is incremented on every change of the cluster membership.
+func (api *API) TopologyEpoch() uint64 {
+	return api.cluster.Epoch()
+}
+
+// ClockSkew returns the clock skew of each node of the cluster whose clock
+// differs from this node's by more than the clock skew threshold, by node ID.
+func (api *API) ClockSkew() map[string]time.Duration {
+	skews := api.server.clockSkew.skewed()
+	for id := range skews {
+		if api.cluster.nodeByID(id) == nil {
+			delete(skews, id)
+		}
+	}
+	return skews
+}
+
+// ProtocolVersion returns the protocol version of the cluster: the highest
+// version spoken by all its nodes, or zero if some node speaks no common
+// version with this one.
+func (api *API) ProtocolVersion() int {
+	return api.cluster.protocolVersion()
+}
+
+// CircuitBreakers returns the state of the circuit breakers of the peers
+// whose last queries from this node failed.
+func (api *API) CircuitBreakers() []CircuitBreakerStatus {
+	statuses := api.server.breaker.statuses()
+	for i := range statuses {
+		for _, node := range api.cluster.Nodes() {
+			if node.URI.HostPort() == statuses[i].Host {
+				statuses[i].NodeID = node.ID
+				break
+			}
+		}
+	}
+	return statuses
+}
+
 // Node gets the ID, URI and coordinator status for this particular node.
 func (api *API) Node() *Node {
 	node := api.server.node()
@@ -676,14 +1415,14 @@ func (api *API) Node() *Node {
 
 // RecalculateCaches forces all TopN caches to be updated. Used mainly for integration tests.
 func (api *API) RecalculateCaches(ctx context.Context) error {
-	span, _ := tracing.StartSpanFromContext(ctx, "API.RecalculateCaches")
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.RecalculateCaches")
 	defer span.Finish()
 
 	if err := api.validate(apiRecalculateCaches); err != nil {
 		return errors.Wrap(err, "validating api method")
 	}
 
-	err := api.server.SendSync(&RecalculateCaches{})
+	err := api.server.SendSyncContext(ctx, &RecalculateCaches{})
 	if err != nil {
 		return errors.Wrap(err, "broacasting message")
 	}
@@ -691,6 +1430,122 @@ func (api *API) RecalculateCaches(ctx context.Context) error {
 	return nil
 }
 
+// SyncAntiEntropy runs an anti-entropy sync of the given shards of index, or
+// of every shard if none are given, or of every index if index is empty. Each
+// shard is synced by its first available owner. Unless remote is set the
+// request is forwarded to the other available nodes in the cluster. It returns
+// the number of bits which were reconciled, and an error naming the nodes
+// which could not be reached.
+func (api *API) SyncAntiEntropy(ctx context.Context, index string, shards []uint64, remote bool) (int, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.SyncAntiEntropy")
+	defer span.Finish()
+
+	if err := api.validate(apiSyncAntiEntropy); err != nil {
+		return 0, errors.Wrap(err, "validating api method")
+	}
+
+	if index == "" && len(shards) > 0 {
+		return 0, NewBadRequestError(errors.New("index is required when syncing shards"))
+	} else if index != "" && api.holder.Index(index) == nil {
+		return 0, newNotFoundError(ErrIndexNotFound, index)
+	}
+
+	total, err := api.server.syncer.syncHolder(index, shards, true)
+	if err != nil {
+		return total, errors.Wrap(err, "syncing holder")
+	}
+
+	if remote {
+		return total, nil
+	}
+
+	// Nodes which are down are skipped, since the next owners of their shards
+	// sync them. Every other node is asked, even once one cannot be reached.
+	var failed []string
+	var ferr error
+	for _, node := range api.cluster.Nodes() {
+		if node.ID == api.server.nodeID || !api.cluster.nodeAvailable(node) {
+			continue
+		}
+		n, err := api.server.defaultClient.SyncAntiEntropy(ctx, &node.URI, index, shards, true)
+		if err != nil {
+			if ferr == nil {
+				ferr = err
+			}
+			failed = append(failed, node.ID)
+			continue
+		}
+		total += n
+	}
+	if ferr != nil {
+		return total, errors.Wrapf(ferr, "forwarding anti-entropy sync to nodes %s", strings.Join(failed, ", "))
+	}
+	return total, nil
+}
+
+// SelfHeal starts pulling the shards which this node owns, but holds no data
+// for, from their replicas. It returns immediately; the progress is reported
+// by SelfHealStatus.
+func (api *API) SelfHeal(ctx context.Context) (SelfHealStatus, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.SelfHeal")
+	defer span.Finish()
+
+	if err := api.validate(apiSelfHeal); err != nil {
+		return SelfHealStatus{}, errors.Wrap(err, "validating api method")
+	}
+	return api.server.startSelfHeal()
+}
+
+// ReplicationStatus returns the progress of replication from the upstream
+// cluster of a standby.
+func (api *API) ReplicationStatus(ctx context.Context) ReplicationStatus {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.ReplicationStatus")
+	defer span.Finish()
+	return api.server.replicator.Status()
+}
+
+// PromoteStandby stops replication from the upstream cluster and makes the
+// node writable. Every node of a standby cluster must be promoted.
+func (api *API) PromoteStandby(ctx context.Context) (ReplicationStatus, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.PromoteStandby")
+	defer span.Finish()
+
+	if err := api.validate(apiPromoteStandby); err != nil {
+		return ReplicationStatus{}, errors.Wrap(err, "validating api method")
+	}
+	return api.server.promoteStandby()
+}
+
+// SelfHealStatus returns the progress of the most recent self-heal.
+func (api *API) SelfHealStatus(ctx context.Context) SelfHealStatus {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.SelfHealStatus")
+	defer span.Finish()
+	return api.server.selfHealer.Status()
+}
+
+// Queries returns the queries being executed by this node, oldest first.
+func (api *API) Queries(ctx context.Context) ([]QueryInfo, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.Queries")
+	defer span.Finish()
+
+	if err := api.validate(apiQueries); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	return api.queries.list(), nil
+}
+
+// CancelQuery cancels the running query with the given ID. The query fails
+// with ErrQueryCancelledByOperator.
+func (api *API) CancelQuery(ctx context.Context, id string) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.CancelQuery")
+	defer span.Finish()
+
+	if err := api.validate(apiQueries); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+	return api.queries.cancel(id)
+}
+
 // ClusterMessage is for internal use. It decodes a protobuf message out of
 // the body and forwards it to the BroadcastHandler.
 func (api *API) ClusterMessage(ctx context.Context, reqBody io.Reader) error {
@@ -729,12 +1584,60 @@ func (api *API) Schema(ctx context.Context) []*IndexInfo {
 	return api.holder.limitedSchema()
 }
 
+// FullSchema returns the schema of every index, including internal fields,
+// the views of each field, and index aliases.
+func (api *API) FullSchema(ctx context.Context) *Schema {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.FullSchema")
+	defer span.Finish()
+	return &Schema{Indexes: api.holder.Schema(), Aliases: api.holder.IndexAliases()}
+}
+
+// QuarantinedFragments returns the fragments on this node which could not be
+// opened and were moved aside.
+func (api *API) QuarantinedFragments(ctx context.Context) []QuarantinedFragment {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.QuarantinedFragments")
+	defer span.Finish()
+	return api.holder.QuarantinedFragments()
+}
+
+// CompactFragments rewrites the fragments of an index on this node in their
+// most compact form, optionally restricted to a field, a view and a set of
+// shards. Queries against the fragments continue while they are compacted.
+func (api *API) CompactFragments(ctx context.Context, index, field, view string, shards []uint64) (CompactionResult, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.CompactFragments")
+	defer span.Finish()
+
+	if err := api.validate(apiCompactFragments); err != nil {
+		return CompactionResult{}, errors.Wrap(err, "validating api method")
+	}
+	if index == "" {
+		return CompactionResult{}, NewBadRequestError(ErrIndexRequired)
+	} else if field == "" && view != "" {
+		return CompactionResult{}, NewBadRequestError(errors.New("field is required when compacting a view"))
+	}
+	return api.holder.compactFragments(index, field, view, shards)
+}
+
+// Usage returns the number of fragments, disk usage, and approximate memory
+// usage of each index on this node, or of the given index only, by index
+// name.
+func (api *API) Usage(ctx context.Context, index string) (map[string]IndexUsage, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.Usage")
+	defer span.Finish()
+
+	if err := api.validate(apiUsage); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+	return api.holder.Usage(index)
+}
+
 // ApplySchema takes the given schema and applies it across the
 // cluster (if remote is false), or just to this node (if remote is
 // true). This is designed for the use case of replicating a schema
 // from one Pilosa cluster to another which is initially empty. It is
 // not officially supported in other scenarios and may produce
-// surprising results.
+// surprising results. The new indexes and fields of the schema are
+// checked against the schema limits of this node.
 func (api *API) ApplySchema(ctx context.Context, s *Schema, remote bool) error {
 	span, _ := tracing.StartSpanFromContext(ctx, "API.ApplySchema")
 	defer span.Finish()
@@ -743,17 +1646,241 @@ func (api *API) ApplySchema(ctx context.Context, s *Schema, remote bool) error {
 		return errors.Wrap(err, "validating api method")
 	}
 
-	if !remote {
-		nodes := api.cluster.Nodes()
-		for i, node := range nodes {
-			err := api.server.defaultClient.PostSchema(ctx, &node.URI, s, true)
+	if remote {
+		return api.holder.applySchema(s, nil)
+	}
+
+	// Apply the schema here first, so that it is checked against the limits
+	// before any other node changes.
+	api.holder.schemaMu.Lock()
+	defer api.holder.schemaMu.Unlock()
+	if err := api.holder.applySchema(s, api.server.limits); err != nil {
+		return err
+	}
+
+	v := api.schemaChanged()
+	nodes := api.cluster.Nodes()
+	for i, node := range nodes {
+		err := api.server.defaultClient.PostSchema(ctx, &node.URI, s, true)
+		if err != nil {
+			return errors.Wrapf(err, "forwarding post schema to node %d of %d", i+1, len(nodes))
+		}
+	}
+	api.sendSchemaVersion(ctx, v)
+	return nil
+}
+
+// IndexDefinition describes an index, and the fields in it, to be created
+// by CreateSchema.
+type IndexDefinition struct {
+	Name    string
+	Options IndexOptions
+	Fields  []FieldDefinition
+}
+
+// FieldDefinition describes a field to be created by CreateSchema.
+type FieldDefinition struct {
+	Name    string
+	Options []FieldOption
+}
+
+// CreateSchemaResult reports the outcome of creating one index or field. Field
+// is empty for an index.
+type CreateSchemaResult struct {
+	Index   string `json:"index"`
+	Field   string `json:"field,omitempty"`
+	Created bool   `json:"created"`
+	Error   string `json:"error,omitempty"`
+}
+
+// CreateSchema creates each of the given indexes and fields which does not
+// already exist, and broadcasts them to the cluster in a single message, or
+// in a message per item created if some node speaks a protocol version which
+// predates that message. A failure to create one item is reported in its
+// result and does not prevent the others from being created, so that the
+// same definitions can be applied again to complete a partially applied
+// schema. Items which already exist are left unchanged, even if their
+// options differ from the definition.
+func (api *API) CreateSchema(ctx context.Context, defs []IndexDefinition) ([]CreateSchemaResult, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.CreateSchema")
+	defer span.Finish()
+
+	if err := api.validate(apiCreateSchema); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	// The schema broadcast holds every defined item which exists, not only
+	// those created now, so that applying the definitions again also
+	// repairs nodes which missed an earlier broadcast.
+	var results []CreateSchemaResult
+	var created []Message
+	schema := &Schema{}
+	for _, def := range defs {
+		res := CreateSchemaResult{Index: def.Name}
+		idx, err := api.createSchemaIndex(def, &res)
+		results = append(results, res)
+		if res.Created {
+			options := idx.Options()
+			created = append(created, &CreateIndexMessage{Index: idx.Name(), Meta: &options})
+		}
+		if err != nil {
+			for _, fd := range def.Fields {
+				results = append(results, CreateSchemaResult{Index: def.Name, Field: fd.Name, Error: ErrIndexNotFound.Error()})
+			}
+			continue
+		}
+
+		info := &IndexInfo{Name: idx.Name(), Options: idx.Options()}
+		schema.Indexes = append(schema.Indexes, info)
+		for _, fd := range def.Fields {
+			res := CreateSchemaResult{Index: def.Name, Field: fd.Name}
+			field, err := api.createSchemaField(idx, fd, &res)
+			results = append(results, res)
 			if err != nil {
-				return errors.Wrapf(err, "forwarding post schema to node %d of %d", i+1, len(nodes))
+				continue
+			}
+			if res.Created {
+				options := field.Options()
+				created = append(created, &CreateFieldMessage{Index: idx.Name(), Field: field.Name(), Meta: &options})
 			}
+			info.Fields = append(info.Fields, &FieldInfo{Name: field.Name(), Options: field.Options()})
 		}
 	}
 
-	return api.holder.applySchema(s)
+	// Nodes which predate ApplySchema are sent the items created as the
+	// messages which create them one by one.
+	if api.cluster.protocolVersion() < messageProtocolVersions[messageTypeApplySchema] {
+		return results, api.sendSchemaMessages(ctx, created)
+	}
+	if len(created) > 0 {
+		if err := api.sendSchemaChange(ctx, &ApplySchemaMessage{Schema: schema}); err != nil {
+			return results, errors.Wrap(err, "sending ApplySchema message")
+		}
+	} else if len(schema.Indexes) > 0 {
+		if err := api.server.SendSyncContext(ctx, &ApplySchemaMessage{Schema: schema}); err != nil {
+			return results, errors.Wrap(err, "sending ApplySchema message")
+		}
+	}
+	return results, nil
+}
+
+// sendSchemaMessages broadcasts the messages of a schema change in order, as
+// a single change.
+func (api *API) sendSchemaMessages(ctx context.Context, msgs []Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	v := api.schemaChanged()
+	for _, m := range msgs {
+		if err := api.server.SendSyncContext(ctx, m); err != nil {
+			return errors.Wrapf(err, "sending %T", m)
+		}
+	}
+	api.sendSchemaVersion(ctx, v)
+	return nil
+}
+
+// createSchemaIndex creates the index of def if it does not exist, recording
+// the outcome in res.
+func (api *API) createSchemaIndex(def IndexDefinition, res *CreateSchemaResult) (*Index, error) {
+	if idx := api.holder.Index(def.Name); idx != nil {
+		return idx, nil
+	}
+	if !def.Options.TimeQuantum.Valid() {
+		res.Error = ErrInvalidTimeQuantum.Error()
+		return nil, ErrInvalidTimeQuantum
+	}
+	if err := api.server.limits.checkIndex(api.holder); err != nil {
+		res.Error = err.Error()
+		return nil, err
+	}
+	idx, err := api.holder.CreateIndexIfNotExists(def.Name, def.Options)
+	if err != nil {
+		res.Error = err.Error()
+		return nil, err
+	}
+	res.Created = true
+	return idx, nil
+}
+
+// createSchemaField creates the field fd in idx if it does not exist,
+// recording the outcome in res.
+func (api *API) createSchemaField(idx *Index, fd FieldDefinition, res *CreateSchemaResult) (*Field, error) {
+	if field := idx.Field(fd.Name); field != nil {
+		return field, nil
+	}
+
+	fo := FieldOptions{}
+	for _, opt := range fd.Options {
+		if err := opt(&fo); err != nil {
+			res.Error = err.Error()
+			return nil, err
+		}
+	}
+	if fo.Type == FieldTypeTime && fo.TimeQuantum == "" && idx.Options().TimeQuantum == "" {
+		err := errors.New("timeQuantum is required for field type time")
+		res.Error = err.Error()
+		return nil, err
+	}
+	if err := api.server.limits.checkField(idx); err != nil {
+		res.Error = err.Error()
+		return nil, err
+	}
+
+	field, err := idx.CreateFieldIfNotExists(fd.Name, fd.Options...)
+	if err != nil {
+		res.Error = err.Error()
+		return nil, err
+	}
+	res.Created = true
+	return field, nil
+}
+
+// SchemaVersions returns the schema version vector of this node. See
+// Holder.SchemaVersions.
+func (api *API) SchemaVersions(ctx context.Context) map[string]uint64 {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.SchemaVersions")
+	defer span.Finish()
+	return api.holder.SchemaVersions()
+}
+
+// schemaChanged records a schema change made through this node, so that
+// nodes which miss its broadcast pull the new schema once they see the new
+// version via gossip. It returns the version of the change, or zero if it
+// could not be recorded.
+func (api *API) schemaChanged() uint64 {
+	v, err := api.holder.bumpSchemaVersion(api.server.nodeID)
+	if err != nil {
+		api.server.logger.Printf("recording schema version: %s", err)
+		return 0
+	}
+	return v
+}
+
+// sendSchemaChange records a schema change made through this node and sends
+// m, which describes it, to all nodes, followed by the version of the change.
+func (api *API) sendSchemaChange(ctx context.Context, m Message) error {
+	v := api.schemaChanged()
+	if err := api.server.SendSyncContext(ctx, m); err != nil {
+		return err
+	}
+	api.sendSchemaVersion(ctx, v)
+	return nil
+}
+
+// sendSchemaVersion sends the version of a schema change made through this
+// node to all nodes which have applied it, so that they record the change as
+// seen instead of pulling the schema. A node which misses the message pulls
+// the schema once it sees the version via gossip, so failures are only
+// logged. Nodes which predate schema versions can't decode the message, so it
+// is not sent while the cluster has any.
+func (api *API) sendSchemaVersion(ctx context.Context, v uint64) {
+	if v == 0 || api.cluster.checkMessageProtocol(messageTypeSchemaVersion) != nil {
+		return
+	}
+	if err := api.server.SendSyncContext(ctx, &SchemaVersionMessage{Node: api.server.nodeID, Version: v}); err != nil {
+		api.server.logger.Printf("sending schema version: %s", err)
+	}
 }
 
 // Views returns the views in the given field.
@@ -778,7 +1905,7 @@ func (api *API) Views(ctx context.Context, indexName string, fieldName string) (
 
 // DeleteView removes the given view.
 func (api *API) DeleteView(ctx context.Context, indexName string, fieldName string, viewName string) error {
-	span, _ := tracing.StartSpanFromContext(ctx, "API.DeleteView")
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.DeleteView")
 	defer span.Finish()
 
 	if err := api.validate(apiDeleteView); err != nil {
@@ -800,7 +1927,7 @@ func (api *API) DeleteView(ctx context.Context, indexName string, fieldName stri
 	}
 
 	// Send the delete view message to all nodes.
-	err := api.server.SendSync(
+	err := api.server.SendSyncContext(ctx,
 		&DeleteViewMessage{
 			Index: indexName,
 			Field: fieldName,
@@ -893,6 +2020,52 @@ func (api *API) FieldAttrDiff(ctx context.Context, indexName string, fieldName s
 type ImportOptions struct {
 	Clear          bool
 	IgnoreKeyCheck bool
+
+	// ConflictPolicy resolves conflicting values of columns of mutex and
+	// bool fields.
+	ConflictPolicy ImportConflictPolicy
+
+	// Conflicts, if set, is atomically incremented by the number of
+	// conflicting columns.
+	Conflicts *uint64
+
+	// Set and Cleared, if set, are atomically incremented by the number of
+	// bits set and cleared by the import.
+	Set     *uint64
+	Cleared *uint64
+
+	// AutoCreated, if set, is set to true if the index or field of the
+	// import was auto-created.
+	AutoCreated *bool
+}
+
+// ImportConflictPolicy determines how an import into a mutex or bool field
+// resolves a column which is imported with a value other than the value it
+// already has, or with several values.
+type ImportConflictPolicy string
+
+// Import conflict policies.
+const (
+	// ImportConflictLastWriteWins replaces the column's value by the value
+	// imported last. It is the default.
+	ImportConflictLastWriteWins ImportConflictPolicy = "last-write-wins"
+
+	// ImportConflictFirstWriteWins keeps the column's value, or the value
+	// imported first if it has none.
+	ImportConflictFirstWriteWins ImportConflictPolicy = "first-write-wins"
+
+	// ImportConflictError fails the import of the fragment, without
+	// changing it, with a ConflictError.
+	ImportConflictError ImportConflictPolicy = "error"
+)
+
+// validate returns a BadRequestError unless p is a known policy or empty.
+func (p ImportConflictPolicy) validate() error {
+	switch p {
+	case "", ImportConflictLastWriteWins, ImportConflictFirstWriteWins, ImportConflictError:
+		return nil
+	}
+	return NewBadRequestError(errors.Errorf("invalid import conflict policy: %q", p))
 }
 
 // ImportOption is a functional option type for API.Import.
@@ -916,14 +2089,58 @@ func OptImportOptionsIgnoreKeyCheck(b bool) ImportOption {
 	}
 }
 
+// OptImportOptionsConflictPolicy is a functional option on ImportOption
+// used to specify how conflicting values of mutex and bool fields are
+// resolved.
+func OptImportOptionsConflictPolicy(p ImportConflictPolicy) ImportOption {
+	return func(o *ImportOptions) error {
+		if err := p.validate(); err != nil {
+			return err
+		}
+		o.ConflictPolicy = p
+		return nil
+	}
+}
+
+// OptImportOptionsConflicts is a functional option on ImportOption
+// used to count the columns whose values conflicted during the import.
+func OptImportOptionsConflicts(n *uint64) ImportOption {
+	return func(o *ImportOptions) error {
+		o.Conflicts = n
+		return nil
+	}
+}
+
+// OptImportOptionsChanges is a functional option on ImportOption used to
+// count the bits set and cleared by the import.
+func OptImportOptionsChanges(set, cleared *uint64) ImportOption {
+	return func(o *ImportOptions) error {
+		o.Set, o.Cleared = set, cleared
+		return nil
+	}
+}
+
+// OptImportOptionsAutoCreated is a functional option on ImportOption used to
+// report whether the index or field of the import was auto-created.
+func OptImportOptionsAutoCreated(created *bool) ImportOption {
+	return func(o *ImportOptions) error {
+		o.AutoCreated = created
+		return nil
+	}
+}
+
 // Import bulk imports data into a particular index,field,shard.
-func (api *API) Import(ctx context.Context, req *ImportRequest, opts ...ImportOption) error {
+func (api *API) Import(ctx context.Context, req *ImportRequest, opts ...ImportOption) (err error) {
 	span, _ := tracing.StartSpanFromContext(ctx, "API.Import")
 	defer span.Finish()
 
 	if err := api.validate(apiImport); err != nil {
 		return errors.Wrap(err, "validating api method")
 	}
+	if err := api.writes.enter(); err != nil {
+		return err
+	}
+	defer api.writes.leave()
 
 	// Set up import options.
 	options, err := setUpImportOptions(opts...)
@@ -931,65 +2148,49 @@ func (api *API) Import(ctx context.Context, req *ImportRequest, opts ...ImportOp
 		return errors.Wrap(err, "setting up import options")
 	}
 
-	index, field, err := api.indexField(req.Index, req.Field, req.Shard)
+	// Imports forwarded with their keys translated are to indexes and
+	// fields which exist.
+	if !options.IgnoreKeyCheck {
+		if err := api.autoCreateImport(ctx, req.Index, req.Field, "", len(req.ColumnKeys) > 0, len(req.RowKeys) > 0, options); err != nil {
+			return err
+		}
+	}
+
+	ctx, index, field, end, err := api.importIndexField(ctx, req.Index, req.Field, req.Shard)
 	if err != nil {
 		return errors.Wrap(err, "getting index and field")
 	}
+	defer func() {
+		if end() && err != nil {
+			err = ErrIndexRenaming
+		}
+	}()
 
 	// Unless explicitly ignoring key validation (meaning keys have been
 	// translated to ids in a previous step at the coordinator node), then
 	// check to see if keys need translation.
-	if !options.IgnoreKeyCheck {
-		// Translate row keys.
-		if field.keys() {
-			if len(req.RowIDs) != 0 {
-				return errors.New("row ids cannot be used because field uses string keys")
-			}
-			if req.RowIDs, err = field.translateStore.TranslateKeys(req.RowKeys); err != nil {
-				return errors.Wrap(err, "translating rows")
-			}
-		}
-
-		// Translate column keys.
-		if index.Keys() {
-			if len(req.ColumnIDs) != 0 {
-				return errors.New("column ids cannot be used because index uses string keys")
-			}
-			if req.ColumnIDs, err = index.translateStore.TranslateKeys(req.ColumnKeys); err != nil {
-				return errors.Wrap(err, "translating columns")
-			}
+	if !options.IgnoreKeyCheck {
+		if err := translateImportRequest(index, field, req); err != nil {
+			return err
 		}
 
 		// For translated data, map the columnIDs to shards. If
 		// this node does not own the shard, forward to the node that does.
 		if index.Keys() || field.keys() {
-			m := make(map[uint64][]Bit)
-
-			for i, colID := range req.ColumnIDs {
-				shard := colID / ShardWidth
-				if _, ok := m[shard]; !ok {
-					m[shard] = make([]Bit, 0)
-				}
-				bit := Bit{
-					RowID:    req.RowIDs[i],
-					ColumnID: colID,
-				}
-				if len(req.Timestamps) > 0 {
-					bit.Timestamp = req.Timestamps[i]
-				}
-				m[shard] = append(m[shard], bit)
-			}
+			m := splitImportRequest(req)
 
-			// Signal to the receiving nodes to ignore checking for key translation.
+			// Signal to the receiving nodes to ignore checking for key
+			// translation, and tag the requests with the epoch of the
+			// topology the shards are routed with.
 			opts = append(opts, OptImportOptionsIgnoreKeyCheck(true))
+			ctx = WithTopologyEpoch(ctx, api.cluster.Epoch())
 
 			var eg errgroup.Group
-			for shard, bits := range m {
+			for _, r := range m {
 				// TODO: if local node owns this shard we don't need to go through the client
-				shard := shard
-				bits := bits
+				r := r
 				eg.Go(func() error {
-					return api.server.defaultClient.Import(ctx, req.Index, req.Field, shard, bits, opts...)
+					return api.server.defaultClient.ImportShard(ctx, r, opts...)
 				})
 			}
 			return eg.Wait()
@@ -1001,6 +2202,16 @@ func (api *API) Import(ctx context.Context, req *ImportRequest, opts ...ImportOp
 		return errors.Wrap(err, "validating shard ownership")
 	}
 
+	// Column attributes are imported with the bits of their shard.
+	if len(req.ColumnAttrs) > 0 && options.Clear {
+		return NewBadRequestError(errors.New("column attributes cannot be imported with clear"))
+	}
+	for _, set := range req.ColumnAttrs {
+		if set.ID/ShardWidth != req.Shard {
+			return NewBadRequestError(errors.Errorf("column %d of attributes is not in shard %d", set.ID, req.Shard))
+		}
+	}
+
 	// Convert timestamps to time.Time.
 	timestamps := make([]*time.Time, len(req.Timestamps))
 	for i, ts := range req.Timestamps {
@@ -1019,22 +2230,219 @@ func (api *API) Import(ctx context.Context, req *ImportRequest, opts ...ImportOp
 		}
 	}
 
+	// Import column attributes, keeping their previous values so that they
+	// can be restored if the bits fail to import.
+	undo, err := importColumnAttrs(index, req.ColumnAttrs)
+	if err != nil {
+		return errors.Wrap(err, "importing column attributes")
+	}
+
 	// Import into fragment.
 	err = field.Import(req.RowIDs, req.ColumnIDs, timestamps, opts...)
 	if err != nil {
 		api.server.logger.Printf("import error: index=%s, field=%s, shard=%d, columns=%d, err=%s", req.Index, req.Field, req.Shard, len(req.ColumnIDs), err)
+		if len(undo) > 0 {
+			if uerr := index.ColumnAttrStore().SetBulkAttrs(undo); uerr != nil {
+				api.server.logger.Printf("restoring column attributes after import error: index=%s, shard=%d, err=%s", req.Index, req.Shard, uerr)
+			}
+		}
 	}
 	return errors.Wrap(err, "importing")
 }
 
+// ImportBool bulk imports the values of columns of a bool field. The values
+// are imported as the field's false and true rows, like Import, so the
+// columns keep a single value.
+func (api *API) ImportBool(ctx context.Context, req *ImportBoolRequest, opts ...ImportOption) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.ImportBool")
+	defer span.Finish()
+
+	if err := api.validate(apiImport); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	_, field, err := api.indexField(req.Index, req.Field, req.Shard)
+	if err != nil {
+		return errors.Wrap(err, "getting index and field")
+	} else if field.Type() != FieldTypeBool {
+		return NewBadRequestError(errors.Errorf("field %s is not a bool field", req.Field))
+	}
+
+	n := len(req.ColumnIDs)
+	if len(req.ColumnKeys) > 0 {
+		n = len(req.ColumnKeys)
+	}
+	if len(req.Values) > 0 && len(req.Values) != n {
+		return NewBadRequestError(errors.Errorf("mismatch of column/value len: %d != %d", n, len(req.Values)))
+	}
+
+	rowIDs := make([]uint64, n)
+	for i := range rowIDs {
+		rowIDs[i] = falseRowID
+		if len(req.Values) == 0 || req.Values[i] {
+			rowIDs[i] = trueRowID
+		}
+	}
+	return api.Import(ctx, &ImportRequest{
+		Index:      req.Index,
+		Field:      req.Field,
+		Shard:      req.Shard,
+		RowIDs:     rowIDs,
+		ColumnIDs:  req.ColumnIDs,
+		ColumnKeys: req.ColumnKeys,
+	}, opts...)
+}
+
+// translateImportRequest translates the row, column and column attribute keys
+// of req to IDs.
+func translateImportRequest(index *Index, field *Field, req *ImportRequest) (err error) {
+	// Translate row keys.
+	if field.keys() {
+		if len(req.RowIDs) != 0 {
+			return errors.New("row ids cannot be used because field uses string keys")
+		}
+		if req.RowIDs, err = field.translateStore.TranslateKeys(req.RowKeys); err != nil {
+			return errors.Wrap(err, "translating rows")
+		}
+	}
+
+	// Translate column keys.
+	if index.Keys() {
+		if len(req.ColumnIDs) != 0 {
+			return errors.New("column ids cannot be used because index uses string keys")
+		}
+		if req.ColumnIDs, err = index.translateStore.TranslateKeys(req.ColumnKeys); err != nil {
+			return errors.Wrap(err, "translating columns")
+		}
+		if err := translateColumnAttrKeys(index, req.ColumnAttrs); err != nil {
+			return errors.Wrap(err, "translating column attributes")
+		}
+	}
+	return nil
+}
+
+// splitImportRequest maps the translated bits and column attributes of req to
+// a request per shard.
+func splitImportRequest(req *ImportRequest) map[uint64]*ImportRequest {
+	m := make(map[uint64]*ImportRequest)
+	shardReq := func(shard uint64) *ImportRequest {
+		if _, ok := m[shard]; !ok {
+			m[shard] = &ImportRequest{Index: req.Index, Field: req.Field, Shard: shard}
+		}
+		return m[shard]
+	}
+
+	for i, colID := range req.ColumnIDs {
+		r := shardReq(colID / ShardWidth)
+		r.RowIDs = append(r.RowIDs, req.RowIDs[i])
+		r.ColumnIDs = append(r.ColumnIDs, colID)
+		if len(req.Timestamps) > 0 {
+			r.Timestamps = append(r.Timestamps, req.Timestamps[i])
+		}
+	}
+	for _, set := range req.ColumnAttrs {
+		r := shardReq(set.ID / ShardWidth)
+		r.ColumnAttrs = append(r.ColumnAttrs, &ColumnAttrSet{ID: set.ID, Attrs: set.Attrs})
+	}
+	return m
+}
+
+// translateImportValueRequest translates the column keys of req to IDs.
+func translateImportValueRequest(index *Index, req *ImportValueRequest) (err error) {
+	if index.Keys() {
+		if len(req.ColumnIDs) != 0 {
+			return errors.New("column ids cannot be used because index uses string keys")
+		}
+		if req.ColumnIDs, err = index.translateStore.TranslateKeys(req.ColumnKeys); err != nil {
+			return errors.Wrap(err, "translating columns")
+		}
+	}
+	return nil
+}
+
+// splitImportValueRequest maps the translated values of req to the values of
+// each shard.
+func splitImportValueRequest(req *ImportValueRequest) map[uint64][]FieldValue {
+	m := make(map[uint64][]FieldValue)
+	for i, colID := range req.ColumnIDs {
+		shard := colID / ShardWidth
+		m[shard] = append(m[shard], FieldValue{
+			Value:    req.Values[i],
+			ColumnID: colID,
+		})
+	}
+	return m
+}
+
+// translateColumnAttrKeys sets the column IDs of attribute sets from their
+// keys.
+func translateColumnAttrKeys(index *Index, sets []*ColumnAttrSet) error {
+	if len(sets) == 0 {
+		return nil
+	}
+	keys := make([]string, len(sets))
+	for i, set := range sets {
+		if set.ID != 0 {
+			return errors.New("column ids cannot be used because index uses string keys")
+		}
+		keys[i] = set.Key
+	}
+	ids, err := index.translateStore.TranslateKeys(keys)
+	if err != nil {
+		return err
+	}
+	for i, set := range sets {
+		set.ID = ids[i]
+	}
+	return nil
+}
+
+// importColumnAttrs sets column attributes in a single transaction. It
+// returns the attributes which restore the previous values.
+func importColumnAttrs(index *Index, sets []*ColumnAttrSet) (map[uint64]map[string]interface{}, error) {
+	if len(sets) == 0 {
+		return nil, nil
+	}
+
+	store := index.ColumnAttrStore()
+	m := make(map[uint64]map[string]interface{}, len(sets))
+	undo := make(map[uint64]map[string]interface{}, len(sets))
+	for _, set := range sets {
+		prev, err := store.Attrs(set.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading attributes")
+		}
+		if m[set.ID] == nil {
+			m[set.ID] = make(map[string]interface{}, len(set.Attrs))
+			undo[set.ID] = make(map[string]interface{}, len(set.Attrs))
+		}
+		for k, v := range set.Attrs {
+			m[set.ID][k] = v
+			// A nil value deletes an attribute which did not exist.
+			if _, ok := undo[set.ID][k]; !ok {
+				undo[set.ID][k] = prev[k]
+			}
+		}
+	}
+	if err := store.SetBulkAttrs(m); err != nil {
+		return nil, err
+	}
+	index.Stats.Count("ImportColumnAttrs", int64(len(m)), 1.0)
+	return undo, nil
+}
+
 // ImportValue bulk imports values into a particular field.
-func (api *API) ImportValue(ctx context.Context, req *ImportValueRequest, opts ...ImportOption) error {
+func (api *API) ImportValue(ctx context.Context, req *ImportValueRequest, opts ...ImportOption) (err error) {
 	span, _ := tracing.StartSpanFromContext(ctx, "API.ImportValue")
 	defer span.Finish()
 
 	if err := api.validate(apiImportValue); err != nil {
 		return errors.Wrap(err, "validating api method")
 	}
+	if err := api.writes.enter(); err != nil {
+		return err
+	}
+	defer api.writes.leave()
 
 	// Set up import options.
 	options, err := setUpImportOptions(opts...)
@@ -1042,41 +2450,40 @@ func (api *API) ImportValue(ctx context.Context, req *ImportValueRequest, opts .
 		return errors.Wrap(err, "setting up import options")
 	}
 
-	index, field, err := api.indexField(req.Index, req.Field, req.Shard)
+	if !options.IgnoreKeyCheck {
+		if err := api.autoCreateImport(ctx, req.Index, req.Field, FieldTypeInt, len(req.ColumnKeys) > 0, false, options); err != nil {
+			return err
+		}
+	}
+
+	ctx, index, field, end, err := api.importIndexField(ctx, req.Index, req.Field, req.Shard)
 	if err != nil {
 		return errors.Wrap(err, "getting index and field")
 	}
+	defer func() {
+		if end() && err != nil {
+			err = ErrIndexRenaming
+		}
+	}()
 
 	// Unless explicitly ignoring key validation (meaning keys have been
 	// translate to ids in a previous step at the coordinator node), then
 	// check to see if keys need translation.
 	if !options.IgnoreKeyCheck {
-		// Translate column keys.
-		if index.Keys() {
-			if len(req.ColumnIDs) != 0 {
-				return errors.New("column ids cannot be used because index uses string keys")
-			}
-			if req.ColumnIDs, err = index.translateStore.TranslateKeys(req.ColumnKeys); err != nil {
-				return errors.Wrap(err, "translating columns")
-			}
-
-			// For translated data, map the columnIDs to shards. If
-			// this node does not own the shard, forward to the node that does.
-			m := make(map[uint64][]FieldValue)
+		if err := translateImportValueRequest(index, req); err != nil {
+			return err
+		}
 
-			for i, colID := range req.ColumnIDs {
-				shard := colID / ShardWidth
-				if _, ok := m[shard]; !ok {
-					m[shard] = make([]FieldValue, 0)
-				}
-				m[shard] = append(m[shard], FieldValue{
-					Value:    req.Values[i],
-					ColumnID: colID,
-				})
-			}
+		// For translated data, map the columnIDs to shards. If
+		// this node does not own the shard, forward to the node that does.
+		if index.Keys() {
+			m := splitImportValueRequest(req)
 
-			// Signal to the receiving nodes to ignore checking for key translation.
+			// Signal to the receiving nodes to ignore checking for key
+			// translation, and tag the requests with the epoch of the
+			// topology the shards are routed with.
 			opts = append(opts, OptImportOptionsIgnoreKeyCheck(true))
+			ctx = WithTopologyEpoch(ctx, api.cluster.Epoch())
 
 			var eg errgroup.Group
 			for shard, vals := range m {
@@ -1112,6 +2519,351 @@ func (api *API) ImportValue(ctx context.Context, req *ImportValueRequest, opts .
 	return errors.Wrap(err, "importing")
 }
 
+// CreateImportSession opens a session for a chunked import into a field.
+// Chunks are added with AddImportSessionChunk and nothing is written to the
+// field until the session is committed.
+func (api *API) CreateImportSession(ctx context.Context, indexName, fieldName string, opts ...ImportOption) (*ImportSession, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.CreateImportSession")
+	defer span.Finish()
+
+	if err := api.validate(apiImportSession); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	options, err := setUpImportOptions(opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "setting up import options")
+	}
+
+	index := api.holder.Index(indexName)
+	if index == nil {
+		return nil, newNotFoundError(ErrIndexNotFound, indexName)
+	}
+	field := index.Field(fieldName)
+	if field == nil {
+		return nil, newNotFoundError(ErrFieldNotFound, fieldName)
+	}
+
+	s, err := api.holder.importSessions.create(api.holder, indexName, fieldName, field.Type() == FieldTypeInt || field.Type() == FieldTypeTimestamp, options.Clear)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating import session")
+	}
+	return s.status(), nil
+}
+
+// ImportSession returns the state of an open import session, including the
+// chunks which have already been accepted.
+func (api *API) ImportSession(ctx context.Context, id string) (*ImportSession, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.ImportSession")
+	defer span.Finish()
+
+	if err := api.validate(apiImportSession); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	s, err := api.holder.importSessions.get(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.status(), nil
+}
+
+// AddImportSessionChunk stages a chunk of an import session. data is an
+// encoded ImportRequest, or ImportValueRequest for int fields, as accepted by
+// Import and ImportValue. Adding a chunk which was already accepted is a
+// no-op.
+func (api *API) AddImportSessionChunk(ctx context.Context, id, chunk string, data []byte) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.AddImportSessionChunk")
+	defer span.Finish()
+
+	if err := api.validate(apiImportSession); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	if chunk == "" {
+		return NewBadRequestError(errors.New("chunk id required"))
+	}
+
+	s, err := api.holder.importSessions.get(id)
+	if err != nil {
+		return err
+	}
+
+	// Decode the chunk now so that a bad chunk is rejected when it is sent
+	// rather than when the session is committed.
+	req, err := api.decodeImportSessionChunk(s, data)
+	if err != nil {
+		return NewBadRequestError(errors.Wrap(err, "decoding chunk"))
+	}
+
+	// Keyed chunks are routed to the owning nodes when the session is
+	// committed; otherwise every column of the chunk must belong to a shard
+	// owned by this node.
+	var columnIDs []uint64
+	switch req := req.(type) {
+	case *ImportRequest:
+		if len(req.RowKeys) > 0 || len(req.ColumnKeys) > 0 {
+			return s.addChunk(chunk, data)
+		}
+		columnIDs = req.ColumnIDs
+	case *ImportValueRequest:
+		if len(req.ColumnKeys) > 0 {
+			return s.addChunk(chunk, data)
+		}
+		columnIDs = req.ColumnIDs
+	}
+	for _, shard := range importSessionShards(columnIDs) {
+		if err := api.validateShardOwnership(s.meta.Index, shard); err != nil {
+			return errors.Wrap(err, "validating shard ownership")
+		}
+	}
+
+	return s.addChunk(chunk, data)
+}
+
+// CommitImportSession applies the chunks of an import session, in the order
+// they were received, and closes the session. Every chunk is validated and
+// has its keys translated, and its data staged by shard, before anything is
+// applied, so a bad chunk fails the commit without writing anything. The
+// staged data of each shard is then applied at once. If applying a shard
+// fails the session is left open and the commit may be retried; shards which
+// were already applied are skipped.
+func (api *API) CommitImportSession(ctx context.Context, id string) (*ImportSession, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.CommitImportSession")
+	defer span.Finish()
+
+	if err := api.validate(apiImportSession); err != nil {
+		return nil, errors.Wrap(err, "validating api method")
+	}
+
+	s, err := api.holder.importSessions.get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.commit(func(data []byte, stage func(uint64, []byte) error) error {
+		return api.stageImportSessionChunk(s, data, stage)
+	}, func(shard uint64, data [][]byte) error {
+		return api.applyImportSessionShard(ctx, s, shard, data)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "committing import session")
+	}
+	api.holder.importSessions.remove(id)
+
+	return s.status(), nil
+}
+
+// AbortImportSession discards an import session and its staged chunks. A
+// session whose commit has applied some of its shards cannot be aborted.
+func (api *API) AbortImportSession(ctx context.Context, id string) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.AbortImportSession")
+	defer span.Finish()
+
+	if err := api.validate(apiImportSession); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	return api.holder.importSessions.abort(id)
+}
+
+// stageImportSessionChunk decodes and validates a chunk of s, translating its
+// keys, and stages the encoded request of each shard it writes to.
+func (api *API) stageImportSessionChunk(s *importSession, data []byte, stage func(shard uint64, data []byte) error) error {
+	req, err := api.decodeImportSessionChunk(s, data)
+	if err != nil {
+		return errors.Wrap(err, "decoding chunk")
+	}
+
+	var m map[uint64]interface{}
+	var keyed bool
+	switch req := req.(type) {
+	case *ImportRequest:
+		index, field, err := api.indexField(req.Index, req.Field, req.Shard)
+		if err != nil {
+			return errors.Wrap(err, "getting index and field")
+		}
+		if len(req.ColumnAttrs) > 0 && s.meta.Clear {
+			return NewBadRequestError(errors.New("column attributes cannot be imported with clear"))
+		}
+		keyed = index.Keys() || field.keys()
+		if err := translateImportRequest(index, field, req); err != nil {
+			return err
+		}
+		if len(req.RowIDs) != len(req.ColumnIDs) {
+			return NewBadRequestError(errors.Errorf("mismatch of row/column len: %d != %d", len(req.RowIDs), len(req.ColumnIDs)))
+		} else if len(req.Timestamps) > 0 && len(req.Timestamps) != len(req.ColumnIDs) {
+			return NewBadRequestError(errors.Errorf("mismatch of timestamp/column len: %d != %d", len(req.Timestamps), len(req.ColumnIDs)))
+		}
+		if !keyed {
+			for _, set := range req.ColumnAttrs {
+				if set.ID/ShardWidth != req.Shard {
+					return NewBadRequestError(errors.Errorf("column %d of attributes is not in shard %d", set.ID, req.Shard))
+				}
+			}
+		}
+
+		m = make(map[uint64]interface{})
+		for shard, r := range splitImportRequest(req) {
+			m[shard] = r
+		}
+
+	case *ImportValueRequest:
+		index, _, err := api.indexField(req.Index, req.Field, req.Shard)
+		if err != nil {
+			return errors.Wrap(err, "getting index and field")
+		}
+		keyed = index.Keys()
+		if err := translateImportValueRequest(index, req); err != nil {
+			return err
+		}
+		if len(req.Values) != len(req.ColumnIDs) {
+			return NewBadRequestError(errors.Errorf("mismatch of column/value len: %d != %d", len(req.ColumnIDs), len(req.Values)))
+		}
+
+		m = make(map[uint64]interface{})
+		for shard, vals := range splitImportValueRequest(req) {
+			r := &ImportValueRequest{Index: req.Index, Field: req.Field, Shard: shard}
+			for _, v := range vals {
+				r.ColumnIDs = append(r.ColumnIDs, v.ColumnID)
+				r.Values = append(r.Values, v.Value)
+			}
+			m[shard] = r
+		}
+
+	default:
+		return errors.Errorf("unexpected chunk type %T", req)
+	}
+
+	for shard, r := range m {
+		if !keyed {
+			if err := api.validateShardOwnership(s.meta.Index, shard); err != nil {
+				return errors.Wrap(err, "validating shard ownership")
+			}
+		}
+		buf, err := api.Serializer.Marshal(r)
+		if err != nil {
+			return errors.Wrapf(err, "marshalling shard %d", shard)
+		} else if err := stage(shard, buf); err != nil {
+			return errors.Wrapf(err, "staging shard %d", shard)
+		}
+	}
+	return nil
+}
+
+// applyImportSessionShard applies the staged requests of a shard of s, merged
+// in the order they were staged so that later values win, with a single
+// import. Shards of keyed fields are sent to their owning nodes as already
+// translated imports.
+func (api *API) applyImportSessionShard(ctx context.Context, s *importSession, shard uint64, data [][]byte) error {
+	index := api.holder.Index(s.meta.Index)
+	if index == nil {
+		return newNotFoundError(ErrIndexNotFound, s.meta.Index)
+	}
+	field := index.Field(s.meta.Field)
+	if field == nil {
+		return newNotFoundError(ErrFieldNotFound, s.meta.Field)
+	}
+
+	opts := []ImportOption{OptImportOptionsClear(s.meta.Clear)}
+	if s.meta.Values {
+		req := &ImportValueRequest{Index: s.meta.Index, Field: s.meta.Field, Shard: shard}
+		for _, buf := range data {
+			r := &ImportValueRequest{}
+			if err := api.Serializer.Unmarshal(buf, r); err != nil {
+				return errors.Wrap(err, "unmarshalling staged request")
+			}
+			req.ColumnIDs = append(req.ColumnIDs, r.ColumnIDs...)
+			req.Values = append(req.Values, r.Values...)
+		}
+
+		if !index.Keys() {
+			return api.ImportValue(ctx, req, opts...)
+		}
+		vals := make([]FieldValue, len(req.ColumnIDs))
+		for i := range req.ColumnIDs {
+			vals[i] = FieldValue{ColumnID: req.ColumnIDs[i], Value: req.Values[i]}
+		}
+		opts = append(opts, OptImportOptionsIgnoreKeyCheck(true))
+		ctx = WithTopologyEpoch(ctx, api.cluster.Epoch())
+		return api.server.defaultClient.ImportValue(ctx, req.Index, req.Field, shard, vals, opts...)
+	}
+
+	req := &ImportRequest{Index: s.meta.Index, Field: s.meta.Field, Shard: shard}
+	for _, buf := range data {
+		r := &ImportRequest{}
+		if err := api.Serializer.Unmarshal(buf, r); err != nil {
+			return errors.Wrap(err, "unmarshalling staged request")
+		}
+		// The bits of requests without timestamps are set without a time.
+		if len(r.Timestamps) == 0 && len(req.Timestamps) > 0 {
+			r.Timestamps = make([]int64, len(r.ColumnIDs))
+		} else if len(r.Timestamps) > 0 && len(req.Timestamps) == 0 {
+			req.Timestamps = make([]int64, len(req.ColumnIDs))
+		}
+		req.RowIDs = append(req.RowIDs, r.RowIDs...)
+		req.ColumnIDs = append(req.ColumnIDs, r.ColumnIDs...)
+		req.Timestamps = append(req.Timestamps, r.Timestamps...)
+		req.ColumnAttrs = append(req.ColumnAttrs, r.ColumnAttrs...)
+	}
+
+	if !index.Keys() && !field.keys() {
+		return api.Import(ctx, req, opts...)
+	}
+	opts = append(opts, OptImportOptionsIgnoreKeyCheck(true))
+	ctx = WithTopologyEpoch(ctx, api.cluster.Epoch())
+	return api.server.defaultClient.ImportShard(ctx, req, opts...)
+}
+
+// importSessionShards returns the shards of columnIDs.
+func importSessionShards(columnIDs []uint64) []uint64 {
+	shards := make([]uint64, 0, 1)
+	for _, col := range columnIDs {
+		if shard := col / ShardWidth; len(shards) == 0 || shards[len(shards)-1] != shard {
+			shards = append(shards, shard)
+		}
+	}
+	return shards
+}
+
+// decodeImportSessionChunk decodes a chunk of s, filling in or checking the
+// index and field it targets.
+func (api *API) decodeImportSessionChunk(s *importSession, data []byte) (interface{}, error) {
+	if s.meta.Values {
+		req := &ImportValueRequest{}
+		if err := api.Serializer.Unmarshal(data, req); err != nil {
+			return nil, err
+		}
+		if err := checkImportSessionTarget(s, &req.Index, &req.Field); err != nil {
+			return nil, err
+		}
+		return req, nil
+	}
+
+	req := &ImportRequest{}
+	if err := api.Serializer.Unmarshal(data, req); err != nil {
+		return nil, err
+	}
+	if err := checkImportSessionTarget(s, &req.Index, &req.Field); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func checkImportSessionTarget(s *importSession, index, field *string) error {
+	if *index == "" {
+		*index = s.meta.Index
+	} else if *index != s.meta.Index {
+		return errors.Errorf("chunk index %q does not match session index %q", *index, s.meta.Index)
+	}
+	if *field == "" {
+		*field = s.meta.Field
+	} else if *field != s.meta.Field {
+		return errors.Errorf("chunk field %q does not match session field %q", *field, s.meta.Field)
+	}
+	return nil
+}
+
 func importExistenceColumns(index *Index, columnIDs []uint64) error {
 	ef := index.existenceField()
 	if ef == nil {
@@ -1158,6 +2910,8 @@ func (api *API) LongQueryTime() time.Duration {
 	if api.cluster == nil {
 		return 0
 	}
+	api.cluster.mu.RLock()
+	defer api.cluster.mu.RUnlock()
 	return api.cluster.longQueryTime
 }
 
@@ -1189,6 +2943,28 @@ func (api *API) indexField(indexName string, fieldName string, shard uint64) (*I
 	return index, field, nil
 }
 
+// importIndexField returns the index and field of an import, like
+// indexField, and registers the import with the queries of the index, so that
+// renames of the index or of its fields wait for it like for queries. end must
+// be called once the import completes; it returns true if a rename canceled
+// the import.
+func (api *API) importIndexField(ctx context.Context, indexName string, fieldName string, shard uint64) (_ context.Context, _ *Index, _ *Field, end func() bool, err error) {
+	index, field, err := api.indexField(indexName, fieldName, shard)
+	if err != nil {
+		return ctx, nil, nil, nil, err
+	}
+	ctx, end, err = index.queries.begin(ctx)
+	if err != nil {
+		return ctx, nil, nil, nil, err
+	}
+	// The field may have been renamed before the import was registered.
+	if index.Field(fieldName) != field {
+		end()
+		return ctx, nil, nil, nil, newNotFoundError(ErrFieldNotFound, fieldName)
+	}
+	return ctx, index, field, end, nil
+}
+
 // SetCoordinator makes a new Node the cluster coordinator.
 func (api *API) SetCoordinator(ctx context.Context, id string) (oldNode, newNode *Node, err error) {
 	span, _ := tracing.StartSpanFromContext(ctx, "API.SetCoordinator")
@@ -1374,11 +3150,31 @@ const (
 	//apiVersion // not implemented
 	apiViews
 	apiApplySchema
+	apiSetIndexAlias
+	apiDeleteIndexAlias
+	apiShardDistribution
+	apiUpdateIndex
+	apiImportSession
+	apiSyncAntiEntropy
+	apiUndeleteIndex
+	apiSelfHeal
+	apiQueries
+	apiCreateSchema
+	apiRowColumns
+	apiPromoteStandby
+	apiCompactFragments
+	apiUsage
+	apiClusterTopology
+	apiRename
+	apiChanges
 )
 
 var methodsCommon = map[apiMethod]struct{}{
-	apiClusterMessage: {},
-	apiSetCoordinator: {},
+	apiClusterMessage:  {},
+	apiSetCoordinator:  {},
+	apiQueries:         {},
+	apiUsage:           {},
+	apiClusterTopology: {},
 }
 
 var methodsResizing = map[apiMethod]struct{}{
@@ -1396,6 +3192,7 @@ var methodsNormal = map[apiMethod]struct{}{
 	apiExportCSV:            {},
 	apiFragmentBlockData:    {},
 	apiFragmentBlocks:       {},
+	apiFragmentData:         {}, // self-heal pulls shards from replicas
 	apiField:                {},
 	apiFieldAttrDiff:        {},
 	apiImport:               {},
@@ -1408,4 +3205,39 @@ var methodsNormal = map[apiMethod]struct{}{
 	apiShardNodes:           {},
 	apiViews:                {},
 	apiApplySchema:          {},
+	apiSetIndexAlias:        {},
+	apiDeleteIndexAlias:     {},
+	apiShardDistribution:    {},
+	apiUpdateIndex:          {},
+	apiImportSession:        {},
+	apiSyncAntiEntropy:      {},
+	apiUndeleteIndex:        {},
+	apiSelfHeal:             {},
+	apiCreateSchema:         {},
+	apiRowColumns:           {},
+	apiPromoteStandby:       {},
+	apiCompactFragments:     {},
+	apiRename:               {},
+	apiChanges:              {},
+}
+
+// methodsWrite holds the api methods which change the schema or data, and
+// which a standby refuses until it is promoted. Queries and roaring imports
+// are checked where they are made, since they may not write.
+var methodsWrite = map[apiMethod]struct{}{
+	apiCreateField:      {},
+	apiCreateIndex:      {},
+	apiDeleteField:      {},
+	apiDeleteIndex:      {},
+	apiDeleteView:       {},
+	apiImport:           {},
+	apiImportValue:      {},
+	apiApplySchema:      {},
+	apiSetIndexAlias:    {},
+	apiDeleteIndexAlias: {},
+	apiUpdateIndex:      {},
+	apiImportSession:    {},
+	apiUndeleteIndex:    {},
+	apiCreateSchema:     {},
+	apiRename:           {},
 }
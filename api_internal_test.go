@@ -0,0 +1,76 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// Ensure an anti-entropy sync is forwarded to every available node, even
+// once one cannot be reached.
+func TestAPI_SyncAntiEntropy_Forward(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	api := newRecordedAPI(h.Holder, &messageRecorder{})
+	c := api.cluster
+	c.nodes = []*Node{c.Node, {ID: "node1", URI: URI{Host: "node1"}}, {ID: "node2", URI: URI{Host: "node2"}}, {ID: "node3", URI: URI{Host: "node3"}}}
+	for _, n := range c.nodes[:3] {
+		n.State = nodeStateReady
+	}
+	client := &syncRecorder{counts: map[string]int{"node2": 3}}
+	api.server.defaultClient = client
+	api.server.syncer = holderSyncer{Holder: h.Holder, Node: c.Node, Cluster: c, Closing: make(chan struct{})}
+
+	n, err := api.SyncAntiEntropy(context.Background(), "", nil, false)
+	if err == nil || !strings.Contains(err.Error(), "nodes node1") {
+		t.Fatalf("expected error naming node1, got %v", err)
+	} else if n != 3 {
+		t.Fatalf("unexpected bits reconciled: %d", n)
+	} else if !reflect.DeepEqual(client.synced, []string{"node1", "node2"}) {
+		t.Fatalf("unexpected nodes asked to sync: %v", client.synced)
+	}
+}
+
+// syncRecorder is an InternalClient which records the nodes asked to run an
+// anti-entropy sync. Each reconciles the bits in counts, and nodes missing
+// from it cannot be reached.
+type syncRecorder struct {
+	nopInternalClient
+	mu     sync.Mutex
+	counts map[string]int
+	synced []string
+}
+
+func (r *syncRecorder) SyncAntiEntropy(ctx context.Context, uri *URI, index string, shards []uint64, remote bool) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := uri.Host
+	r.synced = append(r.synced, id)
+	n, ok := r.counts[id]
+	if !ok {
+		return 0, errors.New("connection refused")
+	}
+	return n, nil
+}
@@ -17,7 +17,10 @@ package pilosa_test
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"math"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -26,8 +29,10 @@ import (
 	"github.com/pilosa/pilosa/v2"
 	"github.com/pilosa/pilosa/v2/boltdb"
 	"github.com/pilosa/pilosa/v2/http"
+	"github.com/pilosa/pilosa/v2/roaring"
 	"github.com/pilosa/pilosa/v2/server"
 	"github.com/pilosa/pilosa/v2/test"
+	"github.com/pkg/errors"
 )
 
 func TestAPI_Import(t *testing.T) {
@@ -260,9 +265,1582 @@ func TestAPI_ImportValue(t *testing.T) {
 	})
 }
 
+func TestAPI_IndexAlias(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+
+	m0 := c[0]
+	m1 := c[1]
+	ctx := context.Background()
+
+	for _, index := range []string{"events_v1", "events_v2"} {
+		if _, err := m0.API.CreateIndex(ctx, index, pilosa.IndexOptions{}); err != nil {
+			t.Fatalf("creating index %s: %v", index, err)
+		} else if _, err := m0.API.CreateField(ctx, index, "f"); err != nil {
+			t.Fatalf("creating field: %v", err)
+		}
+	}
+	c.Query(t, "events_v1", "Set(1, f=1)")
+	c.Query(t, "events_v2", "Set(2, f=1)")
+
+	// columnsVia queries the alias on the given node and returns the columns
+	// of row 1.
+	columnsVia := func(m *test.Command) ([]uint64, error) {
+		res, err := m.API.Query(ctx, &pilosa.QueryRequest{Index: "events", Query: "Row(f=1)"})
+		if err != nil {
+			return nil, err
+		}
+		return res.Results[0].(*pilosa.Row).Columns(), nil
+	}
+
+	if err := m0.API.SetIndexAlias(ctx, "events", "events_v1"); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*test.Command{m0, m1} {
+		if cols, err := columnsVia(m); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(cols, []uint64{1}) {
+			t.Fatalf("unexpected columns via alias: %v", cols)
+		}
+	}
+
+	// Repoint the alias from node1 and verify both nodes follow.
+	if err := m1.API.SetIndexAlias(ctx, "events", "events_v2"); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*test.Command{m0, m1} {
+		if cols, err := columnsVia(m); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(cols, []uint64{2}) {
+			t.Fatalf("unexpected columns via repointed alias: %v", cols)
+		}
+	}
+
+	t.Run("NonexistentIndex", func(t *testing.T) {
+		if err := m0.API.SetIndexAlias(ctx, "events", "nope"); errors.Cause(err) != pilosa.ErrIndexNotFound {
+			t.Fatalf("expected index not found, got %v", err)
+		}
+		if aliases := m1.API.IndexAliases(ctx); aliases["events"] != "events_v2" {
+			t.Fatalf("unexpected aliases: %v", aliases)
+		}
+	})
+
+	t.Run("ShadowIndex", func(t *testing.T) {
+		if err := m0.API.SetIndexAlias(ctx, "events_v1", "events_v2"); err == nil || !strings.Contains(err.Error(), pilosa.ErrIndexExists.Error()) {
+			t.Fatalf("expected index exists, got %v", err)
+		}
+		if _, err := m0.API.CreateIndex(ctx, "events", pilosa.IndexOptions{}); err == nil || !strings.Contains(err.Error(), pilosa.ErrIndexAliasExists.Error()) {
+			t.Fatalf("expected index alias exists, got %v", err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := m0.API.DeleteIndexAlias(ctx, "events"); err != nil {
+			t.Fatal(err)
+		}
+		if aliases := m1.API.IndexAliases(ctx); len(aliases) != 0 {
+			t.Fatalf("expected no aliases, got %v", aliases)
+		}
+		if err := m0.API.DeleteIndexAlias(ctx, "events"); errors.Cause(err) != pilosa.ErrIndexAliasNotFound {
+			t.Fatalf("expected index alias not found, got %v", err)
+		}
+	})
+}
+
+func TestAPI_IndexTimeQuantum(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+
+	m0 := c[0]
+	m1 := c[1]
+	ctx := context.Background()
+
+	if _, err := m0.API.CreateIndex(ctx, "ts", pilosa.IndexOptions{TimeQuantum: "YMD"}); err != nil {
+		t.Fatal(err)
+	} else if _, err := m0.API.CreateField(ctx, "ts", "f0", pilosa.OptFieldTypeTime("")); err != nil {
+		t.Fatal(err)
+	} else if _, err := m0.API.CreateField(ctx, "ts", "f1", pilosa.OptFieldTypeTime("YM")); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*test.Command{m0, m1} {
+		if q := m.Server.Holder().Index("ts").Options().TimeQuantum; q != "YMD" {
+			t.Fatalf("unexpected index time quantum: %q", q)
+		} else if q := m.Server.Holder().Field("ts", "f0").TimeQuantum(); q != "YMD" {
+			t.Fatalf("expected f0 to inherit the index time quantum, got %q", q)
+		} else if q := m.Server.Holder().Field("ts", "f1").TimeQuantum(); q != "YM" {
+			t.Fatalf("expected f1 to keep its own time quantum, got %q", q)
+		}
+	}
+
+	// Changing the default only affects fields created afterwards.
+	if err := m1.API.SetIndexTimeQuantum(ctx, "ts", "Y"); err != nil {
+		t.Fatal(err)
+	} else if _, err := m0.API.CreateField(ctx, "ts", "f2", pilosa.OptFieldTypeTime("")); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*test.Command{m0, m1} {
+		if q := m.Server.Holder().Index("ts").Options().TimeQuantum; q != "Y" {
+			t.Fatalf("unexpected index time quantum: %q", q)
+		} else if q := m.Server.Holder().Field("ts", "f0").TimeQuantum(); q != "YMD" {
+			t.Fatalf("expected f0 to be unchanged, got %q", q)
+		} else if q := m.Server.Holder().Field("ts", "f2").TimeQuantum(); q != "Y" {
+			t.Fatalf("expected f2 to inherit the new time quantum, got %q", q)
+		}
+	}
+
+	if _, err := m0.API.CreateIndex(ctx, "bad", pilosa.IndexOptions{TimeQuantum: "X"}); err == nil || err.Error() != pilosa.ErrInvalidTimeQuantum.Error() {
+		t.Fatalf("expected invalid time quantum, got %v", err)
+	}
+}
+
+func TestAPI_IndexCompression(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+
+	m0 := c[0]
+	m1 := c[1]
+	ctx := context.Background()
+
+	if _, err := m0.API.CreateIndex(ctx, "cz", pilosa.IndexOptions{Compression: pilosa.CompressionGzip}); err != nil {
+		t.Fatal(err)
+	} else if _, err := m0.API.CreateField(ctx, "cz", "f"); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*test.Command{m0, m1} {
+		if cz := m.Server.Holder().Index("cz").Options().Compression; cz != pilosa.CompressionGzip {
+			t.Fatalf("unexpected index compression: %q", cz)
+		}
+	}
+	m0.MustQuery(t, &pilosa.QueryRequest{Index: "cz", Query: "Set(1, f=1) Set(2, f=1)"})
+
+	if err := m1.API.SetIndexCompression(ctx, "cz", pilosa.CompressionNone); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*test.Command{m0, m1} {
+		if cz := m.Server.Holder().Index("cz").Options().Compression; cz != pilosa.CompressionNone {
+			t.Fatalf("unexpected index compression: %q", cz)
+		}
+	}
+	if res := m1.MustQuery(t, &pilosa.QueryRequest{Index: "cz", Query: "Count(Row(f=1))"}); res.Results[0] != uint64(2) {
+		t.Fatalf("unexpected count: %v", res.Results[0])
+	}
+
+	if err := m0.API.SetIndexCompression(ctx, "cz", "lz4"); err == nil || err.Error() != pilosa.ErrInvalidCompression.Error() {
+		t.Fatalf("expected invalid compression, got %v", err)
+	} else if _, err := m0.API.CreateIndex(ctx, "bad", pilosa.IndexOptions{Compression: "lz4"}); err == nil || err.Error() != pilosa.ErrInvalidCompression.Error() {
+		t.Fatalf("expected invalid compression, got %v", err)
+	}
+}
+
+func TestAPI_IndexDurability(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+
+	ctx := context.Background()
+	if _, err := c[0].API.CreateIndex(ctx, "d", pilosa.IndexOptions{Durability: pilosa.DurabilityAsync}); err != nil {
+		t.Fatal(err)
+	} else if _, err := c[0].API.CreateField(ctx, "d", "f"); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range c {
+		if d := m.Server.Holder().Index("d").Options().Durability; d != pilosa.DurabilityAsync {
+			t.Fatalf("unexpected index durability: %q", d)
+		}
+	}
+	c[0].MustQuery(t, &pilosa.QueryRequest{Index: "d", Query: "Set(1, f=1) Set(2, f=1)"})
+	if res := c[1].MustQuery(t, &pilosa.QueryRequest{Index: "d", Query: "Count(Row(f=1))"}); res.Results[0] != uint64(2) {
+		t.Fatalf("unexpected count: %v", res.Results[0])
+	}
+
+	if _, err := c[0].API.CreateIndex(ctx, "bad", pilosa.IndexOptions{Durability: "never"}); err == nil || err.Error() != pilosa.ErrInvalidDurability.Error() {
+		t.Fatalf("expected invalid durability, got %v", err)
+	}
+}
+
+func TestAPI_MaxMemory(t *testing.T) {
+	// Any node uses more than a byte, so read queries are rejected once the
+	// memory use is first sampled.
+	c := test.MustRunCluster(t, 1, []server.CommandOption{server.OptCommandServerOptions(pilosa.OptServerMaxMemory(1, 0.9, 0.8, time.Hour))})
+	defer c.Close()
+
+	ctx := context.Background()
+	if _, err := c[0].API.CreateIndex(ctx, "i", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	} else if _, err := c[0].API.CreateField(ctx, "i", "f"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Writes are still accepted.
+	c[0].MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: "Set(1, f=1)"})
+	var err error
+	for i := 0; i < 100; i++ {
+		if _, err = c[0].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))"}); err != nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if errors.Cause(err) != pilosa.ErrMemoryLimit {
+		t.Fatalf("expected memory limit error, got %v", err)
+	}
+}
+
+func TestAPI_MaxResultRows(t *testing.T) {
+	c := test.MustRunCluster(t, 2, []server.CommandOption{server.OptCommandServerOptions(pilosa.OptServerQueryMaxResultRows(3))})
+	defer c.Close()
+
+	ctx := context.Background()
+	if _, err := c[0].API.CreateIndex(ctx, "i", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	} else if _, err := c[0].API.CreateField(ctx, "i", "f"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The columns are spread over the shards of both nodes.
+	for shard := uint64(0); shard < 4; shard++ {
+		c[0].MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: fmt.Sprintf("Set(%d, f=1) Set(%d, f=%d)", shard*pilosa.ShardWidth+1, shard*pilosa.ShardWidth+2, shard+2)})
+	}
+
+	for _, tt := range []struct {
+		query         string
+		maxResultRows uint64
+		exp           interface{}
+		truncated     bool
+	}{
+		{query: "Row(f=1)", exp: []uint64{1, pilosa.ShardWidth + 1, 2*pilosa.ShardWidth + 1}, truncated: true},
+		{query: "Row(f=1)", maxResultRows: 2, exp: []uint64{1, pilosa.ShardWidth + 1}, truncated: true},
+		{query: "Row(f=1)", maxResultRows: 10, exp: []uint64{1, pilosa.ShardWidth + 1, 2*pilosa.ShardWidth + 1}, truncated: true},
+		{query: "Row(f=2)", exp: []uint64{2}},
+		{query: "Rows(f)", exp: []uint64{1, 2, 3}, truncated: true},
+		{query: "Rows(f, limit=2)", exp: []uint64{1, 2}},
+		{query: "Count(Row(f=1))", exp: uint64(4)},
+	} {
+		for _, m := range c {
+			resp, err := m.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: tt.query, MaxResultRows: tt.maxResultRows})
+			if err != nil {
+				t.Fatal(err)
+			}
+			result := resp.Results[0]
+			switch r := result.(type) {
+			case *pilosa.Row:
+				result = r.Columns()
+			case pilosa.RowIdentifiers:
+				result = r.Rows
+			}
+			if !reflect.DeepEqual(result, tt.exp) {
+				t.Fatalf("%s (max %d): unexpected result: %v", tt.query, tt.maxResultRows, result)
+			} else if resp.Truncated != tt.truncated {
+				t.Fatalf("%s (max %d): unexpected truncated: %t", tt.query, tt.maxResultRows, resp.Truncated)
+			}
+		}
+	}
+}
+
+func TestAPI_AutoCreate(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		c := test.MustRunCluster(t, 1)
+		defer c.Close()
+
+		if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: "Set(1, f=1)"}); errors.Cause(err) != pilosa.ErrIndexNotFound {
+			t.Fatalf("expected index not found, got %v", err)
+		} else if c[0].Server.Holder().Index("i") != nil {
+			t.Fatal("expected no index")
+		}
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		c := test.MustRunCluster(t, 2, []server.CommandOption{server.OptCommandServerOptions(pilosa.OptServerAutoCreate(""))})
+		defer c.Close()
+		ctx := context.Background()
+
+		if resp := c[0].MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: "Set(1, f=1)"}); !resp.AutoCreated {
+			t.Fatal("expected auto-creation")
+		} else if resp := c[1].MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: "Set(2, f=1) Count(Row(f=1))"}); resp.AutoCreated {
+			t.Fatal("unexpected auto-creation")
+		} else if resp.Results[1] != uint64(2) {
+			t.Fatalf("unexpected count: %v", resp.Results[1])
+		}
+
+		// Keys are used for the index and field written to with them.
+		c[0].MustQuery(t, &pilosa.QueryRequest{Index: "k", Query: `Set("a", f="x")`})
+
+		var created bool
+		if err := c[0].API.Import(ctx, &pilosa.ImportRequest{Index: "i", Field: "g", RowIDs: []uint64{1}, ColumnIDs: []uint64{3}}, pilosa.OptImportOptionsAutoCreated(&created)); err != nil {
+			t.Fatal(err)
+		} else if !created {
+			t.Fatal("expected auto-creation by import")
+		} else if err := c[0].API.ImportValue(ctx, &pilosa.ImportValueRequest{Index: "i", Field: "v", ColumnIDs: []uint64{3}, Values: []int64{-7}}); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, m := range c {
+			if f := m.Server.Holder().Field("i", "f"); f == nil || f.Type() != pilosa.FieldTypeSet {
+				t.Fatalf("unexpected field: %v", f)
+			} else if f := m.Server.Holder().Field("i", "g"); f == nil || f.Type() != pilosa.FieldTypeSet {
+				t.Fatalf("unexpected field: %v", f)
+			} else if f := m.Server.Holder().Field("i", "v"); f == nil || f.Type() != pilosa.FieldTypeInt {
+				t.Fatalf("unexpected field: %v", f)
+			} else if idx := m.Server.Holder().Index("i"); idx.Keys() || !idx.Options().TrackExistence {
+				t.Fatalf("unexpected index options: %+v", idx.Options())
+			} else if idx := m.Server.Holder().Index("k"); idx == nil || !idx.Keys() || !idx.Field("f").Options().Keys {
+				t.Fatal("expected keys")
+			}
+		}
+		if resp := c[1].MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: "Sum(field=v) Row(g=1)"}); resp.Results[0].(pilosa.ValCount).Val != -7 {
+			t.Fatalf("unexpected sum: %v", resp.Results[0])
+		} else if cols := resp.Results[1].(*pilosa.Row).Columns(); !reflect.DeepEqual(cols, []uint64{3}) {
+			t.Fatalf("unexpected columns: %v", cols)
+		}
+	})
+
+	t.Run("InvalidFieldType", func(t *testing.T) {
+		if _, err := pilosa.NewServer(pilosa.OptServerAutoCreate(pilosa.FieldTypeTime)); errors.Cause(err) != pilosa.ErrInvalidAutoCreateFieldType {
+			t.Fatalf("expected invalid field type, got %v", err)
+		}
+	})
+}
+
+func TestAPI_Shutdown(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+
+	m0 := c[0]
+	ctx := context.Background()
+	if _, err := m0.API.CreateIndex(ctx, "i", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	} else if _, err := m0.API.CreateField(ctx, "i", "f"); err != nil {
+		t.Fatal(err)
+	}
+	m0.MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: "Set(1, f=1)"})
+
+	// Writes are refused first, while queries are still served.
+	m0.API.StopWrites()
+	if err := m0.API.DrainWrites(ctx); err != nil {
+		t.Fatal(err)
+	} else if _, err := m0.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Set(2, f=1)"}); errors.Cause(err) != pilosa.ErrShuttingDown {
+		t.Fatalf("expected shutting down, got %v", err)
+	} else if err := m0.API.Import(ctx, &pilosa.ImportRequest{Index: "i", Field: "f", RowIDs: []uint64{1}, ColumnIDs: []uint64{3}}); errors.Cause(err) != pilosa.ErrShuttingDown {
+		t.Fatalf("expected shutting down, got %v", err)
+	} else if res := m0.MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))"}); res.Results[0] != uint64(1) {
+		t.Fatalf("unexpected count: %v", res.Results[0])
+	}
+
+	m0.API.StopQueries()
+	if err := m0.API.DrainQueries(ctx); err != nil {
+		t.Fatal(err)
+	} else if _, err := m0.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))"}); errors.Cause(err) != pilosa.ErrShuttingDown {
+		t.Fatalf("expected shutting down, got %v", err)
+	} else if err := m0.API.FlushFragments(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAPI_ImportSession(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+
+	m0 := c[0]
+	ctx := context.Background()
+
+	if _, err := m0.API.CreateIndex(ctx, "i", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	} else if _, err := m0.API.CreateField(ctx, "i", "f"); err != nil {
+		t.Fatal(err)
+	}
+
+	mustMarshal := func(req *pilosa.ImportRequest) []byte {
+		buf, err := m0.API.Serializer.Marshal(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return buf
+	}
+	chunkA := mustMarshal(&pilosa.ImportRequest{Shard: 0, RowIDs: []uint64{1, 1}, ColumnIDs: []uint64{1, 2}})
+	chunkB := mustMarshal(&pilosa.ImportRequest{Shard: 1, RowIDs: []uint64{1}, ColumnIDs: []uint64{pilosa.ShardWidth + 1}})
+
+	columns := func() []uint64 {
+		res, err := m0.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Row(f=1)"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.Results[0].(*pilosa.Row).Columns()
+	}
+
+	t.Run("Commit", func(t *testing.T) {
+		s, err := m0.API.CreateImportSession(ctx, "i", "f")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, chunk := range []struct {
+			id   string
+			data []byte
+		}{{"a", chunkA}, {"b", chunkB}, {"a", chunkA}} {
+			if err := m0.API.AddImportSessionChunk(ctx, s.ID, chunk.id, chunk.data); err != nil {
+				t.Fatalf("adding chunk %s: %v", chunk.id, err)
+			}
+		}
+
+		if st, err := m0.API.ImportSession(ctx, s.ID); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(st.Chunks, []string{"a", "b"}) || len(st.Applied) != 0 || len(st.Shards) != 0 {
+			t.Fatalf("unexpected session before commit: %+v", st)
+		}
+		if cols := columns(); len(cols) != 0 {
+			t.Fatalf("expected no data before commit, got %v", cols)
+		}
+
+		st, err := m0.API.CommitImportSession(ctx, s.ID)
+		if err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(st.Applied, []string{"a", "b"}) || !reflect.DeepEqual(st.Shards, []uint64{0, 1}) {
+			t.Fatalf("unexpected session after commit: %+v", st)
+		}
+		if cols := columns(); !reflect.DeepEqual(cols, []uint64{1, 2, pilosa.ShardWidth + 1}) {
+			t.Fatalf("unexpected columns: %v", cols)
+		}
+
+		if _, err := m0.API.ImportSession(ctx, s.ID); errors.Cause(err) != pilosa.ErrImportSessionNotFound {
+			t.Fatalf("expected session to be closed, got %v", err)
+		}
+	})
+
+	t.Run("Abort", func(t *testing.T) {
+		s, err := m0.API.CreateImportSession(ctx, "i", "f", pilosa.OptImportOptionsClear(true))
+		if err != nil {
+			t.Fatal(err)
+		} else if err := m0.API.AddImportSessionChunk(ctx, s.ID, "a", chunkA); err != nil {
+			t.Fatal(err)
+		} else if err := m0.API.AbortImportSession(ctx, s.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := m0.API.CommitImportSession(ctx, s.ID); errors.Cause(err) != pilosa.ErrImportSessionNotFound {
+			t.Fatalf("expected session to be aborted, got %v", err)
+		}
+		if cols := columns(); !reflect.DeepEqual(cols, []uint64{1, 2, pilosa.ShardWidth + 1}) {
+			t.Fatalf("expected aborted clear to have no effect, got %v", cols)
+		}
+	})
+
+	t.Run("BadChunk", func(t *testing.T) {
+		s, err := m0.API.CreateImportSession(ctx, "i", "f")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer m0.API.AbortImportSession(ctx, s.ID) // nolint: errcheck
+
+		if err := m0.API.AddImportSessionChunk(ctx, s.ID, "a", []byte("not protobuf")); err == nil || !strings.Contains(err.Error(), "decoding chunk") {
+			t.Fatalf("expected decoding error, got %v", err)
+		}
+		other := mustMarshal(&pilosa.ImportRequest{Index: "other", RowIDs: []uint64{1}, ColumnIDs: []uint64{1}})
+		if err := m0.API.AddImportSessionChunk(ctx, s.ID, "b", other); err == nil || !strings.Contains(err.Error(), "does not match session index") {
+			t.Fatalf("expected index mismatch, got %v", err)
+		}
+	})
+
+	t.Run("BadChunkAtCommit", func(t *testing.T) {
+		s, err := m0.API.CreateImportSession(ctx, "i", "f")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer m0.API.AbortImportSession(ctx, s.ID) // nolint: errcheck
+
+		good := mustMarshal(&pilosa.ImportRequest{Shard: 0, RowIDs: []uint64{1}, ColumnIDs: []uint64{10}})
+		bad := mustMarshal(&pilosa.ImportRequest{Shard: 0, RowIDs: []uint64{1, 1}, ColumnIDs: []uint64{11}})
+		if err := m0.API.AddImportSessionChunk(ctx, s.ID, "a", good); err != nil {
+			t.Fatal(err)
+		} else if err := m0.API.AddImportSessionChunk(ctx, s.ID, "b", bad); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := m0.API.CommitImportSession(ctx, s.ID); err == nil || !strings.Contains(err.Error(), "mismatch of row/column len") {
+			t.Fatalf("expected length mismatch, got %v", err)
+		}
+		if st, err := m0.API.ImportSession(ctx, s.ID); err != nil {
+			t.Fatal(err)
+		} else if len(st.Applied) != 0 || len(st.Shards) != 0 {
+			t.Fatalf("expected nothing applied, got %+v", st)
+		}
+		if cols := columns(); !reflect.DeepEqual(cols, []uint64{1, 2, pilosa.ShardWidth + 1}) {
+			t.Fatalf("expected failed commit to write nothing, got %v", cols)
+		}
+	})
+
+	// Sessions are reloaded when the node restarts, with the chunks they
+	// accepted, and sessions of deleted fields are discarded.
+	t.Run("Restart", func(t *testing.T) {
+		if _, err := m0.API.CreateField(ctx, "i", "g"); err != nil {
+			t.Fatal(err)
+		}
+		s, err := m0.API.CreateImportSession(ctx, "i", "f")
+		if err != nil {
+			t.Fatal(err)
+		} else if err := m0.API.AddImportSessionChunk(ctx, s.ID, "c", mustMarshal(&pilosa.ImportRequest{Shard: 0, RowIDs: []uint64{1}, ColumnIDs: []uint64{3}})); err != nil {
+			t.Fatal(err)
+		}
+		gone, err := m0.API.CreateImportSession(ctx, "i", "g")
+		if err != nil {
+			t.Fatal(err)
+		} else if err := m0.API.DeleteField(ctx, "i", "g"); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m0.Reopen(); err != nil {
+			t.Fatal(err)
+		}
+		if st, err := m0.API.ImportSession(ctx, s.ID); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(st.Chunks, []string{"c"}) {
+			t.Fatalf("unexpected reloaded session: %+v", st)
+		}
+		if _, err := m0.API.ImportSession(ctx, gone.ID); errors.Cause(err) != pilosa.ErrImportSessionNotFound {
+			t.Fatalf("expected session of deleted field to be discarded, got %v", err)
+		}
+
+		if _, err := m0.API.CommitImportSession(ctx, s.ID); err != nil {
+			t.Fatal(err)
+		} else if cols := columns(); !reflect.DeepEqual(cols, []uint64{1, 2, 3, pilosa.ShardWidth + 1}) {
+			t.Fatalf("unexpected columns: %v", cols)
+		}
+	})
+
+	if _, err := m0.API.CreateImportSession(ctx, "i", "nope"); errors.Cause(err) != pilosa.ErrFieldNotFound {
+		t.Fatalf("expected field not found, got %v", err)
+	}
+}
+
 // offsetModHasher represents a simple, mod-based hashing offset by 1.
 type offsetModHasher struct{}
 
 func (*offsetModHasher) Hash(key uint64, n int) int {
 	return int(key+1) % n
 }
+
+func TestAPI_SyncAntiEntropy(t *testing.T) {
+	c := test.MustNewCluster(t, 2)
+	for _, m := range c {
+		m.Config.Cluster.ReplicaN = 2
+		m.Config.AntiEntropy.Interval = 0
+	}
+	if err := c.Start(); err != nil {
+		t.Fatalf("starting cluster: %v", err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	if _, err := c[0].API.CreateIndex(ctx, "i", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	} else if _, err := c[0].API.CreateField(ctx, "i", "f"); err != nil {
+		t.Fatal(err)
+	}
+
+	hldr0 := &test.Holder{Holder: c[0].Server.Holder()}
+	hldr1 := &test.Holder{Holder: c[1].Server.Holder()}
+
+	// Diverge the replicas of shards 0 and 1.
+	hldr0.SetBit("i", "f", 1, 10)
+	hldr0.SetBit("i", "f", 1, 20)
+	hldr1.SetBit("i", "f", 1, ShardWidth+1)
+
+	if _, err := c[0].API.SyncAntiEntropy(ctx, "", []uint64{0}, false); err == nil || !strings.Contains(err.Error(), "index is required") {
+		t.Fatalf("expected index required error, got: %v", err)
+	}
+	if _, err := c[0].API.SyncAntiEntropy(ctx, "x", nil, false); errors.Cause(err) != pilosa.ErrIndexNotFound {
+		t.Fatalf("expected index not found, got: %v", err)
+	}
+
+	// Only shard 0 is synced.
+	if n, err := c[0].API.SyncAntiEntropy(ctx, "i", []uint64{0}, false); err != nil {
+		t.Fatal(err)
+	} else if n != 2 {
+		t.Fatalf("unexpected bits reconciled: %d", n)
+	}
+	if a := hldr1.Row("i", "f", 1).Columns(); !reflect.DeepEqual(a, []uint64{10, 20, ShardWidth + 1}) {
+		t.Fatalf("unexpected columns on node 1: %v", a)
+	} else if a := hldr0.Row("i", "f", 1).Columns(); !reflect.DeepEqual(a, []uint64{10, 20}) {
+		t.Fatalf("unexpected columns on node 0: %v", a)
+	}
+
+	// A full sync reconciles the rest.
+	if n, err := c[1].API.SyncAntiEntropy(ctx, "", nil, false); err != nil {
+		t.Fatal(err)
+	} else if n != 1 {
+		t.Fatalf("unexpected bits reconciled: %d", n)
+	}
+	if a := hldr0.Row("i", "f", 1).Columns(); !reflect.DeepEqual(a, []uint64{10, 20, ShardWidth + 1}) {
+		t.Fatalf("unexpected columns on node 0: %v", a)
+	}
+
+	// Nothing is left to reconcile.
+	if n, err := c[0].API.SyncAntiEntropy(ctx, "i", nil, false); err != nil {
+		t.Fatal(err)
+	} else if n != 0 {
+		t.Fatalf("unexpected bits reconciled: %d", n)
+	}
+}
+
+func TestAPI_SelfHeal(t *testing.T) {
+	c := test.MustNewCluster(t, 2)
+	for _, m := range c {
+		m.Config.Cluster.ReplicaN = 2
+		m.Config.AntiEntropy.Interval = 0
+	}
+	if err := c.Start(); err != nil {
+		t.Fatalf("starting cluster: %v", err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	if _, err := c[0].API.CreateIndex(ctx, "i", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	} else if _, err := c[0].API.CreateField(ctx, "i", "f"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Shards 0 and 1 only exist on node 0, but node 1 knows they are
+	// available, as it would after losing its data directory.
+	hldr0 := &test.Holder{Holder: c[0].Server.Holder()}
+	hldr1 := &test.Holder{Holder: c[1].Server.Holder()}
+	hldr0.SetBit("i", "f", 1, 10)
+	hldr0.SetBit("i", "f", 1, ShardWidth+1)
+	if err := hldr1.Field("i", "f").AddRemoteAvailableShards(roaring.NewBitmap(0, 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	if status := c[1].API.SelfHealStatus(ctx); status.State != pilosa.SelfHealStateIdle {
+		t.Fatalf("unexpected state: %s", status.State)
+	}
+	if status, err := c[1].API.SelfHeal(ctx); err != nil {
+		t.Fatal(err)
+	} else if status.MissingShards != 2 {
+		t.Fatalf("unexpected missing shards: %d", status.MissingShards)
+	}
+
+	var status pilosa.SelfHealStatus
+	if err := test.RetryUntil(5*time.Second, func() error {
+		if status = c[1].API.SelfHealStatus(ctx); status.State == pilosa.SelfHealStateRunning {
+			return errors.New("self-heal still running")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if status.State != pilosa.SelfHealStateDone || status.HealedShards != 2 || status.Fragments < 2 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+	if a := hldr1.Row("i", "f", 1).Columns(); !reflect.DeepEqual(a, []uint64{10, ShardWidth + 1}) {
+		t.Fatalf("unexpected columns on node 1: %v", a)
+	}
+
+	// Nothing is missing anymore.
+	if status, err := c[1].API.SelfHeal(ctx); err != nil {
+		t.Fatal(err)
+	} else if status.MissingShards != 0 {
+		t.Fatalf("unexpected missing shards: %d", status.MissingShards)
+	}
+}
+
+// Ensure nodes which apply a broadcast schema change record its version.
+func TestAPI_SchemaVersions(t *testing.T) {
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+
+	ctx := context.Background()
+	if _, err := c[0].API.CreateIndex(ctx, "i", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	} else if _, err := c[0].API.CreateField(ctx, "i", "f"); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := map[string]uint64{c[0].API.Node().ID: 2}
+	for i := range c {
+		if vv := c[i].API.SchemaVersions(ctx); !reflect.DeepEqual(vv, exp) {
+			t.Fatalf("unexpected schema versions on node %d: %v", i, vv)
+		}
+	}
+}
+
+func TestAPI_UndeleteIndex(t *testing.T) {
+	c := test.MustRunCluster(t, 2,
+		[]server.CommandOption{
+			server.OptCommandServerOptions(pilosa.OptServerTombstoneGracePeriod(time.Hour)),
+		},
+		[]server.CommandOption{
+			server.OptCommandServerOptions(pilosa.OptServerTombstoneGracePeriod(time.Hour)),
+		},
+	)
+	defer c.Close()
+
+	ctx := context.Background()
+	if _, err := c[0].API.CreateIndex(ctx, "i", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	} else if _, err := c[0].API.CreateField(ctx, "i", "f"); err != nil {
+		t.Fatal(err)
+	}
+	hldr := &test.Holder{Holder: c[0].Server.Holder()}
+	hldr.SetBit("i", "f", 1, 10)
+
+	if _, err := c[0].API.UndeleteIndex(ctx, "i"); err == nil || !strings.Contains(err.Error(), pilosa.ErrIndexExists.Error()) {
+		t.Fatalf("expected index exists error, got: %v", err)
+	}
+
+	if err := c[0].API.DeleteIndex(ctx, "i"); err != nil {
+		t.Fatal(err)
+	}
+	for i := range c {
+		if c[i].Server.Holder().Index("i") != nil {
+			t.Fatalf("index still exists on node %d", i)
+		}
+		tombstones, err := c[i].API.IndexTombstones(ctx)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(tombstones) != 1 || tombstones[0].Index != "i" {
+			t.Fatalf("unexpected tombstones on node %d: %+v", i, tombstones)
+		} else if d := tombstones[0].ExpiresAt.Sub(tombstones[0].DeletedAt); d != time.Hour {
+			t.Fatalf("unexpected grace period on node %d: %s", i, d)
+		}
+	}
+
+	if _, err := c[0].API.UndeleteIndex(ctx, "i"); err != nil {
+		t.Fatal(err)
+	}
+	for i := range c {
+		if c[i].Server.Holder().Index("i") == nil {
+			t.Fatalf("index not restored on node %d", i)
+		}
+		if tombstones, err := c[i].API.IndexTombstones(ctx); err != nil {
+			t.Fatal(err)
+		} else if len(tombstones) != 0 {
+			t.Fatalf("unexpected tombstones on node %d: %+v", i, tombstones)
+		}
+	}
+	if a := hldr.Row("i", "f", 1).Columns(); !reflect.DeepEqual(a, []uint64{10}) {
+		t.Fatalf("unexpected columns: %v", a)
+	}
+
+	if _, err := c[0].API.UndeleteIndex(ctx, "x"); errors.Cause(err) != pilosa.ErrIndexTombstoneNotFound {
+		t.Fatalf("expected tombstone not found, got: %v", err)
+	}
+}
+
+func TestAPI_QueryCache(t *testing.T) {
+	c := test.MustRunCluster(t, 1,
+		[]server.CommandOption{
+			server.OptCommandServerOptions(pilosa.OptServerQueryCache(10, 0)),
+		},
+	)
+	defer c.Close()
+
+	ctx := context.Background()
+	if _, err := c[0].API.CreateIndex(ctx, "i", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	} else if _, err := c[0].API.CreateField(ctx, "i", "f"); err != nil {
+		t.Fatal(err)
+	}
+	hldr := &test.Holder{Holder: c[0].Server.Holder()}
+
+	count := func() uint64 {
+		t.Helper()
+		resp, err := c[0].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Results[0].(uint64)
+	}
+
+	hldr.SetBit("i", "f", 1, 10)
+	if n := count(); n != 1 {
+		t.Fatalf("unexpected count: %d", n)
+	}
+
+	// Writes through a query invalidate cached results.
+	if _, err := c[0].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Set(20, f=1)"}); err != nil {
+		t.Fatal(err)
+	} else if n := count(); n != 2 {
+		t.Fatalf("unexpected count after set: %d", n)
+	}
+
+	// As do writes to the fragments directly, e.g. from imports.
+	hldr.SetBit("i", "f", 1, ShardWidth+1)
+	if n := count(); n != 3 {
+		t.Fatalf("unexpected count after import: %d", n)
+	}
+	if _, err := c[0].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "ClearRow(f=1)"}); err != nil {
+		t.Fatal(err)
+	} else if n := count(); n != 0 {
+		t.Fatalf("unexpected count after clear row: %d", n)
+	}
+
+	// Attribute writes invalidate results which include attributes.
+	row := func() map[string]interface{} {
+		t.Helper()
+		resp, err := c[0].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Row(f=1)"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Results[0].(*pilosa.Row).Attrs
+	}
+	if attrs := row(); len(attrs) != 0 {
+		t.Fatalf("unexpected attrs: %v", attrs)
+	}
+	if _, err := c[0].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: `SetRowAttrs(f, 1, x="y")`}); err != nil {
+		t.Fatal(err)
+	} else if attrs := row(); !reflect.DeepEqual(attrs, map[string]interface{}{"x": "y"}) {
+		t.Fatalf("unexpected attrs after set: %v", attrs)
+	}
+
+	// Results are not served for a recreated index.
+	if err := c[0].API.DeleteIndex(ctx, "i"); err != nil {
+		t.Fatal(err)
+	} else if _, err := c[0].API.CreateIndex(ctx, "i", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	} else if _, err := c[0].API.CreateField(ctx, "i", "f"); err != nil {
+		t.Fatal(err)
+	} else if n := count(); n != 0 {
+		t.Fatalf("unexpected count after recreate: %d", n)
+	}
+}
+
+func TestAPI_CreateSchema(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	ctx := context.Background()
+
+	if _, err := c[0].API.CreateIndex(ctx, "i0", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	defs := []pilosa.IndexDefinition{
+		{
+			Name:    "i0",
+			Options: pilosa.IndexOptions{Keys: true},
+			Fields: []pilosa.FieldDefinition{
+				{Name: "f"},
+				{Name: "bad field"},
+			},
+		},
+		{
+			Name:    "i1",
+			Options: pilosa.IndexOptions{Keys: true, TimeQuantum: "YMD"},
+			Fields: []pilosa.FieldDefinition{
+				{Name: "t", Options: []pilosa.FieldOption{pilosa.OptFieldTypeTime("")}},
+				{Name: "n", Options: []pilosa.FieldOption{pilosa.OptFieldTypeInt(0, 100)}},
+			},
+		},
+		{
+			Name:   "bad index",
+			Fields: []pilosa.FieldDefinition{{Name: "f"}},
+		},
+	}
+	results, err := c[1].API.CreateSchema(ctx, defs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := func(results []pilosa.CreateSchemaResult) (created, failed []string) {
+		for _, res := range results {
+			name := res.Index + "/" + res.Field
+			if res.Error != "" {
+				failed = append(failed, name)
+			} else if res.Created {
+				created = append(created, name)
+			}
+		}
+		return created, failed
+	}
+	if created, failed := created(results); !reflect.DeepEqual(created, []string{"i0/f", "i1/", "i1/t", "i1/n"}) {
+		t.Fatalf("unexpected created items: %v", created)
+	} else if !reflect.DeepEqual(failed, []string{"i0/bad field", "bad index/", "bad index/f"}) {
+		t.Fatalf("unexpected failed items: %v", failed)
+	}
+
+	for i, m := range c {
+		hldr := m.Server.Holder()
+		if hldr.Field("i0", "f") == nil {
+			t.Fatalf("node %d: expected field i0/f", i)
+		} else if hldr.Index("i0").Keys() {
+			t.Fatalf("node %d: expected the options of existing index i0 to be unchanged", i)
+		} else if idx := hldr.Index("i1"); idx == nil || !idx.Keys() {
+			t.Fatalf("node %d: expected keyed index i1", i)
+		} else if q := hldr.Field("i1", "t").TimeQuantum(); q != "YMD" {
+			t.Fatalf("node %d: unexpected time quantum: %q", i, q)
+		} else if typ := hldr.Field("i1", "n").Type(); typ != pilosa.FieldTypeInt {
+			t.Fatalf("node %d: unexpected field type: %q", i, typ)
+		}
+	}
+
+	// Applying the definitions again creates nothing new and reports the
+	// same failures.
+	results, err = c[2].API.CreateSchema(ctx, defs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created, failed := created(results); len(created) != 0 {
+		t.Fatalf("unexpected created items: %v", created)
+	} else if len(failed) != 3 {
+		t.Fatalf("unexpected failed items: %v", failed)
+	}
+}
+
+func TestAPI_RowColumns(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	ctx := context.Background()
+
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "k", pilosa.OptFieldKeys())
+	cols := []uint64{1, 2, ShardWidth + 3, 3*ShardWidth - 1, 5 * ShardWidth}
+	var bits [][2]uint64
+	for _, col := range cols {
+		bits = append(bits, [2]uint64{10, col})
+	}
+	bits = append(bits, [2]uint64{11, 4})
+	c.ImportBits(t, "i", "f", bits)
+	if _, err := c[0].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: `Set(7, k="a") Set(8, k="b")`}); err != nil {
+		t.Fatal(err)
+	}
+
+	rowColumns := func(field string, row interface{}, start, end uint64) (columns []uint64, calls int) {
+		t.Helper()
+		if err := c[1].API.RowColumns(ctx, "i", field, row, start, end, func(cols []uint64) error {
+			columns = append(columns, cols...)
+			calls++
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return columns, calls
+	}
+
+	if columns, calls := rowColumns("f", uint64(10), 0, 0); !reflect.DeepEqual(columns, cols) {
+		t.Fatalf("unexpected columns: %v", columns)
+	} else if calls != 4 {
+		t.Fatalf("expected a call per shard, got %d", calls)
+	}
+	if columns, _ := rowColumns("f", uint64(10), 2, 3*ShardWidth-1); !reflect.DeepEqual(columns, []uint64{2, ShardWidth + 3}) {
+		t.Fatalf("unexpected columns in range: %v", columns)
+	}
+	if columns, _ := rowColumns("k", "a", 0, 0); !reflect.DeepEqual(columns, []uint64{7}) {
+		t.Fatalf("unexpected columns of keyed row: %v", columns)
+	}
+
+	if err := c[0].API.RowColumns(ctx, "i", "f", "a", 0, 0, func([]uint64) error { return nil }); err == nil {
+		t.Fatal("expected error for a key in a field without keys")
+	} else if err := c[0].API.RowColumns(ctx, "i", "f", uint64(10), 5, 5, func([]uint64) error { return nil }); err == nil {
+		t.Fatal("expected error for an empty range")
+	}
+}
+
+func TestAPI_Replication(t *testing.T) {
+	primary := test.MustRunCluster(t, 1)
+	defer primary.Close()
+	ctx := context.Background()
+
+	primary.CreateField(t, "i", pilosa.IndexOptions{TrackExistence: true}, "f")
+	primary.CreateField(t, "k", pilosa.IndexOptions{Keys: true}, "f")
+	primary.ImportBits(t, "i", "f", [][2]uint64{{1, 1}, {1, ShardWidth + 1}, {1, 3*ShardWidth + 1}, {2, 5}})
+	if _, err := primary[0].API.Query(ctx, &pilosa.QueryRequest{Index: "k", Query: `Set("a", f=1)`}); err != nil {
+		t.Fatal(err)
+	}
+
+	standby := test.MustRunCluster(t, 2,
+		[]server.CommandOption{
+			server.OptCommandServerOptions(pilosa.OptServerReplicationUpstream(primary[0].URL(), 50*time.Millisecond)),
+		},
+	)
+	defer standby.Close()
+
+	// waitFor polls the standby until query returns the expected result.
+	waitFor := func(index, query string, exp interface{}) {
+		t.Helper()
+		var got interface{}
+		for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(50 * time.Millisecond) {
+			resp, err := standby[1].API.Query(ctx, &pilosa.QueryRequest{Index: index, Query: query})
+			if err != nil {
+				continue
+			}
+			if got = resp.Results[0]; reflect.DeepEqual(got, exp) {
+				return
+			}
+		}
+		t.Fatalf("unexpected result of %s: %v, expected %v", query, got, exp)
+	}
+	waitFor("i", "Count(Row(f=1))", uint64(3))
+	waitFor("i", "Count(Not(Row(f=1)))", uint64(1))
+	waitFor("k", "Count(Row(f=1))", uint64(1))
+	for deadline := time.Now().Add(10 * time.Second); ; time.Sleep(50 * time.Millisecond) {
+		resp, err := standby[1].API.Query(ctx, &pilosa.QueryRequest{Index: "k", Query: "Row(f=1)"})
+		if err == nil && reflect.DeepEqual(resp.Results[0].(*pilosa.Row).Keys, []string{"a"}) {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("column keys were not replicated: %v, %v", resp, err)
+		}
+	}
+
+	status := standby[0].API.ReplicationStatus(ctx)
+	if status.State != pilosa.ReplicationStateStandby || status.Upstream != primary[0].URL() || status.LastSync.IsZero() || status.Fragments == 0 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+
+	// The standby refuses writes and schema changes.
+	if _, err := standby[0].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Set(9, f=1)"}); err == nil || err.Error() != pilosa.ErrReadOnlyStandby.Error() {
+		t.Fatalf("expected read-only error from query, got: %v", err)
+	} else if _, err := standby[0].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Options(Set(9, f=1), excludeColumns=true)"}); err == nil || err.Error() != pilosa.ErrReadOnlyStandby.Error() {
+		t.Fatalf("expected read-only error from nested write, got: %v", err)
+	} else if _, err := standby[0].API.CreateIndex(ctx, "x", pilosa.IndexOptions{}); errors.Cause(err) == nil || errors.Cause(err).Error() != pilosa.ErrReadOnlyStandby.Error() {
+		t.Fatalf("expected read-only error from create index, got: %v", err)
+	}
+
+	// New data and schema changes upstream are pulled.
+	primary.ImportBits(t, "i", "f", [][2]uint64{{1, 2*ShardWidth + 1}})
+	if err := primary[0].API.DeleteField(ctx, "k", "f"); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("i", "Count(Row(f=1))", uint64(4))
+	for deadline := time.Now().Add(10 * time.Second); standby[0].Server.Holder().Field("k", "f") != nil; time.Sleep(50 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("deleted field was not deleted on the standby")
+		}
+	}
+
+	// Promotion stops replication and makes the standby writable.
+	for _, m := range standby {
+		if status, err := m.API.PromoteStandby(ctx); err != nil {
+			t.Fatal(err)
+		} else if status.State != pilosa.ReplicationStatePromoted {
+			t.Fatalf("unexpected state after promotion: %s", status.State)
+		}
+	}
+	if _, err := standby[0].API.PromoteStandby(ctx); errors.Cause(err) == nil {
+		t.Fatal("expected error promoting twice")
+	}
+	if _, err := standby[0].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Set(9, f=1)"}); err != nil {
+		t.Fatal(err)
+	}
+	primary.ImportBits(t, "i", "f", [][2]uint64{{1, 4*ShardWidth + 1}})
+	time.Sleep(200 * time.Millisecond)
+	if resp, err := standby[1].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))"}); err != nil {
+		t.Fatal(err)
+	} else if n := resp.Results[0].(uint64); n != 5 {
+		t.Fatalf("unexpected count after promotion: %d", n)
+	}
+}
+
+// Ensure that a promoted standby stays promoted once restarted.
+func TestAPI_Replication_PromotedRestart(t *testing.T) {
+	ctx := context.Background()
+	primary := test.MustRunCluster(t, 1)
+	defer primary.Close()
+	primary.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+
+	standby := test.MustRunCluster(t, 1,
+		[]server.CommandOption{
+			server.OptCommandServerOptions(pilosa.OptServerReplicationUpstream(primary[0].URL(), 50*time.Millisecond)),
+		},
+	)
+	defer standby.Close()
+	for deadline := time.Now().Add(10 * time.Second); standby[0].Server.Holder().Field("i", "f") == nil; time.Sleep(50 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("schema was not replicated")
+		}
+	}
+	if _, err := standby[0].API.PromoteStandby(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := standby[0].Reopen(); err != nil {
+		t.Fatal(err)
+	} else if status := standby[0].API.ReplicationStatus(ctx); status.State != pilosa.ReplicationStatePromoted {
+		t.Fatalf("unexpected state after restart: %s", status.State)
+	}
+	if _, err := standby[0].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Set(1, f=1)"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAPI_QueryPagination(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	ctx := context.Background()
+
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "g")
+	// Rows 1 to 5 of f have from five columns down to one, in different
+	// shards, and rows 2 and 3 have the same count.
+	var bits [][2]uint64
+	for row, n := range map[uint64]uint64{1: 5, 2: 3, 3: 3, 4: 2, 5: 1} {
+		for col := uint64(0); col < n; col++ {
+			bits = append(bits, [2]uint64{row, col * ShardWidth})
+		}
+	}
+	c.ImportBits(t, "i", "f", bits)
+	c.ImportBits(t, "i", "g", [][2]uint64{{1, 0}, {2, ShardWidth}})
+
+	query := func(q string, limit uint64, cursor string) pilosa.QueryResponse {
+		t.Helper()
+		resp, err := c[0].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: q, Limit: limit, Cursor: cursor})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	// Page through TopN two pairs at a time.
+	var ids []uint64
+	var cursor string
+	for page := 0; ; page++ {
+		resp := query("TopN(f)", 2, cursor)
+		for _, p := range resp.Results[0].([]pilosa.Pair) {
+			ids = append(ids, p.ID)
+		}
+		if !resp.Page.More {
+			if page != 2 {
+				t.Fatalf("unexpected number of pages: %d", page+1)
+			}
+			break
+		}
+		cursor = resp.Page.Cursor
+	}
+	if !reflect.DeepEqual(ids, []uint64{1, 2, 3, 4, 5}) {
+		t.Fatalf("unexpected pairs: %v", ids)
+	}
+
+	// Page through GroupBy, whose groups are ordered by row ID.
+	resp := query("GroupBy(Rows(f), Rows(g))", 1, "")
+	if groups := resp.Results[0].([]pilosa.GroupCount); len(groups) != 1 || groups[0].Group[0].RowID != 1 || groups[0].Group[1].RowID != 1 {
+		t.Fatalf("unexpected first page: %v", groups)
+	} else if !resp.Page.More {
+		t.Fatal("expected more groups")
+	}
+	resp = query("GroupBy(Rows(f), Rows(g))", 0, resp.Page.Cursor)
+	if groups := resp.Results[0].([]pilosa.GroupCount); len(groups) != 8 {
+		t.Fatalf("unexpected remaining groups: %v", groups)
+	} else if resp.Page.More || resp.Page.Cursor != "" {
+		t.Fatalf("unexpected page: %+v", resp.Page)
+	}
+
+	// Without pagination there is no page.
+	if resp := query("TopN(f)", 0, ""); resp.Page != nil || len(resp.Results[0].([]pilosa.Pair)) != 5 {
+		t.Fatalf("unexpected unpaginated response: %+v", resp)
+	}
+
+	// Only single TopN or GroupBy calls can be paginated.
+	for _, q := range []string{"Row(f=1)", "TopN(f) TopN(g)"} {
+		if _, err := c[0].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: q, Limit: 1}); err == nil {
+			t.Fatalf("expected error paginating %s", q)
+		}
+	}
+	if _, err := c[0].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "TopN(f)", Cursor: "!"}); err == nil {
+		t.Fatal("expected error for invalid cursor")
+	}
+}
+
+func TestAPI_QueryLimits(t *testing.T) {
+	c := test.MustRunCluster(t, 1,
+		[]server.CommandOption{
+			server.OptCommandServerOptions(pilosa.OptServerQueryLimits(2, 3)),
+		},
+	)
+	defer c.Close()
+	m := c[0]
+	m.MustCreateIndex(t, "i", pilosa.IndexOptions{})
+	m.MustCreateField(t, "i", "f")
+
+	ctx := context.Background()
+	m.MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: `Count(Row(f=1)) Row(f=2)`})
+	for q, exp := range map[string]string{
+		`Count(Not(Row(f=1)))`:                      "query exceeds the maximum depth of 2",
+		`Row(f=1) Row(f=2) Row(f=3) Row(f=4)`:       "query exceeds the maximum operations of 3",
+		`Count(Union(Row(f=1), Row(f=2))) Row(f=3)`: "query exceeds the maximum depth of 2",
+	} {
+		if _, err := m.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: q}); err == nil || !strings.Contains(err.Error(), exp) {
+			t.Errorf("querying %s: expected %q, got %v", q, exp, err)
+		}
+	}
+
+	// Remote queries are still limited in depth.
+	if _, err := m.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: `Count(Not(Row(f=1)))`, Remote: true, Shards: []uint64{0}}); err == nil || !strings.Contains(err.Error(), "query exceeds the maximum depth of 2") {
+		t.Errorf("expected depth limit error for a remote query, got %v", err)
+	} else if _, err := m.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: `Row(f=1) Row(f=2) Row(f=3) Row(f=4)`, Remote: true, Shards: []uint64{0}}); err != nil {
+		t.Errorf("querying remote: %v", err)
+	}
+}
+
+func TestAPI_Warmup(t *testing.T) {
+	path := filepath.Join(os.TempDir(), fmt.Sprintf("pilosa-warmup-%d.log", time.Now().UnixNano()))
+	defer os.Remove(path)
+	c := test.MustRunCluster(t, 1,
+		[]server.CommandOption{
+			server.OptCommandServerOptions(pilosa.OptServerWarmup(path, 1, 10, time.Minute)),
+		},
+	)
+	defer c.Close()
+	m := c[0]
+	m.MustCreateIndex(t, "i", pilosa.IndexOptions{})
+	m.MustCreateField(t, "i", "f", pilosa.OptFieldKeys())
+	m.MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: `Set(1, f="a")`})
+	m.MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: `Count(Row(f="a"))`})
+
+	// Read queries are recorded with their keys translated.
+	if buf, err := ioutil.ReadFile(path); err != nil {
+		t.Fatal(err)
+	} else if got := string(buf); got != `{"index":"i","query":"Count(Row(f=1))"}`+"\n" {
+		t.Fatalf("unexpected warmup log: %s", got)
+	}
+
+	if err := m.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+	if resp := m.MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: `Count(Row(f="a"))`}); resp.Results[0] != uint64(1) {
+		t.Fatalf("unexpected count: %v", resp.Results[0])
+	}
+}
+
+func TestAPI_ShardOwners(t *testing.T) {
+	c := test.MustRunCluster(t, 3,
+		[]server.CommandOption{
+			server.OptCommandServerOptions(pilosa.OptServerReplicaN(2)),
+		},
+	)
+	defer c.Close()
+
+	ctx := context.Background()
+	if _, err := c[0].API.CreateIndex(ctx, "i", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every node agrees on the owners, which are those of the cluster's
+	// placement.
+	for shard := uint64(0); shard < 10; shard++ {
+		exp, err := c[0].API.ShardNodes(ctx, "i", shard)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range c {
+			owners, err := m.API.ShardOwners(ctx, "i", shard)
+			if err != nil {
+				t.Fatal(err)
+			} else if owners.Primary.ID != exp[0].ID || len(owners.Replicas) != 1 || owners.Replicas[0].ID != exp[1].ID {
+				t.Fatalf("unexpected owners of shard %d: %+v", shard, owners)
+			}
+		}
+	}
+
+	if owners, err := c[1].API.ColumnOwners(ctx, "i", 3*pilosa.ShardWidth+7, ""); err != nil {
+		t.Fatal(err)
+	} else if owners.Shard != 3 || *owners.Column != 3*pilosa.ShardWidth+7 {
+		t.Fatalf("unexpected owners: %+v", owners)
+	}
+
+	if _, err := c[0].API.ShardOwners(ctx, "nope", 0); errors.Cause(err) != pilosa.ErrIndexNotFound {
+		t.Fatalf("expected index not found, got %v", err)
+	} else if _, err := c[0].API.ColumnOwners(ctx, "i", 0, "key"); err == nil {
+		t.Fatal("expected error for a key of an index without keys")
+	}
+
+	// Keys are looked up on every node without being created.
+	if _, err := c[0].API.CreateIndex(ctx, "k", pilosa.IndexOptions{Keys: true}); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range c {
+		if _, err := m.API.ColumnOwners(ctx, "k", 0, "new"); errors.Cause(err) != pilosa.ErrColumnKeyNotFound {
+			t.Fatalf("expected column key not found, got %v", err)
+		}
+	}
+	if id, err := c[0].Server.Holder().Index("k").TranslateStore().FindKey("new"); err != nil || id != 0 {
+		t.Fatalf("unexpected key id: %d, %v", id, err)
+	}
+	c.CreateField(t, "k", pilosa.IndexOptions{Keys: true}, "f")
+	c.Query(t, "k", `Set("a", f=1)`)
+
+	// Keys reach the other nodes asynchronously.
+	for _, m := range c {
+		var owners *pilosa.ShardOwners
+		var err error
+		for i := 0; i < 50; i++ {
+			if owners, err = m.API.ColumnOwners(ctx, "k", 0, "a"); errors.Cause(err) != pilosa.ErrColumnKeyNotFound {
+				break
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		if err != nil {
+			t.Fatal(err)
+		} else if *owners.Column == 0 || owners.Key != "a" {
+			t.Fatalf("unexpected owners: %+v", owners)
+		}
+	}
+}
+
+func TestAPI_SchemaLimits(t *testing.T) {
+	c := test.MustRunCluster(t, 2,
+		[]server.CommandOption{
+			server.OptCommandServerOptions(pilosa.OptServerLimits(2, 2, 0), pilosa.OptServerTombstoneGracePeriod(time.Hour)),
+		},
+	)
+	defer c.Close()
+
+	ctx := context.Background()
+	isLimitError := func(err error) bool {
+		_, ok := errors.Cause(err).(pilosa.BadRequestError)
+		return ok && strings.Contains(err.Error(), "schema limit reached")
+	}
+
+	// The existence field is not counted against the field limit.
+	if _, err := c[0].API.CreateIndex(ctx, "i", pilosa.IndexOptions{TrackExistence: true}); err != nil {
+		t.Fatal(err)
+	} else if _, err := c[0].API.CreateField(ctx, "i", "f"); err != nil {
+		t.Fatal(err)
+	} else if _, err := c[1].API.CreateField(ctx, "i", "g"); err != nil {
+		t.Fatal(err)
+	} else if _, err := c[0].API.CreateField(ctx, "i", "h"); !isLimitError(err) {
+		t.Fatalf("expected field limit error, got %v", err)
+	} else if _, err := c[1].API.CreateField(ctx, "i", "h"); !isLimitError(err) {
+		t.Fatalf("expected field limit error on the other node, got %v", err)
+	}
+
+	// Creating an existing field reports that it exists rather than the limit.
+	if _, err := c[0].API.CreateField(ctx, "i", "f"); err == nil || !strings.Contains(err.Error(), pilosa.ErrFieldExists.Error()) {
+		t.Fatalf("expected field exists error, got %v", err)
+	}
+
+	if _, err := c[0].API.CreateIndex(ctx, "j", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	} else if _, err := c[1].API.CreateIndex(ctx, "k", pilosa.IndexOptions{}); !isLimitError(err) {
+		t.Fatalf("expected index limit error, got %v", err)
+	} else if _, err := c[0].API.CreateIndex(ctx, "i", pilosa.IndexOptions{}); err == nil || !strings.Contains(err.Error(), pilosa.ErrIndexExists.Error()) {
+		t.Fatalf("expected index exists error, got %v", err)
+	}
+
+	results, err := c[0].API.CreateSchema(ctx, []pilosa.IndexDefinition{
+		{Name: "j", Fields: []pilosa.FieldDefinition{{Name: "a"}, {Name: "b"}, {Name: "c"}}},
+		{Name: "k"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, res := range results {
+		failed := res.Field == "c" || res.Index == "k"
+		if failed != (res.Error != "") {
+			t.Fatalf("unexpected result: %+v", res)
+		}
+	}
+	if c[1].Server.Holder().Index("k") != nil {
+		t.Fatal("expected index k not to be created")
+	}
+
+	// Applied schemas are checked as a whole, and change no node if they
+	// exceed a limit.
+	if err := c[0].API.ApplySchema(ctx, &pilosa.Schema{Indexes: c[0].Server.Holder().Schema()}, false); err != nil {
+		t.Fatalf("applying existing schema: %v", err)
+	} else if err := c[0].API.ApplySchema(ctx, &pilosa.Schema{Indexes: []*pilosa.IndexInfo{{Name: "k"}}}, false); !isLimitError(err) {
+		t.Fatalf("expected index limit error from applied schema, got %v", err)
+	} else if err := c[1].API.ApplySchema(ctx, &pilosa.Schema{Indexes: []*pilosa.IndexInfo{{Name: "j", Fields: []*pilosa.FieldInfo{{Name: "d"}}}}}, false); !isLimitError(err) {
+		t.Fatalf("expected field limit error from applied schema, got %v", err)
+	}
+	for _, m := range c {
+		if m.Server.Holder().Index("k") != nil || m.Server.Holder().Field("j", "d") != nil {
+			t.Fatal("expected applied schema not to be created")
+		}
+	}
+
+	// Undeleting an index is checked against the index limit.
+	if err := c[0].API.DeleteIndex(ctx, "j"); err != nil {
+		t.Fatal(err)
+	} else if _, err := c[0].API.CreateIndex(ctx, "k", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	} else if _, err := c[1].API.UndeleteIndex(ctx, "j"); !isLimitError(err) {
+		t.Fatalf("expected index limit error from undelete, got %v", err)
+	} else if c[0].Server.Holder().Index("j") != nil {
+		t.Fatal("expected index j not to be undeleted")
+	} else if err := c[0].API.DeleteIndex(ctx, "k"); err != nil {
+		t.Fatal(err)
+	} else if _, err := c[1].API.UndeleteIndex(ctx, "j"); err != nil {
+		t.Fatalf("undeleting within the limit: %v", err)
+	}
+}
+
+func TestAPI_QuerySafeMode(t *testing.T) {
+	c := test.MustRunCluster(t, 1,
+		[]server.CommandOption{
+			server.OptCommandServerOptions(pilosa.OptServerQuerySafeMode(2, 100)),
+		},
+	)
+	defer c.Close()
+
+	ctx := context.Background()
+	if _, err := c[0].API.CreateIndex(ctx, "i", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	} else if _, err := c[0].API.CreateField(ctx, "i", "f"); err != nil {
+		t.Fatal(err)
+	} else if _, err := c[0].API.CreateField(ctx, "i", "g"); err != nil {
+		t.Fatal(err)
+	}
+	hldr := &test.Holder{Holder: c[0].Server.Holder()}
+	hldr.SetBit("i", "f", 1, 0)
+	hldr.SetBit("i", "f", 500, 1)
+	hldr.SetBit("i", "g", 1, 0)
+
+	query := func(q string, allow bool) error {
+		_, err := c[0].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: q, AllowUnbounded: allow})
+		return err
+	}
+
+	// Field f has an estimated 501 rows, more than the limit.
+	for _, q := range []string{"TopN(f)", "Rows(f)", "Rows(f, previous=1)", "GroupBy(Rows(g), Rows(f))"} {
+		err := query(q, false)
+		if _, ok := errors.Cause(err).(pilosa.UnboundedQueryError); !ok {
+			t.Fatalf("expected %s to be rejected, got: %v", q, err)
+		}
+		if err := query(q, true); err != nil {
+			t.Fatalf("unexpected error overriding safe mode for %s: %v", q, err)
+		}
+	}
+
+	// Bounded scans and scans of small fields run.
+	for _, q := range []string{"TopN(f, Row(g=1))", "TopN(f, ids=[1])", "Rows(f, limit=10)", "GroupBy(Rows(f), filter=Row(g=1))", "TopN(g)", "Row(f=1)"} {
+		if err := query(q, false); err != nil {
+			t.Fatalf("unexpected error for %s: %v", q, err)
+		}
+	}
+
+	// As do scans of few shards only.
+	hldr.SetBit("i", "g", 1, 2*ShardWidth)
+	hldr.SetBit("i", "g", 1, 3*ShardWidth)
+	if _, err := c[0].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "TopN(g)", Shards: []uint64{0}}); err != nil {
+		t.Fatal(err)
+	} else if err := query("TopN(g)", false); err == nil {
+		t.Fatal("expected TopN across all shards to be rejected")
+	}
+}
+
+func TestAPI_Rename(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+
+	ctx := context.Background()
+	m0 := c[0]
+	m0.MustCreateIndex(t, "i", pilosa.IndexOptions{TrackExistence: true})
+	m0.MustCreateField(t, "i", "f", pilosa.OptFieldTypeSet(pilosa.CacheTypeRanked, 100))
+	m0.MustCreateIndex(t, "j", pilosa.IndexOptions{})
+	m0.MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: fmt.Sprintf("Set(1, f=1) Set(%d, f=1) Set(%d, f=1)", pilosa.ShardWidth+1, 2*pilosa.ShardWidth+1)})
+
+	// Renames are made on the coordinator, and may not take an existing name.
+	if err := c[1].API.RenameIndex(ctx, "i", "k"); errors.Cause(err) == nil || !strings.Contains(err.Error(), pilosa.ErrNodeNotCoordinator.Error()) {
+		t.Fatalf("expected not coordinator error, got %v", err)
+	} else if err := m0.API.RenameIndex(ctx, "i", "j"); err == nil {
+		t.Fatal("expected error renaming to an existing index")
+	} else if err := m0.API.RenameField(ctx, "i", "f", "_exists"); err == nil {
+		t.Fatal("expected error renaming to the existence field")
+	}
+
+	if err := m0.API.RenameIndex(ctx, "i", "k"); err != nil {
+		t.Fatal(err)
+	} else if err := m0.API.RenameField(ctx, "k", "f", "g"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every node serves the renamed index and field.
+	for i, m := range c {
+		if res := m.MustQuery(t, &pilosa.QueryRequest{Index: "k", Query: "Count(Row(g=1))"}); res.Results[0] != uint64(3) {
+			t.Fatalf("node %d: unexpected count: %v", i, res.Results[0])
+		} else if _, err := m.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Count(Row(f=1))"}); err == nil {
+			t.Fatalf("node %d: expected old index to be gone", i)
+		}
+	}
+
+	// A rename which a node fails to apply is rolled back on every node.
+	holder2 := c[2].Server.Holder()
+	rolledBack := func() {
+		t.Helper()
+		for i, m := range c {
+			if res := m.MustQuery(t, &pilosa.QueryRequest{Index: "k", Query: "Count(Row(g=1))"}); res.Results[0] != uint64(3) {
+				t.Fatalf("node %d: unexpected count after rollback: %v", i, res.Results[0])
+			} else if m.Server.Holder().Index("l") != nil || m.Server.Holder().Field("k", "h") != nil {
+				t.Fatalf("node %d: expected rename to be rolled back", i)
+			}
+		}
+	}
+	if err := os.MkdirAll(holder2.IndexPath("l"), 0777); err != nil {
+		t.Fatal(err)
+	} else if err := m0.API.RenameIndex(ctx, "k", "l"); err == nil {
+		t.Fatal("expected error renaming an index to a name a node fails to apply")
+	}
+	rolledBack()
+	if err := os.MkdirAll(filepath.Join(holder2.IndexPath("k"), "h"), 0777); err != nil {
+		t.Fatal(err)
+	} else if err := m0.API.RenameField(ctx, "k", "g", "h"); err == nil {
+		t.Fatal("expected error renaming a field to a name a node fails to apply")
+	}
+	rolledBack()
+}
+
+func TestAPI_Changes(t *testing.T) {
+	c := test.MustRunCluster(t, 1,
+		[]server.CommandOption{
+			server.OptCommandServerOptions(pilosa.OptServerChangeFeedBufferSize(3)),
+		},
+	)
+	defer c.Close()
+	m := c[0]
+	m.MustCreateIndex(t, "i", pilosa.IndexOptions{})
+	m.MustCreateField(t, "i", "f")
+	m.MustCreateField(t, "i", "g")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	started, batches, done := make(chan struct{}), make(chan []pilosa.ChangeEvent, 10), make(chan error)
+	go func() {
+		done <- m.API.Changes(ctx, 0, "i", "f", func(events []pilosa.ChangeEvent) error {
+			if events == nil {
+				close(started)
+			} else {
+				batches <- events
+			}
+			return nil
+		})
+	}()
+	<-started
+
+	m.MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: `Set(10, f=1) Set(10, g=1) Clear(10, f=1)`})
+	var got []pilosa.ChangeEvent
+	for len(got) < 2 {
+		select {
+		case events := <-batches:
+			got = append(got, events...)
+		case <-time.After(time.Second):
+			t.Fatalf("expected changes, got %+v", got)
+		}
+	}
+	if got[0].Seq != 1 || got[0].Type != pilosa.ChangeSet || got[0].Field != "f" || got[0].Row != 1 || got[0].Column != 10 {
+		t.Fatalf("unexpected event: %+v", got[0])
+	} else if got[1].Seq != 3 || got[1].Type != pilosa.ChangeClear || got[1].Field != "f" {
+		t.Fatalf("unexpected event: %+v", got[1])
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	// The first event is overwritten by the next one.
+	m.MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: `Set(11, f=1)`})
+	err := m.API.Changes(context.Background(), 1, "", "", func([]pilosa.ChangeEvent) error { return nil })
+	if err != (pilosa.ResyncRequiredError{Earliest: 2}) {
+		t.Fatalf("expected resync, got %v", err)
+	}
+
+	// Int values are published with their base.
+	m.MustCreateField(t, "i", "v", pilosa.OptFieldTypeInt(100, 200))
+	m.MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: `Set(12, v=150)`})
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	got = nil
+	if err := m.API.Changes(ctx, 5, "i", "v", func(events []pilosa.ChangeEvent) error {
+		if got = append(got, events...); len(got) > 0 {
+			cancel()
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	} else if len(got) != 1 || got[0].Type != pilosa.ChangeValue || got[0].Column != 12 || got[0].Value == nil || *got[0].Value != 150 {
+		t.Fatalf("unexpected events: %+v", got)
+	}
+}
@@ -33,11 +33,28 @@ func _() {
 	_ = x[apiShardNodes-22]
 	_ = x[apiViews-23]
 	_ = x[apiApplySchema-24]
+	_ = x[apiSetIndexAlias-25]
+	_ = x[apiDeleteIndexAlias-26]
+	_ = x[apiShardDistribution-27]
+	_ = x[apiUpdateIndex-28]
+	_ = x[apiImportSession-29]
+	_ = x[apiSyncAntiEntropy-30]
+	_ = x[apiUndeleteIndex-31]
+	_ = x[apiSelfHeal-32]
+	_ = x[apiQueries-33]
+	_ = x[apiCreateSchema-34]
+	_ = x[apiRowColumns-35]
+	_ = x[apiPromoteStandby-36]
+	_ = x[apiCompactFragments-37]
+	_ = x[apiUsage-38]
+	_ = x[apiClusterTopology-39]
+	_ = x[apiRename-40]
+	_ = x[apiChanges-41]
 }
 
-const _apiMethod_name = "apiClusterMessageapiCreateFieldapiCreateIndexapiDeleteFieldapiDeleteAvailableShardapiDeleteIndexapiDeleteViewapiExportCSVapiFragmentBlockDataapiFragmentBlocksapiFragmentDataapiFieldapiFieldAttrDiffapiImportapiImportValueapiIndexapiIndexAttrDiffapiQueryapiRecalculateCachesapiRemoveNodeapiResizeAbortapiSetCoordinatorapiShardNodesapiViewsapiApplySchema"
+const _apiMethod_name = "apiClusterMessageapiCreateFieldapiCreateIndexapiDeleteFieldapiDeleteAvailableShardapiDeleteIndexapiDeleteViewapiExportCSVapiFragmentBlockDataapiFragmentBlocksapiFragmentDataapiFieldapiFieldAttrDiffapiImportapiImportValueapiIndexapiIndexAttrDiffapiQueryapiRecalculateCachesapiRemoveNodeapiResizeAbortapiSetCoordinatorapiShardNodesapiViewsapiApplySchemaapiSetIndexAliasapiDeleteIndexAliasapiShardDistributionapiUpdateIndexapiImportSessionapiSyncAntiEntropyapiUndeleteIndexapiSelfHealapiQueriesapiCreateSchemaapiRowColumnsapiPromoteStandbyapiCompactFragmentsapiUsageapiClusterTopologyapiRenameapiChanges"
 
-var _apiMethod_index = [...]uint16{0, 17, 31, 45, 59, 82, 96, 109, 121, 141, 158, 173, 181, 197, 206, 220, 228, 244, 252, 272, 285, 299, 316, 329, 337, 351}
+var _apiMethod_index = [...]uint16{0, 17, 31, 45, 59, 82, 96, 109, 121, 141, 158, 173, 181, 197, 206, 220, 228, 244, 252, 272, 285, 299, 316, 329, 337, 351, 367, 386, 406, 420, 436, 454, 470, 481, 491, 506, 519, 536, 555, 563, 581, 590, 600}
 
 func (i apiMethod) String() string {
 	if i < 0 || i >= apiMethod(len(_apiMethod_index)-1) {
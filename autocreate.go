@@ -0,0 +1,164 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"math"
+
+	"github.com/pilosa/pilosa/v2/pql"
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidAutoCreateFieldType is returned when fields can't be auto-created
+// with the given type.
+var ErrInvalidAutoCreateFieldType = errors.New("invalid auto-create field type")
+
+// validAutoCreateFieldType returns true if fields can be auto-created with
+// type typ. Time fields need a quantum, so they are not auto-created.
+func validAutoCreateFieldType(typ string) bool {
+	switch typ {
+	case FieldTypeSet, FieldTypeMutex, FieldTypeBool, FieldTypeInt:
+		return true
+	}
+	return false
+}
+
+// autoCreateFieldOptions returns the options of a field auto-created with
+// type typ. keys is ignored for types without keys.
+func autoCreateFieldOptions(typ string, keys bool) []FieldOption {
+	switch typ {
+	case FieldTypeBool:
+		return []FieldOption{OptFieldTypeBool()}
+	case FieldTypeInt:
+		return []FieldOption{OptFieldTypeInt(math.MinInt64, math.MaxInt64)}
+	}
+	opts := []FieldOption{OptFieldTypeDefault()}
+	if typ == FieldTypeMutex {
+		opts = []FieldOption{OptFieldTypeMutex(DefaultCacheType, DefaultCacheSize)}
+	}
+	if keys {
+		opts = append(opts, OptFieldKeys())
+	}
+	return opts
+}
+
+// autoCreate creates the index and field written to if they don't exist and
+// auto-creation is enabled, so that the write can be applied. The index is
+// created with keys if indexKeys is true, and the field with type fieldType,
+// or the configured type if it is empty, and with keys if fieldKeys is true.
+// It returns true if it created either.
+//
+// Creations are serialized with the other schema changes by the schema lock,
+// so that concurrent writes to a missing field create it once. A field
+// created in the meantime by another node is not created again, and is not
+// reported as created.
+func (api *API) autoCreate(ctx context.Context, indexName, fieldName, fieldType string, indexKeys, fieldKeys bool) (created bool, err error) {
+	if api.server.autoCreateFieldType == "" || api.holder.Field(api.holder.resolveIndexAlias(indexName), fieldName) != nil {
+		return false, nil
+	}
+	if fieldType == "" {
+		fieldType = api.server.autoCreateFieldType
+	}
+
+	api.holder.schemaMu.Lock()
+	defer api.holder.schemaMu.Unlock()
+
+	indexName = api.holder.resolveIndexAlias(indexName)
+	if api.holder.Index(indexName) == nil {
+		if err := api.validate(apiCreateIndex); err != nil {
+			return false, errors.Wrap(err, "validating api method")
+		}
+		if _, err := api.createIndex(ctx, indexName, IndexOptions{Keys: indexKeys, TrackExistence: true}); err == nil {
+			api.server.logger.Printf("auto-created index %s", indexName)
+			created = true
+		} else if !isConflict(err, ErrIndexExists) {
+			return false, errors.Wrapf(err, "auto-creating index %s", indexName)
+		}
+	}
+	if api.holder.Field(indexName, fieldName) == nil {
+		if err := api.validate(apiCreateField); err != nil {
+			return created, errors.Wrap(err, "validating api method")
+		}
+		if _, err := api.createField(ctx, indexName, fieldName, autoCreateFieldOptions(fieldType, fieldKeys)...); err == nil {
+			api.server.logger.Printf("auto-created field %s/%s", indexName, fieldName)
+			created = true
+		} else if !isConflict(err, ErrFieldExists) {
+			return created, errors.Wrapf(err, "auto-creating field %s/%s", indexName, fieldName)
+		}
+	}
+	return created, nil
+}
+
+// isConflict returns true if err is a ConflictError wrapping target.
+func isConflict(err, target error) bool {
+	c, ok := errors.Cause(err).(ConflictError)
+	return ok && c.error == target
+}
+
+// autoCreateQuery auto-creates the indexes and fields which the Set() and
+// Store() calls of a query write to. Keys are used for the index if a column
+// is given as a string, and for a field if a row is.
+func (api *API) autoCreateQuery(ctx context.Context, indexName string, q *pql.Query) (created bool, err error) {
+	if api.server.autoCreateFieldType == "" {
+		return false, nil
+	}
+	for _, c := range q.Calls {
+		if c.Name != "Set" && c.Name != "Store" {
+			continue
+		}
+		fieldName, err := c.FieldArg()
+		if err != nil {
+			continue
+		}
+		_, indexKeys := c.Args["_"+columnLabel].(string)
+		_, fieldKeys := c.Args[fieldName].(string)
+		ok, err := api.autoCreate(ctx, indexName, fieldName, "", indexKeys, fieldKeys)
+		if err != nil {
+			return created, err
+		}
+		created = created || ok
+	}
+	return created, nil
+}
+
+// autoCreateImport auto-creates the index and field of an import, and
+// reports it in options.
+func (api *API) autoCreateImport(ctx context.Context, indexName, fieldName, fieldType string, indexKeys, fieldKeys bool, options *ImportOptions) error {
+	created, err := api.autoCreate(ctx, indexName, fieldName, fieldType, indexKeys, fieldKeys)
+	if err != nil {
+		return err
+	} else if created && options.AutoCreated != nil {
+		*options.AutoCreated = true
+	}
+	return nil
+}
+
+// ImportFieldType returns the type of the field imported into: the type of
+// the field, or the type it is auto-created with if it doesn't exist and
+// auto-creation is enabled.
+func (api *API) ImportFieldType(ctx context.Context, indexName, fieldName string) (string, error) {
+	field, err := api.Field(ctx, indexName, fieldName)
+	if err == nil {
+		return field.Type(), nil
+	}
+	switch errors.Cause(err) {
+	case ErrIndexNotFound, ErrFieldNotFound:
+		if api.server.autoCreateFieldType != "" {
+			return api.server.autoCreateFieldType, nil
+		}
+	}
+	return "", err
+}
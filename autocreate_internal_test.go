@@ -0,0 +1,91 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestAPI_autoCreate(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	client := &messageRecorder{}
+	api := newRecordedAPI(h.Holder, client)
+	api.server.autoCreateFieldType = FieldTypeSet
+	ctx := context.Background()
+
+	t.Run("Created", func(t *testing.T) {
+		if created, err := api.autoCreate(ctx, "i", "f", "", false, false); err != nil {
+			t.Fatal(err)
+		} else if !created {
+			t.Fatal("expected index and field to be created")
+		}
+		if created, err := api.autoCreate(ctx, "i", "f", "", false, false); err != nil {
+			t.Fatal(err)
+		} else if created {
+			t.Fatal("expected existing field not to be created")
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		client.err = errors.New("unreachable")
+		defer func() { client.err = nil }()
+		if created, err := api.autoCreate(ctx, "j", "f", "", false, false); err == nil {
+			t.Fatal("expected error")
+		} else if created {
+			t.Fatal("expected failed creation not to be reported")
+		}
+	})
+
+	t.Run("SchemaLock", func(t *testing.T) {
+		h.schemaMu.Lock()
+		done := make(chan error, 1)
+		go func() {
+			_, err := api.autoCreate(ctx, "i", "g", "", false, false)
+			done <- err
+		}()
+		select {
+		case <-done:
+			t.Fatal("expected auto-create to wait for the schema lock")
+		case <-time.After(50 * time.Millisecond):
+		}
+		h.schemaMu.Unlock()
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		} else if h.Field("i", "g") == nil {
+			t.Fatal("expected field to be created")
+		}
+	})
+}
+
+func TestIsConflict(t *testing.T) {
+	if !isConflict(errors.Wrap(newConflictError(ErrFieldExists), "creating field"), ErrFieldExists) {
+		t.Fatal("expected wrapped field exists conflict")
+	}
+	if isConflict(newConflictError(ErrIndexExists), ErrFieldExists) {
+		t.Fatal("expected other conflict not to match")
+	}
+	if isConflict(ErrFieldExists, ErrFieldExists) {
+		t.Fatal("expected bare error not to be a conflict")
+	}
+}
@@ -19,6 +19,7 @@ import (
 
 	"encoding/binary"
 	"fmt"
+	"os"
 	"sort"
 	"sync"
 	"time"
@@ -67,6 +68,7 @@ func (c *attrCache) Set(id uint64, attrs map[string]interface{}) {
 type attrStore struct {
 	mu        sync.RWMutex
 	path      string
+	perm      os.FileMode
 	db        *bolt.DB
 	attrCache *attrCache
 }
@@ -80,9 +82,18 @@ func newAttrCache() *attrCache {
 
 // NewAttrStore returns a new instance of AttrStore.
 func NewAttrStore(path string) pilosa.AttrStore {
-	return &attrStore{
-		path:      path,
-		attrCache: newAttrCache(),
+	return NewAttrStoreWithPerm(pilosa.DefaultFilePerm)(path)
+}
+
+// NewAttrStoreWithPerm returns a function which returns new instances of
+// AttrStore whose database files are created with the given mode.
+func NewAttrStoreWithPerm(perm os.FileMode) func(path string) pilosa.AttrStore {
+	return func(path string) pilosa.AttrStore {
+		return &attrStore{
+			path:      path,
+			perm:      perm,
+			attrCache: newAttrCache(),
+		}
 	}
 }
 
@@ -92,7 +103,7 @@ func (s *attrStore) Path() string { return s.path }
 // Open opens and initializes the store.
 func (s *attrStore) Open() error {
 	// Open storage.
-	db, err := bolt.Open(s.path, 0666, &bolt.Options{Timeout: 1 * time.Second})
+	db, err := bolt.Open(s.path, s.perm, &bolt.Options{Timeout: 1 * time.Second})
 	if err != nil {
 		return errors.Wrap(err, "opening storage")
 	}
@@ -33,12 +33,22 @@ var (
 
 // OpenTranslateStore opens and initializes a boltdb translation store.
 func OpenTranslateStore(path, index, field string) (pilosa.TranslateStore, error) {
-	s := NewTranslateStore(index, field)
-	s.Path = path
-	if err := s.Open(); err != nil {
-		return nil, err
+	return OpenTranslateStoreWithPerm(pilosa.DefaultDirPerm, pilosa.DefaultFilePerm)(path, index, field)
+}
+
+// OpenTranslateStoreWithPerm returns a function which opens and initializes
+// boltdb translation stores, creating their directories and files with the
+// given modes.
+func OpenTranslateStoreWithPerm(dirPerm, filePerm os.FileMode) pilosa.OpenTranslateStoreFunc {
+	return func(path, index, field string) (pilosa.TranslateStore, error) {
+		s := NewTranslateStore(index, field)
+		s.Path = path
+		s.DirPerm, s.FilePerm = dirPerm, filePerm
+		if err := s.Open(); err != nil {
+			return nil, err
+		}
+		return s, nil
 	}
-	return s, nil
 }
 
 // Ensure type implements interface.
@@ -60,6 +70,10 @@ type TranslateStore struct {
 
 	// File path to database file.
 	Path string
+
+	// The modes with which the directory and database file are created.
+	DirPerm  os.FileMode
+	FilePerm os.FileMode
 }
 
 // NewTranslateStore returns a new instance of TranslateStore.
@@ -69,14 +83,16 @@ func NewTranslateStore(index, field string) *TranslateStore {
 		field:       field,
 		closing:     make(chan struct{}),
 		writeNotify: make(chan struct{}),
+		DirPerm:     pilosa.DefaultDirPerm,
+		FilePerm:    pilosa.DefaultFilePerm,
 	}
 }
 
 // Open opens the translate file.
 func (s *TranslateStore) Open() (err error) {
-	if err := os.MkdirAll(filepath.Dir(s.Path), 0777); err != nil {
+	if err := os.MkdirAll(filepath.Dir(s.Path), s.DirPerm); err != nil {
 		return errors.Wrapf(err, "mkdir %s", filepath.Dir(s.Path))
-	} else if s.db, err = bolt.Open(s.Path, 0666, &bolt.Options{Timeout: 1 * time.Second}); err != nil {
+	} else if s.db, err = bolt.Open(s.Path, s.FilePerm, &bolt.Options{Timeout: 1 * time.Second}); err != nil {
 		return errors.Wrapf(err, "open file: %s", err)
 	}
 
@@ -178,6 +194,18 @@ func (s *TranslateStore) TranslateKey(key string) (id uint64, _ error) {
 	return id, nil
 }
 
+// FindKey returns the ID of a string key without creating it.
+// Returns zero if the key does not exist.
+func (s *TranslateStore) FindKey(key string) (id uint64, _ error) {
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		id = findIDByKey(tx.Bucket([]byte("keys")), key)
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
 // TranslateKeys converts a string key to an integer ID.
 // If key does not have an associated id then one is created.
 func (s *TranslateStore) TranslateKeys(keys []string) (ids []uint64, _ error) {
@@ -50,6 +50,29 @@ func TestTranslateStore_TranslateKey(t *testing.T) {
 	}
 }
 
+func TestTranslateStore_FindKey(t *testing.T) {
+	s := MustOpenNewTranslateStore()
+	defer MustCloseTranslateStore(s)
+
+	// Ensure a missing key is not created.
+	if id, err := s.FindKey("foo"); err != nil {
+		t.Fatal(err)
+	} else if id != 0 {
+		t.Fatalf("FindKey()=%d, want 0", id)
+	} else if id, err := s.TranslateKey("bar"); err != nil {
+		t.Fatal(err)
+	} else if got, want := id, uint64(1); got != want {
+		t.Fatalf("TranslateKey()=%d, want %d", got, want)
+	}
+
+	// Ensure an existing key is found.
+	if id, err := s.FindKey("bar"); err != nil {
+		t.Fatal(err)
+	} else if got, want := id, uint64(1); got != want {
+		t.Fatalf("FindKey()=%d, want %d", got, want)
+	}
+}
+
 func TestTranslateStore_TranslateKeys(t *testing.T) {
 	s := MustOpenNewTranslateStore()
 	defer MustCloseTranslateStore(s)
@@ -0,0 +1,180 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/logger"
+	"github.com/pkg/errors"
+)
+
+// ErrCircuitOpen is returned for requests to a peer whose circuit breaker is
+// open. The executor retries the shards of such a peer on their replicas.
+var ErrCircuitOpen = errors.New("circuit breaker open for peer")
+
+// Circuit breaker states.
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+// CircuitBreaker short-circuits the internal queries sent to a peer after
+// threshold consecutive failures or timeouts, for a cool-down period. Once the
+// cool-down elapses, the next request is let through as a probe: its success
+// closes the breaker and its failure opens it for another cool-down. Peers
+// are identified by host and port. A nil CircuitBreaker lets every request
+// through.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	peers     map[string]*peerCircuit
+
+	now    func() time.Time
+	logger logger.Logger
+}
+
+type peerCircuit struct {
+	failures int
+	openedAt time.Time // zero while closed
+	probeAt  time.Time // zero unless a probe is in flight
+}
+
+// NewCircuitBreaker returns a CircuitBreaker which opens after threshold
+// consecutive failures, for cooldown. It returns nil, which never opens, if
+// threshold is not positive.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		peers:     make(map[string]*peerCircuit),
+		now:       time.Now,
+		logger:    logger.NopLogger,
+	}
+}
+
+// Allow returns ErrCircuitOpen if requests to host are short-circuited.
+// Once the cool-down elapses, a single probe is allowed; another one is only
+// allowed if it has not completed within a cool-down.
+func (b *CircuitBreaker) Allow(host string) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	p := b.peers[host]
+	if p == nil || p.openedAt.IsZero() {
+		return nil
+	}
+	now := b.now()
+	if now.Sub(p.openedAt) < b.cooldown || (!p.probeAt.IsZero() && now.Sub(p.probeAt) < b.cooldown) {
+		return errors.Wrap(ErrCircuitOpen, host)
+	}
+	p.probeAt = now
+	return nil
+}
+
+// Success records a successful request to host, which closes its breaker.
+func (b *CircuitBreaker) Success(host string) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	p := b.peers[host]
+	if p == nil {
+		return
+	}
+	if !p.openedAt.IsZero() {
+		b.logger.Printf("circuit breaker for %s closed", host)
+	}
+	delete(b.peers, host)
+}
+
+// Failure records a failed or timed out request to host. The breaker opens
+// once threshold requests in a row have failed, or when a probe fails.
+func (b *CircuitBreaker) Failure(host string) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	p := b.peers[host]
+	if p == nil {
+		p = &peerCircuit{}
+		b.peers[host] = p
+	}
+	p.failures++
+	if !p.openedAt.IsZero() {
+		// A failed probe restarts the cool-down.
+		if !p.probeAt.IsZero() {
+			p.openedAt, p.probeAt = b.now(), time.Time{}
+		}
+		return
+	}
+	if p.failures >= b.threshold {
+		p.openedAt = b.now()
+		b.logger.Printf("circuit breaker for %s opened after %d consecutive failures, for %s", host, p.failures, b.cooldown)
+	}
+}
+
+// CircuitBreakerStatus is the state of the circuit breaker of a peer.
+type CircuitBreakerStatus struct {
+	NodeID string `json:"nodeID,omitempty"`
+	Host   string `json:"host"`
+	State  string `json:"state"`
+
+	// Failures is the number of consecutive failed requests.
+	Failures int `json:"failures"`
+
+	// Until is the end of the cool-down of an open breaker.
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// statuses returns the state of the breakers of the peers whose last
+// requests failed, sorted by host.
+func (b *CircuitBreaker) statuses() []CircuitBreakerStatus {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	a := make([]CircuitBreakerStatus, 0, len(b.peers))
+	for host, p := range b.peers {
+		st := CircuitBreakerStatus{Host: host, State: CircuitClosed, Failures: p.failures}
+		if !p.openedAt.IsZero() {
+			until := p.openedAt.Add(b.cooldown)
+			st.State, st.Until = CircuitOpen, &until
+			if !now.Before(until) {
+				st.State = CircuitHalfOpen
+			}
+		}
+		a = append(a, st)
+	}
+	sort.Slice(a, func(i, j int) bool { return a[i].Host < a[j].Host })
+	return a
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	if b := NewCircuitBreaker(0, time.Second); b != nil {
+		t.Fatal("expected disabled breaker")
+	} else if err := b.Allow("a:10101"); err != nil {
+		t.Fatalf("expected disabled breaker to allow, got %v", err)
+	}
+
+	now := time.Unix(1000, 0)
+	b := NewCircuitBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	assertState := func(state string, failures int) {
+		t.Helper()
+		st := b.statuses()
+		if len(st) != 1 || st[0].Host != "a:10101" || st[0].State != state || st[0].Failures != failures {
+			t.Fatalf("unexpected statuses: %+v", st)
+		}
+	}
+
+	// A success resets the count of failures.
+	b.Failure("a:10101")
+	assertState(CircuitClosed, 1)
+	b.Success("a:10101")
+	if st := b.statuses(); len(st) != 0 {
+		t.Fatalf("unexpected statuses: %+v", st)
+	}
+
+	// The breaker opens at the threshold.
+	b.Failure("a:10101")
+	if err := b.Allow("a:10101"); err != nil {
+		t.Fatalf("expected closed breaker, got %v", err)
+	}
+	b.Failure("a:10101")
+	assertState(CircuitOpen, 2)
+	if err := b.Allow("a:10101"); errors.Cause(err) != ErrCircuitOpen {
+		t.Fatalf("expected open breaker, got %v", err)
+	} else if err := b.Allow("b:10101"); err != nil {
+		t.Fatalf("expected other peer to be allowed, got %v", err)
+	}
+
+	// After the cool-down a single probe is let through, and its failure
+	// opens the breaker again.
+	now = now.Add(time.Minute)
+	assertState(CircuitHalfOpen, 2)
+	if err := b.Allow("a:10101"); err != nil {
+		t.Fatalf("expected probe, got %v", err)
+	} else if err := b.Allow("a:10101"); errors.Cause(err) != ErrCircuitOpen {
+		t.Fatalf("expected a single probe, got %v", err)
+	}
+	b.Failure("a:10101")
+	assertState(CircuitOpen, 3)
+	if err := b.Allow("a:10101"); errors.Cause(err) != ErrCircuitOpen {
+		t.Fatalf("expected open breaker, got %v", err)
+	}
+
+	// A successful probe closes the breaker.
+	now = now.Add(time.Minute)
+	if err := b.Allow("a:10101"); err != nil {
+		t.Fatalf("expected probe, got %v", err)
+	}
+	b.Success("a:10101")
+	if err := b.Allow("a:10101"); err != nil {
+		t.Fatalf("expected closed breaker, got %v", err)
+	} else if st := b.statuses(); len(st) != 0 {
+		t.Fatalf("unexpected statuses: %+v", st)
+	}
+}
@@ -69,6 +69,12 @@ const (
 	messageTypeRecalculateCaches
 	messageTypeNodeEvent
 	messageTypeNodeStatus
+	messageTypeSetIndexAlias
+	messageTypeUpdateIndex
+	messageTypeUndeleteIndex
+	messageTypeApplySchema
+	messageTypeRename
+	messageTypeSchemaVersion
 )
 
 // MarshalInternalMessage serializes the pilosa message and adds pilosa internal
@@ -116,6 +122,18 @@ func getMessage(typ byte) Message {
 		return &NodeEvent{}
 	case messageTypeNodeStatus:
 		return &NodeStatus{}
+	case messageTypeSetIndexAlias:
+		return &SetIndexAliasMessage{}
+	case messageTypeUpdateIndex:
+		return &UpdateIndexMessage{}
+	case messageTypeUndeleteIndex:
+		return &UndeleteIndexMessage{}
+	case messageTypeApplySchema:
+		return &ApplySchemaMessage{}
+	case messageTypeRename:
+		return &RenameMessage{}
+	case messageTypeSchemaVersion:
+		return &SchemaVersionMessage{}
 	default:
 		panic(fmt.Sprintf("unknown message type %d", typ))
 	}
@@ -155,6 +173,18 @@ func getMessageType(m Message) byte {
 		return messageTypeNodeEvent
 	case *NodeStatus:
 		return messageTypeNodeStatus
+	case *SetIndexAliasMessage:
+		return messageTypeSetIndexAlias
+	case *UpdateIndexMessage:
+		return messageTypeUpdateIndex
+	case *UndeleteIndexMessage:
+		return messageTypeUndeleteIndex
+	case *ApplySchemaMessage:
+		return messageTypeApplySchema
+	case *RenameMessage:
+		return messageTypeRename
+	case *SchemaVersionMessage:
+		return messageTypeSchemaVersion
 	default:
 		panic(fmt.Sprintf("don't have type for message %#v", m))
 	}
@@ -0,0 +1,278 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrChangeFeedDisabled is returned when the changes of a node are requested
+// but its change feed is disabled.
+var ErrChangeFeedDisabled = errors.New("change feed is disabled")
+
+// Types of change events.
+const (
+	ChangeSet        = "set"
+	ChangeClear      = "clear"
+	ChangeValue      = "value"
+	ChangeClearValue = "clearValue"
+)
+
+// changeBatchSize is the maximum number of events read from the change
+// feed at once.
+const changeBatchSize = 1000
+
+// ChangeEvent is a bit set or cleared in the standard view of a field, or a
+// value set or cleared in an int field. Value events have no row, and Value
+// is only set for ChangeValue. Events are numbered by a sequence which
+// starts at 1 when the node starts.
+type ChangeEvent struct {
+	Seq    uint64    `json:"seq"`
+	Type   string    `json:"type"`
+	Index  string    `json:"index"`
+	Field  string    `json:"field"`
+	Row    uint64    `json:"row"`
+	Column uint64    `json:"column"`
+	Value  *int64    `json:"value,omitempty"`
+	Time   time.Time `json:"time"`
+}
+
+// ResyncRequiredError is returned when the changes requested are no longer,
+// or not yet, in the change feed. A consumer which fell behind has missed
+// events, and must resync its state before reading again from Earliest, the
+// earliest sequence still available.
+type ResyncRequiredError struct {
+	Earliest uint64
+}
+
+func (e ResyncRequiredError) Error() string {
+	return fmt.Sprintf("resync required: earliest available sequence is %d", e.Earliest)
+}
+
+// changeFeed holds the latest change events of a node in a ring buffer of
+// a fixed size. It is shared by all the fragments of a holder. A nil
+// changeFeed records nothing.
+type changeFeed struct {
+	mu     sync.Mutex
+	events []ChangeEvent
+	next   uint64        // sequence of the next event
+	notify chan struct{} // closed when events are published
+
+	now func() time.Time
+}
+
+// newChangeFeed returns a changeFeed holding up to size events. It returns
+// nil if size is not positive.
+func newChangeFeed(size int) *changeFeed {
+	if size <= 0 {
+		return nil
+	}
+	return &changeFeed{
+		events: make([]ChangeEvent, size),
+		next:   1,
+		notify: make(chan struct{}),
+		now:    time.Now,
+	}
+}
+
+// publish records that the given positions of a fragment were set or
+// cleared. The positions must be sorted.
+func (c *changeFeed) publish(typ, index, field string, shard uint64, positions []uint64) {
+	if c == nil || len(positions) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for _, pos := range positions {
+		c.append(ChangeEvent{
+			Type:   typ,
+			Index:  index,
+			Field:  field,
+			Row:    pos / ShardWidth,
+			Column: shard*ShardWidth + pos%ShardWidth,
+			Time:   now,
+		})
+	}
+	c.broadcast()
+}
+
+// publishValues records that the given values of a field were set or
+// cleared.
+func (c *changeFeed) publishValues(index, field string, values []valueChange) {
+	if c == nil || len(values) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for _, v := range values {
+		ev := ChangeEvent{
+			Type:   ChangeClearValue,
+			Index:  index,
+			Field:  field,
+			Column: v.column,
+			Time:   now,
+		}
+		if !v.clear {
+			value := v.value
+			ev.Type, ev.Value = ChangeValue, &value
+		}
+		c.append(ev)
+	}
+	c.broadcast()
+}
+
+// append numbers ev and adds it to the ring buffer. It is unprotected.
+func (c *changeFeed) append(ev ChangeEvent) {
+	ev.Seq = c.next
+	c.events[c.next%uint64(len(c.events))] = ev
+	c.next++
+}
+
+// broadcast wakes the readers waiting for events. It is unprotected.
+func (c *changeFeed) broadcast() {
+	close(c.notify)
+	c.notify = make(chan struct{})
+}
+
+// nextSeq returns the sequence of the next event to be published.
+func (c *changeFeed) nextSeq() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.next
+}
+
+// earliest returns the earliest sequence still held. It is unprotected.
+func (c *changeFeed) earliest() uint64 {
+	if size := uint64(len(c.events)); c.next > size {
+		return c.next - size
+	}
+	return 1
+}
+
+// read returns up to max events starting at sequence from. If there are
+// none yet, it returns a channel which is closed once there are. It returns
+// a ResyncRequiredError if from is no longer held, or is past the next
+// sequence.
+func (c *changeFeed) read(from uint64, max int) ([]ChangeEvent, <-chan struct{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if earliest := c.earliest(); from < earliest || from > c.next {
+		return nil, nil, ResyncRequiredError{Earliest: earliest}
+	}
+	n := c.next - from
+	if n > uint64(max) {
+		n = uint64(max)
+	}
+	if n == 0 {
+		return nil, c.notify, nil
+	}
+	size := uint64(len(c.events))
+	events := make([]ChangeEvent, n)
+	for i := range events {
+		events[i] = c.events[(from+uint64(i))%size]
+	}
+	return events, nil, nil
+}
+
+// changedPositions returns the sorted, distinct positions of a which are
+// set in the fragment if set is true, or cleared otherwise. It is
+// unprotected, and used to find which bits an import is about to change.
+func (f *fragment) changedPositions(a []uint64, set bool) []uint64 {
+	positions := make([]uint64, 0, len(a))
+	for _, pos := range a {
+		if f.storage.Contains(pos) != set {
+			positions = append(positions, pos)
+		}
+	}
+	sort.Slice(positions, func(i, j int) bool { return positions[i] < positions[j] })
+	n := 0
+	for i, pos := range positions {
+		if i == 0 || pos != positions[n-1] {
+			positions[n] = pos
+			n++
+		}
+	}
+	return positions[:n]
+}
+
+// publishChanges publishes the positions set or cleared in the fragment to
+// the change feed. Only the standard view is published, since the other
+// views repeat its bits.
+func (f *fragment) publishChanges(typ string, positions []uint64) {
+	if f.view != viewStandard {
+		return
+	}
+	f.changes.publish(typ, f.index, f.field, f.shard, positions)
+}
+
+// valueChange is a value set or cleared in a column of a BSI view.
+type valueChange struct {
+	column uint64
+	value  int64
+	clear  bool
+}
+
+// publishesValues returns true if the values set and cleared in the fragment
+// are published to the change feed.
+func (f *fragment) publishesValues() bool {
+	return f.changes != nil && strings.HasPrefix(f.view, viewBSIGroupPrefix)
+}
+
+// changedValues returns the values of an import which change the fragment,
+// sorted by column. When a column is imported more than once, the last value
+// wins. It is unprotected, and values are returned with the base added.
+func (f *fragment) changedValues(columnIDs []uint64, values []int64, bitDepth uint, clear bool) ([]valueChange, error) {
+	seen := make(map[uint64]struct{}, len(columnIDs))
+	changed := make([]valueChange, 0, len(columnIDs))
+	for i := len(columnIDs) - 1; i >= 0; i-- {
+		columnID := columnIDs[i]
+		if _, ok := seen[columnID]; ok {
+			continue
+		}
+		seen[columnID] = struct{}{}
+		ok, err := f.valueChanges(columnID, bitDepth, values[i], clear)
+		if err != nil {
+			return nil, err
+		} else if ok {
+			changed = append(changed, valueChange{column: columnID, value: values[i] + f.base, clear: clear})
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool { return changed[i].column < changed[j].column })
+	return changed, nil
+}
+
+// valueChanges returns true if setting, or clearing, the value of a column
+// would change it. It is unprotected.
+func (f *fragment) valueChanges(columnID uint64, bitDepth uint, value int64, clear bool) (bool, error) {
+	old, exists, err := f.unprotectedValue(columnID, bitDepth)
+	if err != nil {
+		return false, errors.Wrap(err, "getting value")
+	}
+	if clear {
+		return exists, nil
+	}
+	return !exists || old != value, nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/roaring"
+)
+
+func TestChangeFeed(t *testing.T) {
+	if c := newChangeFeed(0); c != nil {
+		t.Fatal("expected disabled feed")
+	} else {
+		c.publish(ChangeSet, "i", "f", 0, []uint64{1})
+	}
+
+	now := time.Unix(1000, 0)
+	c := newChangeFeed(3)
+	c.now = func() time.Time { return now }
+
+	c.publish(ChangeSet, "i", "f", 2, []uint64{1, ShardWidth + 5})
+	events, _, err := c.read(1, 10)
+	if err != nil {
+		t.Fatal(err)
+	} else if exp := []ChangeEvent{
+		{Seq: 1, Type: ChangeSet, Index: "i", Field: "f", Row: 0, Column: 2*ShardWidth + 1, Time: now},
+		{Seq: 2, Type: ChangeSet, Index: "i", Field: "f", Row: 1, Column: 2*ShardWidth + 5, Time: now},
+	}; !reflect.DeepEqual(events, exp) {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+	if events, _, err := c.read(2, 1); err != nil {
+		t.Fatal(err)
+	} else if len(events) != 1 || events[0].Seq != 2 {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+
+	// Waiting readers are notified of new events.
+	events, wait, err := c.read(3, 10)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(events) != 0 {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+	c.publish(ChangeClear, "i", "f", 0, []uint64{7, 8})
+	select {
+	case <-wait:
+	default:
+		t.Fatal("expected notification")
+	}
+
+	// Events which were overwritten, or are not yet published, require a
+	// resync.
+	if _, _, err := c.read(1, 10); err != (ResyncRequiredError{Earliest: 2}) {
+		t.Fatalf("expected resync, got %v", err)
+	} else if _, _, err := c.read(6, 10); err != (ResyncRequiredError{Earliest: 2}) {
+		t.Fatalf("expected resync, got %v", err)
+	} else if events, _, err := c.read(2, 10); err != nil {
+		t.Fatal(err)
+	} else if len(events) != 3 || events[0].Seq != 2 || events[2].Seq != 4 || events[2].Type != ChangeClear {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+}
+
+func TestFragment_Changes(t *testing.T) {
+	f := mustOpenFragment("i", "f", viewStandard, 1, "")
+	defer f.Clean(t)
+	f.changes = newChangeFeed(100)
+
+	type change struct {
+		typ         string
+		row, column uint64
+	}
+	var from uint64 = 1
+	assertChanges := func(exp ...change) {
+		t.Helper()
+		events, _, err := f.changes.read(from, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []change
+		for _, e := range events {
+			if e.Index != "i" || e.Field != "f" {
+				t.Fatalf("unexpected event: %+v", e)
+			}
+			got = append(got, change{e.Type, e.Row, e.Column})
+		}
+		if !reflect.DeepEqual(got, exp) {
+			t.Fatalf("unexpected changes: %+v", got)
+		}
+		from += uint64(len(events))
+	}
+
+	// Only bits which change are published.
+	f.mustSetBits(1, ShardWidth+2)
+	f.mustSetBits(1, ShardWidth+2)
+	assertChanges(change{ChangeSet, 1, ShardWidth + 2})
+	if _, err := f.clearBit(1, ShardWidth+2); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.clearBit(1, ShardWidth+2); err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(change{ChangeClear, 1, ShardWidth + 2})
+
+	f.mustSetBits(2, ShardWidth+3)
+	if err := f.bulkImport([]uint64{2, 2, 3}, []uint64{ShardWidth + 3, ShardWidth + 4, ShardWidth + 4}, &ImportOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(change{ChangeSet, 2, ShardWidth + 3}, change{ChangeSet, 2, ShardWidth + 4}, change{ChangeSet, 3, ShardWidth + 4})
+
+	var buf bytes.Buffer
+	if _, err := roaring.NewBitmap(pos(2, 3), pos(2, 5)).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	} else if err := f.importRoaringT(buf.Bytes(), true); err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(change{ChangeClear, 2, ShardWidth + 3})
+
+	// Rows which are replaced publish only the bits which differ.
+	if _, err := f.setRow(NewRow(ShardWidth+5), 2); err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(change{ChangeClear, 2, ShardWidth + 4}, change{ChangeSet, 2, ShardWidth + 5})
+	if _, err := f.setRow(NewRow(), 2); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.clearRow(3); err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(change{ChangeClear, 2, ShardWidth + 5}, change{ChangeClear, 3, ShardWidth + 4})
+
+	// The other views are not published.
+	g := mustOpenFragment("i", "f", viewStandard+"_2019", 1, "")
+	defer g.Clean(t)
+	g.changes = f.changes
+	g.mustSetBits(1, ShardWidth+2)
+	assertChanges()
+}
+
+func TestFragment_ValueChanges(t *testing.T) {
+	f := mustOpenFragment("i", "f", viewBSIGroupPrefix+"f", 1, "")
+	defer f.Clean(t)
+	f.changes = newChangeFeed(100)
+	f.base = 10
+
+	type change struct {
+		typ    string
+		column uint64
+		value  int64
+	}
+	var from uint64 = 1
+	assertChanges := func(exp ...change) {
+		t.Helper()
+		events, _, err := f.changes.read(from, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []change
+		for _, e := range events {
+			if e.Index != "i" || e.Field != "f" || (e.Value != nil) != (e.Type == ChangeValue) {
+				t.Fatalf("unexpected event: %+v", e)
+			}
+			c := change{typ: e.Type, column: e.Column}
+			if e.Value != nil {
+				c.value = *e.Value
+			}
+			got = append(got, c)
+		}
+		if !reflect.DeepEqual(got, exp) {
+			t.Fatalf("unexpected changes: %+v", got)
+		}
+		from += uint64(len(events))
+	}
+
+	// Only values which change are published, with the base added.
+	for _, v := range []int64{5, 5, 6} {
+		if _, err := f.setValue(ShardWidth+1, 8, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assertChanges(change{ChangeValue, ShardWidth + 1, 15}, change{ChangeValue, ShardWidth + 1, 16})
+	for i := 0; i < 2; i++ {
+		if _, err := f.clearValue(ShardWidth+1, 8, 6); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assertChanges(change{ChangeClearValue, ShardWidth + 1, 0})
+
+	// The last value imported for a column wins.
+	if err := f.importValue([]uint64{ShardWidth + 2, ShardWidth + 1, ShardWidth + 2}, []int64{3, 6, 4}, 8, false); err != nil {
+		t.Fatal(err)
+	} else if err := f.importValue([]uint64{ShardWidth + 2}, []int64{4}, 8, false); err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(change{ChangeValue, ShardWidth + 1, 16}, change{ChangeValue, ShardWidth + 2, 14})
+
+	// Large imports are published too.
+	f.MaxOpN = 0
+	if err := f.importValue([]uint64{ShardWidth + 1}, []int64{7}, 8, false); err != nil {
+		t.Fatal(err)
+	} else if err := f.importValue([]uint64{ShardWidth + 2, ShardWidth + 3}, []int64{0, 0}, 8, true); err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(change{ChangeValue, ShardWidth + 1, 17}, change{ChangeClearValue, ShardWidth + 2, 0})
+}
@@ -45,14 +45,19 @@ type FieldValue struct {
 // I don't want to let it go unquestioned.
 type InternalClient interface {
 	MaxShardByIndex(ctx context.Context) (map[string]uint64, error)
+	MaxShardByIndexNode(ctx context.Context, uri *URI) (map[string]uint64, error)
 	Schema(ctx context.Context) ([]*IndexInfo, error)
+	SchemaNode(ctx context.Context, uri *URI) ([]*IndexInfo, error)
+	FullSchemaNode(ctx context.Context, uri *URI) (*Schema, error)
 	PostSchema(ctx context.Context, uri *URI, s *Schema, remote bool) error
 	CreateIndex(ctx context.Context, index string, opt IndexOptions) error
 	FragmentNodes(ctx context.Context, index string, shard uint64) ([]*Node, error)
+	FragmentNodesNode(ctx context.Context, uri *URI, index string, shard uint64) ([]*Node, error)
 	Nodes(ctx context.Context) ([]*Node, error)
 	Query(ctx context.Context, index string, queryRequest *QueryRequest) (*QueryResponse, error)
 	QueryNode(ctx context.Context, uri *URI, index string, queryRequest *QueryRequest) (*QueryResponse, error)
 	Import(ctx context.Context, index, field string, shard uint64, bits []Bit, opts ...ImportOption) error
+	ImportShard(ctx context.Context, req *ImportRequest, opts ...ImportOption) error
 	ImportK(ctx context.Context, index, field string, bits []Bit, opts ...ImportOption) error
 	EnsureIndex(ctx context.Context, name string, options IndexOptions) error
 	EnsureField(ctx context.Context, indexName string, fieldName string) error
@@ -62,13 +67,14 @@ type InternalClient interface {
 	ExportCSV(ctx context.Context, index, field string, shard uint64, w io.Writer) error
 	CreateField(ctx context.Context, index, field string) error
 	CreateFieldWithOptions(ctx context.Context, index, field string, opt FieldOptions) error
-	FragmentBlocks(ctx context.Context, uri *URI, index, field, view string, shard uint64) ([]FragmentBlock, error)
+	FragmentBlocks(ctx context.Context, uri *URI, index, field, view string, shard uint64, checksum string) ([]FragmentBlock, string, error)
 	BlockData(ctx context.Context, uri *URI, index, field, view string, shard uint64, block int) ([]uint64, []uint64, error)
 	ColumnAttrDiff(ctx context.Context, uri *URI, index string, blks []AttrBlock) (map[uint64]map[string]interface{}, error)
 	RowAttrDiff(ctx context.Context, uri *URI, index, field string, blks []AttrBlock) (map[uint64]map[string]interface{}, error)
 	SendMessage(ctx context.Context, uri *URI, msg []byte) error
 	RetrieveShardFromURI(ctx context.Context, index, field, view string, shard uint64, uri URI) (io.ReadCloser, error)
 	ImportRoaring(ctx context.Context, uri *URI, index, field string, shard uint64, remote bool, req *ImportRoaringRequest) error
+	SyncAntiEntropy(ctx context.Context, uri *URI, index string, shards []uint64, remote bool) (int, error)
 }
 
 //===============
@@ -103,7 +109,16 @@ var _ InternalClient = newNopInternalClient()
 func (n nopInternalClient) MaxShardByIndex(context.Context) (map[string]uint64, error) {
 	return nil, nil
 }
+func (n nopInternalClient) MaxShardByIndexNode(context.Context, *URI) (map[string]uint64, error) {
+	return nil, nil
+}
 func (n nopInternalClient) Schema(ctx context.Context) ([]*IndexInfo, error) { return nil, nil }
+func (n nopInternalClient) SchemaNode(ctx context.Context, uri *URI) ([]*IndexInfo, error) {
+	return nil, nil
+}
+func (n nopInternalClient) FullSchemaNode(ctx context.Context, uri *URI) (*Schema, error) {
+	return nil, nil
+}
 func (n nopInternalClient) PostSchema(ctx context.Context, uri *URI, s *Schema, remote bool) error {
 	return nil
 }
@@ -114,6 +129,9 @@ func (n nopInternalClient) CreateIndex(ctx context.Context, index string, opt In
 func (n nopInternalClient) FragmentNodes(ctx context.Context, index string, shard uint64) ([]*Node, error) {
 	return nil, nil
 }
+func (n nopInternalClient) FragmentNodesNode(ctx context.Context, uri *URI, index string, shard uint64) ([]*Node, error) {
+	return nil, nil
+}
 func (n nopInternalClient) Nodes(ctx context.Context) ([]*Node, error) {
 	return nil, nil
 }
@@ -126,6 +144,9 @@ func (n nopInternalClient) QueryNode(ctx context.Context, uri *URI, index string
 func (n nopInternalClient) Import(ctx context.Context, index, field string, shard uint64, bits []Bit, opts ...ImportOption) error {
 	return nil
 }
+func (n nopInternalClient) ImportShard(ctx context.Context, req *ImportRequest, opts ...ImportOption) error {
+	return nil
+}
 func (n nopInternalClient) ImportK(ctx context.Context, index, field string, bits []Bit, opts ...ImportOption) error {
 	return nil
 }
@@ -154,8 +175,8 @@ func (n nopInternalClient) CreateField(ctx context.Context, index, field string)
 func (n nopInternalClient) CreateFieldWithOptions(ctx context.Context, index, field string, opt FieldOptions) error {
 	return nil
 }
-func (n nopInternalClient) FragmentBlocks(ctx context.Context, uri *URI, index, field, view string, shard uint64) ([]FragmentBlock, error) {
-	return nil, nil
+func (n nopInternalClient) FragmentBlocks(ctx context.Context, uri *URI, index, field, view string, shard uint64, checksum string) ([]FragmentBlock, string, error) {
+	return nil, checksum, nil
 }
 func (n nopInternalClient) BlockData(ctx context.Context, uri *URI, index, field, view string, shard uint64, block int) ([]uint64, []uint64, error) {
 	return nil, nil, nil
@@ -172,3 +193,6 @@ func (n nopInternalClient) SendMessage(ctx context.Context, uri *URI, msg []byte
 func (n nopInternalClient) RetrieveShardFromURI(ctx context.Context, index, field, view string, shard uint64, uri URI) (io.ReadCloser, error) {
 	return nil, nil
 }
+func (n nopInternalClient) SyncAntiEntropy(ctx context.Context, uri *URI, index string, shards []uint64, remote bool) (int, error) {
+	return 0, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/logger"
+)
+
+// defaultClockSkewThreshold is the difference between the clocks of two
+// nodes above which a warning is logged.
+const defaultClockSkewThreshold = time.Minute
+
+// clockSkewDetector compares the clocks of remote nodes, as reported by the
+// node statuses they exchange over gossip, with the local clock. It is purely
+// diagnostic: skewed nodes are logged and reported, but not otherwise treated
+// differently. The measured skew includes the delay in delivering the status.
+type clockSkewDetector struct {
+	mu        sync.Mutex
+	threshold time.Duration
+	skews     map[string]time.Duration // of the skewed nodes, by node ID
+
+	now    func() time.Time
+	logger logger.Logger
+}
+
+// newClockSkewDetector returns a clockSkewDetector with the default threshold.
+func newClockSkewDetector() *clockSkewDetector {
+	return &clockSkewDetector{
+		threshold: defaultClockSkewThreshold,
+		skews:     make(map[string]time.Duration),
+		now:       time.Now,
+		logger:    logger.NopLogger,
+	}
+}
+
+// observe compares the time reported by a node, in nanoseconds since the
+// epoch, with the local clock. It logs a warning when the node's clock starts
+// differing by more than the threshold. A zero time, sent by nodes which do
+// not report their clock, and a zero threshold are ignored.
+func (d *clockSkewDetector) observe(nodeID string, remote int64) {
+	if remote == 0 {
+		return
+	}
+	skew := time.Unix(0, remote).Sub(d.now())
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.threshold <= 0 {
+		return
+	}
+
+	_, skewed := d.skews[nodeID]
+	if skew > d.threshold || skew < -d.threshold {
+		if !skewed {
+			d.logger.Printf("WARNING: clock of node %s differs from the local clock by %s, more than %s", nodeID, skew, d.threshold)
+		}
+		d.skews[nodeID] = skew
+	} else if skewed {
+		d.logger.Printf("clock of node %s is back within %s of the local clock", nodeID, d.threshold)
+		delete(d.skews, nodeID)
+	}
+}
+
+// skewed returns the clock skew of each node whose clock differs from the
+// local clock by more than the threshold, by node ID. A positive skew means
+// the node's clock is ahead.
+func (d *clockSkewDetector) skewed() map[string]time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	m := make(map[string]time.Duration, len(d.skews))
+	for id, skew := range d.skews {
+		m[id] = skew
+	}
+	return m
+}
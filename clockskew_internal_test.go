@@ -0,0 +1,53 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestClockSkewDetector(t *testing.T) {
+	now := time.Unix(1000000, 0)
+	d := newClockSkewDetector()
+	d.now = func() time.Time { return now }
+
+	d.observe("ahead", now.Add(2*time.Minute).UnixNano())
+	d.observe("behind", now.Add(-90*time.Second).UnixNano())
+	d.observe("close", now.Add(30*time.Second).UnixNano())
+	d.observe("unknown", 0)
+
+	exp := map[string]time.Duration{"ahead": 2 * time.Minute, "behind": -90 * time.Second}
+	if skews := d.skewed(); !reflect.DeepEqual(skews, exp) {
+		t.Fatalf("unexpected skews: %v", skews)
+	}
+
+	// A node whose clock is corrected is no longer reported.
+	d.observe("ahead", now.Add(time.Second).UnixNano())
+	exp = map[string]time.Duration{"behind": -90 * time.Second}
+	if skews := d.skewed(); !reflect.DeepEqual(skews, exp) {
+		t.Fatalf("unexpected skews: %v", skews)
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		d := newClockSkewDetector()
+		d.threshold = 0
+		d.observe("ahead", time.Now().Add(time.Hour).UnixNano())
+		if skews := d.skewed(); len(skews) != 0 {
+			t.Fatalf("unexpected skews: %v", skews)
+		}
+	})
+}
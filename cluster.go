@@ -33,6 +33,7 @@ import (
 	"github.com/pilosa/pilosa/v2/internal"
 	"github.com/pilosa/pilosa/v2/logger"
 	"github.com/pilosa/pilosa/v2/roaring"
+	"github.com/pilosa/pilosa/v2/stats"
 	"github.com/pilosa/pilosa/v2/tracing"
 	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
@@ -73,6 +74,15 @@ type Node struct {
 	URI           URI    `json:"uri"`
 	IsCoordinator bool   `json:"isCoordinator"`
 	State         string `json:"state"`
+
+	// Zone is the failure domain, e.g. the rack, of the node. The replicas
+	// of a shard are placed in distinct zones when possible.
+	Zone string `json:"zone,omitempty"`
+
+	// The range of protocol versions the node speaks. Both are zero for
+	// nodes which predate versioning.
+	ProtocolVersion    int `json:"protocolVersion,omitempty"`
+	MinProtocolVersion int `json:"minProtocolVersion,omitempty"`
 }
 
 func (n *Node) Clone() *Node {
@@ -204,8 +214,11 @@ type cluster struct { // nolint: maligned
 	// Maximum number of Set() or Clear() commands per request.
 	maxWritesPerRequest int
 
-	// Data directory path.
+	// Data directory path, and the modes with which it and the topology
+	// file are created.
 	Path     string
+	dirPerm  os.FileMode
+	filePerm os.FileMode
 	Topology *Topology
 
 	// Required for cluster Resize.
@@ -215,8 +228,22 @@ type cluster struct { // nolint: maligned
 	holder      *Holder
 	broadcaster broadcaster
 
+	// upstream is the node of the primary cluster which this cluster is a
+	// standby of, if any.
+	upstream *Node
+
+	// quorum records the nodes heard from since startup, if the node
+	// requires a quorum to start.
+	quorum *startQuorum
+
 	joiningLeavingNodes chan nodeAction
 
+	// deadRoutingDelay is how long a node which gossip reports dead keeps
+	// receiving queries before it is removed from routing. pendingRemovals
+	// holds the timers of the nodes waiting out the delay, by node ID.
+	deadRoutingDelay time.Duration
+	pendingRemovals  map[string]*time.Timer
+
 	// joining is held open until this node
 	// receives ClusterStatus from the coordinator.
 	joining chan struct{}
@@ -233,6 +260,7 @@ type cluster struct { // nolint: maligned
 	closing chan struct{}
 
 	logger logger.Logger
+	stats  stats.StatsClient
 
 	InternalClient InternalClient
 }
@@ -244,14 +272,19 @@ func newCluster() *cluster {
 		partitionN: defaultPartitionN,
 		ReplicaN:   1,
 
+		dirPerm:  DefaultDirPerm,
+		filePerm: DefaultFilePerm,
+
 		joiningLeavingNodes: make(chan nodeAction, 10), // buffered channel
 		jobs:                make(map[int64]*resizeJob),
+		pendingRemovals:     make(map[string]*time.Timer),
 		closing:             make(chan struct{}),
 		joining:             make(chan struct{}),
 
 		InternalClient: newNopInternalClient(),
 
 		logger: logger.NopLogger,
+		stats:  stats.NopStatsClient,
 	}
 }
 
@@ -282,6 +315,14 @@ func (c *cluster) abortAntiEntropy() {
 	}
 }
 
+// writesPerRequestLimit returns the maximum number of write commands per
+// request.
+func (c *cluster) writesPerRequestLimit() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.maxWritesPerRequest
+}
+
 func (c *cluster) coordinatorNode() *Node {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -389,6 +430,10 @@ func (c *cluster) addNode(node *Node) error {
 	if !c.addNodeBasicSorted(node) {
 		return nil
 	}
+	if err := c.unprotectedBumpEpoch(); err != nil {
+		return errors.Wrap(err, "bumping topology epoch")
+	}
+	c.unprotectedCheckZones()
 
 	// If the cluster membership has changed, reset the primary for
 	// translate store replication.
@@ -415,7 +460,12 @@ func (c *cluster) addNode(node *Node) error {
 // new topology. unprotected.
 func (c *cluster) removeNode(nodeID string) error {
 	// remove from cluster
-	c.removeNodeBasicSorted(nodeID)
+	if c.removeNodeBasicSorted(nodeID) {
+		if err := c.unprotectedBumpEpoch(); err != nil {
+			return errors.Wrap(err, "bumping topology epoch")
+		}
+		c.unprotectedCheckZones()
+	}
 
 	// If the cluster membership has changed, reset the primary for
 	// translate store replication.
@@ -587,7 +637,51 @@ func (c *cluster) unprotectedStatus() *ClusterStatus {
 		ClusterID: c.id,
 		State:     c.state,
 		Nodes:     c.nodes,
+		Epoch:     c.unprotectedEpoch(),
+	}
+}
+
+// Epoch returns the topology epoch. Safe for concurrent use.
+func (c *cluster) Epoch() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.unprotectedEpoch()
+}
+
+// unprotectedEpoch returns the topology epoch. unprotected.
+func (c *cluster) unprotectedEpoch() uint64 {
+	if c.Topology == nil {
+		return 0
 	}
+	return c.Topology.epoch
+}
+
+// unprotectedBumpEpoch records a change of the cluster membership. Only the
+// coordinator increments the epoch; the other nodes adopt it from the
+// ClusterStatus the coordinator broadcasts. unprotected.
+func (c *cluster) unprotectedBumpEpoch() error {
+	if !c.unprotectedIsCoordinator() || c.Topology == nil {
+		return nil
+	}
+	c.Topology.epoch++
+	return c.saveTopology()
+}
+
+// topologyEpochKey is the context key of the topology epoch a request was
+// routed with.
+type topologyEpochKey struct{}
+
+// WithTopologyEpoch returns a context carrying the topology epoch used to
+// route requests made with it. Internal clients send the epoch along so that
+// the receiving node can reject requests routed with an outdated shard map.
+func WithTopologyEpoch(ctx context.Context, epoch uint64) context.Context {
+	return context.WithValue(ctx, topologyEpochKey{}, epoch)
+}
+
+// TopologyEpochFromContext returns the topology epoch carried by ctx, if any.
+func TopologyEpochFromContext(ctx context.Context) (uint64, bool) {
+	epoch, ok := ctx.Value(topologyEpochKey{}).(uint64)
+	return epoch, ok
 }
 
 func (c *cluster) nodeByID(id string) *Node {
@@ -632,10 +726,13 @@ func (c *cluster) nodePositionByID(nodeID string) int {
 func (c *cluster) addNodeBasicSorted(node *Node) bool {
 	n := c.unprotectedNodeByID(node.ID)
 	if n != nil {
-		if n.State != node.State || n.IsCoordinator != node.IsCoordinator || n.URI != node.URI {
+		if n.State != node.State || n.IsCoordinator != node.IsCoordinator || n.URI != node.URI || n.Zone != node.Zone ||
+			n.ProtocolVersion != node.ProtocolVersion || n.MinProtocolVersion != node.MinProtocolVersion {
 			n.State = node.State
 			n.IsCoordinator = node.IsCoordinator
 			n.URI = node.URI
+			n.Zone = node.Zone
+			n.ProtocolVersion, n.MinProtocolVersion = node.ProtocolVersion, node.MinProtocolVersion
 			return true
 		}
 		return false
@@ -867,14 +964,15 @@ func (c *cluster) fragSources(to *cluster, idx *Index) (map[string][]*ResizeSour
 	return m, nil
 }
 
-// partition returns the partition that a shard belongs to.
+// partition returns the partition that a shard belongs to. Renamed indexes
+// keep the partitions of the name they were created with.
 func (c *cluster) partition(index string, shard uint64) int {
 	var buf [8]byte
 	binary.BigEndian.PutUint64(buf[:], shard)
 
 	// Hash the bytes and mod by partition count.
 	h := fnv.New64a()
-	_, _ = h.Write([]byte(index))
+	_, _ = h.Write([]byte(c.holder.placementName(index)))
 	_, _ = h.Write(buf[:])
 	return int(h.Sum64() % uint64(c.partitionN))
 }
@@ -886,6 +984,14 @@ func (c *cluster) ShardNodes(index string, shard uint64) []*Node {
 	return c.shardNodes(index, shard)
 }
 
+// shardOwners returns the nodes that own a fragment, primary first, along
+// with the topology epoch at which they own it.
+func (c *cluster) shardOwners(index string, shard uint64) ([]*Node, uint64) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.shardNodes(index, shard), c.unprotectedEpoch()
+}
+
 // shardNodes returns a list of nodes that own a fragment. unprotected
 func (c *cluster) shardNodes(index string, shard uint64) []*Node {
 	return c.partitionNodes(c.partition(index, shard))
@@ -898,30 +1004,125 @@ func (c *cluster) ownsShard(nodeID string, index string, shard uint64) bool {
 	return Nodes(c.shardNodes(index, shard)).ContainsID(nodeID)
 }
 
+// availableShardOwner returns the first owner of a fragment which is
+// available, or nil if none is.
+func (c *cluster) availableShardOwner(index string, shard uint64) *Node {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, n := range c.shardNodes(index, shard) {
+		if c.nodeAvailable(n) {
+			return n
+		}
+	}
+	return nil
+}
+
+// nodeAvailable returns true if n is ready to serve requests. The nodes of a
+// static cluster are always available, since their states are not tracked.
+func (c *cluster) nodeAvailable(n *Node) bool {
+	return c.Static || n.State == nodeStateReady
+}
+
 // partitionNodes returns a list of nodes that own a partition. unprotected.
 func (c *cluster) partitionNodes(partitionID int) []*Node {
+	replicaN := c.unprotectedReplicaN()
+
+	// Determine primary owner node.
+	nodeIndex := c.Hasher.Hash(uint64(partitionID), len(c.nodes))
 
-	// Default replica count to between one and the number of nodes.
-	// The replica count can be zero if there are no nodes.
+	if c.zonePlacement(replicaN) {
+		return c.zonePartitionNodes(partitionID, nodeIndex, replicaN)
+	}
+
+	// Collect nodes around the ring.
+	nodes := make([]*Node, replicaN)
+	for i := 0; i < replicaN; i++ {
+		nodes[i] = c.nodes[(nodeIndex+i)%len(c.nodes)]
+	}
+
+	return nodes
+}
+
+// unprotectedReplicaN returns the replica count, between one and the number
+// of nodes. It is zero if there are no nodes.
+func (c *cluster) unprotectedReplicaN() int {
 	replicaN := c.ReplicaN
 	if replicaN > len(c.nodes) {
 		replicaN = len(c.nodes)
 	} else if replicaN == 0 {
 		replicaN = 1
 	}
+	return replicaN
+}
 
-	// Determine primary owner node.
-	nodeIndex := c.Hasher.Hash(uint64(partitionID), len(c.nodes))
+// zoneCount returns the number of distinct zones of the nodes, counting
+// nodes without a zone as one zone, and whether any node has a zone.
+// unprotected.
+func (c *cluster) zoneCount() (n int, declared bool) {
+	zones := make(map[string]struct{})
+	for _, node := range c.nodes {
+		zones[node.Zone] = struct{}{}
+		if node.Zone != "" {
+			declared = true
+		}
+	}
+	return len(zones), declared
+}
 
-	// Collect nodes around the ring.
-	nodes := make([]*Node, replicaN)
-	for i := 0; i < replicaN; i++ {
-		nodes[i] = c.nodes[(nodeIndex+i)%len(c.nodes)]
+// zonePlacement returns true if the replicas of each partition are placed
+// in distinct zones, which requires nodes to declare at least replicaN
+// zones. Otherwise replicas are placed on the nodes following the primary
+// on the ring. unprotected.
+func (c *cluster) zonePlacement(replicaN int) bool {
+	if replicaN <= 1 {
+		return false
+	}
+	n, declared := c.zoneCount()
+	return declared && n >= replicaN
+}
+
+// zonePartitionNodes returns the replicaN nodes which own the partition
+// whose primary is the node at nodeIndex. The primary is the same as with
+// ring placement. The replicas are taken from the other zones, in an order
+// rotated by the partition so that no zone receives more replicas than the
+// others, and each is the first node of its zone on the ring after the
+// primary. Since nodes are sorted by ID the placement only depends on the
+// nodes and their zones. unprotected.
+func (c *cluster) zonePartitionNodes(partitionID, nodeIndex, replicaN int) []*Node {
+	primary := c.nodes[nodeIndex]
+	nodes := make([]*Node, 0, replicaN)
+	nodes = append(nodes, primary)
+
+	// Find the first node of each other zone on the ring.
+	firsts := make(map[string]*Node)
+	zones := make([]string, 0)
+	for i := 1; i < len(c.nodes); i++ {
+		node := c.nodes[(nodeIndex+i)%len(c.nodes)]
+		if node.Zone == primary.Zone {
+			continue
+		} else if _, ok := firsts[node.Zone]; ok {
+			continue
+		}
+		firsts[node.Zone] = node
+		zones = append(zones, node.Zone)
 	}
+	sort.Strings(zones)
 
+	for i := 0; i < len(zones) && len(nodes) < replicaN; i++ {
+		nodes = append(nodes, firsts[zones[(partitionID+i)%len(zones)]])
+	}
 	return nodes
 }
 
+// unprotectedCheckZones logs a warning if nodes declare zones but too few of
+// them to place the replicas of each shard in distinct zones.
+func (c *cluster) unprotectedCheckZones() {
+	replicaN := c.unprotectedReplicaN()
+	if n, declared := c.zoneCount(); declared && replicaN > 1 && n < replicaN {
+		c.logger.Printf("WARNING: nodes declare %d zones, fewer than the replica count %d; placing replicas regardless of zones", n, replicaN)
+	}
+}
+
 // containsShards is like OwnsShards, but it includes replicas.
 func (c *cluster) containsShards(index string, availableShards *roaring.Bitmap, node *Node) []uint64 {
 	var shards []uint64
@@ -938,6 +1139,85 @@ func (c *cluster) containsShards(index string, availableShards *roaring.Bitmap,
 	return shards
 }
 
+// ShardDistribution reports how the shards of each index are placed across
+// the nodes of the cluster.
+type ShardDistribution struct {
+	Nodes []*NodeShards `json:"nodes"`
+
+	// Balance is the ratio of the most shards owned by any node to the
+	// fewest, counting both primary and replica ownership. A perfectly
+	// balanced cluster has a balance of 1. A node which owns no shards is
+	// counted as owning one so that the ratio stays finite.
+	Balance float64 `json:"balance"`
+}
+
+// NodeShards reports the number of shards owned by a node.
+type NodeShards struct {
+	ID      string                  `json:"id"`
+	Primary int                     `json:"primary"`
+	Replica int                     `json:"replica"`
+	Indexes map[string]*ShardCounts `json:"indexes"`
+}
+
+// ShardCounts holds the number of shards owned as primary and as replica.
+type ShardCounts struct {
+	Primary int `json:"primary"`
+	Replica int `json:"replica"`
+}
+
+// shardDistribution aggregates the placement of the given shards by node.
+func (c *cluster) shardDistribution(shardsByIndex map[string]*roaring.Bitmap) *ShardDistribution {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	dist := &ShardDistribution{Nodes: make([]*NodeShards, len(c.nodes))}
+	byID := make(map[string]*NodeShards, len(c.nodes))
+	for i, node := range c.nodes {
+		ns := &NodeShards{ID: node.ID, Indexes: make(map[string]*ShardCounts)}
+		dist.Nodes[i] = ns
+		byID[node.ID] = ns
+	}
+
+	for index, shards := range shardsByIndex {
+		for _, ns := range dist.Nodes {
+			ns.Indexes[index] = &ShardCounts{}
+		}
+		shards.ForEach(func(shard uint64) {
+			for i, node := range c.shardNodes(index, shard) {
+				ns := byID[node.ID]
+				if i == 0 {
+					ns.Primary++
+					ns.Indexes[index].Primary++
+				} else {
+					ns.Replica++
+					ns.Indexes[index].Replica++
+				}
+			}
+		})
+	}
+
+	if len(dist.Nodes) > 0 {
+		min, max := -1, 0
+		for _, ns := range dist.Nodes {
+			n := ns.Primary + ns.Replica
+			if min == -1 || n < min {
+				min = n
+			}
+			if n > max {
+				max = n
+			}
+		}
+		if min == 0 {
+			min = 1
+		}
+		if max == 0 {
+			max = 1
+		}
+		dist.Balance = float64(max) / float64(min)
+	}
+	return dist
+}
+
 // Hasher represents an interface to hash integers into buckets.
 type Hasher interface {
 	// Hashes the key into a number between [0,N).
@@ -1024,6 +1304,13 @@ func (c *cluster) close() error {
 	close(c.closing)
 	c.wg.Wait()
 
+	c.mu.Lock()
+	for id, timer := range c.pendingRemovals {
+		timer.Stop()
+		delete(c.pendingRemovals, id)
+	}
+	c.mu.Unlock()
+
 	return nil
 }
 
@@ -1326,7 +1613,7 @@ func (c *cluster) followResizeInstruction(instr *ResizeInstruction) error {
 			// Sync the NodeStatus received in the resize instruction.
 			// Sync schema.
 			c.logger.Debugf("holder applySchema")
-			if err := c.holder.applySchema(instr.NodeStatus.Schema); err != nil {
+			if err := c.holder.applySchema(instr.NodeStatus.Schema, nil); err != nil {
 				return errors.Wrap(err, "applying schema")
 			}
 
@@ -1586,6 +1873,11 @@ type Topology struct {
 	// nodeStates holds the state of each node according to
 	// the coordinator. Used during startup and data load.
 	nodeStates map[string]string
+
+	// epoch is incremented on every change of the cluster membership so
+	// that requests routed with an outdated shard map can be detected.
+	// Protected by the cluster's mu.
+	epoch uint64
 }
 
 func newTopology() *Topology {
@@ -1679,13 +1971,13 @@ func (c *cluster) loadTopology() error {
 // saveTopology writes the current topology to disk. unprotected.
 func (c *cluster) saveTopology() error {
 
-	if err := os.MkdirAll(c.Path, 0777); err != nil {
+	if err := os.MkdirAll(c.Path, c.dirPerm); err != nil {
 		return errors.Wrap(err, "creating directory")
 	}
 
 	if buf, err := proto.Marshal(encodeTopology(c.Topology)); err != nil {
 		return errors.Wrap(err, "marshalling")
-	} else if err := ioutil.WriteFile(filepath.Join(c.Path, ".topology"), buf, 0666); err != nil {
+	} else if err := ioutil.WriteFile(filepath.Join(c.Path, ".topology"), buf, c.filePerm); err != nil {
 		return errors.Wrap(err, "writing file")
 	}
 	return nil
@@ -1759,6 +2051,10 @@ func (c *cluster) ReceiveEvent(e *NodeEvent) (err error) {
 	switch e.Event {
 	case NodeJoin:
 		c.logger.Debugf("nodeJoin of %s on %s", e.Node.URI, c.Node.URI)
+		c.quorum.heard(e.Node.ID)
+		if c.cancelNodeRemoval(e.Node.ID) {
+			c.logger.Printf("node %s recovered before its removal from routing", e.Node.ID)
+		}
 		// Ignore the event if this is not the coordinator.
 		if !c.isCoordinator() {
 			return nil
@@ -1769,17 +2065,8 @@ func (c *cluster) ReceiveEvent(e *NodeEvent) (err error) {
 		defer c.mu.Unlock()
 		if c.unprotectedIsCoordinator() {
 			c.logger.Printf("received node leave: %v", e.Node)
-			// if removeNodeBasicSorted succeeds, that means that the node was
-			// not already removed by a removeNode request. We treat this as the
-			// host being temporarily unavailable, and expect it to come back
-			// up.
 			if confirmNodeDown(e.Node.URI, c.logger) {
-				if c.removeNodeBasicSorted(e.Node.ID) {
-					c.Topology.nodeStates[e.Node.ID] = nodeStateDown
-					// put the cluster into STARTING if we've lost a number of nodes
-					// equal to or greater than ReplicaN
-					err = c.unprotectedSetStateAndBroadcast(c.determineClusterState())
-				}
+				err = c.unprotectedNodeDown(e.Node.ID)
 			} else {
 				c.logger.Printf("ignored received node leave: %v", e.Node)
 			}
@@ -1792,11 +2079,82 @@ func (c *cluster) ReceiveEvent(e *NodeEvent) (err error) {
 	return err
 }
 
+// unprotectedNodeDown removes a node which gossip reports dead from routing,
+// once it has stayed dead for the dead routing delay.
+func (c *cluster) unprotectedNodeDown(nodeID string) error {
+	if c.deadRoutingDelay <= 0 {
+		return c.unprotectedRemoveDeadNode(nodeID)
+	}
+	if _, ok := c.pendingRemovals[nodeID]; ok || c.nodePositionByID(nodeID) < 0 {
+		return nil
+	}
+
+	c.logger.Printf("removing dead node %s from routing in %s", nodeID, c.deadRoutingDelay)
+	c.pendingRemovals[nodeID] = time.AfterFunc(c.deadRoutingDelay, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		// The node recovered, or the cluster closed, while the timer fired.
+		if _, ok := c.pendingRemovals[nodeID]; !ok {
+			return
+		}
+		delete(c.pendingRemovals, nodeID)
+		select {
+		case <-c.closing:
+			return
+		default:
+		}
+
+		c.stats.Count("deadRoutingRemoved", 1, 1.0)
+		if err := c.unprotectedRemoveDeadNode(nodeID); err != nil {
+			c.logger.Printf("removing dead node %s: %s", nodeID, err)
+		}
+	})
+	return nil
+}
+
+// unprotectedRemoveDeadNode removes a dead node from routing.
+func (c *cluster) unprotectedRemoveDeadNode(nodeID string) error {
+	// if removeNodeBasicSorted succeeds, that means that the node was
+	// not already removed by a removeNode request. We treat this as the
+	// host being temporarily unavailable, and expect it to come back
+	// up.
+	if !c.removeNodeBasicSorted(nodeID) {
+		return nil
+	}
+	c.Topology.nodeStates[nodeID] = nodeStateDown
+	// put the cluster into STARTING if we've lost a number of nodes
+	// equal to or greater than ReplicaN
+	return c.unprotectedSetStateAndBroadcast(c.determineClusterState())
+}
+
+// cancelNodeRemoval keeps a node which recovered within the dead routing
+// delay in routing. It returns true if the node was waiting to be removed.
+func (c *cluster) cancelNodeRemoval(nodeID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	timer, ok := c.pendingRemovals[nodeID]
+	if !ok {
+		return false
+	}
+	timer.Stop()
+	delete(c.pendingRemovals, nodeID)
+	c.stats.Count("deadRoutingSaved", 1, 1.0)
+	return true
+}
+
 // nodeJoin should only be called by the coordinator.
 func (c *cluster) nodeJoin(node *Node) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.logger.Printf("node join event on coordinator, node: %s, id: %s", node.URI, node.ID)
+	// A node which speaks no common protocol version can't join, since
+	// the messages exchanged with it would fail to decode.
+	if v, err := node.protocolVersion(); err != nil {
+		c.logger.Printf("refusing join of node %s: %v", node.ID, err)
+		return errors.Wrapf(err, "node %s", node.ID)
+	} else if v < ProtocolVersion {
+		c.logger.Printf("node %s speaks protocol version %d, lower than this node's %d", node.ID, v, ProtocolVersion)
+	}
 	if c.needTopologyAgreement() {
 		// A host that is not part of the topology can't be added to the STARTING cluster.
 		if !c.Topology.ContainsID(node.ID) {
@@ -1839,6 +2197,8 @@ func (c *cluster) nodeJoin(node *Node) error {
 			c.logger.Printf("node: %v changed URI from %s to %s", cnode.ID, cnode.URI, node.URI)
 			cnode.URI = node.URI
 		}
+		// The node may have been restarted with another version.
+		cnode.ProtocolVersion, cnode.MinProtocolVersion = node.ProtocolVersion, node.MinProtocolVersion
 		return c.unprotectedSetStateAndBroadcast(c.determineClusterState())
 	}
 
@@ -1919,7 +2279,7 @@ func (c *cluster) nodeLeave(nodeID string) error {
 func (c *cluster) nodeStatus() *NodeStatus {
 	ns := &NodeStatus{
 		Node:   c.Node,
-		Schema: &Schema{Indexes: c.holder.Schema()},
+		Schema: &Schema{Indexes: c.holder.Schema(), Aliases: c.holder.IndexAliases()},
 	}
 	var availableShards *roaring.Bitmap
 	for _, idx := range ns.Schema.Indexes {
@@ -1991,6 +2351,15 @@ func (c *cluster) mergeClusterStatus(cs *ClusterStatus) error {
 		}
 	}
 
+	// Adopt the coordinator's topology epoch. A newly elected coordinator
+	// may lag behind, so the epoch never moves backwards.
+	if c.Topology != nil && cs.Epoch > c.Topology.epoch {
+		c.Topology.epoch = cs.Epoch
+		if err := c.saveTopology(); err != nil {
+			return errors.Wrap(err, "saving topology")
+		}
+	}
+
 	c.unprotectedSetState(cs.State)
 
 	c.markAsJoined()
@@ -2017,7 +2386,8 @@ func (c *cluster) unprotectedPreviousNode() *Node {
 }
 
 // PrimaryReplicaNode returns the node listed before the current node in c.Nodes.
-// This is different than "previous node" as the first node always returns nil.
+// This is different than "previous node" as the first node always returns nil,
+// or the upstream node if the cluster is a standby.
 func (c *cluster) PrimaryReplicaNode() *Node {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -2026,12 +2396,27 @@ func (c *cluster) PrimaryReplicaNode() *Node {
 
 func (c *cluster) unprotectedPrimaryReplicaNode() *Node {
 	pos := c.nodePositionByID(c.Node.ID)
-	if pos <= 0 {
+	if pos < 0 {
 		return nil
+	} else if pos == 0 {
+		return c.upstream
 	}
 	return c.nodes[pos-1]
 }
 
+// setUpstream sets the node of the upstream cluster which the first node of a
+// standby cluster replicates translate stores from, and restarts translate
+// store replication. A nil node makes the first node the primary again.
+func (c *cluster) setUpstream(node *Node) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.upstream = node
+	if c.holder == nil {
+		return nil
+	}
+	return c.holder.setPrimaryTranslateStore(c.unprotectedPrimaryReplicaNode())
+}
+
 // setStatic is unprotected, but only called before the cluster has been started
 // (and therefore not concurrently).
 func (c *cluster) setStatic(hosts []string) error {
@@ -2053,6 +2438,7 @@ type ClusterStatus struct {
 	ClusterID string
 	State     string
 	Nodes     []*Node
+	Epoch     uint64
 }
 
 // ResizeInstruction contains the instruction provided to a node
@@ -2079,6 +2465,7 @@ type ResizeSource struct {
 // Schema contains information about indexes and their configuration.
 type Schema struct {
 	Indexes []*IndexInfo
+	Aliases map[string]string
 }
 
 func encodeTopology(topology *Topology) *internal.Topology {
@@ -2088,6 +2475,7 @@ func encodeTopology(topology *Topology) *internal.Topology {
 	return &internal.Topology{
 		ClusterID: topology.clusterID,
 		NodeIDs:   topology.nodeIDs,
+		Epoch:     topology.epoch,
 	}
 }
 
@@ -2099,6 +2487,7 @@ func decodeTopology(topology *internal.Topology) (*Topology, error) {
 	t := newTopology()
 	t.clusterID = topology.ClusterID
 	t.nodeIDs = topology.NodeIDs
+	t.epoch = topology.Epoch
 	sort.Slice(t.nodeIDs,
 		func(i, j int) bool {
 			return t.nodeIDs[i] < t.nodeIDs[j]
@@ -2120,11 +2509,53 @@ type CreateIndexMessage struct {
 	Meta  *IndexOptions
 }
 
+// UpdateIndexMessage is an internal message indicating that the mutable
+// options of an index have changed.
+type UpdateIndexMessage struct {
+	Index string
+	Meta  *IndexOptions
+}
+
 // DeleteIndexMessage is an internal message indicating index deletion.
 type DeleteIndexMessage struct {
 	Index string
 }
 
+// UndeleteIndexMessage is an internal message indicating that a tombstoned
+// index has been restored.
+type UndeleteIndexMessage struct {
+	Index string
+}
+
+// ApplySchemaMessage is an internal message indicating that the indexes and
+// fields of Schema should be created if they do not exist.
+type ApplySchemaMessage struct {
+	Schema *Schema
+}
+
+// SetIndexAliasMessage is an internal message indicating that an index alias
+// has been repointed. An empty Index indicates the alias was removed.
+type SetIndexAliasMessage struct {
+	Alias string
+	Index string
+}
+
+// RenameMessage is an internal message indicating that an index, or the
+// field Field of the index if it is set, has been renamed to NewName.
+type RenameMessage struct {
+	Index   string
+	Field   string
+	NewName string
+}
+
+// SchemaVersionMessage is an internal message, sent after the broadcast of a
+// schema change, holding the version of the change in the schema version
+// vector entry of the node it was made through.
+type SchemaVersionMessage struct {
+	Node    string
+	Version uint64
+}
+
 // CreateFieldMessage is an internal message indicating field creation.
 type CreateFieldMessage struct {
 	Index string
@@ -2188,6 +2619,14 @@ type NodeStatus struct {
 	Node    *Node
 	Indexes []*IndexStatus
 	Schema  *Schema
+
+	// SchemaVersions is the schema version vector of the node. See
+	// Holder.SchemaVersions.
+	SchemaVersions map[string]uint64
+
+	// Time is the time on the node's clock when it sent the status, in
+	// nanoseconds since the epoch.
+	Time int64
 }
 
 // IndexStatus is an internal message representing the contents of an index.
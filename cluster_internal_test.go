@@ -345,6 +345,143 @@ func TestCluster_Owners(t *testing.T) {
 	}
 }
 
+// Ensure a shard is assigned to its first owner which is ready.
+func TestCluster_AvailableShardOwner(t *testing.T) {
+	c := newCluster()
+	c.nodes = []*Node{
+		{ID: "a", State: nodeStateReady},
+		{ID: "b", State: nodeStateReady},
+		{ID: "c", State: nodeStateReady},
+	}
+	c.ReplicaN = 2
+	owners := c.shardNodes("i", 0)
+
+	if n := c.availableShardOwner("i", 0); n != owners[0] {
+		t.Fatalf("expected primary owner, got %v", n)
+	}
+	owners[0].State = nodeStateDown
+	if n := c.availableShardOwner("i", 0); n != owners[1] {
+		t.Fatalf("expected replica owner, got %v", n)
+	}
+	owners[1].State = nodeStateDown
+	if n := c.availableShardOwner("i", 0); n != nil {
+		t.Fatalf("expected no owner, got %v", n)
+	}
+
+	// Node states are not tracked in a static cluster.
+	c.Static = true
+	if n := c.availableShardOwner("i", 0); n != owners[0] {
+		t.Fatalf("expected primary owner of static cluster, got %v", n)
+	}
+}
+
+// Ensure replicas are placed in distinct zones when the nodes declare enough.
+func TestCluster_ZoneOwners(t *testing.T) {
+	c := cluster{
+		nodes: []*Node{
+			{ID: "a", Zone: "rack1"},
+			{ID: "b", Zone: "rack1"},
+			{ID: "c", Zone: "rack2"},
+			{ID: "d", Zone: "rack2"},
+		},
+		Hasher:   NewTestModHasher(),
+		ReplicaN: 2,
+		logger:   logger.NopLogger,
+	}
+
+	// The primary stays on the ring and the replica skips its zone.
+	for partitionID, exp := range [][]*Node{
+		{c.nodes[0], c.nodes[2]},
+		{c.nodes[1], c.nodes[2]},
+		{c.nodes[2], c.nodes[0]},
+		{c.nodes[3], c.nodes[0]},
+	} {
+		if a := c.partitionNodes(partitionID); !reflect.DeepEqual(a, exp) {
+			t.Fatalf("unexpected owners of partition %d: %s", partitionID, spew.Sdump(a))
+		}
+	}
+
+	// Too few zones fall back to the ring.
+	c.ReplicaN = 3
+	if a := c.partitionNodes(1); !reflect.DeepEqual(a, []*Node{c.nodes[1], c.nodes[2], c.nodes[3]}) {
+		t.Fatalf("unexpected owners: %s", spew.Sdump(a))
+	}
+
+	// Nodes without a zone count as one zone.
+	c.nodes = append(c.nodes, &Node{ID: "e"})
+	if a := c.partitionNodes(1); !reflect.DeepEqual(a, []*Node{c.nodes[1], c.nodes[2], c.nodes[4]}) {
+		t.Fatalf("unexpected owners: %s", spew.Sdump(a))
+	}
+}
+
+// Ensure the replicas of a node's partitions are spread over the other zones.
+func TestCluster_ZoneOwners_Balanced(t *testing.T) {
+	c := cluster{
+		nodes: []*Node{
+			{ID: "a", Zone: "rack1"},
+			{ID: "b", Zone: "rack2"},
+			{ID: "c", Zone: "rack2"},
+			{ID: "d", Zone: "rack3"},
+		},
+		Hasher:   &jmphasher{},
+		ReplicaN: 2,
+		logger:   logger.NopLogger,
+	}
+
+	// Walking the ring from "a" always reaches rack2 first.
+	n, zones := 0, make(map[string]int)
+	for partitionID := 0; partitionID < defaultPartitionN; partitionID++ {
+		if nodes := c.partitionNodes(partitionID); nodes[0].ID == "a" {
+			n++
+			zones[nodes[1].Zone]++
+		}
+	}
+	for _, zone := range []string{"rack2", "rack3"} {
+		if zones[zone] < n/3 {
+			t.Fatalf("zone %s has %d of %d replicas: %v", zone, zones[zone], n, zones)
+		}
+	}
+}
+
+// Ensure shard ownership is aggregated by node.
+func TestCluster_ShardDistribution(t *testing.T) {
+	c := newCluster()
+	c.ReplicaN = 2
+	c.nodes = []*Node{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+
+	shards := roaring.NewBitmap()
+	for i := uint64(0); i < 30; i++ {
+		shards.Add(i)
+	}
+	dist := c.shardDistribution(map[string]*roaring.Bitmap{"i": shards})
+
+	primary, replica := 0, 0
+	for _, ns := range dist.Nodes {
+		if ns.Indexes["i"].Primary != ns.Primary || ns.Indexes["i"].Replica != ns.Replica {
+			t.Fatalf("unexpected index counts for node %s: %+v", ns.ID, ns.Indexes["i"])
+		}
+		primary += ns.Primary
+		replica += ns.Replica
+	}
+	if primary != 30 || replica != 30 {
+		t.Fatalf("unexpected totals: primary=%d, replica=%d", primary, replica)
+	} else if dist.Balance < 1 {
+		t.Fatalf("unexpected balance: %f", dist.Balance)
+	}
+
+	// A node which owns nothing skews the balance.
+	c.nodes = append(c.nodes, &Node{ID: "d"})
+	c.ReplicaN = 1
+	c.Hasher = NewTestModHasher()
+	c.partitionN = 3
+	dist = c.shardDistribution(map[string]*roaring.Bitmap{"i": shards})
+	if dist.Nodes[3].Primary != 0 {
+		t.Fatalf("expected node d to own nothing, got %+v", dist.Nodes[3])
+	} else if dist.Balance < 10 {
+		t.Fatalf("unexpected balance: %f", dist.Balance)
+	}
+}
+
 // Ensure the partitioner can assign a fragment to a partition.
 func TestCluster_Partition(t *testing.T) {
 	if err := quick.Check(func(index string, shard uint64, partitionN int) bool {
@@ -581,6 +718,60 @@ func TestCluster_Topology(t *testing.T) {
 	})
 }
 
+// Ensure the topology epoch is bumped by the coordinator on membership
+// changes and adopted by the other nodes.
+func TestCluster_TopologyEpoch(t *testing.T) {
+	c1 := NewTestCluster(1)
+	node1 := &Node{ID: "node1", URI: NewTestURIFromHostPort("host1", 0)}
+
+	t.Run("Coordinator", func(t *testing.T) {
+		if err := c1.addNode(node1); err != nil {
+			t.Fatal(err)
+		} else if epoch := c1.Epoch(); epoch != 1 {
+			t.Fatalf("expected epoch 1, got %d", epoch)
+		}
+
+		// Adding the same node does not change the topology.
+		if err := c1.addNode(node1); err != nil {
+			t.Fatal(err)
+		} else if epoch := c1.Epoch(); epoch != 1 {
+			t.Fatalf("expected epoch 1, got %d", epoch)
+		}
+
+		if err := c1.removeNode(node1.ID); err != nil {
+			t.Fatal(err)
+		} else if epoch := c1.Epoch(); epoch != 2 {
+			t.Fatalf("expected epoch 2, got %d", epoch)
+		}
+
+		// The epoch is persisted with the topology.
+		if err := c1.loadTopology(); err != nil {
+			t.Fatal(err)
+		} else if epoch := c1.Epoch(); epoch != 2 {
+			t.Fatalf("expected loaded epoch 2, got %d", epoch)
+		}
+	})
+
+	t.Run("NonCoordinator", func(t *testing.T) {
+		c2 := NewTestCluster(2)
+		c2.Node = c2.nodes[1]
+		nodes := []*Node{c2.nodes[0], c2.nodes[1]}
+
+		if err := c2.mergeClusterStatus(&ClusterStatus{State: ClusterStateNormal, Nodes: nodes, Epoch: 5}); err != nil {
+			t.Fatal(err)
+		} else if epoch := c2.Epoch(); epoch != 5 {
+			t.Fatalf("expected epoch 5, got %d", epoch)
+		}
+
+		// An older epoch is ignored.
+		if err := c2.mergeClusterStatus(&ClusterStatus{State: ClusterStateNormal, Nodes: nodes, Epoch: 3}); err != nil {
+			t.Fatal(err)
+		} else if epoch := c2.Epoch(); epoch != 5 {
+			t.Fatalf("expected epoch 5, got %d", epoch)
+		}
+	})
+}
+
 // Ensure that general cluster functionality works as expected.
 func TestCluster_ResizeStates(t *testing.T) {
 
@@ -920,6 +1111,72 @@ func TestCluster_UpdateCoordinator(t *testing.T) {
 	})
 }
 
+func TestCluster_DeadRoutingDelay(t *testing.T) {
+	t.Run("Recovered", func(t *testing.T) {
+		c := NewTestCluster(3)
+		c.Static = true
+		c.deadRoutingDelay = 50 * time.Millisecond
+		node := c.nodes[1]
+
+		c.mu.Lock()
+		err := c.unprotectedNodeDown(node.ID)
+		c.mu.Unlock()
+		if err != nil {
+			t.Fatal(err)
+		} else if c.nodeByID(node.ID) == nil {
+			t.Fatalf("expected node to stay in routing during the delay")
+		}
+
+		if err := c.ReceiveEvent(&NodeEvent{Event: NodeJoin, Node: node}); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(2 * c.deadRoutingDelay)
+		if c.nodeByID(node.ID) == nil {
+			t.Fatalf("expected recovered node to stay in routing")
+		} else if c.cancelNodeRemoval(node.ID) {
+			t.Fatalf("expected no pending removal")
+		}
+	})
+
+	t.Run("Removed", func(t *testing.T) {
+		c := NewTestCluster(3)
+		c.Static = true
+		c.deadRoutingDelay = 50 * time.Millisecond
+		node := c.nodes[1]
+
+		c.mu.Lock()
+		err := c.unprotectedNodeDown(node.ID)
+		c.mu.Unlock()
+		if err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(2 * c.deadRoutingDelay)
+		c.mu.RLock()
+		state := c.Topology.nodeStates[node.ID]
+		c.mu.RUnlock()
+		if c.nodeByID(node.ID) != nil {
+			t.Fatalf("expected dead node to be removed from routing")
+		} else if state != nodeStateDown {
+			t.Fatalf("unexpected node state: %s", state)
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		c := NewTestCluster(3)
+		c.Static = true
+		node := c.nodes[1]
+
+		c.mu.Lock()
+		err := c.unprotectedNodeDown(node.ID)
+		c.mu.Unlock()
+		if err != nil {
+			t.Fatal(err)
+		} else if c.nodeByID(node.ID) != nil {
+			t.Fatalf("expected dead node to be removed from routing")
+		}
+	})
+}
+
 func TestCluster_confirmNodeDownUp(t *testing.T) {
 	r := mux.NewRouter()
 	r.HandleFunc("/version", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -16,6 +16,8 @@ package cmd
 
 import (
 	"io"
+	"io/ioutil"
+	"strings"
 
 	"github.com/pilosa/pilosa/v2/ctl"
 	"github.com/pilosa/pilosa/v2/server"
@@ -23,6 +25,8 @@ import (
 	"github.com/pilosa/pilosa/v2/tracing/opentracing"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 	jaegercfg "github.com/uber/jaeger-client-go/config"
 )
 
@@ -68,6 +72,10 @@ It will load existing data from the configured
 directory and start listening for client connections
 on the configured port.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			Server.ReloadConfig = func() (*server.Config, error) {
+				return reloadServerConfig(cmd.Flags())
+			}
+
 			// Start & run the server.
 			if err := Server.Start(); err != nil {
 				return errors.Wrap(err, "running server")
@@ -83,6 +91,7 @@ on the configured port.`,
 				}
 				cfg.Reporter = &jaegercfg.ReporterConfig{
 					LocalAgentHostPort: Server.Config.Tracing.AgentHostPort,
+					CollectorEndpoint:  Server.Config.Tracing.CollectorEndpoint,
 				}
 				tracer, closer, err := cfg.NewTracer()
 				if err != nil {
@@ -100,3 +109,33 @@ on the configured port.`,
 	ctl.BuildServerFlags(serveCmd, Server)
 	return serveCmd
 }
+
+// reloadServerConfig builds a new server config the same way it was built at
+// startup: flags set on the command line take precedence over the
+// environment, which takes precedence over the config file.
+func reloadServerConfig(flags *pflag.FlagSet) (*server.Config, error) {
+	srv := server.NewCommand(nil, ioutil.Discard, ioutil.Discard)
+	cmd := &cobra.Command{}
+	ctl.BuildServerFlags(cmd, srv)
+	cmd.Flags().StringP("config", "c", "", "Configuration file to read from.")
+
+	var err error
+	flags.Visit(func(f *pflag.Flag) {
+		if err != nil || cmd.Flags().Lookup(f.Name) == nil {
+			return
+		}
+		value := f.Value.String()
+		if f.Value.Type() == "stringSlice" {
+			value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+		}
+		err = cmd.Flags().Set(f.Name, value)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "copying flags")
+	}
+
+	if err := setAllConfig(viper.New(), cmd.Flags(), "PILOSA"); err != nil {
+		return nil, err
+	}
+	return srv.Config, nil
+}
@@ -16,6 +16,7 @@ package cmd_test
 
 import (
 	"io/ioutil"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -77,6 +78,34 @@ func TestServerConfig(t *testing.T) {
 				v.Check(cmd.Server.Config.Translation.MapSize, 100000)
 				v.Check(cmd.Server.Config.Profile.BlockRate, 9123)
 				v.Check(cmd.Server.Config.Profile.MutexFraction, 444)
+				if v.Error() != nil {
+					return v.Error()
+				}
+
+				// A reload re-reads the config file with the same precedence.
+				// Without its environment override, the file's
+				// max-writes-per-request is used.
+				os.Unsetenv("PILOSA_MAX_WRITES_PER_REQUEST")
+				err := ioutil.WriteFile(os.Getenv("PILOSA_CONFIG"), []byte(`
+	data-dir = "/tmp/myFileDatadir"
+	max-writes-per-request = 1500
+	[cluster]
+		long-query-time = "1m10s"
+	[anti-entropy]
+		interval = "7m0s"
+	`), 0600)
+				if err != nil {
+					return err
+				}
+				reloaded, err := cmd.Server.ReloadConfig()
+				if err != nil {
+					return errors.Wrap(err, "reloading config")
+				}
+				v.Check(reloaded.DataDir, actualDataDir)
+				v.Check(reloaded.Cluster.Hosts, []string{"localhost:42454", "localhost:10110"})
+				v.Check(reloaded.Cluster.LongQueryTime, toml.Duration(time.Second*90))
+				v.Check(reloaded.AntiEntropy.Interval, toml.Duration(time.Minute*7))
+				v.Check(reloaded.MaxWritesPerRequest, 1500)
 				return v.Error()
 			},
 		},
@@ -84,8 +113,48 @@ func TestServerConfig(t *testing.T) {
 		{
 			args: []string{"server",
 				"--anti-entropy.interval", "9m0s",
+				"--anti-entropy.checksum", "container",
+				"--anti-entropy.queue-size", "4",
+				"--anti-entropy.queue-policy", "drop",
+				"--anti-entropy.queue-timeout", "5s",
+				"--index.flush-interval", "30s",
+				"--index.fsync-on-flush=false",
+				"--index.tombstone-grace-period", "24h",
+				"--index.compaction-threshold", "0.25",
+				"--query.cache.size", "100",
+				"--handler.max-body-bytes", "1048576",
+				"--cluster.self-heal-threshold", "0.5",
+				"--cluster.owner-change-retries", "5",
+				"--cluster.breaker-threshold", "3",
+				"--cluster.require-quorum-on-start",
+				"--max-memory", "8589934592",
+				"--memory.low-watermark", "0.7",
+				"--auto-create",
+				"--auto-create-field-type", "mutex",
+				"--shutdown.query-timeout", "1m",
+				"--handler.listener-count", "2",
+				"--handler.listener-backlog", "4096",
 				"--profile.block-rate", "4832",
 				"--profile.mutex-fraction", "8290",
+				"--dir-perm", "0750",
+				"--default-index", "repository",
+				"--metric.usage-interval", "5m",
+				"--gossip.dead-routing-delay", "10s",
+				"--cluster.clock-skew-threshold", "30s",
+				"--log-tail-lines", "50",
+				"--node.zone", "rack-1",
+				"--query.safe-mode.enabled",
+				"--query.safe-mode.max-rows", "5000",
+				"--query.partial-results",
+				"--query.max-depth", "20",
+				"--query.max-result-rows", "1000",
+				"--warmup.sample-rate", "0.5",
+				"--warmup.timeout", "10s",
+				"--admin.port", "10111",
+				"--admin.tls.enable-client-verification",
+				"--replication.upstream", "http://localhost:20101",
+				"--change-feed.buffer-size", "100000",
+				"--limits.max-fields-per-index", "500",
 			},
 			env: map[string]string{
 				"PILOSA_CLUSTER_HOSTS":          "localhost:1110,localhost:1111",
@@ -105,14 +174,73 @@ func TestServerConfig(t *testing.T) {
 	[profile]
 		block-rate = 100
 		mutex-fraction = 10
+	[query.cache]
+		size = 10
+		ttl = "5m"
 	`,
 			validation: func() error {
 				v := validator{}
 				v.Check(cmd.Server.Config.Cluster.Hosts, []string{"localhost:1110", "localhost:1111"})
 				v.Check(cmd.Server.Config.AntiEntropy.Interval, toml.Duration(time.Minute*9))
+				v.Check(cmd.Server.Config.AntiEntropy.Checksum, "container")
+				v.Check(cmd.Server.Config.AntiEntropy.QueueSize, 4)
+				v.Check(cmd.Server.Config.AntiEntropy.QueuePolicy, "drop")
+				v.Check(cmd.Server.Config.AntiEntropy.QueueTimeout, toml.Duration(5*time.Second))
+				v.Check(cmd.Server.Config.Index.FlushInterval, toml.Duration(time.Second*30))
+				v.Check(cmd.Server.Config.Index.FsyncOnFlush, false)
+				v.Check(cmd.Server.Config.Index.TombstoneGracePeriod, toml.Duration(time.Hour*24))
+				v.Check(cmd.Server.Config.Index.TombstoneReapInterval, toml.Duration(time.Minute))
+				v.Check(cmd.Server.Config.Index.CompactionThreshold, 0.25)
+				v.Check(cmd.Server.Config.Index.CompactionInterval, toml.Duration(time.Hour))
+				v.Check(cmd.Server.Config.Query.Cache.Size, 100)
+				v.Check(cmd.Server.Config.Query.Cache.TTL, toml.Duration(time.Minute*5))
+				v.Check(cmd.Server.Config.Handler.MaxBodyBytes, int64(1048576))
+				v.Check(cmd.Server.Config.Cluster.SelfHealThreshold, 0.5)
+				v.Check(cmd.Server.Config.Cluster.OwnerChangeRetries, 5)
+				v.Check(cmd.Server.Config.Cluster.BreakerThreshold, 3)
+				v.Check(cmd.Server.Config.Cluster.BreakerCooldown, toml.Duration(30*time.Second))
+				v.Check(cmd.Server.Config.Cluster.RequireQuorumOnStart, true)
+				v.Check(cmd.Server.Config.Cluster.QuorumTimeout, toml.Duration(5*time.Minute))
+				v.Check(cmd.Server.Config.Metric.UsageInterval, toml.Duration(5*time.Minute))
+				v.Check(cmd.Server.Config.Gossip.DeadRoutingDelay, toml.Duration(10*time.Second))
+				v.Check(cmd.Server.Config.Cluster.ClockSkewThreshold, toml.Duration(30*time.Second))
+				v.Check(cmd.Server.Config.LogTailLines, 50)
+				v.Check(cmd.Server.Config.Node.Zone, "rack-1")
+				v.Check(cmd.Server.Config.Query.SafeMode.Enabled, true)
+				v.Check(cmd.Server.Config.Query.SafeMode.MaxShards, 100)
+				v.Check(cmd.Server.Config.Query.SafeMode.MaxRows, uint64(5000))
+				v.Check(cmd.Server.Config.Query.PartialResults, true)
+				v.Check(cmd.Server.Config.Query.MaxDepth, 20)
+				v.Check(cmd.Server.Config.Query.MaxOps, 10000)
+				v.Check(cmd.Server.Config.Query.MaxResultRows, uint64(1000))
+				v.Check(cmd.Server.Config.Warmup.SampleRate, 0.5)
+				v.Check(cmd.Server.Config.Warmup.MaxQueries, 1000)
+				v.Check(cmd.Server.Config.Warmup.Timeout, toml.Duration(10*time.Second))
+				v.Check(cmd.Server.Config.Admin.Port, 10111)
+				v.Check(cmd.Server.Config.Admin.TLS.EnableClientVerification, true)
+				v.Check(cmd.Server.Config.Handler.ListenerCount, 2)
+				v.Check(cmd.Server.Config.Handler.ListenerBacklog, 4096)
 				v.Check(cmd.Server.Config.Translation.MapSize, 100000)
 				v.Check(cmd.Server.Config.Profile.BlockRate, 4832)
 				v.Check(cmd.Server.Config.Profile.MutexFraction, 8290)
+				v.Check(cmd.Server.Config.DirPerm, "0750")
+				v.Check(cmd.Server.Config.DefaultIndex, "repository")
+				v.Check(cmd.Server.Config.Replication.Upstream, "http://localhost:20101")
+				v.Check(cmd.Server.Config.Replication.Interval, toml.Duration(time.Minute))
+				v.Check(cmd.Server.Config.ChangeFeed.BufferSize, 100000)
+				v.Check(cmd.Server.Config.MaxMemory, uint64(8589934592))
+				v.Check(cmd.Server.Config.Memory.HighWatermark, 0.9)
+				v.Check(cmd.Server.Config.Memory.LowWatermark, 0.7)
+				v.Check(cmd.Server.Config.Memory.CheckInterval, toml.Duration(time.Second))
+				v.Check(cmd.Server.Config.AutoCreate, true)
+				v.Check(cmd.Server.Config.AutoCreateFieldType, "mutex")
+				v.Check(cmd.Server.Config.Shutdown.WriteTimeout, toml.Duration(10*time.Second))
+				v.Check(cmd.Server.Config.Shutdown.QueryTimeout, toml.Duration(time.Minute))
+				v.Check(cmd.Server.Config.Shutdown.FlushTimeout, toml.Duration(time.Minute))
+				v.Check(cmd.Server.Config.Shutdown.ListenerTimeout, toml.Duration(30*time.Second))
+				v.Check(cmd.Server.Config.Limits.MaxIndexes, 10000)
+				v.Check(cmd.Server.Config.Limits.MaxFieldsPerIndex, 500)
+				v.Check(cmd.Server.Config.Limits.MaxOpenFiles, uint64(900000))
 				return v.Error()
 			},
 		},
@@ -123,12 +251,17 @@ func TestServerConfig(t *testing.T) {
 			cfgFileContent: `
 	bind = "localhost:19444"
 	data-dir = "` + actualDataDir + `"
+	file-perm = "0640"
 	[cluster]
 		hosts = [
 			"localhost:19444",
 		]
+		owner-change-backoff = "250ms"
+		owner-change-max-backoff = "2s"
 	[anti-entropy]
 		interval = "11m0s"
+	[replication]
+		interval = "30s"
 	[metric]
 		service = "statsd"
 		host = "127.0.0.1:8125"
@@ -140,8 +273,12 @@ func TestServerConfig(t *testing.T) {
 			validation: func() error {
 				v := validator{}
 				v.Check(cmd.Server.Config.Cluster.Hosts, []string{"localhost:19444"})
+				v.Check(cmd.Server.Config.Cluster.OwnerChangeBackoff, toml.Duration(time.Millisecond*250))
+				v.Check(cmd.Server.Config.Cluster.OwnerChangeMaxBackoff, toml.Duration(time.Second*2))
 				v.Check(cmd.Server.Config.AntiEntropy.Interval, toml.Duration(time.Minute*11))
 				v.Check(cmd.Server.Config.LogPath, logFile.Name())
+				v.Check(cmd.Server.Config.FilePerm, "0640")
+				v.Check(cmd.Server.Config.Replication.Interval, toml.Duration(time.Second*30))
 				v.Check(cmd.Server.Config.Metric.Service, "statsd")
 				v.Check(cmd.Server.Config.Metric.Host, "127.0.0.1:8125")
 				v.Check(cmd.Server.Config.Profile.BlockRate, 5352)
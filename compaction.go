@@ -0,0 +1,199 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// defaultCompactionInterval is how often fragments are checked for
+// fragmentation when automatic compaction is enabled.
+const defaultCompactionInterval = time.Hour
+
+// FragmentCompaction reports the compaction of a fragment.
+type FragmentCompaction struct {
+	Index string `json:"index"`
+	Field string `json:"field"`
+	View  string `json:"view"`
+	Shard uint64 `json:"shard"`
+
+	// BytesBefore and BytesAfter are the sizes of the data file of the
+	// fragment before and after it was compacted.
+	BytesBefore int64 `json:"bytesBefore"`
+	BytesAfter  int64 `json:"bytesAfter"`
+}
+
+// CompactionResult reports the compaction of a set of fragments.
+type CompactionResult struct {
+	Fragments      []FragmentCompaction `json:"fragments"`
+	BytesReclaimed int64                `json:"bytesReclaimed"`
+}
+
+// compact rewrites the data file of the fragment with every container in its
+// most compact form, dropping empty containers and the operation log. The
+// compacted bitmap is built while queries continue against the current one,
+// and swapped in under the lock unless the fragment was written to meanwhile,
+// in which case it is rebuilt under the lock. A fragment which compaction
+// would shrink by less than the fraction minReclaim of its size is left as
+// it is. It returns the size of the data file before and after.
+func (f *fragment) compact(minReclaim float64) (before, after int64, err error) {
+	f.mu.RLock()
+	storage, gen := f.storage, f.generation.load()
+	bm := storage.Clone()
+	f.mu.RUnlock()
+	bm.Optimize()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	fi, err := os.Stat(f.path)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "statting data file")
+	}
+	before = fi.Size()
+
+	// Writes are only detected if the fragment belongs to an index.
+	if f.generation == nil || f.storage != storage || f.generation.load() != gen {
+		bm = f.storage.Clone()
+		bm.Optimize()
+	}
+
+	if minReclaim > 0 {
+		n, _, err := writeFragmentData(ioutil.Discard, bm, f.compression.load())
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "sizing compacted bitmap")
+		} else if before == 0 || float64(before-n)/float64(before) < minReclaim {
+			return before, before, nil
+		}
+	}
+
+	bm.Flags = f.storage.Flags
+	f.totalOpN += int64(f.opN)
+	f.totalOps += int64(f.ops)
+	f.snapshotsTaken++
+	if after, err = unprotectedWriteToFragment(f, bm); err != nil {
+		return 0, 0, errors.Wrap(err, "writing compacted bitmap")
+	}
+	f.stats.Count("compactBytesReclaimed", before-after, 1.0)
+	return before, after, nil
+}
+
+// compactFragments compacts the fragments of an index, optionally restricted
+// to a field, a view and a set of shards.
+func (h *Holder) compactFragments(index, fieldName, viewName string, shards []uint64) (CompactionResult, error) {
+	result := CompactionResult{Fragments: []FragmentCompaction{}}
+
+	idx := h.Index(index)
+	if idx == nil {
+		return result, newNotFoundError(ErrIndexNotFound, index)
+	}
+	fields := idx.Fields()
+	if fieldName != "" {
+		f := idx.Field(fieldName)
+		if f == nil {
+			return result, newNotFoundError(ErrFieldNotFound, fieldName)
+		}
+		fields = []*Field{f}
+	}
+
+	for _, f := range fields {
+		views := f.views()
+		if viewName != "" {
+			v := f.view(viewName)
+			if v == nil {
+				return result, newNotFoundError(ErrViewNotFound, viewName)
+			}
+			views = []*view{v}
+		}
+
+		for _, v := range views {
+			frags := v.allFragments()
+			if len(shards) > 0 {
+				frags = nil
+				for _, shard := range shards {
+					if frag := v.Fragment(shard); frag != nil {
+						frags = append(frags, frag)
+					}
+				}
+			}
+
+			for _, frag := range frags {
+				before, after, err := frag.compact(0)
+				if err != nil {
+					return result, errors.Wrapf(err, "compacting fragment %s/%s/%s/%d", frag.index, frag.field, frag.view, frag.shard)
+				}
+				result.Fragments = append(result.Fragments, FragmentCompaction{
+					Index:       frag.index,
+					Field:       frag.field,
+					View:        frag.view,
+					Shard:       frag.shard,
+					BytesBefore: before,
+					BytesAfter:  after,
+				})
+				result.BytesReclaimed += before - after
+			}
+		}
+	}
+	return result, nil
+}
+
+// monitorCompaction periodically compacts the fragments which compaction
+// would shrink by at least the compaction threshold.
+// This is run in a goroutine.
+func (h *Holder) monitorCompaction() {
+	ticker := time.NewTicker(h.compactionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.closing:
+			return
+		case <-ticker.C:
+			h.compact()
+		}
+	}
+}
+
+// compact compacts every fragment which compaction would shrink by at least
+// the compaction threshold.
+func (h *Holder) compact() {
+	var total int64
+	for _, index := range h.Indexes() {
+		for _, field := range index.Fields() {
+			for _, view := range field.views() {
+				for _, fragment := range view.allFragments() {
+					select {
+					case <-h.closing:
+						return
+					default:
+					}
+
+					before, after, err := fragment.compact(h.compactionThreshold)
+					if err != nil {
+						h.Logger.Printf("ERROR compacting fragment: err=%s, path=%s", err, fragment.path)
+					}
+					total += before - after
+				}
+			}
+		}
+	}
+	if total > 0 {
+		h.Logger.Printf("compaction reclaimed %d bytes", total)
+	}
+}
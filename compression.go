@@ -0,0 +1,146 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"sync/atomic"
+
+	"github.com/pilosa/pilosa/v2/roaring"
+	"github.com/pkg/errors"
+)
+
+// Compression algorithms of the fragment data files of an index.
+const (
+	CompressionNone = ""
+	CompressionGzip = "gzip"
+)
+
+// ErrInvalidCompression is returned for an unknown compression algorithm.
+var ErrInvalidCompression = errors.New("invalid compression")
+
+// ValidCompression returns true if c is a valid compression algorithm.
+func ValidCompression(c string) bool {
+	return c == CompressionNone || c == CompressionGzip
+}
+
+// compressedFragmentMagic starts the data files of compressed fragments. An
+// uncompressed data file starts with a roaring cookie, which never matches.
+var compressedFragmentMagic = []byte("PFZ1")
+
+// compressedHeaderSize is the size of the header of a compressed data file:
+// the magic and the length of the compressed bitmap which follows it. The
+// operation log is appended, uncompressed, after the compressed bitmap.
+const compressedHeaderSize = 12
+
+// fragmentCompression holds the compression of the data files of the
+// fragments of an index. It is shared by the fragments, which apply a change
+// the next time they write their data file. A nil fragmentCompression means
+// no compression.
+type fragmentCompression struct {
+	v atomic.Value // string
+}
+
+func (c *fragmentCompression) load() string {
+	if c == nil {
+		return CompressionNone
+	}
+	s, _ := c.v.Load().(string)
+	return s
+}
+
+func (c *fragmentCompression) store(s string) { c.v.Store(s) }
+
+// writeFragmentData writes bm to w as the bitmap of a data file, compressed
+// with the given algorithm. It returns the number of bytes written and the
+// size of the bitmap uncompressed.
+func writeFragmentData(w io.Writer, bm *roaring.Bitmap, compression string) (n, raw int64, err error) {
+	if compression == CompressionNone {
+		n, err = bm.WriteTo(w)
+		return n, n, err
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if raw, err = bm.WriteTo(zw); err != nil {
+		return 0, raw, errors.Wrap(err, "compressing bitmap")
+	} else if err := zw.Close(); err != nil {
+		return 0, raw, errors.Wrap(err, "closing compressor")
+	}
+
+	header := make([]byte, compressedHeaderSize)
+	copy(header, compressedFragmentMagic)
+	binary.LittleEndian.PutUint64(header[len(compressedFragmentMagic):], uint64(buf.Len()))
+	hn, err := w.Write(header)
+	if err != nil {
+		return int64(hn), raw, err
+	}
+	n, err = buf.WriteTo(w)
+	return int64(hn) + n, raw, err
+}
+
+// isCompressedFragmentData returns true if data, or its first bytes, are
+// those of a compressed data file.
+func isCompressedFragmentData(data []byte) bool {
+	return bytes.HasPrefix(data, compressedFragmentMagic)
+}
+
+// DecodeFragmentData returns the contents of a fragment data file as an
+// uncompressed roaring bitmap followed by its operation log. Uncompressed
+// data is returned as it is.
+func DecodeFragmentData(data []byte) ([]byte, error) {
+	if !isCompressedFragmentData(data) {
+		return data, nil
+	}
+	body, ops, err := splitCompressedFragmentData(data)
+	if err != nil {
+		return nil, err
+	}
+	bm, err := decompressBitmap(body)
+	if err != nil {
+		return nil, err
+	}
+	return append(bm, ops...), nil
+}
+
+// splitCompressedFragmentData splits a compressed data file into the
+// compressed bitmap and the operation log which follows it.
+func splitCompressedFragmentData(data []byte) (body, ops []byte, err error) {
+	if len(data) < compressedHeaderSize {
+		return nil, nil, errors.New("truncated compressed fragment header")
+	}
+	n := binary.LittleEndian.Uint64(data[len(compressedFragmentMagic):compressedHeaderSize])
+	if n > uint64(len(data)-compressedHeaderSize) {
+		return nil, nil, errors.Errorf("compressed bitmap of %d bytes exceeds data file", n)
+	}
+	return data[compressedHeaderSize : compressedHeaderSize+n], data[compressedHeaderSize+n:], nil
+}
+
+// decompressBitmap decompresses the compressed bitmap of a data file.
+func decompressBitmap(body []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "opening compressed bitmap")
+	}
+	bm, err := ioutil.ReadAll(zr)
+	if err != nil {
+		return nil, errors.Wrap(err, "decompressing bitmap")
+	}
+	return bm, nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/pilosa/pilosa/v2/roaring"
+)
+
+func TestDecodeFragmentData(t *testing.T) {
+	bm := roaring.NewBitmap(1, 2, 70000, 1<<20)
+	var raw bytes.Buffer
+	if _, err := bm.WriteTo(&raw); err != nil {
+		t.Fatal(err)
+	}
+
+	// Uncompressed data is returned as it is.
+	if data, err := DecodeFragmentData(raw.Bytes()); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(data, raw.Bytes()) {
+		t.Fatal("unexpected uncompressed data")
+	}
+
+	var buf bytes.Buffer
+	n, size, err := writeFragmentData(&buf, bm, CompressionGzip)
+	if err != nil {
+		t.Fatal(err)
+	} else if n != int64(buf.Len()) || size != int64(raw.Len()) {
+		t.Fatalf("unexpected sizes: n=%d size=%d", n, size)
+	} else if !isCompressedFragmentData(buf.Bytes()) {
+		t.Fatal("expected compressed data")
+	}
+
+	data, err := DecodeFragmentData(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := roaring.NewBitmap()
+	if err := other.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(other.Slice(), bm.Slice()) {
+		t.Fatalf("unexpected bits: %v", other.Slice())
+	}
+
+	if _, err := DecodeFragmentData(buf.Bytes()[:compressedHeaderSize-1]); err == nil {
+		t.Fatal("expected truncated header error")
+	} else if _, err := DecodeFragmentData(buf.Bytes()[:compressedHeaderSize+1]); err == nil {
+		t.Fatal("expected truncated bitmap error")
+	}
+}
+
+// Ensure a compressed fragment keeps its data, including the operations
+// appended after a snapshot, across restarts.
+func TestFragment_Compression(t *testing.T) {
+	f := mustOpenFragment("i", "f", viewStandard, 0, "")
+	f.compression = &fragmentCompression{}
+	f.compression.store(CompressionGzip)
+	for i := uint64(0); i < 1000; i++ {
+		f.mustSetBits(1, i*3)
+	}
+	if err := f.Snapshot(); err != nil {
+		t.Fatal(err)
+	} else if f.compressedBytes == 0 || f.uncompressedBytes <= f.compressedBytes {
+		t.Fatalf("unexpected sizes: compressed=%d uncompressed=%d", f.compressedBytes, f.uncompressedBytes)
+	}
+	f.mustSetBits(2, 5)
+
+	assertData := func() {
+		t.Helper()
+		if n := f.row(1).Count(); n != 1000 {
+			t.Fatalf("unexpected count: %d", n)
+		} else if cols := f.row(2).Columns(); !reflect.DeepEqual(cols, []uint64{5}) {
+			t.Fatalf("unexpected columns: %v", cols)
+		}
+	}
+
+	if err := f.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+	assertData()
+	if buf, err := ioutil.ReadFile(f.path); err != nil {
+		t.Fatal(err)
+	} else if !isCompressedFragmentData(buf) {
+		t.Fatal("expected compressed data file")
+	} else if f.compressedBytes == 0 {
+		t.Fatal("expected compressed size after reopening")
+	}
+
+	// Disabling compression decompresses the data file on the next snapshot.
+	f.compression.store(CompressionNone)
+	if err := f.Snapshot(); err != nil {
+		t.Fatal(err)
+	} else if err := f.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+	assertData()
+	if buf, err := ioutil.ReadFile(f.path); err != nil {
+		t.Fatal(err)
+	} else if isCompressedFragmentData(buf) {
+		t.Fatal("expected uncompressed data file")
+	} else if f.compressedBytes != 0 {
+		t.Fatalf("unexpected compressed size: %d", f.compressedBytes)
+	}
+	f.Clean(t)
+}
+
+// Ensure the compression of an index is validated, persisted and applied to
+// its fragments.
+func TestIndex_Compression(t *testing.T) {
+	index := mustOpenIndex(IndexOptions{})
+	defer os.RemoveAll(index.Path())
+	defer index.Close()
+
+	opt := index.Options()
+	opt.Compression = "lz4"
+	if err := index.setOptions(opt); err != ErrInvalidCompression {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	opt.Compression = CompressionGzip
+	if err := index.setOptions(opt); err != nil {
+		t.Fatal(err)
+	}
+
+	field, err := index.CreateField("f")
+	if err != nil {
+		t.Fatal(err)
+	} else if _, err := field.SetBit(1, 2, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := index.reopen(); err != nil {
+		t.Fatal(err)
+	} else if c := index.Options().Compression; c != CompressionGzip {
+		t.Fatalf("unexpected compression: %q", c)
+	}
+
+	frag := index.Field("f").view(viewStandard).Fragment(0)
+	if buf, err := ioutil.ReadFile(frag.path); err != nil {
+		t.Fatal(err)
+	} else if !isCompressedFragmentData(buf) {
+		t.Fatal("expected compressed data file")
+	} else if cols := frag.row(1).Columns(); !reflect.DeepEqual(cols, []uint64{2}) {
+		t.Fatalf("unexpected columns: %v", cols)
+	}
+}
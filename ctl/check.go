@@ -97,9 +97,13 @@ func (cmd *CheckCommand) checkBitmapFile(path string) (err error) {
 			err = e
 		}
 	}()
-	// Attach the mmap file to the bitmap.
+	// Attach the mmap file to the bitmap, decompressing it if needed.
+	bmData, err := pilosa.DecodeFragmentData(data)
+	if err != nil {
+		return errors.Wrap(err, "decoding")
+	}
 	bm := roaring.NewBitmap()
-	if err := bm.UnmarshalBinary(data); err != nil {
+	if err := bm.UnmarshalBinary(bmData); err != nil {
 		return errors.Wrap(err, "unmarshalling")
 	}
 
@@ -117,6 +117,7 @@ func (cmd *ImportCommand) Run(ctx context.Context) error {
 
 	// Determine the field type in order to correctly handle the input data.
 	fieldType := pilosa.DefaultFieldType
+	var timeUnit string
 	schema, err := cmd.client.Schema(ctx)
 	if err != nil {
 		return errors.Wrap(err, "getting schema")
@@ -130,6 +131,7 @@ func (cmd *ImportCommand) Run(ctx context.Context) error {
 				if field.Name == cmd.Field {
 					useRowKeys = field.Options.Keys
 					fieldType = field.Options.Type
+					timeUnit = field.Options.TimeUnit
 					break
 				}
 			}
@@ -140,7 +142,7 @@ func (cmd *ImportCommand) Run(ctx context.Context) error {
 	// Import each path and import by shard.
 	for _, path := range cmd.Paths {
 		logger.Printf("parsing: %s", path)
-		if err := cmd.importPath(ctx, fieldType, useColumnKeys, useRowKeys, path); err != nil {
+		if err := cmd.importPath(ctx, fieldType, timeUnit, useColumnKeys, useRowKeys, path); err != nil {
 			return err
 		}
 	}
@@ -161,10 +163,13 @@ func (cmd *ImportCommand) ensureSchema(ctx context.Context) error {
 }
 
 // importPath parses a path into bits and imports it to the server.
-func (cmd *ImportCommand) importPath(ctx context.Context, fieldType string, useColumnKeys, useRowKeys bool, path string) error {
+func (cmd *ImportCommand) importPath(ctx context.Context, fieldType, timeUnit string, useColumnKeys, useRowKeys bool, path string) error {
 	// If fieldType is `int`, treat the import data as values to be range-encoded.
 	if fieldType == pilosa.FieldTypeInt {
-		return cmd.bufferValues(ctx, useColumnKeys, path)
+		return cmd.bufferValues(ctx, useColumnKeys, "", path)
+	} else if fieldType == pilosa.FieldTypeTimestamp {
+		// Timestamps are values too, which may be given as times.
+		return cmd.bufferValues(ctx, useColumnKeys, timeUnit, path)
 	}
 	return cmd.bufferBits(ctx, useColumnKeys, useRowKeys, path)
 }
@@ -285,8 +290,10 @@ func (cmd *ImportCommand) importBits(ctx context.Context, useColumnKeys, useRowK
 	return nil
 }
 
-// bufferValues buffers slices of FieldValues to be imported as a batch.
-func (cmd *ImportCommand) bufferValues(ctx context.Context, useColumnKeys bool, path string) error {
+// bufferValues buffers slices of FieldValues to be imported as a batch. If
+// timeUnit is set, the values are timestamps, either in the time unit or in
+// RFC3339 format.
+func (cmd *ImportCommand) bufferValues(ctx context.Context, useColumnKeys bool, timeUnit, path string) error {
 	a := make([]pilosa.FieldValue, 0, cmd.BufferSize)
 
 	var r *csv.Reader
@@ -337,7 +344,12 @@ func (cmd *ImportCommand) bufferValues(ctx context.Context, useColumnKeys bool,
 		}
 
 		// Parse FieldValue.
-		value, err := strconv.ParseInt(record[1], 10, 64)
+		var value int64
+		if timeUnit != "" {
+			value, err = pilosa.ParseTimestamp(record[1], timeUnit)
+		} else {
+			value, err = strconv.ParseInt(record[1], 10, 64)
+		}
 		if err != nil {
 			return fmt.Errorf("invalid value on row %d: %q", rnum, record[1])
 		}
@@ -70,11 +70,15 @@ func (cmd *InspectCommand) Run(_ context.Context) error {
 			fmt.Fprintf(cmd.Stderr, "inspect command: munmap failed: %v", err)
 		}
 	}()
-	// Attach the mmap file to the bitmap.
+	// Attach the mmap file to the bitmap, decompressing it if needed.
 	t := time.Now()
 	fmt.Fprintf(cmd.Stderr, "unmarshalling bitmap...")
+	bmData, err := pilosa.DecodeFragmentData(data)
+	if err != nil {
+		return errors.Wrap(err, "decoding")
+	}
 	bm := roaring.NewBitmap()
-	if err := bm.UnmarshalBinary(data); err != nil {
+	if err := bm.UnmarshalBinary(bmData); err != nil {
 		return errors.Wrap(err, "unmarshalling")
 	}
 	fmt.Fprintf(cmd.Stderr, " (%s)\n", time.Since(t))
@@ -101,7 +105,7 @@ func (cmd *InspectCommand) Run(_ context.Context) error {
 			ci.Type,
 			ci.N,
 			ci.Alloc,
-			uintptr(ci.Pointer)-uintptr(unsafe.Pointer(&data[0])),
+			uintptr(ci.Pointer)-uintptr(unsafe.Pointer(&bmData[0])),
 		)
 	}
 	tw.Flush()
@@ -28,23 +28,59 @@ func BuildServerFlags(cmd *cobra.Command, srv *server.Command) {
 	flags.StringVarP(&srv.Config.Bind, "bind", "b", srv.Config.Bind, "Default URI on which pilosa should listen.")
 	flags.StringVar(&srv.Config.Advertise, "advertise", srv.Config.Advertise, "Address to advertise externally.")
 	flags.IntVarP(&srv.Config.MaxWritesPerRequest, "max-writes-per-request", "", srv.Config.MaxWritesPerRequest, "Number of write commands per request.")
+	flags.StringVarP(&srv.Config.DefaultIndex, "default-index", "", srv.Config.DefaultIndex, "Index of query and import requests which omit the index from their path.")
 	flags.StringVar(&srv.Config.LogPath, "log-path", srv.Config.LogPath, "Log path")
+	flags.IntVarP(&srv.Config.LogTailLines, "log-tail-lines", "", srv.Config.LogTailLines, "Number of recent log lines served by /logs/tail. 0 disables the endpoint.")
+	flags.StringVarP(&srv.Config.DirPerm, "dir-perm", "", srv.Config.DirPerm, "Octal mode with which data directories are created. Defaults to 0777.")
+	flags.StringVarP(&srv.Config.FilePerm, "file-perm", "", srv.Config.FilePerm, "Octal mode with which data files and the log file are created. Defaults to 0666, and 0600 for the node ID and log files.")
 	flags.BoolVar(&srv.Config.Verbose, "verbose", srv.Config.Verbose, "Enable verbose logging")
 	flags.Uint64Var(&srv.Config.MaxMapCount, "max-map-count", srv.Config.MaxMapCount, "Limits the maximum number of active mmaps. Pilosa will fall back to reading files once this is exhausted. Set below your system's vm.max_map_count.")
 	flags.Uint64Var(&srv.Config.MaxFileCount, "max-file-count", srv.Config.MaxFileCount, "Soft limit on the maximum number of fragment files Pilosa keeps open simultaneously.")
+	flags.DurationVar((*time.Duration)(&srv.Config.Shutdown.WriteTimeout), "shutdown.write-timeout", time.Duration(srv.Config.Shutdown.WriteTimeout), "Maximum time to wait on shutdown for the writes in flight to complete.")
+	flags.DurationVar((*time.Duration)(&srv.Config.Shutdown.QueryTimeout), "shutdown.query-timeout", time.Duration(srv.Config.Shutdown.QueryTimeout), "Maximum time to wait on shutdown for the queries in flight to complete before cancelling them.")
+	flags.DurationVar((*time.Duration)(&srv.Config.Shutdown.FlushTimeout), "shutdown.flush-timeout", time.Duration(srv.Config.Shutdown.FlushTimeout), "Maximum time to wait on shutdown for the fragments to be flushed.")
+	flags.DurationVar((*time.Duration)(&srv.Config.Shutdown.ListenerTimeout), "shutdown.listener-timeout", time.Duration(srv.Config.Shutdown.ListenerTimeout), "Maximum time to wait on shutdown for open HTTP requests before closing the listeners.")
+	flags.Uint64Var(&srv.Config.MaxMemory, "max-memory", srv.Config.MaxMemory, "Soft limit, in bytes, on the memory used by the Go runtime, past whose high watermark read queries are rejected. 0 disables the limit.")
+	flags.BoolVar(&srv.Config.AutoCreate, "auto-create", srv.Config.AutoCreate, "Create the missing indexes and fields written to instead of failing the write. Keep disabled in production.")
+	flags.StringVar(&srv.Config.AutoCreateFieldType, "auto-create-field-type", srv.Config.AutoCreateFieldType, "Type of the fields auto-created by writes: set, mutex, bool or int.")
+	flags.Float64Var(&srv.Config.Memory.HighWatermark, "memory.high-watermark", srv.Config.Memory.HighWatermark, "Fraction of max-memory above which read queries are rejected.")
+	flags.Float64Var(&srv.Config.Memory.LowWatermark, "memory.low-watermark", srv.Config.Memory.LowWatermark, "Fraction of max-memory below which read queries are accepted again.")
+	flags.DurationVar((*time.Duration)(&srv.Config.Memory.CheckInterval), "memory.check-interval", time.Duration(srv.Config.Memory.CheckInterval), "Interval at which the memory use is compared with max-memory.")
+	flags.IntVar(&srv.Config.Limits.MaxIndexes, "limits.max-indexes", srv.Config.Limits.MaxIndexes, "Number of indexes above which creating an index is rejected. 0 disables the limit.")
+	flags.IntVar(&srv.Config.Limits.MaxFieldsPerIndex, "limits.max-fields-per-index", srv.Config.Limits.MaxFieldsPerIndex, "Number of fields in an index above which creating a field is rejected. 0 disables the limit.")
+	flags.Uint64Var(&srv.Config.Limits.MaxOpenFiles, "limits.max-open-files", srv.Config.Limits.MaxOpenFiles, "Number of open fragment files above which creating an index or field is rejected. 0 disables the limit.")
 
 	// TLS
 	SetTLSConfig(flags, &srv.Config.TLS.CertificatePath, &srv.Config.TLS.CertificateKeyPath, &srv.Config.TLS.CACertPath, &srv.Config.TLS.SkipVerify, &srv.Config.TLS.EnableClientVerification)
+	flags.IntVarP(&srv.Config.Admin.Port, "admin.port", "", srv.Config.Admin.Port, "Port serving the admin endpoints instead of the main port. 0 serves them on the main port.")
+	flags.StringVarP(&srv.Config.Admin.TLS.CertificatePath, "admin.tls.certificate", "", srv.Config.Admin.TLS.CertificatePath, "TLS certificate path of the admin port; defaults to the main TLS configuration")
+	flags.StringVarP(&srv.Config.Admin.TLS.CertificateKeyPath, "admin.tls.key", "", srv.Config.Admin.TLS.CertificateKeyPath, "TLS certificate key path of the admin port")
+	flags.StringVarP(&srv.Config.Admin.TLS.CACertPath, "admin.tls.ca-certificate", "", srv.Config.Admin.TLS.CACertPath, "TLS CA certificate path of the admin port")
+	flags.BoolVarP(&srv.Config.Admin.TLS.EnableClientVerification, "admin.tls.enable-client-verification", "", srv.Config.Admin.TLS.EnableClientVerification, "Enable TLS certificate client verification for connections to the admin port")
 
 	// Handler
 	flags.StringSliceVarP(&srv.Config.Handler.AllowedOrigins, "handler.allowed-origins", "", []string{}, "Comma separated list of allowed origin URIs (for CORS/WebUI).")
+	flags.Int64Var(&srv.Config.Handler.MaxBodyBytes, "handler.max-body-bytes", srv.Config.Handler.MaxBodyBytes, "Maximum size in bytes of a request body. 0 disables the limit.")
+	flags.IntVar(&srv.Config.Handler.ListenerCount, "handler.listener-count", srv.Config.Handler.ListenerCount, "Number of listeners accepting connections on the bind address. More than 1 requires SO_REUSEPORT.")
+	flags.IntVar(&srv.Config.Handler.ListenerBacklog, "handler.listener-backlog", srv.Config.Handler.ListenerBacklog, "Length of each listener's queue of pending connections. 0 uses the operating system default.")
 
 	// Cluster
 	flags.BoolVarP(&srv.Config.Cluster.Disabled, "cluster.disabled", "", srv.Config.Cluster.Disabled, "Disabled multi-node cluster communication (used for testing)")
 	flags.BoolVarP(&srv.Config.Cluster.Coordinator, "cluster.coordinator", "", srv.Config.Cluster.Coordinator, "Host that will act as cluster coordinator during startup and resizing.")
 	flags.IntVarP(&srv.Config.Cluster.ReplicaN, "cluster.replicas", "", 1, "Number of hosts each piece of data should be stored on.")
+	flags.StringVarP(&srv.Config.Node.Zone, "node.zone", "", srv.Config.Node.Zone, "Failure domain of the node. Replicas of a shard are placed in distinct zones when possible.")
 	flags.StringSliceVarP(&srv.Config.Cluster.Hosts, "cluster.hosts", "", []string{}, "Comma separated list of hosts in cluster. Only used for testing.")
 	flags.DurationVarP((*time.Duration)(&srv.Config.Cluster.LongQueryTime), "cluster.long-query-time", "", time.Minute, "Duration that will trigger log and stat messages for slow queries.")
+	flags.Float64Var(&srv.Config.Cluster.SelfHealThreshold, "cluster.self-heal-threshold", srv.Config.Cluster.SelfHealThreshold, "Fraction of owned shards which must be missing at startup to pull them from replicas. 0 disables the automatic self-heal.")
+	flags.DurationVar((*time.Duration)(&srv.Config.Cluster.ClockSkewThreshold), "cluster.clock-skew-threshold", (time.Duration)(srv.Config.Cluster.ClockSkewThreshold), "Difference between the clocks of two nodes above which a warning is logged. 0 disables the check.")
+	flags.StringVar(&srv.Config.Cluster.BootstrapTopology, "cluster.bootstrap-topology", srv.Config.Cluster.BootstrapTopology, "Path of a topology document, exported from /cluster/topology, to bootstrap a new node from.")
+	flags.IntVar(&srv.Config.Cluster.OwnerChangeRetries, "cluster.owner-change-retries", srv.Config.Cluster.OwnerChangeRetries, "Number of times internal shard requests rejected because shard ownership changed are retried against the new owners.")
+	flags.DurationVar((*time.Duration)(&srv.Config.Cluster.OwnerChangeBackoff), "cluster.owner-change-backoff", time.Duration(srv.Config.Cluster.OwnerChangeBackoff), "Delay before the first retry of an internal shard request after shard ownership changed, doubled on each subsequent retry.")
+	flags.DurationVar((*time.Duration)(&srv.Config.Cluster.OwnerChangeMaxBackoff), "cluster.owner-change-max-backoff", time.Duration(srv.Config.Cluster.OwnerChangeMaxBackoff), "Maximum delay between retries of an internal shard request after shard ownership changed.")
+	flags.IntVar(&srv.Config.Cluster.BreakerThreshold, "cluster.breaker-threshold", srv.Config.Cluster.BreakerThreshold, "Number of consecutive failed queries to a node after which queries to it are routed to replicas for the breaker cool-down. 0 disables the circuit breaker.")
+	flags.DurationVar((*time.Duration)(&srv.Config.Cluster.BreakerCooldown), "cluster.breaker-cooldown", time.Duration(srv.Config.Cluster.BreakerCooldown), "Duration for which queries to a node are short-circuited once its circuit breaker opens.")
+	flags.BoolVar(&srv.Config.Cluster.RequireQuorumOnStart, "cluster.require-quorum-on-start", srv.Config.Cluster.RequireQuorumOnStart, "Wait for a quorum of the cluster's nodes to be reachable before opening, and refuse writes until then.")
+	flags.DurationVar((*time.Duration)(&srv.Config.Cluster.QuorumTimeout), "cluster.quorum-timeout", time.Duration(srv.Config.Cluster.QuorumTimeout), "Maximum time to wait for a quorum at startup before opening without one. 0 waits indefinitely.")
 
 	// Translation
 	flags.StringVarP(&srv.Config.Translation.PrimaryURL, "translation.primary-url", "", srv.Config.Translation.PrimaryURL, "DEPRECATED: URL for primary translation node for replication.")
@@ -65,18 +101,56 @@ func BuildServerFlags(cmd *cobra.Command, srv *server.Command) {
 	flags.IntVarP(&srv.Config.Gossip.Nodes, "gossip.nodes", "", srv.Config.Gossip.Nodes, "Number of random nodes to send gossip messages to per GossipInterval.")
 	flags.DurationVarP((*time.Duration)(&srv.Config.Gossip.Interval), "gossip.interval", "", (time.Duration)(srv.Config.Gossip.Interval), "Interval between sending messages that need to be gossiped that haven't piggybacked on probing messages.")
 	flags.DurationVarP((*time.Duration)(&srv.Config.Gossip.ToTheDeadTime), "gossip.to-the-dead-time", "", (time.Duration)(srv.Config.Gossip.ToTheDeadTime), "Interval after which a node has died that we will still try to gossip to it.")
+	flags.DurationVarP((*time.Duration)(&srv.Config.Gossip.DeadRoutingDelay), "gossip.dead-routing-delay", "", (time.Duration)(srv.Config.Gossip.DeadRoutingDelay), "Time a node must stay dead before it is removed from query routing.")
+
+	// Index
+	flags.DurationVarP((*time.Duration)(&srv.Config.Index.FlushInterval), "index.flush-interval", "", (time.Duration)(srv.Config.Index.FlushInterval), "Interval at which fragments are flushed to disk.")
+	flags.BoolVarP(&srv.Config.Index.FsyncOnFlush, "index.fsync-on-flush", "", srv.Config.Index.FsyncOnFlush, "Fsync fragment files when they are flushed.")
+	flags.DurationVarP((*time.Duration)(&srv.Config.Index.TombstoneGracePeriod), "index.tombstone-grace-period", "", (time.Duration)(srv.Config.Index.TombstoneGracePeriod), "How long deleted indexes are kept and can be undeleted. Zero removes them immediately.")
+	flags.DurationVarP((*time.Duration)(&srv.Config.Index.TombstoneReapInterval), "index.tombstone-reap-interval", "", (time.Duration)(srv.Config.Index.TombstoneReapInterval), "Interval at which expired index tombstones are removed.")
+	flags.Float64VarP(&srv.Config.Index.CompactionThreshold, "index.compaction-threshold", "", srv.Config.Index.CompactionThreshold, "Fraction of its size which compaction must reclaim from a fragment for it to be compacted automatically. Zero disables automatic compaction.")
+	flags.DurationVarP((*time.Duration)(&srv.Config.Index.CompactionInterval), "index.compaction-interval", "", (time.Duration)(srv.Config.Index.CompactionInterval), "Interval at which fragments are checked for automatic compaction.")
+	flags.BoolVarP(&srv.Config.Index.SkipCorruptFragments, "index.skip-corrupt-fragments", "", srv.Config.Index.SkipCorruptFragments, "Quarantine fragments that fail to open instead of failing to start.")
 
 	// AntiEntropy
 	flags.DurationVarP((*time.Duration)(&srv.Config.AntiEntropy.Interval), "anti-entropy.interval", "", (time.Duration)(srv.Config.AntiEntropy.Interval), "Interval at which to run anti-entropy routine.")
+	flags.StringVarP(&srv.Config.AntiEntropy.Checksum, "anti-entropy.checksum", "", srv.Config.AntiEntropy.Checksum, "Algorithm of the block checksums compared by anti-entropy: standard or container.")
+	flags.IntVarP(&srv.Config.AntiEntropy.QueueSize, "anti-entropy.queue-size", "", srv.Config.AntiEntropy.QueueSize, "Maximum number of anti-entropy syncs running or waiting to run. 0 does not bound the queue.")
+	flags.StringVarP(&srv.Config.AntiEntropy.QueuePolicy, "anti-entropy.queue-policy", "", srv.Config.AntiEntropy.QueuePolicy, "Policy for anti-entropy syncs requested while the queue is full: block or drop.")
+	flags.DurationVarP((*time.Duration)(&srv.Config.AntiEntropy.QueueTimeout), "anti-entropy.queue-timeout", "", (time.Duration)(srv.Config.AntiEntropy.QueueTimeout), "Time the block policy waits for room in the anti-entropy queue before rescheduling a sync.")
+
+	// Query
+	flags.IntVarP(&srv.Config.Query.Cache.Size, "query.cache.size", "", srv.Config.Query.Cache.Size, "Maximum number of cached read-only query results. Zero disables the cache.")
+	flags.DurationVarP((*time.Duration)(&srv.Config.Query.Cache.TTL), "query.cache.ttl", "", (time.Duration)(srv.Config.Query.Cache.TTL), "Maximum age of a cached query result. Zero keeps results until invalidated.")
+	flags.BoolVarP(&srv.Config.Query.SafeMode.Enabled, "query.safe-mode.enabled", "", srv.Config.Query.SafeMode.Enabled, "Reject queries which scan an entire large field without a bound.")
+	flags.IntVarP(&srv.Config.Query.SafeMode.MaxShards, "query.safe-mode.max-shards", "", srv.Config.Query.SafeMode.MaxShards, "Number of shards above which safe mode rejects an unbounded scan. Zero disables the limit.")
+	flags.Uint64VarP(&srv.Config.Query.SafeMode.MaxRows, "query.safe-mode.max-rows", "", srv.Config.Query.SafeMode.MaxRows, "Estimated number of rows above which safe mode rejects an unbounded scan. Zero disables the limit.")
+	flags.StringVarP(&srv.Config.Warmup.Path, "warmup.path", "", srv.Config.Warmup.Path, "File to record a sample of read queries to, and replay them from on startup. Empty disables the warmup.")
+	flags.Float64VarP(&srv.Config.Warmup.SampleRate, "warmup.sample-rate", "", srv.Config.Warmup.SampleRate, "Fraction of read queries recorded for warmup.")
+	flags.IntVarP(&srv.Config.Warmup.MaxQueries, "warmup.max-queries", "", srv.Config.Warmup.MaxQueries, "Number of most recent recorded queries replayed on startup.")
+	flags.DurationVarP((*time.Duration)(&srv.Config.Warmup.Timeout), "warmup.timeout", "", (time.Duration)(srv.Config.Warmup.Timeout), "Maximum time spent replaying recorded queries on startup.")
+	flags.IntVarP(&srv.Config.Query.MaxDepth, "query.max-depth", "", srv.Config.Query.MaxDepth, "Maximum nesting depth of the calls of a query. Zero disables the limit.")
+	flags.IntVarP(&srv.Config.Query.MaxOps, "query.max-ops", "", srv.Config.Query.MaxOps, "Maximum number of calls in a query, nested calls included. Zero disables the limit.")
+	flags.Uint64VarP(&srv.Config.Query.MaxResultRows, "query.max-result-rows", "", srv.Config.Query.MaxResultRows, "Maximum number of rows or columns returned for each call of a query. Longer results are truncated. Zero disables the limit.")
+	flags.BoolVarP(&srv.Config.Query.PartialResults, "query.partial-results", "", srv.Config.Query.PartialResults, "Return the results of the available shards, and the list of unavailable shards, when no node can serve some shards of a query.")
+
+	// Replication
+	flags.StringVarP(&srv.Config.Replication.Upstream, "replication.upstream", "", srv.Config.Replication.Upstream, "URL of a node of the primary cluster to replicate from as a read-only standby.")
+	flags.DurationVarP((*time.Duration)(&srv.Config.Replication.Interval), "replication.interval", "", (time.Duration)(srv.Config.Replication.Interval), "Interval at which a standby pulls the schema and data of its upstream cluster.")
+
+	// Change feed
+	flags.IntVarP(&srv.Config.ChangeFeed.BufferSize, "change-feed.buffer-size", "", srv.Config.ChangeFeed.BufferSize, "Number of most recent bit changes held for streaming at /changes. Zero disables the change feed.")
 
 	// Metric
 	flags.StringVarP(&srv.Config.Metric.Service, "metric.service", "", srv.Config.Metric.Service, "Where to send stats: can be expvar (in-memory served at /debug/vars), statsd or none.")
 	flags.StringVarP(&srv.Config.Metric.Host, "metric.host", "", srv.Config.Metric.Host, "URI to send metrics when metric.service is statsd.")
 	flags.DurationVarP((*time.Duration)(&srv.Config.Metric.PollInterval), "metric.poll-interval", "", (time.Duration)(srv.Config.Metric.PollInterval), "Polling interval metrics.")
+	flags.DurationVarP((*time.Duration)(&srv.Config.Metric.UsageInterval), "metric.usage-interval", "", (time.Duration)(srv.Config.Metric.UsageInterval), "Interval between storage usage metrics; 0 disables them.")
 	flags.BoolVarP((&srv.Config.Metric.Diagnostics), "metric.diagnostics", "", srv.Config.Metric.Diagnostics, "Enabled diagnostics reporting.")
 
 	// Tracing
 	flags.StringVarP(&srv.Config.Tracing.AgentHostPort, "tracing.agent-host-port", "", srv.Config.Tracing.AgentHostPort, "Jaeger agent host:port.")
+	flags.StringVarP(&srv.Config.Tracing.CollectorEndpoint, "tracing.collector-endpoint", "", srv.Config.Tracing.CollectorEndpoint, "Jaeger collector URL to send spans to instead of the agent.")
 	flags.StringVarP(&srv.Config.Tracing.SamplerType, "tracing.sampler-type", "", srv.Config.Tracing.SamplerType, "Jaeger sampler type or 'off' to disable tracing completely.")
 	flags.Float64VarP(&srv.Config.Tracing.SamplerParam, "tracing.sampler-param", "", srv.Config.Tracing.SamplerParam, "Jaeger sampler parameter.")
 
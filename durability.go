@@ -0,0 +1,122 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"bytes"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// Durability levels of the writes to an index. They determine when the
+// operations written to the data file of a fragment reach the disk, relative
+// to the write returning.
+const (
+	// DurabilityAsync buffers the operations in memory, and writes them to
+	// the data file when the fragment is flushed, snapshotted or closed.
+	DurabilityAsync = "async"
+
+	// DurabilityFlush writes the operations to the data file before the
+	// write returns, but only fsyncs the file when the fragment is flushed.
+	// It is the default.
+	DurabilityFlush = "flush"
+
+	// DurabilityFsync fsyncs the data file before the write returns.
+	DurabilityFsync = "fsync"
+)
+
+// ErrInvalidDurability is returned for an unknown durability level.
+var ErrInvalidDurability = errors.New("invalid durability")
+
+// ValidDurability returns true if d is a valid durability level, or empty for
+// the default.
+func ValidDurability(d string) bool {
+	switch d {
+	case "", DurabilityAsync, DurabilityFlush, DurabilityFsync:
+		return true
+	}
+	return false
+}
+
+// syncWriter fsyncs its file after every write.
+type syncWriter struct {
+	file *os.File
+}
+
+func (w syncWriter) Write(p []byte) (int, error) {
+	n, err := w.file.Write(p)
+	if err != nil {
+		return n, err
+	}
+	return n, w.file.Sync()
+}
+
+// opBuffer holds the operations written under async durability in memory
+// until they are flushed. Unlike a bufio.Writer, it never writes part of
+// them on its own, since a crash would then leave a torn operation at the
+// end of the data file, which fails to open.
+type opBuffer struct {
+	file *os.File
+	buf  bytes.Buffer
+}
+
+func (b *opBuffer) Write(p []byte) (int, error) {
+	return b.buf.Write(p)
+}
+
+// flush writes the buffered operations to the data file at once. If the
+// write fails, the file is truncated back to its previous end and the
+// operations are kept, so that it still ends with a whole operation.
+func (b *opBuffer) flush() error {
+	if b.buf.Len() == 0 {
+		return nil
+	}
+	fi, err := b.file.Stat()
+	if err != nil {
+		return errors.Wrap(err, "statting")
+	}
+	if _, err := b.file.Write(b.buf.Bytes()); err != nil {
+		if terr := b.file.Truncate(fi.Size()); terr != nil {
+			return errors.Wrapf(terr, "truncating after failed write: %v", err)
+		}
+		return err
+	}
+	b.buf.Reset()
+	return nil
+}
+
+// opWriter returns the writer to which the operation log of the fragment is
+// written, according to the durability of its index. It is unprotected.
+func (f *fragment) opWriter() io.Writer {
+	switch f.durability {
+	case DurabilityAsync:
+		f.opBuf = &opBuffer{file: f.file}
+		return f.opBuf
+	case DurabilityFsync:
+		return syncWriter{file: f.file}
+	}
+	return f.file
+}
+
+// flushOps writes the operations buffered under async durability to the data
+// file. It is unprotected.
+func (f *fragment) flushOps() error {
+	if f.opBuf == nil {
+		return nil
+	}
+	return errors.Wrap(f.opBuf.flush(), "flushing operations")
+}
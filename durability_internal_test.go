@@ -0,0 +1,130 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// Ensure the operations of a fragment with async durability only reach its
+// data file when it is flushed or closed, and those of the other levels
+// before the write returns.
+func TestFragment_Durability(t *testing.T) {
+	fileSize := func(f *fragment) int64 {
+		t.Helper()
+		fi, err := os.Stat(f.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fi.Size()
+	}
+
+	for _, durability := range []string{DurabilityFlush, DurabilityFsync} {
+		t.Run(durability, func(t *testing.T) {
+			f := mustOpenFragment("i", "f", viewStandard, 0, "")
+			defer f.Clean(t)
+			f.durability = durability
+			if err := f.Reopen(); err != nil {
+				t.Fatal(err)
+			}
+
+			size := fileSize(f)
+			f.mustSetBits(1, 2)
+			if fileSize(f) <= size {
+				t.Fatal("expected operation to be written")
+			}
+		})
+	}
+
+	t.Run(DurabilityAsync, func(t *testing.T) {
+		f := mustOpenFragment("i", "f", viewStandard, 0, "")
+		defer f.Clean(t)
+		f.durability = DurabilityAsync
+		if err := f.Reopen(); err != nil {
+			t.Fatal(err)
+		}
+
+		size := fileSize(f)
+		f.mustSetBits(1, 2)
+		if fileSize(f) != size {
+			t.Fatal("expected operation to be buffered")
+		} else if _, err := f.flush(false); err != nil {
+			t.Fatal(err)
+		} else if fileSize(f) <= size {
+			t.Fatal("expected operation to be written on flush")
+		}
+
+		f.mustSetBits(1, 3)
+		if err := f.Reopen(); err != nil {
+			t.Fatal(err)
+		} else if cols := f.row(1).Columns(); !reflect.DeepEqual(cols, []uint64{2, 3}) {
+			t.Fatalf("unexpected columns: %v", cols)
+		}
+
+		// A crash while more operations are buffered than a page loses
+		// them, but leaves a data file which opens.
+		for i := uint64(4); i < 1000; i++ {
+			f.mustSetBits(1, i)
+		}
+		data, err := ioutil.ReadFile(f.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g := newFragment(f.path+".crash", "i", "f", viewStandard, 0, 0)
+		g.snapshotQueue = newSnapshotQueue(1, 1, nil)
+		if err := ioutil.WriteFile(g.path, data, 0600); err != nil {
+			t.Fatal(err)
+		} else if err := g.Open(); err != nil {
+			t.Fatalf("opening after crash: %v", err)
+		}
+		defer g.Clean(t)
+		if cols := g.row(1).Columns(); !reflect.DeepEqual(cols, []uint64{2, 3}) {
+			t.Fatalf("unexpected columns after crash: %v", cols)
+		}
+		if _, err := f.flush(false); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// Ensure the durability of an index is persisted and applied to its
+// fragments.
+func TestIndex_Durability(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	h.MustCreateIndexIfNotExists("i", IndexOptions{Durability: DurabilityFsync})
+	h.SetBit("i", "f", 1, 10)
+	if err := h.Holder.Close(); err != nil {
+		t.Fatal(err)
+	} else if err := h.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+
+	index := h.Index("i")
+	if d := index.Options().Durability; d != DurabilityFsync {
+		t.Fatalf("unexpected durability: %q", d)
+	} else if frag := index.Field("f").view(viewStandard).Fragment(0); frag.durability != DurabilityFsync {
+		t.Fatalf("unexpected fragment durability: %q", frag.durability)
+	} else if _, ok := frag.storage.OpWriter.(syncWriter); !ok {
+		t.Fatalf("unexpected op writer: %T", frag.storage.OpWriter)
+	}
+}
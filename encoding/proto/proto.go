@@ -177,6 +177,54 @@ func (Serializer) Unmarshal(buf []byte, m pilosa.Message) error {
 		}
 		decodeNodeStatus(msg, mt)
 		return nil
+	case *pilosa.SetIndexAliasMessage:
+		msg := &internal.SetIndexAliasMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling SetIndexAliasMessage")
+		}
+		decodeSetIndexAliasMessage(msg, mt)
+		return nil
+	case *pilosa.UpdateIndexMessage:
+		msg := &internal.UpdateIndexMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling UpdateIndexMessage")
+		}
+		decodeUpdateIndexMessage(msg, mt)
+		return nil
+	case *pilosa.UndeleteIndexMessage:
+		msg := &internal.UndeleteIndexMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling UndeleteIndexMessage")
+		}
+		decodeUndeleteIndexMessage(msg, mt)
+		return nil
+	case *pilosa.ApplySchemaMessage:
+		msg := &internal.ApplySchemaMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling ApplySchemaMessage")
+		}
+		decodeApplySchemaMessage(msg, mt)
+		return nil
+	case *pilosa.RenameMessage:
+		msg := &internal.RenameMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling RenameMessage")
+		}
+		decodeRenameMessage(msg, mt)
+		return nil
+	case *pilosa.SchemaVersionMessage:
+		msg := &internal.SchemaVersionMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling SchemaVersionMessage")
+		}
+		decodeSchemaVersionMessage(msg, mt)
+		return nil
 	case *pilosa.Node:
 		msg := &internal.Node{}
 		err := proto.Unmarshal(buf, msg)
@@ -209,6 +257,14 @@ func (Serializer) Unmarshal(buf []byte, m pilosa.Message) error {
 		}
 		decodeImportRequest(msg, mt)
 		return nil
+	case *pilosa.ImportBoolRequest:
+		msg := &internal.ImportBoolRequest{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling ImportBoolRequest")
+		}
+		decodeImportBoolRequest(msg, mt)
+		return nil
 	case *pilosa.ImportValueRequest:
 		msg := &internal.ImportValueRequest{}
 		err := proto.Unmarshal(buf, msg)
@@ -306,6 +362,18 @@ func encodeToProto(m pilosa.Message) proto.Message {
 		return encodeNodeEventMessage(mt)
 	case *pilosa.NodeStatus:
 		return encodeNodeStatus(mt)
+	case *pilosa.SetIndexAliasMessage:
+		return encodeSetIndexAliasMessage(mt)
+	case *pilosa.UpdateIndexMessage:
+		return encodeUpdateIndexMessage(mt)
+	case *pilosa.UndeleteIndexMessage:
+		return encodeUndeleteIndexMessage(mt)
+	case *pilosa.ApplySchemaMessage:
+		return encodeApplySchemaMessage(mt)
+	case *pilosa.RenameMessage:
+		return encodeRenameMessage(mt)
+	case *pilosa.SchemaVersionMessage:
+		return encodeSchemaVersionMessage(mt)
 	case *pilosa.Node:
 		return encodeNode(mt)
 	case *pilosa.QueryRequest:
@@ -316,6 +384,8 @@ func encodeToProto(m pilosa.Message) proto.Message {
 		return encodeImportRequest(mt)
 	case *pilosa.ImportValueRequest:
 		return encodeImportValueRequest(mt)
+	case *pilosa.ImportBoolRequest:
+		return encodeImportBoolRequest(mt)
 	case *pilosa.ImportRoaringRequest:
 		return encodeImportRoaringRequest(mt)
 	case *pilosa.ImportResponse:
@@ -350,25 +420,44 @@ func encodeBlockDataResponse(m *pilosa.BlockDataResponse) *internal.BlockDataRes
 
 func encodeImportResponse(m *pilosa.ImportResponse) *internal.ImportResponse {
 	return &internal.ImportResponse{
-		Err: m.Err,
+		Err:         m.Err,
+		Bits:        m.Bits,
+		ColumnAttrs: m.ColumnAttrs,
+		Conflicts:   m.Conflicts,
+		Set:         m.Set,
+		Cleared:     m.Cleared,
+		Batches:     m.Batches,
+		AutoCreated: m.AutoCreated,
 	}
 }
 
 func encodeImportRequest(m *pilosa.ImportRequest) *internal.ImportRequest {
 	return &internal.ImportRequest{
+		Index:       m.Index,
+		Field:       m.Field,
+		Shard:       m.Shard,
+		RowIDs:      m.RowIDs,
+		ColumnIDs:   m.ColumnIDs,
+		RowKeys:     m.RowKeys,
+		ColumnKeys:  m.ColumnKeys,
+		Timestamps:  m.Timestamps,
+		ColumnAttrs: encodeColumnAttrSets(m.ColumnAttrs),
+	}
+}
+
+func encodeImportValueRequest(m *pilosa.ImportValueRequest) *internal.ImportValueRequest {
+	return &internal.ImportValueRequest{
 		Index:      m.Index,
 		Field:      m.Field,
 		Shard:      m.Shard,
-		RowIDs:     m.RowIDs,
 		ColumnIDs:  m.ColumnIDs,
-		RowKeys:    m.RowKeys,
 		ColumnKeys: m.ColumnKeys,
-		Timestamps: m.Timestamps,
+		Values:     m.Values,
 	}
 }
 
-func encodeImportValueRequest(m *pilosa.ImportValueRequest) *internal.ImportValueRequest {
-	return &internal.ImportValueRequest{
+func encodeImportBoolRequest(m *pilosa.ImportBoolRequest) *internal.ImportBoolRequest {
+	return &internal.ImportBoolRequest{
 		Index:      m.Index,
 		Field:      m.Field,
 		Shard:      m.Shard,
@@ -402,13 +491,23 @@ func encodeQueryRequest(m *pilosa.QueryRequest) *internal.QueryRequest {
 		Remote:          m.Remote,
 		ExcludeRowAttrs: m.ExcludeRowAttrs,
 		ExcludeColumns:  m.ExcludeColumns,
+		Limit:           m.Limit,
+		Cursor:          m.Cursor,
+		MaxResultRows:   m.MaxResultRows,
 	}
 }
 
 func encodeQueryResponse(m *pilosa.QueryResponse) *internal.QueryResponse {
 	pb := &internal.QueryResponse{
-		Results:        make([]*internal.QueryResult, len(m.Results)),
-		ColumnAttrSets: encodeColumnAttrSets(m.ColumnAttrSets),
+		Results:           make([]*internal.QueryResult, len(m.Results)),
+		ColumnAttrSets:    encodeColumnAttrSets(m.ColumnAttrSets),
+		Thresholds:        m.Thresholds,
+		UnavailableShards: m.UnavailableShards,
+		Truncated:         m.Truncated,
+		AutoCreated:       m.AutoCreated,
+	}
+	if m.Page != nil {
+		pb.Page = &internal.QueryPage{More: m.Page.More, Cursor: m.Page.Cursor}
 	}
 
 	for i := range m.Results {
@@ -442,6 +541,10 @@ func encodeQueryResponse(m *pilosa.QueryResponse) *internal.QueryResponse {
 		case pilosa.Pair:
 			pb.Results[i].Type = queryResultTypePair
 			pb.Results[i].Pairs = []*internal.Pair{encodePair(result)}
+		case pilosa.DistinctCount:
+			pb.Results[i].Type = queryResultTypeDistinctCount
+			pb.Results[i].N = result.Count
+			pb.Results[i].Approximate = result.Approximate
 		case nil:
 			pb.Results[i].Type = queryResultTypeNil
 		default:
@@ -488,6 +591,26 @@ func encodeResizeSource(m *pilosa.ResizeSource) *internal.ResizeSource {
 func encodeSchema(m *pilosa.Schema) *internal.Schema {
 	return &internal.Schema{
 		Indexes: encodeIndexInfos(m.Indexes),
+		Aliases: encodeIndexAliases(m.Aliases),
+	}
+}
+
+func encodeIndexAliases(m map[string]string) []*internal.IndexAlias {
+	if len(m) == 0 {
+		return nil
+	}
+	aliases := make([]*internal.IndexAlias, 0, len(m))
+	for alias, index := range m {
+		aliases = append(aliases, &internal.IndexAlias{Alias: alias, Index: index})
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Alias < aliases[j].Alias })
+	return aliases
+}
+
+func encodeSetIndexAliasMessage(m *pilosa.SetIndexAliasMessage) *internal.SetIndexAliasMessage {
+	return &internal.SetIndexAliasMessage{
+		Alias: m.Alias,
+		Index: m.Index,
 	}
 }
 
@@ -503,6 +626,7 @@ func encodeIndexInfo(idx *pilosa.IndexInfo) *internal.Index {
 	return &internal.Index{
 		Name:   idx.Name,
 		Fields: encodeFieldInfos(idx.Fields),
+		Meta:   encodeIndexMeta(&idx.Options),
 	}
 }
 
@@ -541,6 +665,7 @@ func encodeFieldOptions(o *pilosa.FieldOptions) *internal.FieldOptions {
 		BitDepth:    uint64(o.BitDepth),
 		TimeQuantum: string(o.TimeQuantum),
 		Keys:        o.Keys,
+		TimeUnit:    o.TimeUnit,
 	}
 }
 
@@ -560,6 +685,10 @@ func encodeNode(n *pilosa.Node) *internal.Node {
 		URI:           encodeURI(n.URI),
 		IsCoordinator: n.IsCoordinator,
 		State:         n.State,
+		Zone:          n.Zone,
+
+		ProtocolVersion:    uint32(n.ProtocolVersion),
+		MinProtocolVersion: uint32(n.MinProtocolVersion),
 	}
 }
 
@@ -576,6 +705,7 @@ func encodeClusterStatus(m *pilosa.ClusterStatus) *internal.ClusterStatus {
 		State:     m.State,
 		ClusterID: m.ClusterID,
 		Nodes:     encodeNodes(m.Nodes),
+		Epoch:     m.Epoch,
 	}
 }
 
@@ -598,6 +728,44 @@ func encodeIndexMeta(m *pilosa.IndexOptions) *internal.IndexMeta {
 	return &internal.IndexMeta{
 		Keys:           m.Keys,
 		TrackExistence: m.TrackExistence,
+		TimeQuantum:    string(m.TimeQuantum),
+		Compression:    m.Compression,
+		PlacementName:  m.PlacementName,
+		Durability:     m.Durability,
+	}
+}
+
+func encodeUpdateIndexMessage(m *pilosa.UpdateIndexMessage) *internal.UpdateIndexMessage {
+	return &internal.UpdateIndexMessage{
+		Index: m.Index,
+		Meta:  encodeIndexMeta(m.Meta),
+	}
+}
+
+func encodeUndeleteIndexMessage(m *pilosa.UndeleteIndexMessage) *internal.UndeleteIndexMessage {
+	return &internal.UndeleteIndexMessage{
+		Index: m.Index,
+	}
+}
+
+func encodeApplySchemaMessage(m *pilosa.ApplySchemaMessage) *internal.ApplySchemaMessage {
+	return &internal.ApplySchemaMessage{
+		Schema: encodeSchema(m.Schema),
+	}
+}
+
+func encodeRenameMessage(m *pilosa.RenameMessage) *internal.RenameMessage {
+	return &internal.RenameMessage{
+		Index:   m.Index,
+		Field:   m.Field,
+		NewName: m.NewName,
+	}
+}
+
+func encodeSchemaVersionMessage(m *pilosa.SchemaVersionMessage) *internal.SchemaVersionMessage {
+	return &internal.SchemaVersionMessage{
+		Node:    m.Node,
+		Version: m.Version,
 	}
 }
 
@@ -682,9 +850,11 @@ func encodeNodeEventMessage(m *pilosa.NodeEvent) *internal.NodeEventMessage {
 
 func encodeNodeStatus(m *pilosa.NodeStatus) *internal.NodeStatus {
 	return &internal.NodeStatus{
-		Node:    encodeNode(m.Node),
-		Indexes: encodeIndexStatuses(m.Indexes),
-		Schema:  encodeSchema(m.Schema),
+		Node:           encodeNode(m.Node),
+		Indexes:        encodeIndexStatuses(m.Indexes),
+		Schema:         encodeSchema(m.Schema),
+		SchemaVersions: m.SchemaVersions,
+		Time:           m.Time,
 	}
 }
 
@@ -769,6 +939,17 @@ func decodeResizeSource(rs *internal.ResizeSource, m *pilosa.ResizeSource) {
 func decodeSchema(s *internal.Schema, m *pilosa.Schema) {
 	m.Indexes = make([]*pilosa.IndexInfo, len(s.Indexes))
 	decodeIndexes(s.Indexes, m.Indexes)
+	if len(s.Aliases) > 0 {
+		m.Aliases = make(map[string]string, len(s.Aliases))
+		for _, a := range s.Aliases {
+			m.Aliases[a.Alias] = a.Index
+		}
+	}
+}
+
+func decodeSetIndexAliasMessage(pb *internal.SetIndexAliasMessage, m *pilosa.SetIndexAliasMessage) {
+	m.Alias = pb.Alias
+	m.Index = pb.Index
 }
 
 func decodeIndexes(idxs []*internal.Index, m []*pilosa.IndexInfo) {
@@ -780,6 +961,9 @@ func decodeIndexes(idxs []*internal.Index, m []*pilosa.IndexInfo) {
 
 func decodeIndex(idx *internal.Index, m *pilosa.IndexInfo) {
 	m.Name = idx.Name
+	if idx.Meta != nil {
+		decodeIndexMeta(idx.Meta, &m.Options)
+	}
 	m.Fields = make([]*pilosa.FieldInfo, len(idx.Fields))
 	decodeFields(idx.Fields, m.Fields)
 }
@@ -811,6 +995,7 @@ func decodeFieldOptions(options *internal.FieldOptions, m *pilosa.FieldOptions)
 	m.BitDepth = uint(options.BitDepth)
 	m.TimeQuantum = pilosa.TimeQuantum(options.TimeQuantum)
 	m.Keys = options.Keys
+	m.TimeUnit = options.TimeUnit
 }
 
 func decodeNodes(a []*internal.Node, m []*pilosa.Node) {
@@ -825,6 +1010,7 @@ func decodeClusterStatus(cs *internal.ClusterStatus, m *pilosa.ClusterStatus) {
 	m.ClusterID = cs.ClusterID
 	m.Nodes = make([]*pilosa.Node, len(cs.Nodes))
 	decodeNodes(cs.Nodes, m.Nodes)
+	m.Epoch = cs.Epoch
 }
 
 func decodeNode(node *internal.Node, m *pilosa.Node) {
@@ -832,6 +1018,9 @@ func decodeNode(node *internal.Node, m *pilosa.Node) {
 	decodeURI(node.URI, &m.URI)
 	m.IsCoordinator = node.IsCoordinator
 	m.State = node.State
+	m.Zone = node.Zone
+	m.ProtocolVersion = int(node.ProtocolVersion)
+	m.MinProtocolVersion = int(node.MinProtocolVersion)
 }
 
 func decodeURI(i *internal.URI, m *pilosa.URI) {
@@ -855,6 +1044,38 @@ func decodeCreateIndexMessage(pb *internal.CreateIndexMessage, m *pilosa.CreateI
 func decodeIndexMeta(pb *internal.IndexMeta, m *pilosa.IndexOptions) {
 	m.Keys = pb.Keys
 	m.TrackExistence = pb.TrackExistence
+	m.TimeQuantum = pilosa.TimeQuantum(pb.TimeQuantum)
+	m.Compression = pb.Compression
+	m.PlacementName = pb.PlacementName
+	m.Durability = pb.Durability
+}
+
+func decodeUpdateIndexMessage(pb *internal.UpdateIndexMessage, m *pilosa.UpdateIndexMessage) {
+	m.Index = pb.Index
+	m.Meta = &pilosa.IndexOptions{}
+	decodeIndexMeta(pb.Meta, m.Meta)
+}
+
+func decodeUndeleteIndexMessage(pb *internal.UndeleteIndexMessage, m *pilosa.UndeleteIndexMessage) {
+	m.Index = pb.Index
+}
+
+func decodeApplySchemaMessage(pb *internal.ApplySchemaMessage, m *pilosa.ApplySchemaMessage) {
+	m.Schema = &pilosa.Schema{}
+	if pb.Schema != nil {
+		decodeSchema(pb.Schema, m.Schema)
+	}
+}
+
+func decodeRenameMessage(pb *internal.RenameMessage, m *pilosa.RenameMessage) {
+	m.Index = pb.Index
+	m.Field = pb.Field
+	m.NewName = pb.NewName
+}
+
+func decodeSchemaVersionMessage(pb *internal.SchemaVersionMessage, m *pilosa.SchemaVersionMessage) {
+	m.Node = pb.Node
+	m.Version = pb.Version
 }
 
 func decodeDeleteIndexMessage(pb *internal.DeleteIndexMessage, m *pilosa.DeleteIndexMessage) {
@@ -921,9 +1142,14 @@ func decodeNodeEventMessage(pb *internal.NodeEventMessage, m *pilosa.NodeEvent)
 
 func decodeNodeStatus(pb *internal.NodeStatus, m *pilosa.NodeStatus) {
 	m.Node = &pilosa.Node{}
+	if pb.Node != nil {
+		decodeNode(pb.Node, m.Node)
+	}
 	m.Indexes = decodeIndexStatuses(pb.Indexes)
 	m.Schema = &pilosa.Schema{}
 	decodeSchema(pb.Schema, m.Schema)
+	m.SchemaVersions = pb.SchemaVersions
+	m.Time = pb.Time
 }
 
 func decodeIndexStatuses(a []*internal.IndexStatus) []*pilosa.IndexStatus {
@@ -963,6 +1189,9 @@ func decodeQueryRequest(pb *internal.QueryRequest, m *pilosa.QueryRequest) {
 	m.Remote = pb.Remote
 	m.ExcludeRowAttrs = pb.ExcludeRowAttrs
 	m.ExcludeColumns = pb.ExcludeColumns
+	m.Limit = pb.Limit
+	m.Cursor = pb.Cursor
+	m.MaxResultRows = pb.MaxResultRows
 }
 
 func decodeImportRequest(pb *internal.ImportRequest, m *pilosa.ImportRequest) {
@@ -974,6 +1203,10 @@ func decodeImportRequest(pb *internal.ImportRequest, m *pilosa.ImportRequest) {
 	m.RowKeys = pb.RowKeys
 	m.ColumnKeys = pb.ColumnKeys
 	m.Timestamps = pb.Timestamps
+	if len(pb.ColumnAttrs) > 0 {
+		m.ColumnAttrs = make([]*pilosa.ColumnAttrSet, len(pb.ColumnAttrs))
+		decodeColumnAttrSets(pb.ColumnAttrs, m.ColumnAttrs)
+	}
 }
 
 func decodeImportValueRequest(pb *internal.ImportValueRequest, m *pilosa.ImportValueRequest) {
@@ -985,6 +1218,15 @@ func decodeImportValueRequest(pb *internal.ImportValueRequest, m *pilosa.ImportV
 	m.Values = pb.Values
 }
 
+func decodeImportBoolRequest(pb *internal.ImportBoolRequest, m *pilosa.ImportBoolRequest) {
+	m.Index = pb.Index
+	m.Field = pb.Field
+	m.Shard = pb.Shard
+	m.ColumnIDs = pb.ColumnIDs
+	m.ColumnKeys = pb.ColumnKeys
+	m.Values = pb.Values
+}
+
 func decodeImportRoaringRequest(pb *internal.ImportRoaringRequest, m *pilosa.ImportRoaringRequest) {
 	views := map[string][]byte{}
 	for _, view := range pb.Views {
@@ -996,6 +1238,13 @@ func decodeImportRoaringRequest(pb *internal.ImportRoaringRequest, m *pilosa.Imp
 
 func decodeImportResponse(pb *internal.ImportResponse, m *pilosa.ImportResponse) {
 	m.Err = pb.Err
+	m.Bits = pb.Bits
+	m.ColumnAttrs = pb.ColumnAttrs
+	m.Conflicts = pb.Conflicts
+	m.Set = pb.Set
+	m.Cleared = pb.Cleared
+	m.Batches = pb.Batches
+	m.AutoCreated = pb.AutoCreated
 }
 
 func decodeBlockDataRequest(pb *internal.BlockDataRequest, m *pilosa.BlockDataRequest) {
@@ -1021,6 +1270,13 @@ func decodeQueryResponse(pb *internal.QueryResponse, m *pilosa.QueryResponse) {
 	}
 	m.Results = make([]interface{}, len(pb.Results))
 	decodeQueryResults(pb.Results, m.Results)
+	if pb.Page != nil {
+		m.Page = &pilosa.QueryPage{More: pb.Page.More, Cursor: pb.Page.Cursor}
+	}
+	m.Thresholds = pb.Thresholds
+	m.UnavailableShards = pb.UnavailableShards
+	m.Truncated = pb.Truncated
+	m.AutoCreated = pb.AutoCreated
 }
 
 func decodeColumnAttrSets(pb []*internal.ColumnAttrSet, m []*pilosa.ColumnAttrSet) {
@@ -1064,6 +1320,7 @@ const (
 	queryResultTypeGroupCounts
 	queryResultTypeRowIdentifiers
 	queryResultTypePair
+	queryResultTypeDistinctCount
 )
 
 func decodeQueryResult(pb *internal.QueryResult) interface{} {
@@ -1088,6 +1345,8 @@ func decodeQueryResult(pb *internal.QueryResult) interface{} {
 		return decodeGroupCounts(pb.GroupCounts)
 	case queryResultTypePair:
 		return decodePair(pb.Pairs[0])
+	case queryResultTypeDistinctCount:
+		return pilosa.DistinctCount{Count: pb.N, Approximate: pb.Approximate}
 	}
 	panic(fmt.Sprintf("unknown type: %d", pb.Type))
 }
@@ -1188,8 +1447,9 @@ func decodePair(pb *internal.Pair) pilosa.Pair {
 
 func decodeValCount(pb *internal.ValCount) pilosa.ValCount {
 	return pilosa.ValCount{
-		Val:   pb.Val,
-		Count: pb.Count,
+		Val:       pb.Val,
+		Count:     pb.Count,
+		Timestamp: pb.Timestamp,
 	}
 }
 
@@ -1277,8 +1537,9 @@ func encodePair(p pilosa.Pair) *internal.Pair {
 
 func encodeValCount(vc pilosa.ValCount) *internal.ValCount {
 	return &internal.ValCount{
-		Val:   vc.Val,
-		Count: vc.Count,
+		Val:       vc.Val,
+		Count:     vc.Count,
+		Timestamp: vc.Timestamp,
 	}
 }
 
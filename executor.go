@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"sort"
 	"sync"
 	"time"
@@ -51,8 +52,23 @@ type executor struct {
 	// Client used for remote requests.
 	client InternalQueryClient
 
-	// Maximum number of Set() or Clear() commands per request.
-	MaxWritesPerRequest int
+	// Maximum number of times shards are remapped to their new owners when
+	// a request is rejected because the topology changed, and the delay
+	// before the first retry, which doubles on each subsequent one up to
+	// OwnerChangeMaxBackoff.
+	OwnerChangeRetries    int
+	OwnerChangeBackoff    time.Duration
+	OwnerChangeMaxBackoff time.Duration
+
+	// PartialResults skips the shards of read calls which no node can
+	// serve instead of failing the query. The skipped shards are listed in
+	// the response.
+	PartialResults bool
+
+	// MaxResultRows is the maximum number of rows or columns returned for
+	// each call of a query. Results beyond it are truncated, and the
+	// response flagged as such. Zero disables the limit.
+	MaxResultRows uint64
 
 	workersWG      sync.WaitGroup
 	workerPoolSize int
@@ -110,12 +126,10 @@ func (e *executor) Close() error {
 }
 
 // Execute executes a PQL query.
-func (e *executor) Execute(ctx context.Context, index string, q *pql.Query, shards []uint64, opt *execOptions) (QueryResponse, error) {
+func (e *executor) Execute(ctx context.Context, index string, q *pql.Query, shards []uint64, opt *execOptions) (resp QueryResponse, err error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.Execute")
 	defer span.Finish()
 
-	resp := QueryResponse{}
-
 	// Check for query cancellation.
 	if err := validateQueryContext(ctx); err != nil {
 		return resp, err
@@ -131,8 +145,20 @@ func (e *executor) Execute(ctx context.Context, index string, q *pql.Query, shar
 		return resp, newNotFoundError(ErrIndexNotFound, index)
 	}
 
+	// Hold off renames of the index, and of its fields, until the query
+	// completes. Queries canceled by a rename are told to retry.
+	ctx, end, err := idx.queries.begin(ctx)
+	if err != nil {
+		return resp, err
+	}
+	defer func() {
+		if end() && err != nil {
+			err = ErrIndexRenaming
+		}
+	}()
+
 	// Verify that the number of writes do not exceed the maximum.
-	if e.MaxWritesPerRequest > 0 && q.WriteCallN() > e.MaxWritesPerRequest {
+	if max := e.Cluster.writesPerRequestLimit(); max > 0 && q.WriteCallN() > max {
 		return resp, ErrTooManyWrites
 	}
 
@@ -141,6 +167,24 @@ func (e *executor) Execute(ctx context.Context, index string, q *pql.Query, shar
 		opt = &execOptions{}
 	}
 
+	// Remote calls are made to the nodes owning each shard according to
+	// the current topology; tag them with its epoch.
+	if !opt.Remote {
+		ctx = WithTopologyEpoch(ctx, e.Cluster.Epoch())
+	}
+
+	// Only the coordinating node skips unavailable shards; the shards sent
+	// to a remote node are all owned by it. Writes are never partial.
+	if e.PartialResults && !opt.Remote && opt.unavailable == nil && !isWriteQuery(q) {
+		opt.unavailable = &unavailableShards{}
+	}
+
+	// The coordinating node applies the lower of the server's and the
+	// request's limit on result rows, and passes it to remote nodes.
+	if !opt.Remote && e.MaxResultRows > 0 && (opt.MaxResultRows == 0 || opt.MaxResultRows > e.MaxResultRows) {
+		opt.MaxResultRows = e.MaxResultRows
+	}
+
 	// Translate query keys to ids, if necessary.
 	// No need to translate a remote call.
 	if !opt.Remote {
@@ -159,6 +203,13 @@ func (e *executor) Execute(ctx context.Context, index string, q *pql.Query, shar
 	}
 
 	resp.Results = results
+	resp.UnavailableShards = opt.unavailable.slice()
+	if !opt.Remote {
+		resp.Truncated = truncateResults(results, opt.MaxResultRows)
+		if resp.Thresholds, err = topNThresholds(q.Calls); err != nil {
+			return resp, err
+		}
+	}
 
 	// Fill column attributes if requested.
 	if opt.ColumnAttrs {
@@ -327,6 +378,9 @@ func (e *executor) executeCall(ctx context.Context, index string, c *pql.Call, s
 	case "Rows":
 		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
 		return e.executeRows(ctx, index, c, shards, opt)
+	case "CountDistinct":
+		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
+		return e.executeCountDistinct(ctx, index, c, shards, opt)
 	case "GroupBy":
 		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
 		return e.executeGroupBy(ctx, index, c, shards, opt)
@@ -471,6 +525,9 @@ func (e *executor) executeMin(ctx context.Context, index string, c *pql.Call, sh
 	if other.Count == 0 {
 		return ValCount{}, nil
 	}
+	if f := e.Holder.Field(index, callArgString(c, "field")); f != nil && f.Type() == FieldTypeTimestamp {
+		other.Timestamp = formatTimestamp(other.Val, f.TimeUnit())
+	}
 	return other, nil
 }
 
@@ -507,6 +564,9 @@ func (e *executor) executeMax(ctx context.Context, index string, c *pql.Call, sh
 	if other.Count == 0 {
 		return ValCount{}, nil
 	}
+	if f := e.Holder.Field(index, callArgString(c, "field")); f != nil && f.Type() == FieldTypeTimestamp {
+		other.Timestamp = formatTimestamp(other.Val, f.TimeUnit())
+	}
 	return other, nil
 }
 
@@ -595,6 +655,9 @@ func (e *executor) executeBitmapCall(ctx context.Context, index string, c *pql.C
 			other = NewRow()
 		}
 		other.Merge(v.(*Row))
+		if max := opt.resultLimit(); max > 0 {
+			other.truncate(max)
+		}
 		return other
 	}
 
@@ -869,6 +932,10 @@ func (e *executor) executeTopN(ctx context.Context, index string, c *pql.Call, s
 	if err != nil {
 		return nil, fmt.Errorf("executeTopN: %v", err)
 	}
+	threshold, err := topNThreshold(c)
+	if err != nil {
+		return nil, fmt.Errorf("executeTopN: %v", err)
+	}
 
 	// Execute original query.
 	pairs, err := e.executeTopNShards(ctx, index, c, shards, opt)
@@ -878,8 +945,10 @@ func (e *executor) executeTopN(ctx context.Context, index string, c *pql.Call, s
 
 	// If this call is against specific ids, or we didn't get results,
 	// or we are part of a larger distributed query then don't refetch.
-	if len(pairs) == 0 || len(idsArg) > 0 || opt.Remote {
+	if opt.Remote {
 		return pairs, nil
+	} else if len(pairs) == 0 || len(idsArg) > 0 {
+		return pairsWithMinCount(pairs, threshold), nil
 	}
 	// Only the original caller should refetch the full counts.
 	other := c.Clone()
@@ -888,10 +957,15 @@ func (e *executor) executeTopN(ctx context.Context, index string, c *pql.Call, s
 	sort.Sort(uint64Slice(ids))
 	other.Args["ids"] = ids
 
+	// The full counts include the shards in which a row is below the
+	// threshold; it is applied to the merged counts instead.
+	delete(other.Args, "threshold")
+
 	trimmedList, err := e.executeTopNShards(ctx, index, other, shards, opt)
 	if err != nil {
 		return nil, errors.Wrap(err, "retrieving full counts")
 	}
+	trimmedList = pairsWithMinCount(trimmedList, threshold)
 
 	if n != 0 && int(n) < len(trimmedList) {
 		trimmedList = trimmedList[0:n]
@@ -899,6 +973,53 @@ func (e *executor) executeTopN(ctx context.Context, index string, c *pql.Call, s
 	return trimmedList, nil
 }
 
+// topNThreshold returns the minimum count of the rows returned by a TopN call.
+func topNThreshold(c *pql.Call) (uint64, error) {
+	threshold, _, err := c.UintArg("threshold")
+	if err != nil {
+		return 0, err
+	} else if threshold == 0 {
+		threshold = defaultMinThreshold
+	}
+	return threshold, nil
+}
+
+// topNThresholds returns the threshold of each TopN call of calls, and zero
+// for the others. It returns nil unless one of the calls sets a threshold, so
+// that responses to other queries are unchanged.
+func topNThresholds(calls []*pql.Call) ([]uint64, error) {
+	var explicit bool
+	thresholds := make([]uint64, len(calls))
+	for i, c := range calls {
+		if c.Name != "TopN" {
+			continue
+		}
+		if _, ok := c.Args["threshold"]; ok {
+			explicit = true
+		}
+		threshold, err := topNThreshold(c)
+		if err != nil {
+			return nil, fmt.Errorf("TopN: %v", err)
+		}
+		thresholds[i] = threshold
+	}
+	if !explicit {
+		return nil, nil
+	}
+	return thresholds, nil
+}
+
+// pairsWithMinCount returns the pairs with a count of at least min.
+func pairsWithMinCount(pairs []Pair, min uint64) []Pair {
+	other := pairs[:0]
+	for _, p := range pairs {
+		if p.Count >= min {
+			other = append(other, p)
+		}
+	}
+	return other
+}
+
 func (e *executor) executeTopNShards(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) ([]Pair, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeTopNShards")
 	defer span.Finish()
@@ -935,7 +1056,7 @@ func (e *executor) executeTopNShard(ctx context.Context, index string, c *pql.Ca
 	n, _, err := c.UintArg("n")
 	if err != nil {
 		return nil, fmt.Errorf("executeTopNShard: %v", err)
-	} else if f := e.Holder.Field(index, fieldName); f != nil && f.Type() == FieldTypeInt {
+	} else if f := e.Holder.Field(index, fieldName); f != nil && (f.Type() == FieldTypeInt || f.Type() == FieldTypeTimestamp) {
 		return nil, fmt.Errorf("cannot compute TopN() on integer field: %q", fieldName)
 	}
 
@@ -944,7 +1065,7 @@ func (e *executor) executeTopNShard(ctx context.Context, index string, c *pql.Ca
 	if err != nil {
 		return nil, fmt.Errorf("executeTopNShard: %v", err)
 	}
-	minThreshold, _, err := c.UintArg("threshold")
+	minThreshold, err := topNThreshold(c)
 	if err != nil {
 		return nil, fmt.Errorf("executeTopNShard: %v", err)
 	}
@@ -978,10 +1099,6 @@ func (e *executor) executeTopNShard(ctx context.Context, index string, c *pql.Ca
 		return nil, fmt.Errorf("cannot compute TopN(), field has no cache: %q", fieldName)
 	}
 
-	if minThreshold == 0 {
-		minThreshold = defaultMinThreshold
-	}
-
 	if tanimotoThreshold > 100 {
 		return nil, errors.New("Tanimoto Threshold is from 1 to 100 only")
 	}
@@ -1105,7 +1222,11 @@ func (e *executor) executeGroupBy(ctx context.Context, index string, c *pql.Call
 			return nil, errors.Wrap(err, "getting column")
 		}
 		if hasLimit || hasCol { // we need to perform this query cluster-wide ahead of executeGroupByShard
-			childRows[i], err = e.executeRows(ctx, index, child, shards, opt)
+			// The rows grouped by are not a result, so they are not
+			// limited by the maximum number of result rows.
+			childOpt := *opt
+			childOpt.MaxResultRows = 0
+			childRows[i], err = e.executeRows(ctx, index, child, shards, &childOpt)
 			if err != nil {
 				return nil, errors.Wrap(err, "getting rows for ")
 			}
@@ -1291,7 +1412,7 @@ func (e *executor) executeRows(ctx context.Context, index string, c *pql.Call, s
 
 	// Execute calls in bulk on each remote node and merge.
 	mapFn := func(shard uint64) (interface{}, error) {
-		return e.executeRowsShard(ctx, index, fieldName, c, shard)
+		return e.executeRowsShard(ctx, index, fieldName, c, shard, opt.resultLimit())
 	}
 
 	// Determine limit so we can use it when reducing.
@@ -1301,6 +1422,9 @@ func (e *executor) executeRows(ctx context.Context, index string, c *pql.Call, s
 	} else if hasLimit {
 		limit = int(lim)
 	}
+	if max := opt.resultLimit(); max > 0 && uint64(limit) > max {
+		limit = int(max)
+	}
 
 	// Merge returned results at coordinating node.
 	reduceFn := func(prev, v interface{}) interface{} {
@@ -1316,7 +1440,9 @@ func (e *executor) executeRows(ctx context.Context, index string, c *pql.Call, s
 	return results, nil
 }
 
-func (e *executor) executeRowsShard(_ context.Context, index string, fieldName string, c *pql.Call, shard uint64) (RowIDs, error) {
+// executeRowsShard returns the rows of a shard. At most maxRows rows are
+// returned, in addition to the limit of the call, unless maxRows is zero.
+func (e *executor) executeRowsShard(_ context.Context, index string, fieldName string, c *pql.Call, shard uint64, maxRows uint64) (RowIDs, error) {
 	// Fetch index.
 	idx := e.Holder.Index(index)
 	if idx == nil {
@@ -1418,9 +1544,14 @@ func (e *executor) executeRowsShard(_ context.Context, index string, fieldName s
 	}
 
 	limit := int(^uint(0) >> 1)
-	if lim, hasLimit, err := c.UintArg("limit"); err != nil {
+	lim, hasLimit, err := c.UintArg("limit")
+	if err != nil {
 		return nil, errors.Wrap(err, "getting limit")
-	} else if hasLimit {
+	}
+	if maxRows > 0 && (!hasLimit || lim > maxRows) {
+		lim, hasLimit = maxRows, true
+	}
+	if hasLimit {
 		filters = append(filters, filterWithLimit(lim))
 		limit = int(lim)
 	}
@@ -1438,6 +1569,108 @@ func (e *executor) executeRowsShard(_ context.Context, index string, fieldName s
 	return rowIDs, nil
 }
 
+// executeCountDistinct executes a CountDistinct() call, counting the rows of a
+// field which have a bit set in at least one column of the optional filter.
+// Remote nodes return the IDs of the rows found in their shards, which are
+// merged and counted by the coordinating node.
+func (e *executor) executeCountDistinct(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (interface{}, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeCountDistinct")
+	defer span.Finish()
+
+	fieldName := callArgString(c, "field")
+	if fieldName == "" {
+		return nil, errors.New("CountDistinct(): field required")
+	} else if len(c.Children) > 1 {
+		return nil, errors.New("CountDistinct() only accepts a single bitmap input")
+	}
+	f := e.Holder.Field(index, fieldName)
+	if f == nil {
+		return nil, newNotFoundError(ErrFieldNotFound, fieldName)
+	} else if f.Type() == FieldTypeInt || f.Type() == FieldTypeTimestamp {
+		return nil, errors.New("CountDistinct() is not supported on int fields")
+	}
+
+	// Sampling is applied by the coordinating node only; remote nodes are
+	// sent the sampled shards.
+	var approximate bool
+	total := len(shards)
+	if !opt.Remote {
+		if sample, ok, err := callArgFloat(c, "sample"); err != nil {
+			return nil, errors.Wrap(err, "getting sample")
+		} else if ok {
+			if sample <= 0 || sample > 1 {
+				return nil, errors.New("CountDistinct() sample must be greater than 0 and at most 1")
+			}
+			sampled := sampleShards(shards, sample)
+			approximate = len(sampled) < len(shards)
+			shards = sampled
+		}
+	}
+
+	// Execute calls in bulk on each remote node and merge.
+	mapFn := func(shard uint64) (interface{}, error) {
+		return e.executeCountDistinctShard(ctx, index, f, c, shard)
+	}
+
+	// Merge returned results at coordinating node.
+	reduceFn := func(prev, v interface{}) interface{} {
+		other, _ := prev.(RowIDs)
+		return other.merge(v.(RowIDs), int(^uint(0)>>1))
+	}
+
+	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return nil, err
+	}
+	rowIDs, _ := result.(RowIDs)
+	if opt.Remote {
+		return rowIDs, nil
+	}
+	count := uint64(len(rowIDs))
+	if approximate {
+		// Scale the rows found in the sample up to all the shards.
+		count = uint64(math.Round(float64(count) * float64(total) / float64(len(shards))))
+	}
+	return DistinctCount{Count: count, Approximate: approximate}, nil
+}
+
+// executeCountDistinctShard returns the IDs of the rows of f in shard which
+// intersect the filter of c, if any.
+func (e *executor) executeCountDistinctShard(ctx context.Context, index string, f *Field, c *pql.Call, shard uint64) (RowIDs, error) {
+	frag := e.Holder.fragment(index, f.Name(), viewStandard, shard)
+	if frag == nil {
+		return RowIDs{}, nil
+	}
+
+	var filters []rowFilter
+	if len(c.Children) == 1 {
+		row, err := e.executeBitmapCallShard(ctx, index, c.Children[0], shard)
+		if err != nil {
+			return nil, err
+		}
+		seg := row.segment(shard)
+		if seg == nil || seg.data.Count() == 0 {
+			return RowIDs{}, nil
+		}
+		filters = append(filters, filterIntersecting(seg.data, shard))
+	}
+	return frag.rows(0, filters...), nil
+}
+
+// sampleShards returns an evenly spaced subset of shards holding roughly the
+// given fraction of them, and at least one.
+func sampleShards(shards []uint64, fraction float64) []uint64 {
+	n := int(math.Ceil(float64(len(shards)) * fraction))
+	if n >= len(shards) {
+		return shards
+	}
+	sampled := make([]uint64, 0, n)
+	for i := 0; i < n; i++ {
+		sampled = append(sampled, shards[i*len(shards)/n])
+	}
+	return sampled
+}
+
 func (e *executor) executeRowShard(ctx context.Context, index string, c *pql.Call, shard uint64) (*Row, error) {
 	span, _ := tracing.StartSpanFromContext(ctx, "Executor.executeRowShard")
 	defer span.Finish()
@@ -1557,6 +1790,14 @@ func (e *executor) executeRowBSIGroupShard(ctx context.Context, index string, c
 		return nil, newNotFoundError(ErrFieldNotFound, fieldName)
 	}
 
+	// Timestamp bounds may be given as times rather than integers.
+	if f.Type() == FieldTypeTimestamp {
+		var err error
+		if cond, err = f.timestampCondition(cond); err != nil {
+			return nil, errors.Wrap(err, "Row()")
+		}
+	}
+
 	// EQ null           (not implemented: flip frag.NotNull with max ColumnID)
 	// NEQ null          frag.NotNull()
 	// BETWEEN a,b(in)   BETWEEN/frag.RowBetween()
@@ -1883,7 +2124,7 @@ func (e *executor) executeClearBitField(ctx context.Context, index string, c *pq
 		}
 
 		// Forward call to remote node otherwise.
-		res, err := e.remoteExec(ctx, node, index, &pql.Query{Calls: []*pql.Call{c}}, nil)
+		res, err := e.remoteExec(ctx, node, index, &pql.Query{Calls: []*pql.Call{c}}, nil, nil)
 		if err != nil {
 			return false, err
 		}
@@ -2104,6 +2345,19 @@ func (e *executor) executeSet(ctx context.Context, index string, c *pql.Call, op
 		}
 	}
 
+	// Timestamp field, set from either an integer or a time.
+	if f.Type() == FieldTypeTimestamp {
+		v, ok := c.Args[fieldName]
+		if !ok {
+			return false, fmt.Errorf("Set() row argument '%v' required", rowLabel)
+		}
+		rowVal, err := f.timestampValue(v)
+		if err != nil {
+			return false, fmt.Errorf("reading Set() row: %v", err)
+		}
+		return e.executeSetValueField(ctx, index, c, f, colID, rowVal, opt)
+	}
+
 	// Int field.
 	if f.Type() == FieldTypeInt {
 		// Read row value.
@@ -2164,7 +2418,7 @@ func (e *executor) executeSetBitField(ctx context.Context, index string, c *pql.
 		}
 
 		// Forward call to remote node otherwise.
-		res, err := e.remoteExec(ctx, node, index, &pql.Query{Calls: []*pql.Call{c}}, nil)
+		res, err := e.remoteExec(ctx, node, index, &pql.Query{Calls: []*pql.Call{c}}, nil, nil)
 		if err != nil {
 			return false, err
 		}
@@ -2199,7 +2453,7 @@ func (e *executor) executeSetValueField(ctx context.Context, index string, c *pq
 		}
 
 		// Forward call to remote node otherwise.
-		res, err := e.remoteExec(ctx, node, index, &pql.Query{Calls: []*pql.Call{c}}, nil)
+		res, err := e.remoteExec(ctx, node, index, &pql.Query{Calls: []*pql.Call{c}}, nil, nil)
 		if err != nil {
 			return false, err
 		}
@@ -2253,7 +2507,7 @@ func (e *executor) executeSetRowAttrs(ctx context.Context, index string, c *pql.
 	resp := make(chan error, len(nodes))
 	for _, node := range nodes {
 		go func(node *Node) {
-			_, err := e.remoteExec(ctx, node, index, &pql.Query{Calls: []*pql.Call{c}}, nil)
+			_, err := e.remoteExec(ctx, node, index, &pql.Query{Calls: []*pql.Call{c}}, nil, nil)
 			resp <- err
 		}(node)
 	}
@@ -2348,7 +2602,7 @@ func (e *executor) executeBulkSetRowAttrs(ctx context.Context, index string, cal
 	resp := make(chan error, len(nodes))
 	for _, node := range nodes {
 		go func(node *Node) {
-			_, err := e.remoteExec(ctx, node, index, &pql.Query{Calls: calls}, nil)
+			_, err := e.remoteExec(ctx, node, index, &pql.Query{Calls: calls}, nil, nil)
 			resp <- err
 		}(node)
 	}
@@ -2400,7 +2654,7 @@ func (e *executor) executeSetColumnAttrs(ctx context.Context, index string, c *p
 	resp := make(chan error, len(nodes))
 	for _, node := range nodes {
 		go func(node *Node) {
-			_, err := e.remoteExec(ctx, node, index, &pql.Query{Calls: []*pql.Call{c}}, nil)
+			_, err := e.remoteExec(ctx, node, index, &pql.Query{Calls: []*pql.Call{c}}, nil, nil)
 			resp <- err
 		}(node)
 	}
@@ -2416,9 +2670,11 @@ func (e *executor) executeSetColumnAttrs(ctx context.Context, index string, c *p
 }
 
 // remoteExec executes a PQL query remotely for a set of shards on a node.
-func (e *executor) remoteExec(ctx context.Context, node *Node, index string, q *pql.Query, shards []uint64) (results []interface{}, err error) { // nolint: interfacer
-	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeExec")
+// The limit on result rows of opt, if not nil, is passed to the node.
+func (e *executor) remoteExec(ctx context.Context, node *Node, index string, q *pql.Query, shards []uint64, opt *execOptions) (results []interface{}, err error) { // nolint: interfacer
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.remoteExec")
 	defer span.Finish()
+	span.LogKV("node", node.ID, "shards", len(shards))
 
 	// Encode request object.
 	pbreq := &QueryRequest{
@@ -2426,6 +2682,9 @@ func (e *executor) remoteExec(ctx context.Context, node *Node, index string, q *
 		Shards: shards,
 		Remote: true,
 	}
+	if opt != nil {
+		pbreq.MaxResultRows = opt.MaxResultRows
+	}
 
 	pb, err := e.client.QueryNode(ctx, &node.URI, index, pbreq)
 	if err != nil {
@@ -2453,10 +2712,30 @@ loop:
 	return m, nil
 }
 
+// ownerChangeBackoff returns the delay before retry n, counted from zero, of
+// shards rejected because the topology changed: OwnerChangeBackoff doubled n
+// times, clamped to OwnerChangeMaxBackoff.
+func (e *executor) ownerChangeBackoff(n int) time.Duration {
+	d := e.OwnerChangeBackoff
+	for i := 0; i < n && d < e.OwnerChangeMaxBackoff; i++ {
+		d *= 2
+	}
+	if d > e.OwnerChangeMaxBackoff {
+		d = e.OwnerChangeMaxBackoff
+	}
+	return d
+}
+
 // mapReduce maps and reduces data across the cluster.
 //
 // If a mapping of shards to a node fails then the shards are resplit across
 // secondary nodes and retried. This continues to occur until all nodes are exhausted.
+// If the node rejects the shards because their ownership changed then they are
+// remapped against the current topology, up to OwnerChangeRetries times.
+//
+// Under the partial results policy, shards which no remaining node can serve
+// are skipped and recorded in opt instead of failing the call. The call still
+// fails if every shard is skipped.
 func (e *executor) mapReduce(ctx context.Context, index string, shards []uint64, c *pql.Call, opt *execOptions, mapFn mapFunc, reduceFn reduceFunc) (interface{}, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.mapReduce")
 	defer span.Finish()
@@ -2479,13 +2758,16 @@ func (e *executor) mapReduce(ctx context.Context, index string, shards []uint64,
 	}
 
 	// Start mapping across all primary owners.
-	if err := e.mapper(ctx, ch, nodes, index, shards, c, opt, mapFn, reduceFn); err != nil {
+	shardN, err := e.mapperPartial(ctx, ch, nodes, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
 		return nil, errors.Wrap(err, "starting mapper")
+	} else if shardN > 0 && shardN >= len(shards) {
+		return nil, errShardUnavailable
 	}
 
 	// Iterate over all map responses and reduce.
 	var result interface{}
-	var shardN int
+	var reduced, retries int
 	for {
 		select {
 		case <-ctx.Done():
@@ -2494,31 +2776,79 @@ func (e *executor) mapReduce(ctx context.Context, index string, shards []uint64,
 			// On error retry against remaining nodes. If an error returns then
 			// the context will cancel and cause all open goroutines to return.
 
-			if resp.err != nil {
+			if errors.Cause(resp.err) == ErrTopologyChanged {
+				// Retries are shared by all the shards of the call so that
+				// an unstable topology surfaces an error.
+				if retries >= e.OwnerChangeRetries {
+					return nil, resp.err
+				}
+				select {
+				case <-ctx.Done():
+					return nil, errors.Wrap(ctx.Err(), "context done")
+				case <-time.After(e.ownerChangeBackoff(retries)):
+				}
+				retries++
+
+				// Remap the shards with the topology known by now.
+				ctx = WithTopologyEpoch(ctx, e.Cluster.Epoch())
+				nodes = Nodes(e.Cluster.Nodes()).Clone()
+			} else if resp.err != nil {
 				// Filter out unavailable nodes.
 				nodes = Nodes(nodes).Filter(resp.node)
+			}
 
-				// Begin mapper against secondary nodes.
-				if err := e.mapper(ctx, ch, nodes, index, resp.shards, c, opt, mapFn, reduceFn); errors.Cause(err) == errShardUnavailable {
+			if resp.err != nil {
+				// Begin mapper against the remaining nodes.
+				skipped, err := e.mapperPartial(ctx, ch, nodes, index, resp.shards, c, opt, mapFn, reduceFn)
+				if errors.Cause(err) == errShardUnavailable {
 					return nil, resp.err
 				} else if err != nil {
 					return nil, errors.Wrap(err, "calling mapper")
 				}
-				continue
+				shardN += skipped
+			} else {
+				// Reduce value.
+				result = reduceFn(result, resp.result)
+				shardN += len(resp.shards)
+				reduced++
 			}
 
-			// Reduce value.
-			result = reduceFn(result, resp.result)
-
 			// If all shards have been processed then return.
-			shardN += len(resp.shards)
 			if shardN >= len(shards) {
+				if reduced == 0 {
+					return nil, resp.err
+				}
 				return result, nil
 			}
 		}
 	}
 }
 
+// mapperPartial maps shards like mapper. Under the partial results policy, the
+// shards which no node of nodes can serve are recorded as unavailable and the
+// others are mapped; it returns the number of shards skipped.
+func (e *executor) mapperPartial(ctx context.Context, ch chan mapResponse, nodes []*Node, index string, shards []uint64, c *pql.Call, opt *execOptions, mapFn mapFunc, reduceFn reduceFunc) (int, error) {
+	err := e.mapper(ctx, ch, nodes, index, shards, c, opt, mapFn, reduceFn)
+	if opt.unavailable == nil || errors.Cause(err) != errShardUnavailable {
+		return 0, err
+	}
+
+	available := make([]uint64, 0, len(shards))
+	for _, shard := range shards {
+		if _, err := e.shardsByNode(nodes, index, []uint64{shard}); err != nil {
+			opt.unavailable.add(shard)
+		} else {
+			available = append(available, shard)
+		}
+	}
+	if len(available) > 0 {
+		if err := e.mapper(ctx, ch, nodes, index, available, c, opt, mapFn, reduceFn); err != nil {
+			return 0, err
+		}
+	}
+	return len(shards) - len(available), nil
+}
+
 func (e *executor) mapper(ctx context.Context, ch chan mapResponse, nodes []*Node, index string, shards []uint64, c *pql.Call, opt *execOptions, mapFn mapFunc, reduceFn reduceFunc) error {
 	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.mapper")
 	defer span.Finish()
@@ -2538,7 +2868,7 @@ func (e *executor) mapper(ctx context.Context, ch chan mapResponse, nodes []*Nod
 			if n.ID == e.Node.ID {
 				resp.result, resp.err = e.mapperLocal(ctx, nodeShards, mapFn, reduceFn)
 			} else if !opt.Remote {
-				results, err := e.remoteExec(ctx, n, index, &pql.Query{Calls: []*pql.Call{c}}, nodeShards)
+				results, err := e.remoteExec(ctx, n, index, &pql.Query{Calls: []*pql.Call{c}}, nodeShards, opt)
 				if len(results) > 0 {
 					resp.result = results[0]
 				}
@@ -2565,7 +2895,14 @@ type job struct {
 
 func worker(work chan job) {
 	for j := range work {
+		// Skip the shards of queries which were cancelled while queued.
+		if j.ctx.Err() != nil {
+			continue
+		}
+		span, _ := tracing.StartSpanFromContext(j.ctx, "Executor.mapShard")
+		span.LogKV("shard", j.shard)
 		result, err := j.mapFn(j.shard)
+		span.Finish()
 
 		select {
 		case <-j.ctx.Done():
@@ -2701,10 +3038,10 @@ func (e *executor) translateCall(index string, idx *Index, c *pql.Call) error {
 				}
 				c.Args[rowKey] = id
 			}
-		} else {
-			if isString(c.Args[rowKey]) {
-				return errors.New("string 'row' value not allowed unless field 'keys' option enabled")
-			}
+		} else if isString(c.Args[rowKey]) && field.Type() != FieldTypeTimestamp {
+			// Timestamp values may be times, which are parsed by the
+			// calls reading them.
+			return errors.New("string 'row' value not allowed unless field 'keys' option enabled")
 		}
 	}
 
@@ -2962,6 +3299,80 @@ type execOptions struct {
 	ExcludeRowAttrs bool
 	ExcludeColumns  bool
 	ColumnAttrs     bool
+
+	// MaxResultRows is the maximum number of rows or columns returned for
+	// each call. Zero disables the limit.
+	MaxResultRows uint64
+
+	// unavailable collects the shards skipped under the partial results
+	// policy. It is nil when the policy is strict.
+	unavailable *unavailableShards
+}
+
+// resultLimit returns the number of rows or columns of a result kept while it
+// is assembled: one more than the maximum, so that the coordinating node can
+// tell the result was truncated. It is zero if results are not limited.
+func (opt *execOptions) resultLimit() uint64 {
+	if opt.MaxResultRows == 0 {
+		return 0
+	}
+	return opt.MaxResultRows + 1
+}
+
+// truncateResults cuts down the columns of each row result, and the rows of
+// each Rows() result, to max. It returns true if any result was truncated.
+func truncateResults(results []interface{}, max uint64) (truncated bool) {
+	if max == 0 {
+		return false
+	}
+	for i, result := range results {
+		switch result := result.(type) {
+		case *Row:
+			if result.truncate(max) {
+				truncated = true
+			}
+		case RowIDs:
+			if uint64(len(result)) > max {
+				results[i] = result[:max]
+				truncated = true
+			}
+		}
+	}
+	return truncated
+}
+
+// unavailableShards is the set of shards skipped by a query because no node
+// could serve them.
+type unavailableShards struct {
+	mu     sync.Mutex
+	shards map[uint64]struct{}
+}
+
+func (u *unavailableShards) add(shard uint64) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.shards == nil {
+		u.shards = make(map[uint64]struct{})
+	}
+	u.shards[shard] = struct{}{}
+}
+
+// slice returns the shards in order, or nil if there are none.
+func (u *unavailableShards) slice() []uint64 {
+	if u == nil {
+		return nil
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if len(u.shards) == 0 {
+		return nil
+	}
+	shards := make([]uint64, 0, len(u.shards))
+	for shard := range u.shards {
+		shards = append(shards, shard)
+	}
+	sort.Slice(shards, func(i, j int) bool { return shards[i] < shards[j] })
+	return shards
 }
 
 // hasOnlySetRowAttrs returns true if calls only contains SetRowAttrs() calls.
@@ -2996,10 +3407,23 @@ func needsShards(calls []*pql.Call) bool {
 	return false
 }
 
+// DistinctCount is the result of a CountDistinct() call. Approximate is set if
+// the count was computed from a sample of the shards, in which case Count is
+// the number of distinct rows found in the sampled shards scaled by the
+// ratio of all the shards to the sampled ones.
+type DistinctCount struct {
+	Count       uint64 `json:"count"`
+	Approximate bool   `json:"approximate"`
+}
+
 // ValCount represents a grouping of sum & count for Sum() and Average() calls.
 type ValCount struct {
 	Val   int64 `json:"value"`
 	Count int64 `json:"count"`
+
+	// Timestamp is Val formatted as an RFC3339 time, for the minimum and
+	// maximum of timestamp fields.
+	Timestamp string `json:"timestamp,omitempty"`
 }
 
 func (vc *ValCount) add(other ValCount) ValCount {
@@ -3043,6 +3467,24 @@ func callArgBool(call *pql.Call, key string) (bool, error) {
 	return b, nil
 }
 
+// callArgFloat returns the numeric value of the key argument of call, if set.
+func callArgFloat(call *pql.Call, key string) (float64, bool, error) {
+	value, ok := call.Args[key]
+	if !ok {
+		return 0, false, nil
+	}
+	switch v := value.(type) {
+	case float64:
+		return v, true, nil
+	case int64:
+		return float64(v), true, nil
+	case uint64:
+		return float64(v), true, nil
+	default:
+		return 0, true, fmt.Errorf("invalid numeric argument type: %T", value)
+	}
+}
+
 func callArgString(call *pql.Call, key string) string {
 	value, ok := call.Args[key]
 	if !ok {
@@ -15,13 +15,17 @@
 package pilosa
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/pilosa/pilosa/v2/pql"
+	"github.com/pkg/errors"
 )
 
 func TestExecutor_TranslateGroupByCall(t *testing.T) {
@@ -222,3 +226,165 @@ func TestFieldRowMarshalJSON(t *testing.T) {
 		t.Fatalf("unexpected json: %s", b)
 	}
 }
+
+// ownerChangeQueryClient rejects the first n queries as if the topology had
+// changed, then returns the number of shards queried.
+type ownerChangeQueryClient struct {
+	mu      sync.Mutex
+	cluster *cluster
+	n       int
+	calls   int
+	epochs  []uint64
+}
+
+func (c *ownerChangeQueryClient) QueryNode(ctx context.Context, uri *URI, index string, req *QueryRequest) (*QueryResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	epoch, _ := TopologyEpochFromContext(ctx)
+	c.epochs = append(c.epochs, epoch)
+	if c.calls <= c.n {
+		c.cluster.mu.Lock()
+		c.cluster.Topology.epoch++
+		c.cluster.mu.Unlock()
+		return nil, errors.Wrap(ErrTopologyChanged, "server error 409 Conflict")
+	}
+	return &QueryResponse{Results: []interface{}{uint64(len(req.Shards))}}, nil
+}
+
+// Ensure that shards rejected because their ownership changed are retried with
+// the current topology a bounded number of times.
+func TestExecutor_MapReduceOwnerChange(t *testing.T) {
+	// Only query shards owned by the remote node.
+	var shards []uint64
+	owners := NewTestCluster(2)
+	for shard := uint64(0); len(shards) < 8; shard++ {
+		if owners.ShardNodes("i", shard)[0].ID == "node1" {
+			shards = append(shards, shard)
+		}
+	}
+	mapFn := func(shard uint64) (interface{}, error) { return uint64(1), nil }
+	reduceFn := func(prev, v interface{}) interface{} {
+		n, _ := prev.(uint64)
+		return n + v.(uint64)
+	}
+
+	run := func(fail, retries int) (*ownerChangeQueryClient, interface{}, error) {
+		cluster := NewTestCluster(2)
+		client := &ownerChangeQueryClient{cluster: cluster, n: fail}
+		e := newExecutor(optExecutorInternalQueryClient(client))
+		defer e.Close()
+		e.Node = cluster.Node
+		e.Cluster = cluster
+		e.OwnerChangeRetries = retries
+
+		ctx := WithTopologyEpoch(context.Background(), cluster.Epoch())
+		c := &pql.Call{Name: "Count"}
+		result, err := e.mapReduce(ctx, "i", shards, c, &execOptions{}, mapFn, reduceFn)
+		return client, result, err
+	}
+
+	t.Run("Retried", func(t *testing.T) {
+		client, result, err := run(2, 3)
+		if err != nil {
+			t.Fatal(err)
+		} else if result != uint64(len(shards)) {
+			t.Fatalf("expected %d, got %v", len(shards), result)
+		} else if client.calls != 3 {
+			t.Fatalf("expected 3 calls, got %d", client.calls)
+		} else if got := fmt.Sprint(client.epochs); got != "[0 1 2]" {
+			t.Fatalf("expected requests routed with epochs [0 1 2], got %s", got)
+		}
+	})
+
+	t.Run("Exhausted", func(t *testing.T) {
+		client, _, err := run(10, 2)
+		if errors.Cause(err) != ErrTopologyChanged {
+			t.Fatalf("expected topology changed error, got %v", err)
+		} else if client.calls != 3 {
+			t.Fatalf("expected 3 calls, got %d", client.calls)
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		client, _, err := run(1, 0)
+		if errors.Cause(err) != ErrTopologyChanged {
+			t.Fatalf("expected topology changed error, got %v", err)
+		} else if client.calls != 1 {
+			t.Fatalf("expected 1 call, got %d", client.calls)
+		}
+	})
+}
+
+func TestExecutor_OwnerChangeBackoff(t *testing.T) {
+	e := &executor{OwnerChangeBackoff: 100 * time.Millisecond, OwnerChangeMaxBackoff: time.Second}
+	for n, exp := range map[int]time.Duration{
+		0:    100 * time.Millisecond,
+		1:    200 * time.Millisecond,
+		3:    800 * time.Millisecond,
+		4:    time.Second,
+		1000: time.Second,
+	} {
+		if d := e.ownerChangeBackoff(n); d != exp {
+			t.Errorf("retry %d: expected %s, got %s", n, exp, d)
+		}
+	}
+}
+
+// downQueryClient fails every query as if the remote node were down.
+type downQueryClient struct{}
+
+func (downQueryClient) QueryNode(ctx context.Context, uri *URI, index string, req *QueryRequest) (*QueryResponse, error) {
+	return nil, errors.New("connection refused")
+}
+
+// Ensure that shards which no node can serve fail the call under the strict
+// policy, and are skipped and recorded under the partial results policy.
+func TestExecutor_MapReducePartialResults(t *testing.T) {
+	cluster := NewTestCluster(2)
+	var shards, local, remote []uint64
+	for shard := uint64(0); shard < 16; shard++ {
+		shards = append(shards, shard)
+		if cluster.ShardNodes("i", shard)[0].ID == "node0" {
+			local = append(local, shard)
+		} else {
+			remote = append(remote, shard)
+		}
+	}
+	mapFn := func(shard uint64) (interface{}, error) { return uint64(1), nil }
+	reduceFn := func(prev, v interface{}) interface{} {
+		n, _ := prev.(uint64)
+		return n + v.(uint64)
+	}
+
+	e := newExecutor(optExecutorInternalQueryClient(downQueryClient{}))
+	defer e.Close()
+	e.Node = cluster.Node
+	e.Cluster = cluster
+	c := &pql.Call{Name: "Count"}
+
+	t.Run("Strict", func(t *testing.T) {
+		if _, err := e.mapReduce(context.Background(), "i", shards, c, &execOptions{}, mapFn, reduceFn); err == nil || !strings.Contains(err.Error(), "connection refused") {
+			t.Fatalf("expected remote error, got %v", err)
+		}
+	})
+
+	t.Run("Partial", func(t *testing.T) {
+		opt := &execOptions{unavailable: &unavailableShards{}}
+		result, err := e.mapReduce(context.Background(), "i", shards, c, opt, mapFn, reduceFn)
+		if err != nil {
+			t.Fatal(err)
+		} else if result != uint64(len(local)) {
+			t.Fatalf("expected %d, got %v", len(local), result)
+		} else if got, exp := fmt.Sprint(opt.unavailable.slice()), fmt.Sprint(remote); got != exp {
+			t.Fatalf("expected unavailable shards %s, got %s", exp, got)
+		}
+	})
+
+	t.Run("NoneAvailable", func(t *testing.T) {
+		opt := &execOptions{unavailable: &unavailableShards{}}
+		if _, err := e.mapReduce(context.Background(), "i", remote, c, opt, mapFn, reduceFn); err == nil {
+			t.Fatal("expected error when every shard is unavailable")
+		}
+	})
+}
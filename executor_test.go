@@ -22,9 +22,11 @@ import (
 	"io/ioutil"
 	"math"
 	"math/rand"
+	gohttp "net/http"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -36,6 +38,7 @@ import (
 	"github.com/pilosa/pilosa/v2/http"
 	"github.com/pilosa/pilosa/v2/server"
 	"github.com/pilosa/pilosa/v2/test"
+	"github.com/pilosa/pilosa/v2/tracing"
 	"github.com/pkg/errors"
 )
 
@@ -1190,6 +1193,43 @@ func TestExecutor_Execute_TopN_fill(t *testing.T) {
 	}
 }
 
+// Ensure a TopN() query excludes rows with counts below its threshold.
+func TestExecutor_Execute_TopN_Threshold(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+	hldr := test.Holder{Holder: c[0].Server.Holder()}
+
+	for _, col := range []uint64{0, 1, ShardWidth, ShardWidth + 1} {
+		hldr.SetBit("i", "f", 0, col)
+	}
+	for _, col := range []uint64{0, 1, ShardWidth} {
+		hldr.SetBit("i", "f", 1, col)
+	}
+	hldr.SetBit("i", "f", 2, 0)
+	hldr.SetBit("i", "f", 2, ShardWidth)
+	c[0].MustRecalculateCaches(t)
+
+	if result, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `TopN(f, threshold=2) Count(Row(f=0))`}); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(result.Results[0], []pilosa.Pair{
+		{ID: 0, Count: 4},
+		{ID: 1, Count: 3},
+	}) {
+		t.Fatalf("unexpected result: %s", spew.Sdump(result))
+	} else if !reflect.DeepEqual(result.Thresholds, []uint64{2, 0}) {
+		t.Fatalf("unexpected thresholds: %v", result.Thresholds)
+	}
+
+	// The threshold is only reported if it is set.
+	if result, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `TopN(f)`}); err != nil {
+		t.Fatal(err)
+	} else if len(result.Results[0].([]pilosa.Pair)) != 3 {
+		t.Fatalf("unexpected result: %s", spew.Sdump(result))
+	} else if result.Thresholds != nil {
+		t.Fatalf("unexpected thresholds: %v", result.Thresholds)
+	}
+}
+
 // Ensure
 func TestExecutor_Execute_TopN_fill_small(t *testing.T) {
 	c := test.MustRunCluster(t, 1)
@@ -2216,6 +2256,65 @@ func TestExecutor_Execute_Row_BSIGroup(t *testing.T) {
 }
 
 // Ensure a Range(bsiGroup) query can be executed. (Deprecated)
+// Ensure timestamp fields can be set and queried with epoch values or times.
+func TestExecutor_Execute_Timestamp(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+	hldr := test.Holder{Holder: c[0].Server.Holder()}
+
+	idx, err := hldr.CreateIndex("i", pilosa.IndexOptions{})
+	if err != nil {
+		t.Fatal(err)
+	} else if _, err := idx.CreateField("ts", pilosa.OptFieldTypeTimestamp(pilosa.TimeUnitMilliseconds)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `
+		Set(1, ts="2020-01-01T00:00:00Z")
+		Set(2, ts="2020-01-15T12:30:00.250Z")
+		Set(` + strconv.Itoa(ShardWidth+3) + `, ts=1580515200000)
+		Set(4, ts="1969-12-31T23:59:59Z")
+	`}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		query string
+		exp   []uint64
+	}{
+		{`Row(ts > "2020-01-01T00:00:00Z")`, []uint64{2, ShardWidth + 3}},
+		{`Row(ts >= 2020-01-01T00:00)`, []uint64{1, 2, ShardWidth + 3}},
+		{`Row(ts < 0)`, []uint64{4}},
+		{`Row(ts == "2020-01-15T13:30:00.250+01:00")`, []uint64{2}},
+		{`Row(ts >< ["2020-01-10T00:00:00Z", "2020-02-01T00:00:00Z"])`, []uint64{2, ShardWidth + 3}},
+		{`Row(ts >< [1577836800000, 1578000000000])`, []uint64{1}},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			if result, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: tt.query}); err != nil {
+				t.Fatal(err)
+			} else if got := result.Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(got, tt.exp) {
+				t.Fatalf("columns=%v, expected %v", got, tt.exp)
+			}
+		})
+	}
+
+	t.Run("MinMax", func(t *testing.T) {
+		if result, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `Min(field=ts) Max(field=ts)`}); err != nil {
+			t.Fatal(err)
+		} else if vc := result.Results[0].(pilosa.ValCount); vc.Val != -1000 || vc.Timestamp != "1969-12-31T23:59:59Z" {
+			t.Fatalf("unexpected min: %+v", vc)
+		} else if vc := result.Results[1].(pilosa.ValCount); vc.Val != 1580515200000 || vc.Timestamp != "2020-02-01T00:00:00Z" {
+			t.Fatalf("unexpected max: %+v", vc)
+		}
+	})
+
+	t.Run("InvalidTimestamp", func(t *testing.T) {
+		if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `Row(ts > "yesterday")`}); err == nil || !strings.Contains(err.Error(), "invalid timestamp") {
+			t.Fatalf("expected invalid timestamp error, got %v", err)
+		}
+	})
+}
+
 func TestExecutor_Execute_Range_BSIGroup_Deprecated(t *testing.T) {
 	c := test.MustRunCluster(t, 1)
 	defer c.Close()
@@ -3336,6 +3435,63 @@ func TestExecutor_Execute_Rows(t *testing.T) {
 	}
 }
 
+func TestExecutor_Execute_CountDistinct(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "general")
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "filter")
+	c.ImportBits(t, "i", "general", [][2]uint64{
+		{10, 0},
+		{10, ShardWidth + 1},
+		{11, 2},
+		{11, ShardWidth + 2},
+		{12, ShardWidth + 2},
+		{13, 3*ShardWidth + 3},
+	})
+	c.ImportBits(t, "i", "filter", [][2]uint64{
+		{1, 2},
+		{1, ShardWidth + 1},
+		{1, 3*ShardWidth + 4},
+	})
+
+	for _, tt := range []struct {
+		query    string
+		expected pilosa.DistinctCount
+	}{
+		{query: `CountDistinct(field=general)`, expected: pilosa.DistinctCount{Count: 4}},
+		{query: `CountDistinct(Row(filter=1), field=general)`, expected: pilosa.DistinctCount{Count: 2}},
+		{query: `CountDistinct(Row(filter=2), field=general)`, expected: pilosa.DistinctCount{Count: 0}},
+		{query: `CountDistinct(field=general, sample=1)`, expected: pilosa.DistinctCount{Count: 4}},
+		// 3 rows are found in 2 of the 3 shards holding data.
+		{query: `CountDistinct(field=general, sample=0.5)`, expected: pilosa.DistinctCount{Count: 5, Approximate: true}},
+	} {
+		if res := c.Query(t, "i", tt.query).Results[0].(pilosa.DistinctCount); res != tt.expected {
+			t.Errorf("%s: expected %+v, got %+v", tt.query, tt.expected, res)
+		}
+	}
+
+	// The approximate flag survives protobuf encoding.
+	client, err := http.NewInternalClient(c[0].API.Node().URI.HostPort(), http.GetHTTPClient(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := client.Query(context.Background(), "i", &pilosa.QueryRequest{Query: `CountDistinct(field=general, sample=0.5)`}); err != nil {
+		t.Fatal(err)
+	} else if res := resp.Results[0]; res != (pilosa.DistinctCount{Count: 5, Approximate: true}) {
+		t.Errorf("unexpected protobuf result: %+v", res)
+	}
+
+	for _, query := range []string{
+		`CountDistinct()`,
+		`CountDistinct(field=general, sample=0)`,
+		`CountDistinct(field=general, sample=1.5)`,
+	} {
+		if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: query}); err == nil {
+			t.Errorf("%s: expected error", query)
+		}
+	}
+}
+
 func TestExecutor_Execute_RowsTime(t *testing.T) {
 	writeQuery := fmt.Sprintf(`
 		Set(9, f=1, 2001-01-01T00:00)
@@ -4136,3 +4292,99 @@ func TestExecutor_Execute_Shift(t *testing.T) {
 		}
 	})
 }
+
+// Ensure a query is traced across nodes, and a schema broadcast with it.
+func TestExecutor_Tracing(t *testing.T) {
+	tracer := &recordingTracer{}
+	defer func(prev tracing.Tracer) { tracing.GlobalTracer = prev }(tracing.GlobalTracer)
+	tracing.GlobalTracer = tracer
+
+	c := test.MustRunCluster(t, 2)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	for shard := uint64(0); shard < 8; shard++ {
+		c.Query(t, "i", fmt.Sprintf("Set(%d, f=1)", shard*ShardWidth))
+	}
+
+	tracer.reset()
+	if res := c.Query(t, "i", "Count(Row(f=1))"); res.Results[0] != uint64(8) {
+		t.Fatalf("unexpected count: %v", res.Results[0])
+	}
+	if n := tracer.count("Executor.mapShard", "Executor.mapperLocal"); n != 8 {
+		t.Fatalf("expected a span for each of 8 shards, got %d", n)
+	} else if tracer.count("Executor.remoteExec", "Executor.mapper") == 0 {
+		t.Fatal("expected a span for the request to the other node")
+	} else if tracer.count("HTTP", "QueryNode") == 0 {
+		t.Fatal("expected the trace to continue on the other node")
+	}
+
+	tracer.reset()
+	if _, err := c[0].API.CreateField(context.Background(), "i", "g"); err != nil {
+		t.Fatal(err)
+	} else if tracer.count("Server.SendSync", "API.CreateField") != 2 {
+		t.Fatal("expected a span for the broadcast and one for its version")
+	} else if tracer.count("HTTP", "InternalClient.SendMessage") == 0 {
+		t.Fatal("expected the trace to continue on the other node")
+	}
+}
+
+// recordingTracer is a tracer recording the operation name of each span with
+// that of its parent. It passes the operation name of the current span in an
+// HTTP header.
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []recordedSpan
+}
+
+type recordedSpan struct {
+	name, parent string
+}
+
+type recordedSpanKey struct{}
+
+const recordedSpanHeader = "X-Recorded-Span"
+
+func (t *recordingTracer) StartSpanFromContext(ctx context.Context, operationName string) (tracing.Span, context.Context) {
+	parent, _ := ctx.Value(recordedSpanKey{}).(string)
+	t.record(operationName, parent)
+	return t, context.WithValue(ctx, recordedSpanKey{}, operationName)
+}
+
+func (t *recordingTracer) InjectHTTPHeaders(r *gohttp.Request) {
+	if name, ok := r.Context().Value(recordedSpanKey{}).(string); ok {
+		r.Header.Set(recordedSpanHeader, name)
+	}
+}
+
+func (t *recordingTracer) ExtractHTTPHeaders(r *gohttp.Request) (tracing.Span, context.Context) {
+	t.record("HTTP", r.Header.Get(recordedSpanHeader))
+	return t, context.WithValue(r.Context(), recordedSpanKey{}, "HTTP")
+}
+
+func (t *recordingTracer) Finish()                                   {}
+func (t *recordingTracer) LogKV(alternatingKeyValues ...interface{}) {}
+
+func (t *recordingTracer) record(name, parent string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spans = append(t.spans, recordedSpan{name: name, parent: parent})
+}
+
+func (t *recordingTracer) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spans = nil
+}
+
+// count returns the number of spans named name with a parent named parent.
+func (t *recordingTracer) count(name, parent string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var n int
+	for _, s := range t.spans {
+		if s.name == name && s.parent == parent {
+			n++
+		}
+	}
+	return n
+}
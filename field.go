@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
@@ -59,6 +60,8 @@ const (
 	FieldTypeTime  = "time"
 	FieldTypeMutex = "mutex"
 	FieldTypeBool  = "bool"
+
+	FieldTypeTimestamp = "timestamp"
 )
 
 // Field represents a container for views.
@@ -70,6 +73,10 @@ type Field struct {
 
 	viewMap map[string]*view
 
+	// The modes with which directories and files are created.
+	dirPerm  os.FileMode
+	filePerm os.FileMode
+
 	// Row attribute storage and cache
 	rowAttrStore AttrStore
 
@@ -90,6 +97,11 @@ type Field struct {
 	logger logger.Logger
 
 	snapshotQueue chan *fragment
+	quarantine    *fragmentQuarantine
+	changes       *changeFeed
+	durability    string
+	generation    *generation
+	compression   *fragmentCompression
 
 	// Instantiates new translation store on open.
 	OpenTranslateStore OpenTranslateStoreFunc
@@ -155,6 +167,27 @@ func OptFieldTypeInt(min, max int64) FieldOption {
 	}
 }
 
+// OptFieldTypeTimestamp is a functional option on FieldOptions
+// used to specify the field as being type `timestamp`, storing
+// times as the number of units since the Unix epoch.
+func OptFieldTypeTimestamp(unit string) FieldOption {
+	return func(fo *FieldOptions) error {
+		if fo.Type != "" {
+			return errors.Errorf("field type is already set to: %s", fo.Type)
+		}
+		if unit == "" {
+			unit = DefaultTimeUnit
+		} else if !ValidTimeUnit(unit) {
+			return errors.Errorf("invalid time unit: %q", unit)
+		}
+		fo.Type = FieldTypeTimestamp
+		fo.TimeUnit = unit
+		fo.Min = math.MinInt64
+		fo.Max = math.MaxInt64
+		return nil
+	}
+}
+
 // OptFieldTypeTime is a functional option on FieldOptions
 // used to specify the field as being type `time` and to
 // provide any respective configuration values.
@@ -228,6 +261,9 @@ func newField(path, index, name string, opts FieldOption) (*Field, error) {
 
 		viewMap: make(map[string]*view),
 
+		dirPerm:  DefaultDirPerm,
+		filePerm: DefaultFilePerm,
+
 		rowAttrStore: nopStore,
 
 		broadcaster: NopBroadcaster,
@@ -320,7 +356,7 @@ func (f *Field) unprotectedSaveAvailableShards() error {
 	tempPath := path + tempExt
 
 	// Open or create file.
-	file, err := os.OpenFile(tempPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
+	file, err := os.OpenFile(tempPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, f.filePerm)
 	if err != nil {
 		return errors.Wrap(err, "opening temporary available shards file")
 	}
@@ -404,7 +440,7 @@ func (f *Field) Open() error {
 	if err := func() (err error) {
 		// Ensure the field's path exists.
 		f.logger.Debugf("ensure field path exists: %s", f.path)
-		if err := os.MkdirAll(f.path, 0777); err != nil {
+		if err := os.MkdirAll(f.path, f.dirPerm); err != nil {
 			return errors.Wrap(err, "creating field dir")
 		}
 
@@ -554,6 +590,7 @@ func (f *Field) loadMeta() error {
 	f.options.TimeQuantum = TimeQuantum(pb.TimeQuantum)
 	f.options.Keys = pb.Keys
 	f.options.NoStandardView = pb.NoStandardView
+	f.options.TimeUnit = pb.TimeUnit
 
 	return nil
 }
@@ -572,7 +609,7 @@ func (f *Field) saveMeta() error {
 	}
 
 	// Write to meta file.
-	if err := ioutil.WriteFile(tempPath, buf, 0666); err != nil {
+	if err := ioutil.WriteFile(tempPath, buf, f.filePerm); err != nil {
 		return errors.Wrap(err, "writing meta")
 	}
 
@@ -606,8 +643,9 @@ func (f *Field) applyOptions(opt FieldOptions) error {
 		f.options.Base = 0
 		f.options.BitDepth = 0
 		f.options.TimeQuantum = ""
+		f.options.TimeUnit = ""
 		f.options.Keys = opt.Keys
-	case FieldTypeInt:
+	case FieldTypeInt, FieldTypeTimestamp:
 		f.options.Type = opt.Type
 		f.options.CacheType = CacheTypeNone
 		f.options.CacheSize = 0
@@ -616,7 +654,11 @@ func (f *Field) applyOptions(opt FieldOptions) error {
 		f.options.Base = opt.Base
 		f.options.BitDepth = opt.BitDepth
 		f.options.TimeQuantum = ""
+		f.options.TimeUnit = ""
 		f.options.Keys = opt.Keys
+		if opt.Type == FieldTypeTimestamp {
+			f.options.TimeUnit = opt.TimeUnit
+		}
 
 		// Create new bsiGroup.
 		bsig := &bsiGroup{
@@ -642,6 +684,7 @@ func (f *Field) applyOptions(opt FieldOptions) error {
 		f.options.Max = 0
 		f.options.Base = 0
 		f.options.BitDepth = 0
+		f.options.TimeUnit = ""
 		f.options.Keys = opt.Keys
 		f.options.NoStandardView = opt.NoStandardView
 		// Set the time quantum.
@@ -658,6 +701,7 @@ func (f *Field) applyOptions(opt FieldOptions) error {
 		f.options.Base = 0
 		f.options.BitDepth = 0
 		f.options.TimeQuantum = ""
+		f.options.TimeUnit = ""
 		f.options.Keys = false
 	default:
 		return errors.New("invalid field type")
@@ -763,6 +807,13 @@ func (f *Field) TimeQuantum() TimeQuantum {
 	return f.options.TimeQuantum
 }
 
+// TimeUnit returns the time unit of a timestamp field.
+func (f *Field) TimeUnit() string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.options.TimeUnit
+}
+
 // setTimeQuantum sets the time quantum for the field.
 func (f *Field) setTimeQuantum(q TimeQuantum) error {
 	f.mu.Lock()
@@ -882,6 +933,13 @@ func (f *Field) newView(path, name string) *view {
 	view.stats = f.Stats
 	view.broadcaster = f.broadcaster
 	view.snapshotQueue = f.snapshotQueue
+	view.quarantine = f.quarantine
+	view.changes = f.changes
+	view.durability = f.durability
+	view.generation = f.generation
+	view.compression = f.compression
+	view.dirPerm = f.dirPerm
+	view.filePerm = f.filePerm
 	return view
 }
 
@@ -903,6 +961,7 @@ func (f *Field) deleteView(name string) error {
 	}
 
 	delete(f.viewMap, name)
+	f.generation.bump()
 
 	return nil
 }
@@ -1427,6 +1486,7 @@ type FieldOptions struct {
 	CacheType      string      `json:"cacheType,omitempty"`
 	Type           string      `json:"type,omitempty"`
 	TimeQuantum    TimeQuantum `json:"timeQuantum,omitempty"`
+	TimeUnit       string      `json:"timeUnit,omitempty"`
 }
 
 // applyDefaultOptions returns a new FieldOptions object
@@ -1462,6 +1522,7 @@ func encodeFieldOptions(o *FieldOptions) *internal.FieldOptions {
 		TimeQuantum:    string(o.TimeQuantum),
 		Keys:           o.Keys,
 		NoStandardView: o.NoStandardView,
+		TimeUnit:       o.TimeUnit,
 	}
 }
 
@@ -1498,6 +1559,18 @@ func (o *FieldOptions) MarshalJSON() ([]byte, error) {
 			o.Max,
 			o.Keys,
 		})
+	case FieldTypeTimestamp:
+		return json.Marshal(struct {
+			Type     string `json:"type"`
+			TimeUnit string `json:"timeUnit"`
+			BitDepth uint   `json:"bitDepth"`
+			Keys     bool   `json:"keys"`
+		}{
+			o.Type,
+			o.TimeUnit,
+			o.BitDepth,
+			o.Keys,
+		})
 	case FieldTypeTime:
 		return json.Marshal(struct {
 			Type           string      `json:"type"`
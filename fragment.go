@@ -30,6 +30,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 	"unsafe"
@@ -108,7 +109,8 @@ type fragment struct {
 
 	// File-backed storage
 	path               string
-	flags              byte // user-defined flags passed to roaring
+	filePerm           os.FileMode // mode with which files are created
+	flags              byte        // user-defined flags passed to roaring
 	file               *os.File
 	storage            *roaring.Bitmap
 	storageData        []byte
@@ -116,6 +118,8 @@ type fragment struct {
 	totalOps           int64 // total ops (across all snapshots)
 	opN                int   // number of ops since snapshot (may be approximate for imports)
 	ops                int   // number of higher-level operations, as opposed to bit changes
+	dirty              bool  // set when the data file has been written to since the last flush
+	flushedSize        int64 // size of the data file at the last flush
 	snapshotsRequested int   // number of times we've requested a snapshot
 	snapshotsTaken     int   // number of actual snapshot operations
 	snapshotting       bool  // set to true when requesting a snapshot, set to false after snapshot completes
@@ -123,6 +127,19 @@ type fragment struct {
 	snapshotDelays     int
 	snapshotDelayTime  time.Duration
 
+	// Compression of the data file, shared with the other fragments of the
+	// index. compressedBytes is the size of the compressed bitmap at the
+	// start of the data file and uncompressedBytes its size uncompressed;
+	// both are zero if the data file is not compressed.
+	compression       *fragmentCompression
+	compressedBytes   int64
+	uncompressedBytes int64
+
+	// Durability of the writes to the index, which determines how the
+	// operation log is written. opBuf buffers it under async durability.
+	durability string
+	opBuf      *opBuffer
+
 	// Cache for row counts.
 	CacheType string // passed in by field
 	cache     cache
@@ -134,8 +151,10 @@ type fragment struct {
 	// Cache containing full rows (not just counts).
 	rowCache bitmapCache
 
-	// Cached checksums for each block.
-	checksums map[int][]byte
+	// Cached checksums for each block, computed with ChecksumStandard and
+	// ChecksumContainer respectively.
+	checksums          map[int][]byte
+	containerChecksums map[int][]byte
 
 	// Number of operations performed before performing a snapshot.
 	// This limits the size of fragments on the heap and flushes them to disk
@@ -156,6 +175,16 @@ type fragment struct {
 	stats stats.StatsClient
 
 	snapshotQueue chan *fragment
+
+	// Bumped on every write so that cached query results are invalidated.
+	generation *generation
+
+	// If non-nil, the bits set and cleared in the standard view, and the
+	// values set and cleared in a BSI view, are published here.
+	changes *changeFeed
+
+	// Base of the values stored in a BSI view.
+	base int64
 }
 
 // newFragment returns a new instance of Fragment.
@@ -173,6 +202,8 @@ func newFragment(path, index, field, view string, shard uint64, flags byte) *fra
 		Logger: logger.NopLogger,
 		MaxOpN: defaultFragmentMaxOpN,
 
+		filePerm: DefaultFilePerm,
+
 		stats: stats.NopStatsClient,
 	}
 	f.snapshotCond = sync.Cond{L: &f.mu}
@@ -249,6 +280,9 @@ func (f *fragment) Open() error {
 		if err := f.openStorage(true); err != nil {
 			return errors.Wrap(err, "opening storage")
 		}
+		if fi, err := os.Stat(f.path); err == nil {
+			f.flushedSize = fi.Size()
+		}
 
 		// Fill cache with rows persisted to disk.
 		f.Logger.Debugf("open cache for index/field/view/fragment: %s/%s/%s/%d", f.index, f.field, f.view, f.shard)
@@ -258,6 +292,7 @@ func (f *fragment) Open() error {
 
 		// Clear checksums.
 		f.checksums = make(map[int][]byte)
+		f.containerChecksums = make(map[int][]byte)
 
 		// Read last bit to determine max row.
 		f.maxRowID = f.storage.Max() / ShardWidth
@@ -275,11 +310,11 @@ func (f *fragment) Open() error {
 func (f *fragment) reopen() (mustClose bool, err error) {
 	if f.file == nil {
 		// Open the data file to be mmap'd and used as an ops log.
-		f.file, mustClose, err = syswrap.OpenFile(f.path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
+		f.file, mustClose, err = syswrap.OpenFile(f.path, os.O_RDWR|os.O_CREATE|os.O_APPEND, f.filePerm)
 		if err != nil {
 			return mustClose, fmt.Errorf("open file: %s", err)
 		}
-		f.storage.OpWriter = f.file
+		f.storage.OpWriter = f.opWriter()
 	}
 	return mustClose, nil
 }
@@ -323,7 +358,7 @@ func (f *fragment) openStorage(unmarshalData bool) error {
 		unmarshalData = true
 	}
 	// Open the data file to be mmap'd and used as an ops log.
-	file, mustClose, err := syswrap.OpenFile(f.path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
+	file, mustClose, err := syswrap.OpenFile(f.path, os.O_RDWR|os.O_CREATE|os.O_APPEND, f.filePerm)
 	if err != nil {
 		return fmt.Errorf("open file: %s", err)
 	}
@@ -346,6 +381,7 @@ func (f *fragment) openStorage(unmarshalData bool) error {
 	// flag in that case...)
 	var data []byte
 	var newStorageData []byte
+	var compressed bool
 
 	// If the file is empty then initialize it with an empty bitmap.
 	fi, err := f.file.Stat()
@@ -353,11 +389,12 @@ func (f *fragment) openStorage(unmarshalData bool) error {
 		return errors.Wrap(err, "statting file before")
 	} else if fi.Size() == 0 {
 		bi := bufio.NewWriter(f.file)
-		var err error
-		if _, err = f.storage.WriteTo(bi); err != nil {
+		n, raw, err := writeFragmentData(bi, f.storage, f.compression.load())
+		if err != nil {
 			return fmt.Errorf("init storage file: %s", err)
 		}
 		bi.Flush()
+		f.setDataSize(n, raw, f.compression.load())
 		_, err = f.file.Stat()
 		if err != nil {
 			return errors.Wrap(err, "statting file after")
@@ -365,6 +402,16 @@ func (f *fragment) openStorage(unmarshalData bool) error {
 		// there's nothing here, we're not going to try to unmarshal it.
 		unmarshalData = false
 		f.rowCache = &simpleCache{make(map[uint64]*Row)}
+	} else if compressed, err = isCompressedFile(f.file); err != nil {
+		return errors.Wrap(err, "reading header")
+	} else if compressed {
+		// Compressed data files are decompressed onto the heap rather than
+		// mapped. After a snapshot the bitmap is already in memory.
+		if unmarshalData {
+			if data, err = f.readCompressedFile(); err != nil {
+				return err
+			}
+		}
 	} else {
 		// Mmap the underlying file so it can be zero copied.
 		data, err = syswrap.Mmap(int(f.file.Fd()), 0, int(fi.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
@@ -452,11 +499,52 @@ func (f *fragment) openStorage(unmarshalData bool) error {
 	}
 
 	// Attach the file to the bitmap to act as a write-ahead log.
-	f.storage.OpWriter = f.file
+	f.storage.OpWriter = f.opWriter()
 
 	return lastError
 }
 
+// isCompressedFile returns true if file is a compressed data file.
+func isCompressedFile(file *os.File) (bool, error) {
+	header := make([]byte, len(compressedFragmentMagic))
+	if _, err := file.ReadAt(header, 0); err == io.EOF {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return isCompressedFragmentData(header), nil
+}
+
+// readCompressedFile reads and decompresses the data file, which must be
+// compressed, and records the size of its bitmap.
+func (f *fragment) readCompressedFile() ([]byte, error) {
+	buf, err := ioutil.ReadAll(f.file)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading compressed file")
+	}
+	body, ops, err := splitCompressedFragmentData(buf)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decoding compressed file %s", f.path)
+	}
+	bm, err := decompressBitmap(body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decoding compressed file %s", f.path)
+	}
+	f.compressedBytes = int64(len(buf) - len(ops))
+	f.uncompressedBytes = int64(len(bm))
+	return append(bm, ops...), nil
+}
+
+// setDataSize records the size of the bitmap written at the start of the
+// data file with the given compression: n bytes, or raw bytes uncompressed.
+func (f *fragment) setDataSize(n, raw int64, compression string) {
+	if compression == CompressionNone {
+		f.compressedBytes, f.uncompressedBytes = 0, 0
+		return
+	}
+	f.compressedBytes, f.uncompressedBytes = n, raw
+}
+
 // openCache initializes the cache from row ids persisted to disk.
 func (f *fragment) openCache() error {
 	// Determine cache type from field name.
@@ -543,6 +631,7 @@ func (f *fragment) close() error {
 
 	// Remove checksums.
 	f.checksums = nil
+	f.containerChecksums = nil
 
 	return nil
 }
@@ -551,6 +640,9 @@ func (f *fragment) close() error {
 func (f *fragment) safeClose() error {
 	// Flush file, unlock & close.
 	if f.file != nil {
+		if err := f.flushOps(); err != nil {
+			return err
+		}
 		if err := f.file.Sync(); err != nil {
 			return fmt.Errorf("sync: %s", err)
 		}
@@ -562,6 +654,7 @@ func (f *fragment) safeClose() error {
 		}
 	}
 	f.file = nil
+	f.opBuf = nil
 	f.storage.OpWriter = nil
 
 	return nil
@@ -696,9 +789,12 @@ func (f *fragment) unprotectedSetBit(rowID, columnID uint64) (changed bool, err
 	if !changed {
 		return changed, nil
 	}
+	if f.changes != nil {
+		f.publishChanges(ChangeSet, []uint64{pos})
+	}
 
 	// Invalidate block checksum.
-	delete(f.checksums, int(rowID/HashBlockSize))
+	f.invalidateBlockChecksums(int(rowID / HashBlockSize))
 
 	// Increment number of operations until snapshot is required.
 	f.incrementOpN(1)
@@ -758,9 +854,12 @@ func (f *fragment) unprotectedClearBit(rowID, columnID uint64) (changed bool, er
 	if !changed {
 		return changed, nil
 	}
+	if f.changes != nil {
+		f.publishChanges(ChangeClear, []uint64{pos})
+	}
 
 	// Invalidate block checksum.
-	delete(f.checksums, int(rowID/HashBlockSize))
+	f.invalidateBlockChecksums(int(rowID / HashBlockSize))
 
 	// Increment number of operations until snapshot is required.
 	f.incrementOpN(1)
@@ -802,6 +901,12 @@ func (f *fragment) unprotectedSetRow(row *Row, rowID uint64) (changed bool, err
 	// For now we will assume changed is always true.
 	changed = true
 
+	// The row is kept to publish the bits which the new one changes.
+	var before *roaring.Bitmap
+	if f.changes != nil && f.view == viewStandard {
+		before = f.storage.OffsetRange(rowID*ShardWidth, rowID*ShardWidth, (rowID+1)*ShardWidth)
+	}
+
 	// First container of the row in storage.
 	headContainerKey := rowID << shardVsContainerExponent
 
@@ -813,6 +918,9 @@ func (f *fragment) unprotectedSetRow(row *Row, rowID uint64) (changed bool, err
 	// From the given row, get the rowSegment for this shard.
 	seg := row.segment(f.shard)
 	if seg == nil {
+		if before != nil {
+			f.publishChanges(ChangeClear, before.Slice())
+		}
 		return changed, nil
 	}
 
@@ -823,6 +931,12 @@ func (f *fragment) unprotectedSetRow(row *Row, rowID uint64) (changed bool, err
 		f.storage.Containers.Put(headContainerKey+(k%(1<<shardVsContainerExponent)), c)
 	}
 
+	if before != nil {
+		after := f.storage.OffsetRange(rowID*ShardWidth, rowID*ShardWidth, (rowID+1)*ShardWidth)
+		f.publishChanges(ChangeClear, before.Difference(after).Slice())
+		f.publishChanges(ChangeSet, after.Difference(before).Slice())
+	}
+
 	// Update the row in cache.
 	if f.CacheType != CacheTypeNone {
 		n := f.storage.CountRange(rowID*ShardWidth, (rowID+1)*ShardWidth)
@@ -834,6 +948,7 @@ func (f *fragment) unprotectedSetRow(row *Row, rowID uint64) (changed bool, err
 
 	// Snapshot storage.
 	f.enqueueSnapshot()
+	f.generation.bump()
 	f.stats.Count("setRow", 1, 1.0)
 
 	return changed, nil
@@ -857,6 +972,12 @@ func (f *fragment) clearRow(rowID uint64) (bool, error) {
 func (f *fragment) unprotectedClearRow(rowID uint64) (changed bool, err error) {
 	changed = false
 
+	// The row is kept to publish the bits cleared.
+	var before *roaring.Bitmap
+	if f.changes != nil && f.view == viewStandard {
+		before = f.storage.OffsetRange(rowID*ShardWidth, rowID*ShardWidth, (rowID+1)*ShardWidth)
+	}
+
 	// First container of the row in storage.
 	headContainerKey := rowID << shardVsContainerExponent
 
@@ -871,6 +992,9 @@ func (f *fragment) unprotectedClearRow(rowID uint64) (changed bool, err error) {
 			changed = true
 		}
 	}
+	if before != nil {
+		f.publishChanges(ChangeClear, before.Slice())
+	}
 
 	// Clear the row in cache.
 	f.cache.Add(rowID, 0)
@@ -878,6 +1002,7 @@ func (f *fragment) unprotectedClearRow(rowID uint64) (changed bool, err error) {
 
 	// Snapshot storage.
 	f.enqueueSnapshot()
+	f.generation.bump()
 
 	f.stats.Count("clearRow", 1, 1.0)
 
@@ -896,7 +1021,10 @@ func (f *fragment) bit(rowID, columnID uint64) (bool, error) {
 func (f *fragment) value(columnID uint64, bitDepth uint) (value int64, exists bool, err error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
+	return f.unprotectedValue(columnID, bitDepth)
+}
 
+func (f *fragment) unprotectedValue(columnID uint64, bitDepth uint) (value int64, exists bool, err error) {
 	// If existence bit is unset then ignore remaining bits.
 	if v, err := f.bit(bsiExistsBit, columnID); err != nil {
 		return 0, false, errors.Wrap(err, "getting existence bit")
@@ -985,6 +1113,15 @@ func (f *fragment) setValueBase(columnID uint64, bitDepth uint, value int64, cle
 		defer f.safeClose()
 	}
 
+	// The change is found before it is applied, so that only values
+	// which differ are published.
+	var publish bool
+	if f.publishesValues() {
+		if publish, err = f.valueChanges(columnID, bitDepth, value, clear); err != nil {
+			return false, err
+		}
+	}
+
 	// Convert value to an unsigned representation.
 	uvalue := uint64(value)
 	if value < 0 {
@@ -1037,6 +1174,10 @@ func (f *fragment) setValueBase(columnID uint64, bitDepth uint, value int64, cle
 		}
 	}
 
+	if publish {
+		f.changes.publishValues(f.index, f.field, []valueChange{{column: columnID, value: value + f.base, clear: clear}})
+	}
+
 	return changed, nil
 }
 
@@ -1757,6 +1898,15 @@ type topOptions struct {
 	TanimotoThreshold uint64
 }
 
+// Anti-entropy block checksum algorithms.
+const (
+	// ChecksumStandard hashes the position of each bit in a block.
+	ChecksumStandard = "standard"
+	// ChecksumContainer hashes the roaring containers of a block, which
+	// takes much less CPU for blocks holding many bits.
+	ChecksumContainer = "container"
+)
+
 // Checksum returns a checksum for the entire fragment.
 // If two fragments have the same checksum then they have the same data.
 func (f *fragment) Checksum() []byte {
@@ -1771,14 +1921,40 @@ func (f *fragment) Checksum() []byte {
 func (f *fragment) InvalidateChecksums() {
 	f.mu.Lock()
 	f.checksums = make(map[int][]byte)
+	f.containerChecksums = make(map[int][]byte)
 	f.mu.Unlock()
 }
 
-// Blocks returns info for all blocks containing data.
+// invalidateBlockChecksums clears the cached checksums of a block. The caller
+// must hold f.mu.
+func (f *fragment) invalidateBlockChecksums(id int) {
+	delete(f.checksums, id)
+	delete(f.containerChecksums, id)
+}
+
+// Blocks returns info for all blocks containing data, with checksums
+// computed using ChecksumStandard.
 func (f *fragment) Blocks() []FragmentBlock {
+	return f.BlocksWithChecksum(ChecksumStandard)
+}
+
+// BlocksWithChecksum returns info for all blocks containing data, with
+// checksums computed using the given algorithm. Unknown algorithms fall back
+// to ChecksumStandard.
+func (f *fragment) BlocksWithChecksum(algorithm string) []FragmentBlock {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
+	start := time.Now()
+	defer func() {
+		f.stats.WithTags("algorithm:"+algorithm).Timing("checksumBlocks", time.Since(start), 1.0)
+	}()
+
+	if algorithm == ChecksumContainer {
+		return f.containerBlocks()
+	}
+	algorithm = ChecksumStandard
+
 	var a []FragmentBlock
 
 	// Initialize the iterator.
@@ -1840,6 +2016,57 @@ func (f *fragment) Blocks() []FragmentBlock {
 	return a
 }
 
+// containerBlocks returns info for all blocks containing data, with checksums
+// computed using ChecksumContainer. Rather than hashing each bit, the
+// containers of a block are hashed in a form which does not depend on the
+// container type, so that replicas holding the same data agree. The caller
+// must hold f.mu.
+func (f *fragment) containerBlocks() []FragmentBlock {
+	var a []FragmentBlock
+	h := xxhash.New()
+	var buf [8]byte
+	blockID := -1
+
+	// appendBlock caches the checksum of the current block and appends it.
+	appendBlock := func() {
+		if blockID == -1 {
+			return
+		}
+		chksum := h.Sum(nil)
+		f.containerChecksums[blockID] = chksum
+		a = append(a, FragmentBlock{ID: blockID, Checksum: chksum})
+	}
+
+	itr, _ := f.storage.Containers.Iterator(0)
+	for itr.Next() {
+		key, c := itr.Value()
+		if c.N() == 0 {
+			continue
+		}
+
+		id := int((key >> shardVsContainerExponent) / HashBlockSize)
+		if id != blockID {
+			appendBlock()
+			blockID = id
+
+			// Reuse the cached checksum of the block if there is one.
+			if chksum := f.containerChecksums[id]; chksum != nil {
+				a = append(a, FragmentBlock{ID: id, Checksum: chksum})
+				blockID = -1
+				itr, _ = f.storage.Containers.Iterator(uint64(id+1) * HashBlockSize << shardVsContainerExponent)
+				continue
+			}
+			h.Reset()
+		}
+
+		binary.BigEndian.PutUint64(buf[:], key)
+		_, _ = h.Write(buf[:])
+		_, _ = c.WriteCanonicalTo(h)
+	}
+	appendBlock()
+	return a
+}
+
 // readContiguousChecksums appends multiple checksums in a row and returns the count added.
 func (f *fragment) readContiguousChecksums(a *[]FragmentBlock, blockID int) (n int) {
 	for i := 0; ; i++ {
@@ -1871,12 +2098,13 @@ func (f *fragment) blockData(id int) (rowIDs, columnIDs []uint64) {
 //
 // For example, if 3 blocks are compared and two have a set bit and one has a
 // cleared bit then the bit is considered cleared. The function returns the
-// diff per incoming block so that all can be in sync.
-func (f *fragment) mergeBlock(id int, data []pairSet) (sets, clears []pairSet, err error) {
+// diff per incoming block so that all can be in sync, along with the number
+// of local bits which were changed.
+func (f *fragment) mergeBlock(id int, data []pairSet) (sets, clears []pairSet, n int, err error) {
 	// Ensure that all pair sets are of equal length.
 	for i := range data {
 		if len(data[i].rowIDs) != len(data[i].columnIDs) {
-			return nil, nil, fmt.Errorf("pair set mismatch(idx=%d): %d != %d", i, len(data[i].rowIDs), len(data[i].columnIDs))
+			return nil, nil, 0, fmt.Errorf("pair set mismatch(idx=%d): %d != %d", i, len(data[i].rowIDs), len(data[i].columnIDs))
 		}
 	}
 
@@ -1978,18 +2206,18 @@ func (f *fragment) mergeBlock(id int, data []pairSet) (sets, clears []pairSet, e
 	// Set local bits.
 	for i := range sets[0].columnIDs {
 		if _, err := f.unprotectedSetBit(sets[0].rowIDs[i], (f.shard*ShardWidth)+sets[0].columnIDs[i]); err != nil {
-			return nil, nil, errors.Wrap(err, "setting")
+			return nil, nil, 0, errors.Wrap(err, "setting")
 		}
 	}
 
 	// Clear local bits.
 	for i := range clears[0].columnIDs {
 		if _, err := f.unprotectedClearBit(clears[0].rowIDs[i], (f.shard*ShardWidth)+clears[0].columnIDs[i]); err != nil {
-			return nil, nil, errors.Wrap(err, "clearing")
+			return nil, nil, 0, errors.Wrap(err, "clearing")
 		}
 	}
 
-	return sets[1:], clears[1:], nil
+	return sets[1:], clears[1:], len(sets[0].columnIDs) + len(clears[0].columnIDs), nil
 }
 
 // bulkImport bulk imports a set of bits and then snapshots the storage.
@@ -2000,15 +2228,33 @@ func (f *fragment) bulkImport(rowIDs, columnIDs []uint64, options *ImportOptions
 		return fmt.Errorf("mismatch of row/column len: %d != %d", len(rowIDs), len(columnIDs))
 	}
 
+	var setN, clearedN int
+	var err error
 	if f.mutexVector != nil && !options.Clear {
-		return f.bulkImportMutex(rowIDs, columnIDs)
+		var conflicts int
+		conflicts, setN, clearedN, err = f.bulkImportMutex(rowIDs, columnIDs, options.ConflictPolicy)
+		if conflicts > 0 {
+			f.stats.Count("importConflicts", int64(conflicts), 1.0)
+			if options.Conflicts != nil {
+				atomic.AddUint64(options.Conflicts, uint64(conflicts))
+			}
+		}
+	} else {
+		setN, clearedN, err = f.bulkImportStandard(rowIDs, columnIDs, options)
+	}
+	if options.Set != nil && setN > 0 {
+		atomic.AddUint64(options.Set, uint64(setN))
 	}
-	return f.bulkImportStandard(rowIDs, columnIDs, options)
+	if options.Cleared != nil && clearedN > 0 {
+		atomic.AddUint64(options.Cleared, uint64(clearedN))
+	}
+	return err
 }
 
 // bulkImportStandard performs a bulk import on a standard fragment. May mutate
-// its rowIDs and columnIDs arguments.
-func (f *fragment) bulkImportStandard(rowIDs, columnIDs []uint64, options *ImportOptions) (err error) {
+// its rowIDs and columnIDs arguments. It returns the number of bits set and
+// cleared.
+func (f *fragment) bulkImportStandard(rowIDs, columnIDs []uint64, options *ImportOptions) (setN, clearedN int, err error) {
 	// rowSet maintains the set of rowIDs present in this import. It allows the
 	// cache to be updated once per row, instead of once per bit. TODO: consider
 	// sorting by rowID/columnID first and avoiding the map allocation here. (we
@@ -2021,7 +2267,7 @@ func (f *fragment) bulkImportStandard(rowIDs, columnIDs []uint64, options *Impor
 		rowID, columnID := rowIDs[i], columnIDs[i]
 		pos, err := f.pos(rowID, columnID)
 		if err != nil {
-			return err
+			return 0, 0, err
 		}
 		columnIDs[i] = pos
 
@@ -2035,11 +2281,11 @@ func (f *fragment) bulkImportStandard(rowIDs, columnIDs []uint64, options *Impor
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	if options.Clear {
-		err = f.importPositions(nil, positions, rowSet)
+		setN, clearedN, err = f.importPositions(nil, positions, rowSet)
 	} else {
-		err = f.importPositions(positions, nil, rowSet)
+		setN, clearedN, err = f.importPositions(positions, nil, rowSet)
 	}
-	return errors.Wrap(err, "bulkImportStandard")
+	return setN, clearedN, errors.Wrap(err, "bulkImportStandard")
 }
 
 // importPositions takes slices of positions within the fragment to set and
@@ -2049,40 +2295,47 @@ func (f *fragment) bulkImportStandard(rowIDs, columnIDs []uint64, options *Impor
 //
 // importPositions tries to intelligently decide whether or not to do a full
 // snapshot of the fragment or just do in-memory updates while appending
-// operations to the op log.
-func (f *fragment) importPositions(set, clear []uint64, rowSet map[uint64]struct{}) error {
+// operations to the op log. It returns the number of bits it changed.
+func (f *fragment) importPositions(set, clear []uint64, rowSet map[uint64]struct{}) (setN, clearedN int, err error) {
 	mustClose, err := f.reopen()
 	if err != nil {
-		return errors.Wrap(err, "reopening")
+		return 0, 0, errors.Wrap(err, "reopening")
 	}
 	if mustClose {
 		defer f.safeClose()
 	}
 
+	// The changes are found before they are applied, since the counts
+	// returned by AddN and RemoveN don't say which positions changed.
+	var setChanges, clearChanges []uint64
+	if f.changes != nil && f.view == viewStandard {
+		setChanges, clearChanges = f.changedPositions(set, true), f.changedPositions(clear, false)
+	}
+
 	if len(set) > 0 {
 		f.stats.Count("ImportingN", int64(len(set)), 1)
-		changedN, err := f.storage.AddN(set...) // TODO benchmark Add/RemoveN behavior with sorted/unsorted positions
+		setN, err = f.storage.AddN(set...) // TODO benchmark Add/RemoveN behavior with sorted/unsorted positions
 		if err != nil {
-			return errors.Wrap(err, "adding positions")
+			return 0, 0, errors.Wrap(err, "adding positions")
 		}
-		f.stats.Count("ImportedN", int64(changedN), 1)
-		f.incrementOpN(changedN)
+		f.stats.Count("ImportedN", int64(setN), 1)
+		f.incrementOpN(setN)
 	}
 
 	if len(clear) > 0 {
 		f.stats.Count("ClearingN", int64(len(clear)), 1)
-		changedN, err := f.storage.RemoveN(clear...)
+		clearedN, err = f.storage.RemoveN(clear...)
 		if err != nil {
-			return errors.Wrap(err, "clearing positions")
+			return setN, 0, errors.Wrap(err, "clearing positions")
 		}
-		f.stats.Count("ClearedN", int64(changedN), 1)
-		f.incrementOpN(changedN)
+		f.stats.Count("ClearedN", int64(clearedN), 1)
+		f.incrementOpN(clearedN)
 	}
 
 	// Update cache counts for all affected rows.
 	for rowID := range rowSet {
 		// Invalidate block checksum.
-		delete(f.checksums, int(rowID/HashBlockSize))
+		f.invalidateBlockChecksums(int(rowID / HashBlockSize))
 
 		if f.CacheType != CacheTypeNone {
 			n := f.storage.CountRange(rowID*ShardWidth, (rowID+1)*ShardWidth)
@@ -2096,65 +2349,87 @@ func (f *fragment) importPositions(set, clear []uint64, rowSet map[uint64]struct
 		f.cache.Recalculate()
 	}
 
-	return nil
+	f.publishChanges(ChangeSet, setChanges)
+	f.publishChanges(ChangeClear, clearChanges)
+	return setN, clearedN, nil
 }
 
 // bulkImportMutex performs a bulk import on a fragment while ensuring
 // mutex restrictions. Because the mutex requirements must be checked
 // against storage, this method must acquire a write lock on the fragment
 // during the entire process, and it handles every bit independently.
-func (f *fragment) bulkImportMutex(rowIDs, columnIDs []uint64) error {
+//
+// A column which is imported with several values, or with a value other
+// than the one it has, is a conflict, resolved according to policy. It
+// returns the number of conflicting columns, and of bits set and cleared.
+// Under ImportConflictError, the fragment is left unchanged if any column
+// conflicts.
+func (f *fragment) bulkImportMutex(rowIDs, columnIDs []uint64, policy ImportConflictPolicy) (conflicts, setN, clearedN int, err error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	rowSet := make(map[uint64]struct{})
-	// we have to maintain which columns are getting bits set as a map so that
-	// we don't end up setting multiple bits in the same column if a column is
-	// repeated within the import.
-	colSet := make(map[uint64]uint64)
-
-	// Since each imported bit will at most set one bit and clear one bit, we
-	// can reuse the rowIDs and columnIDs slices as the set and clear slice
-	// arguments to importPositions. The set positions we'll get from the
-	// colSet, but we maintain clearIdx as we loop through row and col ids so
-	// that we know how many bits we need to clear and how far through columnIDs
-	// we are.
-	clearIdx := 0
+	// Resolve the columns repeated within the import first so that the
+	// resolution does not depend on the order of the existing values.
+	// rowByCol maps each column to the row it is imported with.
+	rowByCol := make(map[uint64]uint64, len(columnIDs))
+	conflicted := make(map[uint64]struct{})
 	for i := range rowIDs {
 		rowID, columnID := rowIDs[i], columnIDs[i]
-		if existingRowID, found, err := f.mutexVector.Get(columnID); err != nil {
-			return errors.Wrap(err, "getting mutex vector data")
-		} else if found && existingRowID != rowID {
+		if prev, ok := rowByCol[columnID]; ok {
+			if prev != rowID {
+				conflicted[columnID] = struct{}{}
+			}
+			if policy == ImportConflictFirstWriteWins {
+				continue
+			}
+		}
+		rowByCol[columnID] = rowID
+	}
+
+	rowSet := make(map[uint64]struct{})
+
+	// Since each imported column will at most set one bit and clear one bit,
+	// we can reuse the rowIDs and columnIDs slices as the set and clear slice
+	// arguments to importPositions.
+	setIdx, clearIdx := 0, 0
+	for columnID, rowID := range rowByCol {
+		existingRowID, found, err := f.mutexVector.Get(columnID)
+		if err != nil {
+			return 0, 0, 0, errors.Wrap(err, "getting mutex vector data")
+		} else if found && existingRowID == rowID {
+			continue
+		} else if found {
+			conflicted[columnID] = struct{}{}
+			if policy == ImportConflictFirstWriteWins {
+				continue
+			}
+
 			// Determine the position of the bit in the storage.
 			clearPos, err := f.pos(existingRowID, columnID)
 			if err != nil {
-				return err
+				return 0, 0, 0, err
 			}
 			columnIDs[clearIdx] = clearPos
 			clearIdx++
-
 			rowSet[existingRowID] = struct{}{}
-		} else if found && existingRowID == rowID {
-			continue
 		}
 		pos, err := f.pos(rowID, columnID)
 		if err != nil {
-			return err
+			return 0, 0, 0, err
 		}
-		colSet[columnID] = pos
+		rowIDs[setIdx] = pos
+		setIdx++
 		rowSet[rowID] = struct{}{}
 	}
 
-	// re-use rowIDs by populating positions to set from colSet.
-	i := 0
-	for _, pos := range colSet {
-		rowIDs[i] = pos
-		i++
+	if policy == ImportConflictError && len(conflicted) > 0 {
+		return len(conflicted), 0, 0, newConflictError(errors.Errorf("conflicting values for %d columns of shard %d", len(conflicted), f.shard))
 	}
-	toSet := rowIDs[:i]
+	toSet := rowIDs[:setIdx]
 	toClear := columnIDs[:clearIdx]
 
-	return errors.Wrap(f.importPositions(toSet, toClear, rowSet), "importing positions")
+	setN, clearedN, err = f.importPositions(toSet, toClear, rowSet)
+	return len(conflicted), setN, clearedN, errors.Wrap(err, "importing positions")
 }
 
 func (f *fragment) importValueSmallWrite(columnIDs []uint64, values []int64, bitDepth uint, clear bool) error {
@@ -2190,7 +2465,7 @@ func (f *fragment) importValueSmallWrite(columnIDs []uint64, values []int64, bit
 	for i := uint(0); i < bitDepth+1; i++ {
 		rowSet[uint64(i)] = struct{}{}
 	}
-	err := f.importPositions(toSet, toClear, rowSet)
+	_, _, err := f.importPositions(toSet, toClear, rowSet)
 	if err != nil {
 		return errors.Wrap(err, "importing positions")
 	}
@@ -2211,8 +2486,22 @@ func (f *fragment) importValue(columnIDs []uint64, values []int64, bitDepth uint
 		return fmt.Errorf("mismatch of column/value len: %d != %d", len(columnIDs), len(values))
 	}
 
+	// The changes are found before they are applied, so that only values
+	// which differ are published.
+	var changes []valueChange
+	if f.publishesValues() {
+		var err error
+		if changes, err = f.changedValues(columnIDs, values, bitDepth, clear); err != nil {
+			return errors.Wrap(err, "finding changed values")
+		}
+	}
+
 	if len(columnIDs)*int(bitDepth+1)+f.opN < f.MaxOpN {
-		return errors.Wrap(f.importValueSmallWrite(columnIDs, values, bitDepth, clear), "import small write")
+		if err := f.importValueSmallWrite(columnIDs, values, bitDepth, clear); err != nil {
+			return errors.Wrap(err, "import small write")
+		}
+		f.changes.publishValues(f.index, f.field, changes)
+		return nil
 	}
 
 	// Process every value.
@@ -2246,6 +2535,7 @@ func (f *fragment) importValue(columnIDs []uint64, values []int64, bitDepth uint
 	f.enqueueSnapshot()
 	f.unprotectedAwaitSnapshot()
 
+	f.changes.publishValues(f.index, f.field, changes)
 	return nil
 }
 
@@ -2260,6 +2550,18 @@ func (f *fragment) importRoaring(ctx context.Context, data []byte, clear bool) e
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	span.Finish()
+	var changes []uint64
+	if f.changes != nil && f.view == viewStandard {
+		bm := roaring.NewBitmap()
+		if err := bm.UnmarshalBinary(data); err != nil {
+			return errors.Wrap(err, "decoding changes")
+		}
+		if clear {
+			changes = bm.Intersect(f.storage).Slice()
+		} else {
+			changes = bm.Difference(f.storage).Slice()
+		}
+	}
 	span, ctx = tracing.StartSpanFromContext(ctx, "importRoaring.ImportRoaringBits")
 	changed, rowSet, err := f.storage.ImportRoaringBits(data, clear, true, rowSize)
 	span.Finish()
@@ -2285,6 +2587,12 @@ func (f *fragment) importRoaring(ctx context.Context, data []byte, clear bool) e
 		f.cache.Recalculate()
 	}
 
+	if clear {
+		f.publishChanges(ChangeClear, changes)
+	} else {
+		f.publishChanges(ChangeSet, changes)
+	}
+
 	span, _ = tracing.StartSpanFromContext(ctx, "importRoaring.incrementOpN")
 	f.incrementOpN(changed)
 	span.Finish()
@@ -2299,6 +2607,8 @@ func (f *fragment) incrementOpN(changed int) {
 	}
 	f.opN += changed
 	f.ops++
+	f.dirty = true
+	f.generation.bump()
 	if f.opN > f.MaxOpN {
 		f.enqueueSnapshot()
 	}
@@ -2351,7 +2661,7 @@ func unprotectedWriteToFragment(f *fragment, bm *roaring.Bitmap) (n int64, err e
 
 	// Create a temporary file to snapshot to.
 	snapshotPath := f.path + snapshotExt
-	file, err := os.Create(snapshotPath)
+	file, err := os.OpenFile(snapshotPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, f.filePerm)
 	if err != nil {
 		return n, fmt.Errorf("create snapshot file: %s", err)
 	}
@@ -2359,7 +2669,9 @@ func unprotectedWriteToFragment(f *fragment, bm *roaring.Bitmap) (n int64, err e
 
 	// Write storage to snapshot.
 	bw := bufio.NewWriter(file)
-	if n, err = bm.WriteTo(bw); err != nil {
+	compression := f.compression.load()
+	var raw int64
+	if n, raw, err = writeFragmentData(bw, bm, compression); err != nil {
 		return n, fmt.Errorf("snapshot write to: %s", err)
 	}
 
@@ -2385,9 +2697,11 @@ func unprotectedWriteToFragment(f *fragment, bm *roaring.Bitmap) (n int64, err e
 	if err := f.openStorage(false); err != nil {
 		return n, fmt.Errorf("open storage: %s", err)
 	}
+	f.setDataSize(n, raw, compression)
 
 	// Reset operation count.
 	f.opN = 0
+	f.dirty = true
 
 	return n, nil
 }
@@ -2425,13 +2739,53 @@ func (f *fragment) flushCache() error {
 	}
 
 	// Write to disk.
-	if err := ioutil.WriteFile(f.cachePath(), buf, 0666); err != nil {
+	if err := ioutil.WriteFile(f.cachePath(), buf, f.filePerm); err != nil {
 		return errors.Wrap(err, "writing")
 	}
 
 	return nil
 }
 
+// flush flushes the cache and, for a fragment which has been written to since
+// the previous flush, optionally fsyncs the data file. It returns the number
+// of bytes flushed. The cache can be rebuilt from the data so it is never
+// fsynced.
+func (f *fragment) flush(fsync bool) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.flushCache(); err != nil {
+		return 0, errors.Wrap(err, "flushing cache")
+	}
+	if !f.dirty {
+		return 0, nil
+	}
+	if err := f.flushOps(); err != nil {
+		return 0, err
+	}
+
+	fi, err := os.Stat(f.path)
+	if err != nil {
+		return 0, errors.Wrap(err, "statting data file")
+	}
+	// A snapshot rewrites the whole file, in which case it may have shrunk.
+	n := fi.Size() - f.flushedSize
+	if n < 0 {
+		n = fi.Size()
+	}
+
+	// If the file isn't open it was synced when it was closed.
+	if fsync && f.file != nil {
+		if err := f.file.Sync(); err != nil {
+			return 0, errors.Wrap(err, "syncing data file")
+		}
+	}
+
+	f.flushedSize = fi.Size()
+	f.dirty = false
+	return n, nil
+}
+
 // WriteTo writes the fragment's data to w.
 func (f *fragment) WriteTo(w io.Writer) (n int64, err error) {
 	// Force cache flush.
@@ -2465,6 +2819,11 @@ func (f *fragment) writeStorageToArchive(tw *tar.Writer) error {
 		f.mu.Lock()
 		defer f.mu.Unlock()
 
+		// Operations buffered under async durability are part of the
+		// fragment.
+		if err := f.flushOps(); err != nil {
+			return err
+		}
 		fi, err := file.Stat()
 		if err != nil {
 			return errors.Wrap(err, "statting")
@@ -2527,6 +2886,7 @@ func (f *fragment) writeCacheToArchive(tw *tar.Writer) error {
 func (f *fragment) ReadFrom(r io.Reader) (n int64, err error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
+	defer f.generation.bump()
 
 	tr := tar.NewReader(r)
 	for {
@@ -2559,7 +2919,7 @@ func (f *fragment) ReadFrom(r io.Reader) (n int64, err error) {
 func (f *fragment) readStorageFromArchive(r io.Reader) error {
 	// Create a temporary file to copy into.
 	path := f.path + copyExt
-	file, err := os.Create(path)
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, f.filePerm)
 	if err != nil {
 		return errors.Wrap(err, "creating directory")
 	}
@@ -2593,7 +2953,7 @@ func (f *fragment) readCacheFromArchive(r io.Reader) error {
 	buf, err := ioutil.ReadAll(r)
 	if err != nil {
 		return errors.Wrap(err, "reading")
-	} else if err := ioutil.WriteFile(f.cachePath(), buf, 0666); err != nil {
+	} else if err := ioutil.WriteFile(f.cachePath(), buf, f.filePerm); err != nil {
 		return errors.Wrap(err, "writing")
 	}
 
@@ -2640,6 +3000,17 @@ func filterColumn(col uint64) rowFilter {
 	}
 }
 
+// filterIntersecting returns a filter which only includes rows with a bit set
+// in one of the columns of the given row segment, whose positions are
+// absolute column IDs within shard.
+func filterIntersecting(columns *roaring.Bitmap, shard uint64) rowFilter {
+	base := shard << shardVsContainerExponent
+	mask := uint64(1<<shardVsContainerExponent - 1)
+	return func(rowID, key uint64, c *roaring.Container) (include, done bool) {
+		return roaring.IntersectionAny(c, columns.Containers.Get(base+(key&mask))), false
+	}
+}
+
 // TODO: this works, but it would be more performant if the fragment could seek
 // to the next row in the rows list rather than asking the filter for each
 // container serially. The container iterator would need to expose a seek
@@ -2750,7 +3121,7 @@ func upgradeRoaringBSIv2(f *fragment, bitDepth uint) (string, error) {
 
 	// Create temporary file next to existing file.
 	newPath := f.path + ".tmp"
-	file, err := os.OpenFile(newPath, os.O_WRONLY|os.O_CREATE, 0666)
+	file, err := os.OpenFile(newPath, os.O_WRONLY|os.O_CREATE, f.filePerm)
 	if err != nil {
 		return "", err
 	}
@@ -2843,6 +3214,10 @@ type fragmentSyncer struct {
 	Node    *Node
 	Cluster *cluster
 
+	// Checksum is the algorithm of the block checksums to compare. It falls
+	// back to ChecksumStandard if any replica uses a different one.
+	Checksum string
+
 	Closing <-chan struct{}
 }
 
@@ -2857,41 +3232,38 @@ func (s *fragmentSyncer) isClosing() bool {
 }
 
 // syncFragment compares checksums for the local and remote fragments and
-// then merges any blocks which have differences.
-func (s *fragmentSyncer) syncFragment() error {
+// then merges any blocks which have differences. It returns the number of
+// bits which were set or cleared across all replicas.
+func (s *fragmentSyncer) syncFragment() (int, error) {
 	span, ctx := tracing.StartSpanFromContext(context.Background(), "FragmentSyncer.syncFragment")
 	defer span.Finish()
 
 	// Determine replica set.
 	nodes := s.Cluster.shardNodes(s.Fragment.index, s.Fragment.shard)
 	if len(nodes) == 1 {
-		return nil
+		return 0, nil
 	}
 
 	// Create a set of blocks.
-	blockSets := make([][]FragmentBlock, 0, len(nodes))
-	for _, node := range nodes {
-		// Read local blocks.
-		if node.ID == s.Node.ID {
-			b := s.Fragment.Blocks()
-			blockSets = append(blockSets, b)
-			continue
-		}
-
-		// Retrieve remote blocks.
-		blocks, err := s.Cluster.InternalClient.FragmentBlocks(ctx, &node.URI, s.Fragment.index, s.Fragment.field, s.Fragment.view, s.Fragment.shard)
-		if err != nil && err != ErrFragmentNotFound {
-			return errors.Wrap(err, "getting blocks")
-		}
-		blockSets = append(blockSets, blocks)
-
-		// Verify sync is not prematurely closing.
-		if s.isClosing() {
-			return nil
+	checksum := s.Checksum
+	if checksum == "" {
+		checksum = ChecksumStandard
+	}
+	blockSets, agreed, err := s.blockSets(ctx, nodes, checksum)
+	if err != nil {
+		return 0, err
+	} else if !agreed {
+		blockSets, _, err = s.blockSets(ctx, nodes, ChecksumStandard)
+		if err != nil {
+			return 0, err
 		}
 	}
+	if s.isClosing() {
+		return 0, nil
+	}
 
 	// Iterate over all blocks and find differences.
+	var total int
 	checksums := make([][]byte, len(nodes))
 	for {
 		// Find min block id.
@@ -2927,18 +3299,55 @@ func (s *fragmentSyncer) syncFragment() error {
 			continue
 		}
 		// Synchronize block.
-		if err := s.syncBlock(blockID); err != nil {
-			return fmt.Errorf("sync block: id=%d, err=%s", blockID, err)
+		n, err := s.syncBlock(blockID)
+		if err != nil {
+			return total, fmt.Errorf("sync block: id=%d, err=%s", blockID, err)
 		}
+		total += n
 		s.Fragment.stats.Count("BlockRepair", 1, 1.0)
 	}
 
-	return nil
+	return total, nil
+}
+
+// blockSets returns the blocks of the fragment on each of nodes, with
+// checksums computed using the given algorithm. It returns false if any of the
+// nodes used a different algorithm, in which case the checksums cannot be
+// compared.
+func (s *fragmentSyncer) blockSets(ctx context.Context, nodes []*Node, checksum string) ([][]FragmentBlock, bool, error) {
+	blockSets := make([][]FragmentBlock, 0, len(nodes))
+	for _, node := range nodes {
+		// Read local blocks.
+		if node.ID == s.Node.ID {
+			b := s.Fragment.BlocksWithChecksum(checksum)
+			blockSets = append(blockSets, b)
+			continue
+		}
+
+		// Retrieve remote blocks.
+		blocks, used, err := s.Cluster.InternalClient.FragmentBlocks(ctx, &node.URI, s.Fragment.index, s.Fragment.field, s.Fragment.view, s.Fragment.shard, checksum)
+		if err == ErrFragmentNotFound {
+			used = checksum
+		} else if err != nil {
+			return nil, false, errors.Wrap(err, "getting blocks")
+		}
+		if used != checksum {
+			return nil, false, nil
+		}
+		blockSets = append(blockSets, blocks)
+
+		// Verify sync is not prematurely closing.
+		if s.isClosing() {
+			return blockSets, true, nil
+		}
+	}
+	return blockSets, true, nil
 }
 
 // syncBlock sends and receives all rows for a given block.
-// Returns an error if any remote hosts are unreachable.
-func (s *fragmentSyncer) syncBlock(id int) error {
+// Returns the number of bits set or cleared, or an error if any remote hosts
+// are unreachable.
+func (s *fragmentSyncer) syncBlock(id int) (int, error) {
 	span, ctx := tracing.StartSpanFromContext(context.Background(), "FragmentSyncer.syncBlock")
 	defer span.Finish()
 
@@ -2954,7 +3363,7 @@ func (s *fragmentSyncer) syncBlock(id int) error {
 
 		// Verify sync is not prematurely closing.
 		if s.isClosing() {
-			return nil
+			return 0, nil
 		}
 
 		uri := &node.URI
@@ -2963,7 +3372,7 @@ func (s *fragmentSyncer) syncBlock(id int) error {
 		// Only sync the standard block.
 		rowIDs, columnIDs, err := s.Cluster.InternalClient.BlockData(ctx, &node.URI, f.index, f.field, f.view, f.shard, id)
 		if err != nil {
-			return errors.Wrap(err, "getting block")
+			return 0, errors.Wrap(err, "getting block")
 		}
 
 		pairSets = append(pairSets, pairSet{
@@ -2974,13 +3383,13 @@ func (s *fragmentSyncer) syncBlock(id int) error {
 
 	// Verify sync is not prematurely closing.
 	if s.isClosing() {
-		return nil
+		return 0, nil
 	}
 
 	// Merge blocks together.
-	sets, clears, err := f.mergeBlock(id, pairSets)
+	sets, clears, n, err := f.mergeBlock(id, pairSets)
 	if err != nil {
-		return errors.Wrap(err, "merging")
+		return 0, errors.Wrap(err, "merging")
 	}
 
 	// Write updates to remote blocks.
@@ -2991,7 +3400,7 @@ func (s *fragmentSyncer) syncBlock(id int) error {
 		if len(set.columnIDs) > 0 {
 			setData, err := bitsToRoaringData(set)
 			if err != nil {
-				return errors.Wrap(err, "converting bits to roaring data (set)")
+				return n, errors.Wrap(err, "converting bits to roaring data (set)")
 			}
 
 			setReq := &ImportRoaringRequest{
@@ -3000,15 +3409,16 @@ func (s *fragmentSyncer) syncBlock(id int) error {
 			}
 
 			if err := s.Cluster.InternalClient.ImportRoaring(ctx, uris[i], f.index, f.field, f.shard, true, setReq); err != nil {
-				return errors.Wrap(err, "sending roaring data (set)")
+				return n, errors.Wrap(err, "sending roaring data (set)")
 			}
+			n += len(set.columnIDs)
 		}
 
 		// Handle Clears.
 		if len(clear.columnIDs) > 0 {
 			clearData, err := bitsToRoaringData(clear)
 			if err != nil {
-				return errors.Wrap(err, "converting bits to roaring data (clear)")
+				return n, errors.Wrap(err, "converting bits to roaring data (clear)")
 			}
 
 			clearReq := &ImportRoaringRequest{
@@ -3017,12 +3427,13 @@ func (s *fragmentSyncer) syncBlock(id int) error {
 			}
 
 			if err := s.Cluster.InternalClient.ImportRoaring(ctx, uris[i], f.index, f.field, f.shard, true, clearReq); err != nil {
-				return errors.Wrap(err, "sending roaring data (clear)")
+				return n, errors.Wrap(err, "sending roaring data (clear)")
 			}
+			n += len(clear.columnIDs)
 		}
 	}
 
-	return nil
+	return n, nil
 }
 
 // cleanViewName converts a viewname into the equivalent
@@ -795,7 +795,7 @@ func BenchmarkFragment_RepeatedSmallImports(b *testing.B) {
 							}
 							b.StartTimer()
 							for i := 0; i < numUpdates; i++ {
-								err := f.bulkImportStandard(
+								_, _, err := f.bulkImportStandard(
 									updateRows[bitsPerUpdate*i:bitsPerUpdate*(i+1)],
 									updateRows[bitsPerUpdate*i:bitsPerUpdate*(i+1)],
 									&ImportOptions{},
@@ -926,6 +926,99 @@ func TestFragment_Snapshot(t *testing.T) {
 	}
 }
 
+// Ensure a fragment can be compacted without changing its data.
+func TestFragment_Compact(t *testing.T) {
+	f := mustOpenFragment("i", "f", viewStandard, 0, "")
+	defer f.Clean(t)
+	f.generation = &generation{}
+
+	// Set a run of bits one at a time, so they are stored as an array
+	// container and logged as operations, and clear a sparse row.
+	for col := uint64(0); col < 1000; col++ {
+		if _, err := f.setBit(1, col); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := f.setBit(2, 1); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.clearBit(2, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	before, after, err := f.compact(0)
+	if err != nil {
+		t.Fatal(err)
+	} else if after >= before {
+		t.Fatalf("expected compaction to reclaim space, got %d bytes before and %d after", before, after)
+	} else if fi, err := os.Stat(f.path); err != nil {
+		t.Fatal(err)
+	} else if fi.Size() != after {
+		t.Fatalf("expected data file of %d bytes, got %d", after, fi.Size())
+	} else if n := f.row(1).Count(); n != 1000 {
+		t.Fatalf("unexpected count: %d", n)
+	}
+
+	// Writes after the compaction are kept.
+	if _, err := f.setBit(3, 5); err != nil {
+		t.Fatal(err)
+	}
+
+	// A fragment which compaction would not shrink enough is left as it is.
+	size := after
+	if before, after, err := f.compact(0.9); err != nil {
+		t.Fatal(err)
+	} else if before != after || before <= size {
+		t.Fatalf("expected fragment to be left as it is, got %d bytes before and %d after", before, after)
+	}
+
+	// Close and reopen the fragment & verify the data.
+	if err := f.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if n := f.row(1).Count(); n != 1000 {
+		t.Fatalf("unexpected count (reopen): %d", n)
+	} else if n := f.row(2).Count(); n != 0 {
+		t.Fatalf("unexpected count of cleared row (reopen): %d", n)
+	} else if n := f.row(3).Count(); n != 1 {
+		t.Fatalf("unexpected count of row written after compaction (reopen): %d", n)
+	}
+}
+
+// Ensure flushing only reports bytes for fragments written since the last flush.
+func TestFragment_Flush(t *testing.T) {
+	f := mustOpenFragment("i", "f", viewStandard, 0, "")
+	defer f.Clean(t)
+
+	if n, err := f.flush(true); err != nil {
+		t.Fatal(err)
+	} else if n != 0 {
+		t.Fatalf("expected clean fragment to flush nothing, got %d bytes", n)
+	}
+
+	if _, err := f.setBit(1000, 1); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := f.flush(true); err != nil {
+		t.Fatal(err)
+	} else if n <= 0 {
+		t.Fatalf("expected bytes to be flushed, got %d", n)
+	}
+	if n, err := f.flush(false); err != nil {
+		t.Fatal(err)
+	} else if n != 0 {
+		t.Fatalf("expected nothing to flush after flush, got %d bytes", n)
+	}
+
+	// A snapshot rewrites the data file.
+	if err := f.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := f.flush(false); err != nil {
+		t.Fatal(err)
+	} else if n <= 0 {
+		t.Fatalf("expected snapshot to be flushed, got %d", n)
+	}
+}
+
 // Ensure a fragment can iterate over all bits in order.
 func TestFragment_ForEachBit(t *testing.T) {
 	f := mustOpenFragment("i", "f", viewStandard, 0, "")
@@ -1269,6 +1362,52 @@ func TestFragment_Blocks_Empty(t *testing.T) {
 	}
 }
 
+// Ensure container checksums identify the same blocks as standard checksums
+// and change when a block is written to.
+func TestFragment_BlocksWithChecksum_Container(t *testing.T) {
+	f0 := mustOpenFragment("i", "f", viewStandard, 0, "")
+	defer f0.Clean(t)
+	f1 := mustOpenFragment("i", "f", viewStandard, 0, "")
+	defer f1.Clean(t)
+
+	for _, f := range []*fragment{f0, f1} {
+		for _, pos := range [][2]uint64{{0, 0}, {20, 100}, {150, 3}} {
+			if _, err := f.setBit(pos[0], pos[1]); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	standard, container := f0.Blocks(), f0.BlocksWithChecksum(ChecksumContainer)
+	if len(container) != len(standard) {
+		t.Fatalf("unexpected block count: %d, expected %d", len(container), len(standard))
+	}
+	for i := range container {
+		if container[i].ID != standard[i].ID {
+			t.Fatalf("unexpected block id: %d, expected %d", container[i].ID, standard[i].ID)
+		}
+	}
+	if other := f1.BlocksWithChecksum(ChecksumContainer); !reflect.DeepEqual(container, other) {
+		t.Fatalf("expected equal checksums for equal data: %v != %v", container, other)
+	}
+
+	// Writing to a block changes its checksum but not the others.
+	if _, err := f1.setBit(20, 101); err != nil {
+		t.Fatal(err)
+	}
+	other := f1.BlocksWithChecksum(ChecksumContainer)
+	if bytes.Equal(other[0].Checksum, container[0].Checksum) {
+		t.Fatalf("expected checksum to change: %x", other[0].Checksum)
+	} else if !bytes.Equal(other[1].Checksum, container[1].Checksum) {
+		t.Fatalf("unexpected checksum change: %x", other[1].Checksum)
+	}
+
+	// Unknown algorithms fall back to standard checksums.
+	if blocks := f0.BlocksWithChecksum("unknown"); !reflect.DeepEqual(blocks, standard) {
+		t.Fatalf("expected standard checksums: %v != %v", blocks, standard)
+	}
+}
+
 // Ensure a fragment's cache can be persisted between restarts.
 func TestFragment_LRUCache_Persistence(t *testing.T) {
 	f := mustOpenFragment("i", "f", viewStandard, 0, CacheTypeLRU)
@@ -1690,8 +1829,14 @@ func TestFragment_ConcurrentImport(t *testing.T) {
 		defer f.Clean(t)
 
 		eg := errgroup.Group{}
-		eg.Go(func() error { return f.bulkImportStandard([]uint64{1, 2}, []uint64{1, 2}, &ImportOptions{}) })
-		eg.Go(func() error { return f.bulkImportStandard([]uint64{3, 4}, []uint64{3, 4}, &ImportOptions{}) })
+		eg.Go(func() error {
+			_, _, err := f.bulkImportStandard([]uint64{1, 2}, []uint64{1, 2}, &ImportOptions{})
+			return err
+		})
+		eg.Go(func() error {
+			_, _, err := f.bulkImportStandard([]uint64{3, 4}, []uint64{3, 4}, &ImportOptions{})
+			return err
+		})
 		err := eg.Wait()
 		if err != nil {
 			t.Fatalf("importing data to fragment: %v", err)
@@ -1817,6 +1962,86 @@ func TestFragment_ImportMutex(t *testing.T) {
 	}
 }
 
+// Ensure a mutex import resolves conflicting values according to its policy.
+func TestFragment_ImportMutexConflictPolicy(t *testing.T) {
+	tests := []struct {
+		policy    ImportConflictPolicy
+		exp       map[uint64][]uint64
+		conflicts uint64
+		err       bool
+	}{
+		{
+			policy: "",
+			exp: map[uint64][]uint64{
+				1: {0},
+				2: {},
+				3: {1, 2},
+			},
+			conflicts: 2,
+		},
+		{
+			policy: ImportConflictLastWriteWins,
+			exp: map[uint64][]uint64{
+				1: {0},
+				2: {},
+				3: {1, 2},
+			},
+			conflicts: 2,
+		},
+		{
+			policy: ImportConflictFirstWriteWins,
+			exp: map[uint64][]uint64{
+				1: {0, 1},
+				2: {2},
+				3: {},
+			},
+			conflicts: 2,
+		},
+		{
+			policy: ImportConflictError,
+			exp: map[uint64][]uint64{
+				1: {0, 1},
+				2: {},
+				3: {},
+			},
+			conflicts: 2,
+			err:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.policy), func(t *testing.T) {
+			f := mustOpenMutexFragment("i", "f", viewStandard, 0, "")
+			defer f.Clean(t)
+
+			if err := f.bulkImport([]uint64{1, 1}, []uint64{0, 1}, &ImportOptions{}); err != nil {
+				t.Fatalf("bulk importing ids: %v", err)
+			}
+
+			// Column 1 conflicts with its existing value, column 2 is
+			// imported twice and column 0 is imported with its value.
+			var conflicts uint64
+			err := f.bulkImport([]uint64{3, 1, 2, 3}, []uint64{1, 0, 2, 2}, &ImportOptions{ConflictPolicy: test.policy, Conflicts: &conflicts})
+			if test.err {
+				if _, ok := errors.Cause(err).(ConflictError); !ok {
+					t.Fatalf("expected conflict error, got: %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("bulk importing ids: %v", err)
+			}
+			if conflicts != test.conflicts {
+				t.Fatalf("expected %d conflicts, got %d", test.conflicts, conflicts)
+			}
+
+			for k, v := range test.exp {
+				if cols := f.row(k).Columns(); !reflect.DeepEqual(cols, v) {
+					t.Fatalf("row: %d, expected: %v, but got: %v", k, v, cols)
+				}
+			}
+		})
+	}
+}
+
 // Ensure a fragment can import bool values.
 func TestFragment_ImportBool(t *testing.T) {
 	tests := []struct {
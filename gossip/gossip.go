@@ -266,10 +266,10 @@ func NewMemberSet(cfg Config, api *pilosa.API, options ...memberSetOption) (*mem
 	}
 	//
 	conf.TCPTimeout = time.Duration(cfg.StreamTimeout)
-	conf.SuspicionMult = cfg.SuspicionMult
 	conf.PushPullInterval = time.Duration(cfg.PushPullInterval)
-	conf.ProbeTimeout = time.Duration(cfg.ProbeTimeout)
-	conf.ProbeInterval = time.Duration(cfg.ProbeInterval)
+	if err := setProbeConfig(conf, cfg); err != nil {
+		return nil, errors.Wrap(err, "configuring probes")
+	}
 	conf.GossipNodes = cfg.Nodes
 	conf.GossipInterval = time.Duration(cfg.Interval)
 	conf.GossipToTheDeadTime = time.Duration(cfg.ToTheDeadTime)
@@ -291,6 +291,38 @@ func NewMemberSet(cfg Config, api *pilosa.API, options ...memberSetOption) (*mem
 	return g, nil
 }
 
+// setProbeConfig sets the probe interval and timeout and the suspicion
+// multiplier of conf from cfg. Settings which are unset keep memberlist's LAN
+// defaults. The probe timeout must be less than the probe interval, otherwise
+// a probe could still be outstanding when the next one is sent.
+func setProbeConfig(conf *memberlist.Config, cfg Config) error {
+	lan := memberlist.DefaultLANConfig()
+	conf.ProbeInterval = lan.ProbeInterval
+	conf.ProbeTimeout = lan.ProbeTimeout
+	conf.SuspicionMult = lan.SuspicionMult
+
+	if cfg.ProbeInterval < 0 {
+		return errors.New("probe interval must not be negative")
+	} else if cfg.ProbeInterval > 0 {
+		conf.ProbeInterval = time.Duration(cfg.ProbeInterval)
+	}
+	if cfg.ProbeTimeout < 0 {
+		return errors.New("probe timeout must not be negative")
+	} else if cfg.ProbeTimeout > 0 {
+		conf.ProbeTimeout = time.Duration(cfg.ProbeTimeout)
+	}
+	if cfg.SuspicionMult < 0 {
+		return errors.New("suspicion multiplier must not be negative")
+	} else if cfg.SuspicionMult > 0 {
+		conf.SuspicionMult = cfg.SuspicionMult
+	}
+
+	if conf.ProbeTimeout >= conf.ProbeInterval {
+		return fmt.Errorf("probe timeout (%s) must be less than probe interval (%s)", conf.ProbeTimeout, conf.ProbeInterval)
+	}
+	return nil
+}
+
 // NodeMeta implementation of the memberlist.Delegate interface.
 func (g *memberSet) NodeMeta(limit int) []byte {
 	buf, err := g.papi.Serializer.Marshal(g.papi.Node())
@@ -318,12 +350,22 @@ func (g *memberSet) GetBroadcasts(overhead, limit int) [][]byte {
 
 // LocalState implementation of the memberlist.Delegate interface
 // sends this Node's state data.
+//
+// The full schema is only sent when joining. Periodic state exchanges carry
+// the schema version vector instead, and the receiver pulls the schema
+// from this node when the vector shows changes it has missed.
 func (g *memberSet) LocalState(join bool) []byte {
+	schema := g.papi.Schema(context.Background())
 	m := &pilosa.NodeStatus{
-		Node:   g.papi.Node(),
-		Schema: &pilosa.Schema{Indexes: g.papi.Schema(context.Background())},
+		Node:           g.papi.Node(),
+		Schema:         &pilosa.Schema{},
+		SchemaVersions: g.papi.SchemaVersions(context.Background()),
+		Time:           time.Now().UnixNano(),
 	}
-	for _, idx := range m.Schema.Indexes {
+	if join {
+		m.Schema.Indexes = schema
+	}
+	for _, idx := range schema {
 		is := &pilosa.IndexStatus{Name: idx.Name}
 		for _, f := range idx.Fields {
 			availableShards := roaring.NewBitmap()
@@ -451,7 +493,7 @@ type Transport struct {
 // NewTransport returns a NetTransport based on the given host and port.
 // It will dynamically bind to a port if port is 0.
 // This is useful for test cases where specifying a port is not reasonable.
-//func NewTransport(host string, port int) (*memberlist.NetTransport, error) {
+// func NewTransport(host string, port int) (*memberlist.NetTransport, error) {
 func NewTransport(host string, port int, logger *log.Logger) (*Transport, error) {
 	// memberlist config
 	conf := memberlist.DefaultWANConfig()
@@ -570,7 +612,11 @@ type Config struct {
 	//
 	// ProbeTimeout is the timeout to wait for an ack from a probed node
 	// before assuming it is unhealthy. This should be set to 99-percentile
-	// of RTT (round-trip time) on your network.
+	// of RTT (round-trip time) on your network, and must be less than
+	// ProbeInterval.
+	//
+	// If ProbeInterval, ProbeTimeout or SuspicionMult are zero, memberlist's
+	// LAN defaults are used.
 	ProbeInterval toml.Duration `toml:"probe-interval"`
 	ProbeTimeout  toml.Duration `toml:"probe-timeout"`
 
@@ -593,6 +639,12 @@ type Config struct {
 	Interval      toml.Duration `toml:"interval"`
 	Nodes         int           `toml:"nodes"`
 	ToTheDeadTime toml.Duration `toml:"to-the-dead-time"`
+
+	// DeadRoutingDelay is how long a node which gossip reports dead keeps
+	// receiving queries before it is removed from routing, so that a node
+	// which pauses briefly does not cause queries to be rerouted. It is not
+	// passed to memberlist.
+	DeadRoutingDelay toml.Duration `toml:"dead-routing-delay"`
 }
 
 // hostToIP converts host to an IP4 address based on net.LookupIP().
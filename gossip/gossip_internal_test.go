@@ -0,0 +1,64 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gossip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+	"github.com/pilosa/pilosa/v2/toml"
+)
+
+func TestSetProbeConfig(t *testing.T) {
+	lan := memberlist.DefaultLANConfig()
+
+	t.Run("Defaults", func(t *testing.T) {
+		conf := memberlist.DefaultWANConfig()
+		if err := setProbeConfig(conf, Config{}); err != nil {
+			t.Fatal(err)
+		} else if conf.ProbeInterval != lan.ProbeInterval || conf.ProbeTimeout != lan.ProbeTimeout || conf.SuspicionMult != lan.SuspicionMult {
+			t.Fatalf("unexpected probe config: interval=%s timeout=%s mult=%d", conf.ProbeInterval, conf.ProbeTimeout, conf.SuspicionMult)
+		}
+	})
+
+	t.Run("Set", func(t *testing.T) {
+		conf := memberlist.DefaultWANConfig()
+		cfg := Config{
+			ProbeInterval: toml.Duration(5 * time.Second),
+			ProbeTimeout:  toml.Duration(2 * time.Second),
+			SuspicionMult: 8,
+		}
+		if err := setProbeConfig(conf, cfg); err != nil {
+			t.Fatal(err)
+		} else if conf.ProbeInterval != 5*time.Second || conf.ProbeTimeout != 2*time.Second || conf.SuspicionMult != 8 {
+			t.Fatalf("unexpected probe config: interval=%s timeout=%s mult=%d", conf.ProbeInterval, conf.ProbeTimeout, conf.SuspicionMult)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, cfg := range []Config{
+			{ProbeInterval: toml.Duration(time.Second), ProbeTimeout: toml.Duration(time.Second)},
+			{ProbeInterval: toml.Duration(time.Second), ProbeTimeout: toml.Duration(2 * time.Second)},
+			{ProbeTimeout: toml.Duration(2 * time.Second)},
+			{ProbeInterval: toml.Duration(-time.Second)},
+			{SuspicionMult: -1},
+		} {
+			if err := setProbeConfig(memberlist.DefaultWANConfig(), cfg); err == nil {
+				t.Errorf("expected error for %+v", cfg)
+			}
+		}
+	})
+}
@@ -42,6 +42,27 @@ type QueryRequest struct {
 	// If true, indicates that query is part of a larger distributed query.
 	// If false, this request is on the originating node.
 	Remote bool
+
+	// RemoteAddr is the address of the client which sent the query, if
+	// known. It is only used to describe running queries and is not sent
+	// to other nodes.
+	RemoteAddr string
+
+	// Limit is the maximum number of pairs or groups to return from a
+	// query made of a single TopN or GroupBy call. Zero returns them all.
+	Limit uint64
+
+	// Cursor is the cursor of the previous page of a paginated TopN or
+	// GroupBy result. Results up to and including it are skipped.
+	Cursor string
+
+	// AllowUnbounded runs the query even if safe mode would reject it.
+	AllowUnbounded bool
+
+	// MaxResultRows is the maximum number of rows or columns returned for
+	// each call of the query. It can only lower the limit configured on the
+	// server. Zero applies the server's limit.
+	MaxResultRows uint64
 }
 
 // QueryResponse represent a response from a processed query.
@@ -55,6 +76,32 @@ type QueryResponse struct {
 
 	// Error during parsing or execution.
 	Err error
+
+	// Page describes the page of a paginated query result.
+	Page *QueryPage
+
+	// Thresholds holds the minimum count applied by each TopN call, by the
+	// index of its result. It is zero for other calls, and nil unless a TopN
+	// call of the query sets a threshold.
+	Thresholds []uint64
+
+	// UnavailableShards lists the shards skipped under the partial results
+	// policy because no node could serve them. The results are partial if
+	// it is not empty.
+	UnavailableShards []uint64
+
+	// Truncated is true if the rows or columns of some result were cut down
+	// to the maximum number of result rows.
+	Truncated bool
+
+	// AutoCreated is true if an index or field written to by the query was
+	// auto-created.
+	AutoCreated bool
+}
+
+// Partial returns true if the results do not include every shard queried.
+func (resp *QueryResponse) Partial() bool {
+	return len(resp.UnavailableShards) > 0
 }
 
 // MarshalJSON marshals QueryResponse into a JSON-encoded byte slice
@@ -66,11 +113,23 @@ func (resp *QueryResponse) MarshalJSON() ([]byte, error) {
 	}
 
 	return json.Marshal(struct {
-		Results        []interface{}    `json:"results"`
-		ColumnAttrSets []*ColumnAttrSet `json:"columnAttrs,omitempty"`
+		Results           []interface{}    `json:"results"`
+		ColumnAttrSets    []*ColumnAttrSet `json:"columnAttrs,omitempty"`
+		Page              *QueryPage       `json:"page,omitempty"`
+		Thresholds        []uint64         `json:"thresholds,omitempty"`
+		Partial           bool             `json:"partial,omitempty"`
+		UnavailableShards []uint64         `json:"unavailableShards,omitempty"`
+		Truncated         bool             `json:"truncated,omitempty"`
+		AutoCreated       bool             `json:"autoCreated,omitempty"`
 	}{
-		Results:        resp.Results,
-		ColumnAttrSets: resp.ColumnAttrSets,
+		Results:           resp.Results,
+		ColumnAttrSets:    resp.ColumnAttrSets,
+		Page:              resp.Page,
+		Thresholds:        resp.Thresholds,
+		Partial:           resp.Partial(),
+		UnavailableShards: resp.UnavailableShards,
+		Truncated:         resp.Truncated,
+		AutoCreated:       resp.AutoCreated,
 	})
 }
 
@@ -105,6 +164,18 @@ type ImportValueRequest struct {
 	Values     []int64
 }
 
+// ImportBoolRequest describes the import request structure for a bool field
+// import. Values holds the value of each column; when it is empty, every
+// column is set to true.
+type ImportBoolRequest struct {
+	Index      string
+	Field      string
+	Shard      uint64
+	ColumnIDs  []uint64
+	ColumnKeys []string
+	Values     []bool
+}
+
 // ImportRequest describes the import request structure
 // for an import.
 type ImportRequest struct {
@@ -116,6 +187,10 @@ type ImportRequest struct {
 	RowKeys    []string
 	ColumnKeys []string
 	Timestamps []int64
+
+	// ColumnAttrs holds attributes to set on columns of the shard, by column
+	// ID or, if the index uses string keys, by column key.
+	ColumnAttrs []*ColumnAttrSet
 }
 
 // ImportRoaringRequest describes the import request structure
@@ -128,6 +203,26 @@ type ImportRoaringRequest struct {
 // ImportResponse is the structured response of an import.
 type ImportResponse struct {
 	Err string
+
+	// Bits and ColumnAttrs are the number of bits and column attribute sets
+	// imported.
+	Bits        uint64
+	ColumnAttrs uint64
+
+	// Conflicts is the number of columns of a mutex or bool field whose
+	// values conflicted. See ImportConflictPolicy.
+	Conflicts uint64
+
+	// Set and Cleared are the number of bits which the import changed.
+	Set     uint64
+	Cleared uint64
+
+	// Batches is the number of batches of a streaming import applied.
+	Batches uint64
+
+	// AutoCreated is true if the index or field of the import was
+	// auto-created.
+	AutoCreated bool
 }
 
 // BlockDataRequest describes the structure of a request
@@ -27,6 +27,8 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
+	"github.com/pilosa/pilosa/v2/internal"
 	"github.com/pilosa/pilosa/v2/logger"
 	"github.com/pilosa/pilosa/v2/roaring"
 	"github.com/pilosa/pilosa/v2/stats"
@@ -36,14 +38,29 @@ import (
 )
 
 const (
-	// defaultCacheFlushInterval is the default value for Fragment.CacheFlushInterval.
-	defaultCacheFlushInterval = 1 * time.Minute
+	// defaultFlushInterval is the default interval at which fragments are
+	// flushed to disk.
+	defaultFlushInterval = 1 * time.Minute
+
+	// DefaultDirPerm and DefaultFilePerm are the default modes with which
+	// directories and files are created in the data directory. Both are
+	// subject to the umask.
+	DefaultDirPerm  os.FileMode = 0777
+	DefaultFilePerm os.FileMode = 0666
+
+	// defaultPrivateFilePerm is the default mode of the files which only
+	// the node itself reads: its ID and its startup log.
+	defaultPrivateFilePerm os.FileMode = 0600
 
 	// fileLimit is the maximum open file limit (ulimit -n) to automatically set.
 	fileLimit = 262144 // (512^2)
 
 	// existenceFieldName is the name of the internal field used to store existence values.
 	existenceFieldName = "_exists"
+
+	// quarantineDir is the name of the directory, inside a view's fragments
+	// directory, to which unreadable fragments are moved.
+	quarantineDir = ".quarantine"
 )
 
 // Holder represents a container for indexes.
@@ -53,6 +70,18 @@ type Holder struct {
 	// Indexes by name.
 	indexes map[string]*Index
 
+	// Index names by alias.
+	aliases map[string]string
+
+	// schemaMu serializes the schema changes made through the API of this
+	// node, from the local change to its broadcast, so that renames exclude
+	// the creation and deletion of the objects they rename.
+	schemaMu sync.Mutex
+
+	// Schema version vector, see SchemaVersions.
+	schemaVersionsMu sync.Mutex
+	schemaVersions   map[string]uint64
+
 	// opened channel is closed once Open() completes.
 	opened lockedChan
 
@@ -70,13 +99,45 @@ type Holder struct {
 	// Data directory path.
 	Path string
 
-	// The interval at which the cached row ids are persisted to disk.
-	cacheFlushInterval time.Duration
+	// The interval at which dirty fragments, and the cached row ids of all
+	// fragments, are flushed to disk.
+	flushInterval time.Duration
+
+	// If set, fragment files are fsynced when they are flushed.
+	fsyncOnFlush bool
+
+	// The modes with which directories and files are created.
+	dirPerm         os.FileMode
+	filePerm        os.FileMode
+	privateFilePerm os.FileMode
+
+	// How long deleted indexes are kept as tombstones before they are
+	// removed, and how often expired tombstones are checked for. A zero
+	// grace period removes deleted indexes immediately.
+	tombstoneGracePeriod  time.Duration
+	tombstoneReapInterval time.Duration
+
+	// Fragments which compaction would shrink by at least the fraction
+	// compactionThreshold of their size are compacted every
+	// compactionInterval. A zero threshold disables automatic compaction.
+	compactionThreshold float64
+	compactionInterval  time.Duration
 
 	Logger logger.Logger
 
 	snapshotQueue chan *fragment
 
+	// If set, fragments which fail to open are quarantined instead of
+	// failing the open of the holder.
+	skipCorruptFragments bool
+	quarantine           *fragmentQuarantine
+
+	// If non-nil, the bits set and cleared on the node are published here.
+	changes *changeFeed
+
+	// Open import sessions, staged in the data directory.
+	importSessions *importSessions
+
 	// Manages replication from the primary node.
 	primaryTranslateNode     *Node
 	translateStoreReplicator *holderTranslateStoreReplicator
@@ -116,7 +177,10 @@ func (lc *lockedChan) Recv() {
 func NewHolder() *Holder {
 	return &Holder{
 		indexes: make(map[string]*Index),
-		closing: make(chan struct{}),
+		aliases: make(map[string]string),
+
+		schemaVersions: make(map[string]uint64),
+		closing:        make(chan struct{}),
 
 		opened: lockedChan{ch: make(chan struct{})},
 
@@ -125,11 +189,24 @@ func NewHolder() *Holder {
 
 		NewAttrStore: newNopAttrStore,
 
-		cacheFlushInterval: defaultCacheFlushInterval,
+		flushInterval: defaultFlushInterval,
+		fsyncOnFlush:  true,
+
+		dirPerm:         DefaultDirPerm,
+		filePerm:        DefaultFilePerm,
+		privateFilePerm: defaultPrivateFilePerm,
+
+		tombstoneReapInterval: defaultTombstoneReapInterval,
+
+		compactionInterval: defaultCompactionInterval,
 
 		Logger: logger.NopLogger,
 
 		OpenTranslateStore: OpenInMemTranslateStore,
+
+		quarantine: &fragmentQuarantine{},
+
+		importSessions: newImportSessions(),
 	}
 }
 
@@ -141,7 +218,7 @@ func (h *Holder) Open() error {
 	h.setFileLimit()
 
 	h.Logger.Printf("open holder path: %s", h.Path)
-	if err := os.MkdirAll(h.Path, 0777); err != nil {
+	if err := os.MkdirAll(h.Path, h.dirPerm); err != nil {
 		return errors.Wrap(err, "creating directory")
 	}
 
@@ -189,11 +266,31 @@ func (h *Holder) Open() error {
 		h.indexes[index.Name()] = index
 		h.mu.Unlock()
 	}
+
+	if err := h.loadAliases(); err != nil {
+		return errors.Wrap(err, "loading index aliases")
+	}
+	if err := h.loadSchemaVersions(); err != nil {
+		return errors.Wrap(err, "loading schema versions")
+	}
+	if err := h.importSessions.load(h); err != nil {
+		return errors.Wrap(err, "loading import sessions")
+	}
 	h.Logger.Printf("open holder: complete")
 
 	// Periodically flush cache.
 	h.wg.Add(1)
-	go func() { defer h.wg.Done(); h.monitorCacheFlush() }()
+	go func() { defer h.wg.Done(); h.monitorFlush() }()
+
+	// Periodically remove expired tombstones.
+	h.wg.Add(1)
+	go func() { defer h.wg.Done(); h.monitorTombstones() }()
+
+	// Periodically compact fragmented fragments.
+	if h.compactionThreshold > 0 {
+		h.wg.Add(1)
+		go func() { defer h.wg.Done(); h.monitorCompaction() }()
+	}
 
 	h.Stats.Open()
 
@@ -284,7 +381,11 @@ func (h *Holder) availableShardsByIndex() map[string]*roaring.Bitmap {
 func (h *Holder) Schema() []*IndexInfo {
 	var a []*IndexInfo
 	for _, index := range h.Indexes() {
-		di := &IndexInfo{Name: index.Name()}
+		di := &IndexInfo{
+			Name:       index.Name(),
+			Options:    index.Options(),
+			ShardWidth: ShardWidth,
+		}
 		for _, field := range index.Fields() {
 			fi := &FieldInfo{Name: field.Name(), Options: field.Options()}
 			for _, view := range field.views() {
@@ -323,16 +424,32 @@ func (h *Holder) limitedSchema() []*IndexInfo {
 	return a
 }
 
-// applySchema applies an internal Schema to Holder.
-func (h *Holder) applySchema(schema *Schema) error {
+// applySchema applies an internal Schema to Holder. If limits is not nil,
+// the schema is refused as a whole if its new indexes and fields would
+// exceed them, and each new index and field is checked before it is created.
+func (h *Holder) applySchema(schema *Schema, limits *schemaLimits) error {
+	if err := limits.checkSchema(h, schema); err != nil {
+		return NewBadRequestError(err)
+	}
+
 	// Create indexes that don't exist.
 	for _, index := range schema.Indexes {
+		if h.Index(index.Name) == nil {
+			if err := limits.checkIndex(h); err != nil {
+				return NewBadRequestError(err)
+			}
+		}
 		idx, err := h.CreateIndexIfNotExists(index.Name, index.Options)
 		if err != nil {
 			return errors.Wrap(err, "creating index")
 		}
 		// Create fields that don't exist.
 		for _, f := range index.Fields {
+			if idx.Field(f.Name) == nil {
+				if err := limits.checkField(idx); err != nil {
+					return NewBadRequestError(err)
+				}
+			}
 			field, err := idx.createFieldIfNotExists(f.Name, f.Options)
 			if err != nil {
 				return errors.Wrap(err, "creating field")
@@ -346,9 +463,77 @@ func (h *Holder) applySchema(schema *Schema) error {
 			}
 		}
 	}
+	// Point aliases at their indexes, now that they exist.
+	for alias, index := range schema.Aliases {
+		if err := h.SetIndexAlias(alias, index); err != nil {
+			return errors.Wrapf(err, "setting index alias %s", alias)
+		}
+	}
 	return nil
 }
 
+// applyFullSchema makes the local schema match schema. The indexes, fields
+// and aliases which are not in schema are deleted, indexes of schema which
+// were deleted locally are undeleted, the changeable options of indexes are
+// updated, and the rest is created as by applySchema.
+func (h *Holder) applyFullSchema(schema *Schema) error {
+	indexes := make(map[string]*IndexInfo, len(schema.Indexes))
+	for _, ii := range schema.Indexes {
+		indexes[ii.Name] = ii
+	}
+
+	for alias := range h.IndexAliases() {
+		if _, ok := schema.Aliases[alias]; !ok {
+			if err := h.DeleteIndexAlias(alias); err != nil {
+				return errors.Wrapf(err, "deleting alias %s", alias)
+			}
+		}
+	}
+	for _, idx := range h.Indexes() {
+		ii, ok := indexes[idx.Name()]
+		if !ok {
+			if err := h.DeleteIndex(idx.Name()); err != nil {
+				return errors.Wrapf(err, "deleting index %s", idx.Name())
+			}
+			continue
+		}
+
+		fields := make(map[string]struct{}, len(ii.Fields))
+		for _, fi := range ii.Fields {
+			fields[fi.Name] = struct{}{}
+		}
+		for _, f := range idx.Fields() {
+			if _, ok := fields[f.Name()]; !ok {
+				if err := idx.DeleteField(f.Name()); err != nil {
+					return errors.Wrapf(err, "deleting field %s of index %s", f.Name(), idx.Name())
+				}
+			}
+		}
+
+		opt := idx.Options()
+		if opt.TimeQuantum != ii.Options.TimeQuantum || opt.Compression != ii.Options.Compression {
+			opt.TimeQuantum, opt.Compression = ii.Options.TimeQuantum, ii.Options.Compression
+			if err := idx.setOptions(opt); err != nil {
+				return errors.Wrapf(err, "updating index %s", idx.Name())
+			}
+		}
+	}
+
+	tombstones, err := h.Tombstones()
+	if err != nil {
+		return errors.Wrap(err, "reading tombstones")
+	}
+	for _, t := range tombstones {
+		if _, ok := indexes[t.Index]; ok && h.Index(t.Index) == nil {
+			if _, err := h.UndeleteIndex(t.Index); err != nil {
+				return errors.Wrapf(err, "undeleting index %s", t.Index)
+			}
+		}
+	}
+
+	return h.applySchema(schema, nil)
+}
+
 // IndexPath returns the path where a given index is stored.
 func (h *Holder) IndexPath(name string) string { return filepath.Join(h.Path, name) }
 
@@ -361,6 +546,156 @@ func (h *Holder) Index(name string) *Index {
 
 func (h *Holder) index(name string) *Index { return h.indexes[name] }
 
+// placementName returns the name by which the shards of the named index are
+// placed on nodes: the name it was created with.
+func (h *Holder) placementName(name string) string {
+	if h == nil {
+		return name
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if index := h.index(name); index != nil && index.placement != "" {
+		return index.placement
+	}
+	return name
+}
+
+// IndexAliases returns a copy of the alias to index name mapping.
+func (h *Holder) IndexAliases() map[string]string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	m := make(map[string]string, len(h.aliases))
+	for alias, index := range h.aliases {
+		m[alias] = index
+	}
+	return m
+}
+
+// resolveIndexAlias returns the name of the index that name refers to. Index
+// names take precedence over aliases; names which are neither are returned
+// unchanged.
+func (h *Holder) resolveIndexAlias(name string) string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.index(name) != nil {
+		return name
+	}
+	if index, ok := h.aliases[name]; ok {
+		return index
+	}
+	return name
+}
+
+// SetIndexAlias points alias at the named index, replacing any previous
+// target. The alias may not share a name with an existing index.
+func (h *Holder) SetIndexAlias(alias, index string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := validateName(alias); err != nil {
+		return NewBadRequestError(err)
+	}
+	if h.index(alias) != nil {
+		return newConflictError(ErrIndexExists)
+	}
+	if h.index(index) == nil {
+		return newNotFoundError(ErrIndexNotFound, index)
+	}
+	if h.aliases[alias] == index {
+		return nil
+	}
+
+	prev, ok := h.aliases[alias]
+	h.aliases[alias] = index
+	if err := h.saveAliases(); err != nil {
+		if ok {
+			h.aliases[alias] = prev
+		} else {
+			delete(h.aliases, alias)
+		}
+		return errors.Wrap(err, "saving aliases")
+	}
+	return nil
+}
+
+// DeleteIndexAlias removes an alias. The index it pointed to is unaffected.
+func (h *Holder) DeleteIndexAlias(alias string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	index, ok := h.aliases[alias]
+	if !ok {
+		return newNotFoundError(ErrIndexAliasNotFound, alias)
+	}
+	delete(h.aliases, alias)
+	if err := h.saveAliases(); err != nil {
+		h.aliases[alias] = index
+		return errors.Wrap(err, "saving aliases")
+	}
+	return nil
+}
+
+// loadAliases reads the index aliases from the data directory.
+func (h *Holder) loadAliases() error {
+	var pb internal.IndexAliases
+
+	buf, err := ioutil.ReadFile(filepath.Join(h.Path, ".aliases"))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "reading")
+	} else if err := proto.Unmarshal(buf, &pb); err != nil {
+		return errors.Wrap(err, "unmarshalling")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.aliases = make(map[string]string, len(pb.Aliases))
+	for _, a := range pb.Aliases {
+		if h.index(a.Index) == nil {
+			h.Logger.Printf("dropping alias %s to missing index %s", a.Alias, a.Index)
+			continue
+		}
+		h.aliases[a.Alias] = a.Index
+	}
+	return nil
+}
+
+// saveAliases writes the index aliases to the data directory. The caller
+// must hold h.mu.
+func (h *Holder) saveAliases() error {
+	pb := &internal.IndexAliases{Aliases: make([]*internal.IndexAlias, 0, len(h.aliases))}
+	for alias, index := range h.aliases {
+		pb.Aliases = append(pb.Aliases, &internal.IndexAlias{Alias: alias, Index: index})
+	}
+	sort.Slice(pb.Aliases, func(i, j int) bool { return pb.Aliases[i].Alias < pb.Aliases[j].Alias })
+
+	buf, err := proto.Marshal(pb)
+	if err != nil {
+		return errors.Wrap(err, "marshalling")
+	}
+
+	// Replace the file with a complete copy, so that a crash cannot leave it
+	// truncated.
+	path := filepath.Join(h.Path, ".aliases")
+	f, err := os.OpenFile(path+".tmp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, h.filePerm)
+	if err != nil {
+		return errors.Wrap(err, "creating")
+	}
+	if _, err := f.Write(buf); err != nil {
+		f.Close()
+		return errors.Wrap(err, "writing")
+	} else if err := f.Sync(); err != nil {
+		f.Close()
+		return errors.Wrap(err, "syncing")
+	} else if err := f.Close(); err != nil {
+		return errors.Wrap(err, "closing")
+	} else if err := os.Rename(path+".tmp", path); err != nil {
+		return errors.Wrap(err, "renaming")
+	}
+	return nil
+}
+
 // Indexes returns a list of all indexes in the holder.
 func (h *Holder) Indexes() []*Index {
 	h.mu.RLock()
@@ -383,6 +718,8 @@ func (h *Holder) CreateIndex(name string, opt IndexOptions) (*Index, error) {
 	// Ensure index doesn't already exist.
 	if h.index(name) != nil {
 		return nil, newConflictError(ErrIndexExists)
+	} else if _, ok := h.aliases[name]; ok {
+		return nil, newConflictError(ErrIndexAliasExists)
 	}
 	return h.createIndex(name, opt)
 }
@@ -414,6 +751,10 @@ func (h *Holder) createIndex(name string, opt IndexOptions) (*Index, error) {
 
 	index.keys = opt.Keys
 	index.trackExistence = opt.TrackExistence
+	index.timeQuantum = opt.TimeQuantum
+	index.compression.store(opt.Compression)
+	index.placement = opt.PlacementName
+	index.durability = opt.Durability
 
 	if err = index.Open(); err != nil {
 		return nil, errors.Wrap(err, "opening")
@@ -440,8 +781,14 @@ func (h *Holder) newIndex(path, name string) (*Index, error) {
 	index.Stats = h.Stats.WithTags(fmt.Sprintf("index:%s", index.Name()))
 	index.broadcaster = h.broadcaster
 	index.newAttrStore = h.NewAttrStore
-	index.columnAttrs = h.NewAttrStore(filepath.Join(index.path, ".data"))
+	index.columnAttrs = &generationAttrStore{AttrStore: h.NewAttrStore(filepath.Join(index.path, ".data")), generation: index.generation}
 	index.snapshotQueue = h.snapshotQueue
+	index.dirPerm = h.dirPerm
+	index.filePerm = h.filePerm
+	if h.skipCorruptFragments {
+		index.quarantine = h.quarantine
+	}
+	index.changes = h.changes
 	index.holder = h
 	index.OpenTranslateStore = h.OpenTranslateStore
 	return index, nil
@@ -463,14 +810,32 @@ func (h *Holder) DeleteIndex(name string) error {
 		return errors.Wrap(err, "closing")
 	}
 
-	// Delete index directory.
-	if err := os.RemoveAll(h.IndexPath(name)); err != nil {
+	// Delete index directory, or keep it as a tombstone.
+	if h.tombstoneGracePeriod > 0 {
+		if err := h.tombstoneIndex(name); err != nil {
+			return errors.Wrap(err, "tombstoning index")
+		}
+	} else if err := os.RemoveAll(h.IndexPath(name)); err != nil {
 		return errors.Wrap(err, "removing directory")
 	}
 
 	// Remove reference.
 	delete(h.indexes, name)
 
+	// Remove any aliases which pointed to the index.
+	var removed bool
+	for alias, index := range h.aliases {
+		if index == name {
+			delete(h.aliases, alias)
+			removed = true
+		}
+	}
+	if removed {
+		if err := h.saveAliases(); err != nil {
+			return errors.Wrap(err, "saving aliases")
+		}
+	}
+
 	return nil
 }
 
@@ -501,10 +866,62 @@ func (h *Holder) fragment(index, field, view string, shard uint64) *fragment {
 	return v.Fragment(shard)
 }
 
-// monitorCacheFlush periodically flushes all fragment caches sequentially.
+// QuarantinedFragments returns the fragments which were moved aside because
+// they could not be opened.
+func (h *Holder) QuarantinedFragments() []QuarantinedFragment {
+	return h.quarantine.list()
+}
+
+// QuarantinedFragment describes a fragment which failed to open and was moved
+// into quarantine.
+type QuarantinedFragment struct {
+	Index string    `json:"index"`
+	Field string    `json:"field"`
+	View  string    `json:"view"`
+	Shard uint64    `json:"shard"`
+	Path  string    `json:"path"`
+	Error string    `json:"error"`
+	Time  time.Time `json:"time"`
+}
+
+// fragmentQuarantine records quarantined fragments. It is shared by all
+// views of a holder.
+type fragmentQuarantine struct {
+	mu        sync.Mutex
+	fragments []QuarantinedFragment
+}
+
+func (q *fragmentQuarantine) add(f QuarantinedFragment) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.fragments = append(q.fragments, f)
+}
+
+// list returns a copy of the quarantined fragments sorted by index, field,
+// view, and shard.
+func (q *fragmentQuarantine) list() []QuarantinedFragment {
+	q.mu.Lock()
+	a := make([]QuarantinedFragment, len(q.fragments))
+	copy(a, q.fragments)
+	q.mu.Unlock()
+
+	sort.Slice(a, func(i, j int) bool {
+		if a[i].Index != a[j].Index {
+			return a[i].Index < a[j].Index
+		} else if a[i].Field != a[j].Field {
+			return a[i].Field < a[j].Field
+		} else if a[i].View != a[j].View {
+			return a[i].View < a[j].View
+		}
+		return a[i].Shard < a[j].Shard
+	})
+	return a
+}
+
+// monitorFlush periodically flushes all fragments sequentially.
 // This is run in a goroutine.
-func (h *Holder) monitorCacheFlush() {
-	ticker := time.NewTicker(h.cacheFlushInterval)
+func (h *Holder) monitorFlush() {
+	ticker := time.NewTicker(h.flushInterval)
 	defer ticker.Stop()
 
 	for {
@@ -512,29 +929,49 @@ func (h *Holder) monitorCacheFlush() {
 		case <-h.closing:
 			return
 		case <-ticker.C:
-			h.flushCaches()
+			h.flush()
 		}
 	}
 }
 
-func (h *Holder) flushCaches() {
+// flush flushes every fragment and records how long it took and how many
+// bytes were flushed.
+func (h *Holder) flush() {
+	_ = h.flushContext(context.Background())
+}
+
+// flushContext is flush, stopping before the next fragment once ctx is done,
+// in which case it returns the error of ctx.
+func (h *Holder) flushContext(ctx context.Context) error {
+	start := time.Now()
+	var total int64
+	defer func() {
+		h.Stats.Timing("flush", time.Since(start), 1.0)
+		h.Stats.Count("flushBytes", total, 1.0)
+	}()
+
 	for _, index := range h.Indexes() {
 		for _, field := range index.Fields() {
 			for _, view := range field.views() {
 				for _, fragment := range view.allFragments() {
 					select {
 					case <-h.closing:
-						return
+						return nil
+					case <-ctx.Done():
+						return ctx.Err()
 					default:
 					}
 
-					if err := fragment.FlushCache(); err != nil {
-						h.Logger.Printf("ERROR flushing cache: err=%s, path=%s", err, fragment.cachePath())
+					n, err := fragment.flush(h.fsyncOnFlush)
+					if err != nil {
+						h.Logger.Printf("ERROR flushing fragment: err=%s, path=%s", err, fragment.path)
 					}
+					total += n
 				}
 			}
 		}
 	}
+	return nil
 }
 
 // recalculateCaches recalculates caches on every index in the holder. This is
@@ -599,7 +1036,7 @@ func (h *Holder) setFileLimit() {
 func (h *Holder) loadNodeID() (string, error) {
 	idPath := path.Join(h.Path, ".id")
 	h.Logger.Printf("load NodeID: %s", idPath)
-	if err := os.MkdirAll(h.Path, 0777); err != nil {
+	if err := os.MkdirAll(h.Path, h.dirPerm); err != nil {
 		return "", errors.Wrap(err, "creating directory")
 	}
 
@@ -611,7 +1048,7 @@ func (h *Holder) loadNodeID() (string, error) {
 		return "", errors.Wrap(err, "reading file")
 	}
 	nodeID := uuid.NewV4().String()
-	err = ioutil.WriteFile(idPath, []byte(nodeID), 0600)
+	err = ioutil.WriteFile(idPath, []byte(nodeID), h.privateFilePerm)
 	if err != nil {
 		return "", errors.Wrap(err, "writing file")
 	}
@@ -626,7 +1063,7 @@ func (h *Holder) logStartup() error {
 	}
 	logLine := fmt.Sprintf("%s\t%s\n", time, Version)
 
-	f, err := os.OpenFile(h.Path+"/.startup.log", os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
+	f, err := os.OpenFile(h.Path+"/.startup.log", os.O_APPEND|os.O_WRONLY|os.O_CREATE, h.privateFilePerm)
 	if err != nil {
 		return errors.Wrap(err, "opening startup log")
 	}
@@ -887,9 +1324,18 @@ type holderSyncer struct {
 	Node    *Node
 	Cluster *cluster
 
+	// Checksum is the algorithm of the block checksums compared with
+	// the replicas of each fragment.
+	Checksum string
+
 	// Stats
 	Stats stats.StatsClient
 
+	// Queue bounds the number of syncs which are running or waiting to
+	// run. Syncs which do not fit are rescheduled. If nil, syncs are not
+	// bounded.
+	Queue *syncQueue
+
 	// Signals that the sync should stop.
 	Closing <-chan struct{}
 }
@@ -909,56 +1355,95 @@ func (s *holderSyncer) IsClosing() bool {
 
 // SyncHolder compares the holder on host with the local holder and resolves differences.
 func (s *holderSyncer) SyncHolder() error {
+	_, err := s.syncHolder("", nil, false)
+	return err
+}
+
+// syncHolder syncs index, or every index if index is empty, and returns the
+// number of bits reconciled. If shards is not empty only those shards are
+// synced and attributes are left alone. If primaryOnly is set, shards for
+// which this node is not the first available owner are skipped so that a
+// sync which runs on every node only syncs each shard once, by its primary
+// owner or, if that is down, by the next owner which is not.
+//
+// If the queue is full, the sync is rescheduled and ErrAntiEntropyQueueFull
+// is returned.
+func (s *holderSyncer) syncHolder(index string, shards []uint64, primaryOnly bool) (int, error) {
+	if s.Queue != nil {
+		if !s.Queue.acquire(s.Closing) {
+			s.Queue.reschedule(index, shards, primaryOnly)
+			return 0, ErrAntiEntropyQueueFull
+		}
+		defer s.Queue.release()
+	}
+
 	s.mu.Lock() // only allow one instance of SyncHolder to be running at a time
 	defer s.mu.Unlock()
+	var total int
 	ti := time.Now()
 	// Iterate over schema in sorted order.
 	for _, di := range s.Holder.Schema() {
+		if index != "" && di.Name != index {
+			continue
+		}
+
 		// Verify syncer has not closed.
 		if s.IsClosing() {
-			return nil
+			return total, nil
 		}
 
 		// Sync index column attributes.
-		if err := s.syncIndex(di.Name); err != nil {
-			return fmt.Errorf("index sync error: index=%s, err=%s", di.Name, err)
+		if len(shards) == 0 {
+			if err := s.syncIndex(di.Name); err != nil {
+				return total, fmt.Errorf("index sync error: index=%s, err=%s", di.Name, err)
+			}
 		}
 
 		tf := time.Now()
 		for _, fi := range di.Fields {
 			// Verify syncer has not closed.
 			if s.IsClosing() {
-				return nil
+				return total, nil
 			}
 
 			// Sync field row attributes.
-			if err := s.syncField(di.Name, fi.Name); err != nil {
-				return fmt.Errorf("field sync error: index=%s, field=%s, err=%s", di.Name, fi.Name, err)
+			if len(shards) == 0 {
+				if err := s.syncField(di.Name, fi.Name); err != nil {
+					return total, fmt.Errorf("field sync error: index=%s, field=%s, err=%s", di.Name, fi.Name, err)
+				}
 			}
 
 			for _, vi := range fi.Views {
 				// Verify syncer has not closed.
 				if s.IsClosing() {
-					return nil
+					return total, nil
 				}
 
-				itr := s.Holder.Index(di.Name).AvailableShards().Iterator()
-				itr.Seek(0)
-				for shard, eof := itr.Next(); !eof; shard, eof = itr.Next() {
+				viewShards := shards
+				if len(viewShards) == 0 {
+					viewShards = s.Holder.Index(di.Name).AvailableShards().Slice()
+				}
+				for _, shard := range viewShards {
 					// Ignore shards that this host doesn't own.
-					if !s.Cluster.ownsShard(s.Node.ID, di.Name, shard) {
+					if primaryOnly {
+						if !s.isFirstAvailableOwner(di.Name, shard) {
+							continue
+						}
+					} else if !s.Cluster.ownsShard(s.Node.ID, di.Name, shard) {
 						continue
 					}
 
 					// Verify syncer has not closed.
 					if s.IsClosing() {
-						return nil
+						return total, nil
 					}
 
 					// Sync fragment if own it.
-					if err := s.syncFragment(di.Name, fi.Name, vi.Name, shard); err != nil {
-						return fmt.Errorf("fragment sync error: index=%s, field=%s, view=%s, shard=%d, err=%s", di.Name, fi.Name, vi.Name, shard, err)
+					n, err := s.syncFragment(di.Name, fi.Name, vi.Name, shard)
+					if err != nil {
+						return total, fmt.Errorf("fragment sync error: index=%s, field=%s, view=%s, shard=%d, err=%s", di.Name, fi.Name, vi.Name, shard, err)
 					}
+					total += n
 				}
 			}
 			s.Stats.Histogram("syncField", float64(time.Since(tf)), 1.0)
@@ -968,7 +1453,14 @@ func (s *holderSyncer) SyncHolder() error {
 		ti = time.Now() // reset ti
 	}
 
-	return nil
+	return total, nil
+}
+
+// isFirstAvailableOwner returns true if the local node is the first owner
+// of shard which is available.
+func (s *holderSyncer) isFirstAvailableOwner(index string, shard uint64) bool {
+	node := s.Cluster.availableShardOwner(index, shard)
+	return node != nil && node.ID == s.Node.ID
 }
 
 // syncIndex synchronizes index attributes with the rest of the cluster.
@@ -1067,23 +1559,23 @@ func (s *holderSyncer) syncField(index, name string) error {
 }
 
 // syncFragment synchronizes a fragment with the rest of the cluster.
-func (s *holderSyncer) syncFragment(index, field, view string, shard uint64) error {
+func (s *holderSyncer) syncFragment(index, field, view string, shard uint64) (int, error) {
 	// Retrieve local field.
 	f := s.Holder.Field(index, field)
 	if f == nil {
-		return newNotFoundError(ErrFieldNotFound, field)
+		return 0, newNotFoundError(ErrFieldNotFound, field)
 	}
 
 	// Ensure view exists locally.
 	v, err := f.createViewIfNotExists(view)
 	if err != nil {
-		return errors.Wrap(err, "creating view")
+		return 0, errors.Wrap(err, "creating view")
 	}
 
 	// Ensure fragment exists locally.
 	frag, err := v.CreateFragmentIfNotExists(shard)
 	if err != nil {
-		return errors.Wrap(err, "creating fragment")
+		return 0, errors.Wrap(err, "creating fragment")
 	}
 
 	// Sync fragments together.
@@ -1091,13 +1583,15 @@ func (s *holderSyncer) syncFragment(index, field, view string, shard uint64) err
 		Fragment: frag,
 		Node:     s.Node,
 		Cluster:  s.Cluster,
+		Checksum: s.Checksum,
 		Closing:  s.Closing,
 	}
-	if err := fs.syncFragment(); err != nil {
-		return errors.Wrap(err, "syncing fragment")
+	n, err := fs.syncFragment()
+	if err != nil {
+		return n, errors.Wrap(err, "syncing fragment")
 	}
 
-	return nil
+	return n, nil
 }
 
 // holderCleaner removes fragments and data files that are no longer used.
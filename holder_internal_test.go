@@ -15,14 +15,17 @@
 package pilosa
 
 import (
+	"bytes"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pilosa/pilosa/v2/roaring"
+	"github.com/pkg/errors"
 )
 
 type tHolder struct {
@@ -178,6 +181,57 @@ func TestHolder_Optn(t *testing.T) {
 		}
 	})
 
+	t.Run("SkipCorruptFragments", func(t *testing.T) {
+		h := newHolder()
+		defer h.Close()
+
+		if idx, err := h.CreateIndex("foo", IndexOptions{}); err != nil {
+			t.Fatal(err)
+		} else if field, err := idx.CreateField("bar", OptFieldTypeDefault()); err != nil {
+			t.Fatal(err)
+		} else if _, err := field.SetBit(0, 0, nil); err != nil {
+			t.Fatal(err)
+		} else if _, err := field.SetBit(0, ShardWidth, nil); err != nil {
+			t.Fatal(err)
+		} else if err := h.Holder.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		fragPath := filepath.Join(h.Path, "foo", "bar", "views", "standard", "fragments", "1")
+		if err := os.Truncate(fragPath, 2); err != nil {
+			t.Fatal(err)
+		}
+
+		path, logger := h.Path, h.Holder.Logger
+		h.Holder = NewHolder()
+		h.Holder.Path = path
+		h.Holder.Logger = logger
+		h.Holder.skipCorruptFragments = true
+		if err := h.Holder.Open(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if h.fragment("foo", "bar", viewStandard, 0) == nil {
+			t.Fatal("expected fragment 0 to be opened")
+		} else if h.fragment("foo", "bar", viewStandard, 1) != nil {
+			t.Fatal("expected fragment 1 to be skipped")
+		}
+
+		frags := h.QuarantinedFragments()
+		if len(frags) != 1 {
+			t.Fatalf("unexpected quarantined fragments: %+v", frags)
+		} else if f := frags[0]; f.Index != "foo" || f.Field != "bar" || f.View != viewStandard || f.Shard != 1 {
+			t.Fatalf("unexpected quarantined fragment: %+v", f)
+		} else if !strings.Contains(f.Error, "unmarshal storage") {
+			t.Fatalf("unexpected quarantine error: %s", f.Error)
+		}
+
+		if _, err := os.Stat(frags[0].Path); err != nil {
+			t.Fatalf("expected quarantined file: %s", err)
+		} else if _, err := os.Stat(fragPath); !os.IsNotExist(err) {
+			t.Fatalf("expected fragment file to be moved, got: %v", err)
+		}
+	})
 }
 
 // Ensure holder can clean up orphaned fragments.
@@ -321,3 +375,261 @@ func TestHolderCleaner_Reopen(t *testing.T) {
 		t.Fatalf("couldn't close holder: %v", err)
 	}
 }
+
+func TestHolder_ReapTombstones(t *testing.T) {
+	h := newHolder()
+	h.tombstoneGracePeriod = time.Hour
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	for _, name := range []string{"a", "b"} {
+		if _, err := h.CreateIndex(name, IndexOptions{}); err != nil {
+			t.Fatal(err)
+		} else if err := h.DeleteIndex(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Nothing has expired yet.
+	if err := h.reapTombstones(time.Now()); err != nil {
+		t.Fatal(err)
+	} else if tombstones, err := h.Tombstones(); err != nil {
+		t.Fatal(err)
+	} else if len(tombstones) != 2 {
+		t.Fatalf("unexpected tombstones: %+v", tombstones)
+	}
+
+	if _, err := h.UndeleteIndex("a"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Expired tombstones are removed.
+	if err := h.reapTombstones(time.Now().Add(2 * time.Hour)); err != nil {
+		t.Fatal(err)
+	} else if tombstones, err := h.Tombstones(); err != nil {
+		t.Fatal(err)
+	} else if len(tombstones) != 0 {
+		t.Fatalf("unexpected tombstones: %+v", tombstones)
+	}
+	if _, err := h.UndeleteIndex("b"); errors.Cause(err) != ErrIndexTombstoneNotFound {
+		t.Fatalf("expected tombstone not found, got: %v", err)
+	}
+	if h.Index("a") == nil {
+		t.Fatal("expected undeleted index to remain")
+	}
+}
+
+// Ensure an index which fails to open on undelete stays tombstoned.
+func TestHolder_UndeleteIndex_OpenError(t *testing.T) {
+	h := newHolder()
+	h.tombstoneGracePeriod = time.Hour
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	if _, err := h.CreateIndex("i", IndexOptions{}); err != nil {
+		t.Fatal(err)
+	} else if err := h.DeleteIndex("i"); err != nil {
+		t.Fatal(err)
+	}
+	tombstones, err := h.Tombstones()
+	if err != nil {
+		t.Fatal(err)
+	} else if len(tombstones) != 1 {
+		t.Fatalf("unexpected tombstones: %+v", tombstones)
+	}
+
+	// Corrupt the meta file so that the index cannot be opened.
+	if err := ioutil.WriteFile(filepath.Join(tombstones[0].path, ".meta"), []byte("not protobuf"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.UndeleteIndex("i"); err == nil || !strings.Contains(err.Error(), "opening") {
+		t.Fatalf("expected open error, got: %v", err)
+	} else if h.Index("i") != nil {
+		t.Fatal("expected index to stay deleted")
+	}
+	if tombstones, err := h.Tombstones(); err != nil {
+		t.Fatal(err)
+	} else if len(tombstones) != 1 || tombstones[0].Index != "i" {
+		t.Fatalf("expected index to stay tombstoned: %+v", tombstones)
+	} else if _, err := os.Stat(h.IndexPath("i")); !os.IsNotExist(err) {
+		t.Fatalf("expected index directory to be moved back, got: %v", err)
+	}
+}
+
+func TestHolder_SchemaVersions(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	if v, err := h.bumpSchemaVersion("n0"); err != nil {
+		t.Fatal(err)
+	} else if v != 1 {
+		t.Fatalf("unexpected version: %d", v)
+	} else if v, err := h.bumpSchemaVersion("n0"); err != nil {
+		t.Fatal(err)
+	} else if v != 2 {
+		t.Fatalf("unexpected version: %d", v)
+	}
+
+	// A received change only advances the version if no earlier change
+	// through the node was missed.
+	if err := h.advanceSchemaVersion("n2", 2); err != nil {
+		t.Fatal(err)
+	} else if err := h.advanceSchemaVersion("n3", 1); err != nil {
+		t.Fatal(err)
+	} else if vv := h.SchemaVersions(); vv["n2"] != 0 || vv["n3"] != 1 {
+		t.Fatalf("unexpected schema versions: %v", vv)
+	}
+
+	remote := map[string]uint64{"n0": 1, "n1": 3}
+	if !newerSchemaVersions(h.SchemaVersions(), remote) {
+		t.Fatal("expected remote versions to be newer")
+	}
+	if err := h.mergeSchemaVersions(remote); err != nil {
+		t.Fatal(err)
+	}
+	if newerSchemaVersions(h.SchemaVersions(), remote) {
+		t.Fatal("expected merged versions to be up to date")
+	}
+
+	// Versions are persisted across restarts.
+	if err := h.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+	if vv := h.SchemaVersions(); !reflect.DeepEqual(vv, map[string]uint64{"n0": 2, "n1": 3, "n3": 1}) {
+		t.Fatalf("unexpected schema versions: %v", vv)
+	}
+}
+
+// Ensure a full schema replaces the local one, including deletes, aliases,
+// option changes and undeletes.
+func TestHolder_ApplyFullSchema(t *testing.T) {
+	h := newHolder()
+	h.tombstoneGracePeriod = time.Hour
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	for _, name := range []string{"a", "b", "c"} {
+		idx, err := h.CreateIndex(name, IndexOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, field := range []string{"f", "g"} {
+			if _, err := idx.CreateField(field); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := h.SetIndexAlias("x", "a"); err != nil {
+		t.Fatal(err)
+	} else if err := h.SetIndexAlias("y", "b"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The remote schema has index b deleted with its alias, field g of a
+	// deleted, the time quantum of a changed, and c undeleted.
+	remote := &Schema{Aliases: map[string]string{"x": "a"}}
+	for _, ii := range h.Schema() {
+		switch ii.Name {
+		case "a":
+			ii.Options.TimeQuantum = TimeQuantum("YM")
+			ii.Fields = ii.Fields[:1]
+			remote.Indexes = append(remote.Indexes, ii)
+		case "c":
+			remote.Indexes = append(remote.Indexes, ii)
+		}
+	}
+	if err := h.DeleteIndex("c"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := h.applyFullSchema(remote); err != nil {
+		t.Fatal(err)
+	}
+	if h.Index("b") != nil {
+		t.Fatal("expected index b to be deleted")
+	} else if h.Index("c") == nil {
+		t.Fatal("expected index c to be undeleted")
+	} else if tombstones, err := h.Tombstones(); err != nil {
+		t.Fatal(err)
+	} else if len(tombstones) != 1 || tombstones[0].Index != "b" {
+		t.Fatalf("unexpected tombstones: %+v", tombstones)
+	}
+	if a := h.Index("a"); a.Field("g") != nil {
+		t.Fatal("expected field g of a to be deleted")
+	} else if a.Field("f") == nil {
+		t.Fatal("expected field f of a to remain")
+	} else if q := a.Options().TimeQuantum; q != "YM" {
+		t.Fatalf("unexpected time quantum: %s", q)
+	}
+	if aliases := h.IndexAliases(); !reflect.DeepEqual(aliases, map[string]string{"x": "a"}) {
+		t.Fatalf("unexpected aliases: %v", aliases)
+	}
+	if !sameSchema(&Schema{Indexes: h.Schema(), Aliases: h.IndexAliases()}, remote) {
+		t.Fatal("expected local schema to match remote schema")
+	}
+}
+
+func TestHolder_Perm(t *testing.T) {
+	h := newHolder()
+	h.dirPerm, h.filePerm, h.privateFilePerm = 0750, 0640, 0640
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	h.SetBit("i", "f", 1, 1)
+	if err := h.SetIndexAlias("a", "i"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.bumpSchemaVersion("n0"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Snapshots and restores from an archive replace the data file.
+	frag := h.fragment("i", "f", viewStandard, 0)
+	if err := frag.protectedSnapshot(false); err != nil {
+		t.Fatal(err)
+	} else if info, err := os.Stat(frag.path); err != nil {
+		t.Fatal(err)
+	} else if mode := info.Mode().Perm(); mode != 0640 {
+		t.Fatalf("unexpected mode of snapshot: %#o", mode)
+	}
+	var buf bytes.Buffer
+	if _, err := frag.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	} else if _, err := frag.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var files int
+	if err := filepath.Walk(h.Path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == h.Path {
+			return nil // created by the test
+		} else if info.IsDir() {
+			if mode := info.Mode().Perm(); mode != 0750 {
+				t.Errorf("unexpected mode of directory %s: %#o", path, mode)
+			}
+		} else if mode := info.Mode().Perm(); mode != 0640 {
+			t.Errorf("unexpected mode of file %s: %#o", path, mode)
+		} else {
+			files++
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	} else if files == 0 {
+		t.Fatal("expected files to be created")
+	}
+}
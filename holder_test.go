@@ -17,6 +17,7 @@ package pilosa_test
 import (
 	"bytes"
 	"context"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -298,6 +299,38 @@ func TestHolder_DeleteIndex(t *testing.T) {
 	}
 }
 
+// Ensure holder persists index aliases and drops them with their index.
+func TestHolder_IndexAlias(t *testing.T) {
+	hldr := test.MustOpenHolder()
+	defer hldr.Close()
+
+	hldr.MustCreateIndexIfNotExists("i0", pilosa.IndexOptions{})
+	hldr.MustCreateIndexIfNotExists("i1", pilosa.IndexOptions{})
+	if err := hldr.SetIndexAlias("a0", "i0"); err != nil {
+		t.Fatal(err)
+	} else if err := hldr.SetIndexAlias("a1", "i1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A write interrupted before it is renamed into place leaves the
+	// aliases as they were.
+	if err := hldr.Holder.Close(); err != nil {
+		t.Fatal(err)
+	} else if err := ioutil.WriteFile(filepath.Join(hldr.Path, ".aliases.tmp"), []byte{0x0a}, 0666); err != nil {
+		t.Fatal(err)
+	} else if err := hldr.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if aliases := hldr.IndexAliases(); !reflect.DeepEqual(aliases, map[string]string{"a0": "i0", "a1": "i1"}) {
+		t.Fatalf("unexpected aliases after reopen: %v", aliases)
+	}
+
+	if err := hldr.DeleteIndex("i0"); err != nil {
+		t.Fatal(err)
+	} else if aliases := hldr.IndexAliases(); !reflect.DeepEqual(aliases, map[string]string{"a1": "i1"}) {
+		t.Fatalf("unexpected aliases after delete: %v", aliases)
+	}
+}
+
 // Ensure holder can sync with a remote holder.
 func TestHolderSyncer_SyncHolder(t *testing.T) {
 	c := test.MustNewCluster(t, 2)
@@ -0,0 +1,92 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/pilosa/pilosa/v2"
+	"github.com/pkg/errors"
+)
+
+// resyncResponse is the body of a response, or the last line of a stream,
+// telling the client that the changes it asked for are no longer held.
+type resyncResponse struct {
+	Resync struct {
+		Earliest uint64 `json:"earliest"`
+	} `json:"resync"`
+}
+
+// handleGetChanges handles GET /changes requests. The bits set and cleared on
+// the node are streamed as they are applied, as newline-delimited JSON, from
+// the event with sequence from, until the client disconnects. If the events
+// requested are no longer held, the response is a 410 with the earliest
+// sequence still held; if the client falls behind while streaming, the same
+// object is written as the last line instead.
+func (h *Handler) handleGetChanges(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var from uint64
+	if s := q.Get("from"); s != "" {
+		var err error
+		if from, err = strconv.ParseUint(s, 10, 64); err != nil {
+			http.Error(w, "from should be an unsigned integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	var started bool
+	err := h.api.Changes(r.Context(), from, q.Get("index"), q.Get("field"), func(events []pilosa.ChangeEvent) error {
+		if !started {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			started = true
+		}
+		for _, e := range events {
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+		if err := bw.Flush(); err != nil {
+			return err
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		return nil
+	})
+
+	resync, isResync := errors.Cause(err).(pilosa.ResyncRequiredError)
+	var body resyncResponse
+	body.Resync.Earliest = resync.Earliest
+	switch {
+	case err == nil:
+	case !started && isResync:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusGone)
+		_ = json.NewEncoder(w).Encode(body)
+	case !started:
+		resp := successResponse{h: h}
+		resp.write(w, err)
+	case isResync:
+		_ = enc.Encode(body)
+		_ = bw.Flush()
+	default:
+		h.logger.Printf("streaming changes: %s", err)
+	}
+}
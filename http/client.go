@@ -26,6 +26,8 @@ import (
 	"net/url"
 	"sort"
 	"strconv"
+	"strings"
+	"sync/atomic"
 
 	"github.com/pilosa/pilosa/v2"
 	"github.com/pilosa/pilosa/v2/encoding/proto"
@@ -40,6 +42,20 @@ type InternalClient struct {
 
 	// The client to use for HTTP communication.
 	httpClient *http.Client
+
+	// Short-circuits queries to unhealthy peers, if set.
+	breaker *pilosa.CircuitBreaker
+}
+
+// internalClientOption is a functional option type for InternalClient.
+type internalClientOption func(c *InternalClient)
+
+// OptInternalClientCircuitBreaker sets the circuit breaker through which the
+// client sends queries to other nodes.
+func OptInternalClientCircuitBreaker(b *pilosa.CircuitBreaker) internalClientOption {
+	return func(c *InternalClient) {
+		c.breaker = b
+	}
 }
 
 // NewInternalClient returns a new instance of InternalClient to connect to host.
@@ -57,25 +73,37 @@ func NewInternalClient(host string, remoteClient *http.Client) (*InternalClient,
 	return client, nil
 }
 
-func NewInternalClientFromURI(defaultURI *pilosa.URI, remoteClient *http.Client) *InternalClient {
-	return &InternalClient{
+func NewInternalClientFromURI(defaultURI *pilosa.URI, remoteClient *http.Client, opts ...internalClientOption) *InternalClient {
+	c := &InternalClient{
 		defaultURI: defaultURI,
 		serializer: proto.Serializer{},
 		httpClient: remoteClient,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // MaxShardByIndex returns the number of shards on a server by index.
 func (c *InternalClient) MaxShardByIndex(ctx context.Context) (map[string]uint64, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.MaxShardByIndex")
 	defer span.Finish()
-	return c.maxShardByIndex(ctx)
+	return c.maxShardByIndex(ctx, c.defaultURI)
+}
+
+// MaxShardByIndexNode returns the number of shards on the given node by
+// index.
+func (c *InternalClient) MaxShardByIndexNode(ctx context.Context, uri *pilosa.URI) (map[string]uint64, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.MaxShardByIndexNode")
+	defer span.Finish()
+	return c.maxShardByIndex(ctx, uri)
 }
 
 // maxShardByIndex returns the number of shards on a server by index.
-func (c *InternalClient) maxShardByIndex(ctx context.Context) (map[string]uint64, error) {
+func (c *InternalClient) maxShardByIndex(ctx context.Context, uri *pilosa.URI) (map[string]uint64, error) {
 	// Execute request against the host.
-	u := uriPathToURL(c.defaultURI, "/internal/shards/max")
+	u := uriPathToURL(uri, "/internal/shards/max")
 
 	// Build request.
 	req, err := http.NewRequest("GET", u.String(), nil)
@@ -105,9 +133,17 @@ func (c *InternalClient) maxShardByIndex(ctx context.Context) (map[string]uint64
 func (c *InternalClient) Schema(ctx context.Context) ([]*pilosa.IndexInfo, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.Schema")
 	defer span.Finish()
+	return c.SchemaNode(ctx, c.defaultURI)
+}
+
+// SchemaNode returns all index and field schema information of the given
+// node.
+func (c *InternalClient) SchemaNode(ctx context.Context, uri *pilosa.URI) ([]*pilosa.IndexInfo, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.SchemaNode")
+	defer span.Finish()
 
 	// Execute request against the host.
-	u := c.defaultURI.Path("/schema")
+	u := uri.Path("/schema")
 
 	// Build request.
 	req, err := http.NewRequest("GET", u, nil)
@@ -132,6 +168,35 @@ func (c *InternalClient) Schema(ctx context.Context) ([]*pilosa.IndexInfo, error
 	return rsp.Indexes, nil
 }
 
+// FullSchemaNode returns the schema of the given node, including internal
+// fields, the views of every field, and index aliases.
+func (c *InternalClient) FullSchemaNode(ctx context.Context, uri *pilosa.URI) (*pilosa.Schema, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.FullSchemaNode")
+	defer span.Finish()
+
+	// Build request.
+	req, err := http.NewRequest("GET", uri.Path("/internal/schema"), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+
+	req.Header.Set("User-Agent", "pilosa/"+pilosa.Version)
+	req.Header.Set("Accept", "application/json")
+
+	// Execute request.
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var schema pilosa.Schema
+	if err := json.NewDecoder(resp.Body).Decode(&schema); err != nil {
+		return nil, fmt.Errorf("json decode: %s", err)
+	}
+	return &schema, nil
+}
+
 func (c *InternalClient) PostSchema(ctx context.Context, uri *pilosa.URI, s *pilosa.Schema, remote bool) error {
 	u := uri.Path(fmt.Sprintf("/schema?remote=%v", remote))
 	buf, err := json.Marshal(s)
@@ -198,9 +263,17 @@ func (c *InternalClient) CreateIndex(ctx context.Context, index string, opt pilo
 func (c *InternalClient) FragmentNodes(ctx context.Context, index string, shard uint64) ([]*pilosa.Node, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.FragmentNodes")
 	defer span.Finish()
+	return c.FragmentNodesNode(ctx, c.defaultURI, index, shard)
+}
+
+// FragmentNodesNode returns the list of nodes that own a shard, according to
+// the given node.
+func (c *InternalClient) FragmentNodesNode(ctx context.Context, uri *pilosa.URI, index string, shard uint64) ([]*pilosa.Node, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.FragmentNodesNode")
+	defer span.Finish()
 
 	// Execute request against the host.
-	u := uriPathToURL(c.defaultURI, "/internal/fragment/nodes")
+	u := uriPathToURL(uri, "/internal/fragment/nodes")
 	u.RawQuery = (url.Values{"index": {index}, "shard": {strconv.FormatUint(shard, 10)}}).Encode()
 
 	// Build request.
@@ -292,9 +365,14 @@ func (c *InternalClient) QueryNode(ctx context.Context, uri *pilosa.URI, index s
 	req.Header.Set("Accept", "application/x-protobuf")
 	req.Header.Set("User-Agent", "pilosa/"+pilosa.Version)
 
-	// Execute request against the host.
+	// Execute request against the host, unless its breaker is open.
+	host := uri.HostPort()
+	if err := c.breaker.Allow(host); err != nil {
+		return nil, err
+	}
 	resp, err := c.executeRequest(req.WithContext(ctx))
 	if err != nil {
+		c.recordQueryFailure(ctx, host, resp)
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -302,8 +380,10 @@ func (c *InternalClient) QueryNode(ctx context.Context, uri *pilosa.URI, index s
 	// Read body and unmarshal response.
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
+		c.recordQueryFailure(ctx, host, nil)
 		return nil, errors.Wrap(err, "reading")
 	}
+	c.breaker.Success(host)
 
 	qresp := &pilosa.QueryResponse{}
 	if err := c.serializer.Unmarshal(body, qresp); err != nil {
@@ -320,9 +400,18 @@ func (c *InternalClient) Import(ctx context.Context, index, field string, shard
 	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.Import")
 	defer span.Finish()
 
-	if index == "" {
+	return c.ImportShard(ctx, newImportRequest(index, field, shard, bits), opts...)
+}
+
+// ImportShard bulk imports the bits and column attributes of an import
+// request for a single shard to the nodes which own the shard.
+func (c *InternalClient) ImportShard(ctx context.Context, req *pilosa.ImportRequest, opts ...pilosa.ImportOption) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.ImportShard")
+	defer span.Finish()
+
+	if req.Index == "" {
 		return pilosa.ErrIndexRequired
-	} else if field == "" {
+	} else if req.Field == "" {
 		return pilosa.ErrFieldRequired
 	}
 
@@ -335,21 +424,27 @@ func (c *InternalClient) Import(ctx context.Context, index, field string, shard
 		}
 	}
 
-	buf, err := c.marshalImportPayload(index, field, shard, bits)
+	buf, err := c.serializer.Marshal(req)
 	if err != nil {
-		return fmt.Errorf("Error Creating Payload: %s", err)
+		return fmt.Errorf("Error Creating Payload: marshal import request: %s", err)
 	}
 
 	// Retrieve a list of nodes that own the shard.
-	nodes, err := c.FragmentNodes(ctx, index, shard)
+	nodes, err := c.FragmentNodes(ctx, req.Index, req.Shard)
 	if err != nil {
-		return fmt.Errorf("shard nodes: %s", err)
+		return errors.Wrap(err, "shard nodes")
 	}
 
-	// Import to each node.
-	for _, node := range nodes {
-		if err := c.importNode(ctx, node, index, field, buf, options); err != nil {
-			return fmt.Errorf("import node: host=%s, err=%s", node.URI, err)
+	// Import to each node. Replicas hold the same data, so conflicts and
+	// changes are only counted on the first.
+	for i, node := range nodes {
+		if i == 1 {
+			replica := *options
+			replica.Conflicts, replica.Set, replica.Cleared = nil, nil, nil
+			options = &replica
+		}
+		if err := c.importNode(ctx, node, req.Index, req.Field, buf, options); err != nil {
+			return errors.Wrapf(err, "import node: host=%s", node.URI)
 		}
 	}
 
@@ -438,30 +533,29 @@ func (c *InternalClient) EnsureFieldWithOptions(ctx context.Context, indexName s
 
 // marshalImportPayload marshalls the import parameters into a protobuf byte slice.
 func (c *InternalClient) marshalImportPayload(index, field string, shard uint64, bits []pilosa.Bit) ([]byte, error) {
-	// Separate row and column IDs to reduce allocations.
-	rowIDs := Bits(bits).RowIDs()
-	rowKeys := Bits(bits).RowKeys()
-	columnIDs := Bits(bits).ColumnIDs()
-	columnKeys := Bits(bits).ColumnKeys()
-	timestamps := Bits(bits).Timestamps()
-
 	// Marshal data to protobuf.
-	buf, err := c.serializer.Marshal(&pilosa.ImportRequest{
-		Index:      index,
-		Field:      field,
-		Shard:      shard,
-		RowIDs:     rowIDs,
-		RowKeys:    rowKeys,
-		ColumnIDs:  columnIDs,
-		ColumnKeys: columnKeys,
-		Timestamps: timestamps,
-	})
+	buf, err := c.serializer.Marshal(newImportRequest(index, field, shard, bits))
 	if err != nil {
 		return nil, fmt.Errorf("marshal import request: %s", err)
 	}
 	return buf, nil
 }
 
+// newImportRequest returns an import request for bits.
+func newImportRequest(index, field string, shard uint64, bits []pilosa.Bit) *pilosa.ImportRequest {
+	// Separate row and column IDs to reduce allocations.
+	return &pilosa.ImportRequest{
+		Index:      index,
+		Field:      field,
+		Shard:      shard,
+		RowIDs:     Bits(bits).RowIDs(),
+		RowKeys:    Bits(bits).RowKeys(),
+		ColumnIDs:  Bits(bits).ColumnIDs(),
+		ColumnKeys: Bits(bits).ColumnKeys(),
+		Timestamps: Bits(bits).Timestamps(),
+	}
+}
+
 // importNode sends a pre-marshaled import request to a node.
 func (c *InternalClient) importNode(ctx context.Context, node *pilosa.Node, index, field string, buf []byte, opts *pilosa.ImportOptions) error {
 	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.importNode")
@@ -478,6 +572,9 @@ func (c *InternalClient) importNode(ctx context.Context, node *pilosa.Node, inde
 	if opts.IgnoreKeyCheck {
 		vals.Set("ignoreKeyCheck", "true")
 	}
+	if opts.ConflictPolicy != "" {
+		vals.Set("conflictPolicy", string(opts.ConflictPolicy))
+	}
 	url := fmt.Sprintf("%s?%s", u.String(), vals.Encode())
 
 	req, err := http.NewRequest("POST", url, bytes.NewReader(buf))
@@ -508,6 +605,15 @@ func (c *InternalClient) importNode(ctx context.Context, node *pilosa.Node, inde
 	} else if s := isresp.Err; s != "" {
 		return errors.New(s)
 	}
+	if opts.Conflicts != nil {
+		atomic.AddUint64(opts.Conflicts, isresp.Conflicts)
+	}
+	if opts.Set != nil {
+		atomic.AddUint64(opts.Set, isresp.Set)
+	}
+	if opts.Cleared != nil {
+		atomic.AddUint64(opts.Cleared, isresp.Cleared)
+	}
 
 	return nil
 }
@@ -540,13 +646,13 @@ func (c *InternalClient) ImportValue(ctx context.Context, index, field string, s
 	// Retrieve a list of nodes that own the shard.
 	nodes, err := c.FragmentNodes(ctx, index, shard)
 	if err != nil {
-		return fmt.Errorf("shard nodes: %s", err)
+		return errors.Wrap(err, "shard nodes")
 	}
 
 	// Import to each node.
 	for _, node := range nodes {
 		if err := c.importNode(ctx, node, index, field, buf, options); err != nil {
-			return fmt.Errorf("import node: host=%s, err=%s", node.URI, err)
+			return errors.Wrapf(err, "import node: host=%s", node.URI)
 		}
 	}
 
@@ -613,6 +719,54 @@ func (c *InternalClient) marshalImportValuePayload(index, field string, shard ui
 	return buf, nil
 }
 
+// SyncAntiEntropy runs an anti-entropy sync on the node at uri and returns the
+// number of bits reconciled.
+func (c *InternalClient) SyncAntiEntropy(ctx context.Context, uri *pilosa.URI, index string, shards []uint64, remote bool) (int, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.SyncAntiEntropy")
+	defer span.Finish()
+
+	if uri == nil {
+		uri = c.defaultURI
+	}
+	// Remote syncs are sent by other nodes, so they use the internal route,
+	// which is served on the main port even when the admin routes are not.
+	u := uriPathToURL(uri, "/cluster/anti-entropy/sync")
+	if remote {
+		u = uriPathToURL(uri, "/internal/cluster/anti-entropy/sync")
+	}
+	values := url.Values{}
+	if index != "" {
+		values.Set("index", index)
+	}
+	if len(shards) > 0 {
+		a := make([]string, len(shards))
+		for i, shard := range shards {
+			a[i] = strconv.FormatUint(shard, 10)
+		}
+		values.Set("shard", strings.Join(a, ","))
+	}
+	u.RawQuery = values.Encode()
+
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("User-Agent", "pilosa/"+pilosa.Version)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var rsp antiEntropySyncResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rsp); err != nil {
+		return 0, errors.Wrap(err, "decoding")
+	}
+	return rsp.BitsReconciled, nil
+}
+
 // ImportRoaring does fast import of raw bits in roaring format (pilosa or
 // official format, see API.ImportRoaring).
 func (c *InternalClient) ImportRoaring(ctx context.Context, uri *pilosa.URI, index, field string, shard uint64, remote bool, req *pilosa.ImportRoaringRequest) error {
@@ -803,6 +957,8 @@ func (c *InternalClient) CreateFieldWithOptions(ctx context.Context, index, fiel
 		fieldOpt.Max = &opt.Max
 	} else if fieldOpt.Type == "time" {
 		fieldOpt.TimeQuantum = &opt.TimeQuantum
+	} else if fieldOpt.Type == "timestamp" {
+		fieldOpt.TimeUnit = &opt.TimeUnit
 	}
 
 	// TODO: remove buf completely? (depends on whether importer needs to create specific field types)
@@ -838,8 +994,10 @@ func (c *InternalClient) CreateFieldWithOptions(ctx context.Context, index, fiel
 }
 
 // FragmentBlocks returns a list of block checksums for a fragment on a host.
-// Only returns blocks which contain data.
-func (c *InternalClient) FragmentBlocks(ctx context.Context, uri *pilosa.URI, index, field, view string, shard uint64) ([]pilosa.FragmentBlock, error) {
+// Only returns blocks which contain data. The checksums are computed with the
+// requested algorithm if the host uses it too, otherwise with
+// ChecksumStandard; the algorithm used is returned along with the blocks.
+func (c *InternalClient) FragmentBlocks(ctx context.Context, uri *pilosa.URI, index, field, view string, shard uint64, checksum string) ([]pilosa.FragmentBlock, string, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.FragmentBlocks")
 	defer span.Finish()
 
@@ -847,17 +1005,21 @@ func (c *InternalClient) FragmentBlocks(ctx context.Context, uri *pilosa.URI, in
 		uri = c.defaultURI
 	}
 	u := uriPathToURL(uri, "/internal/fragment/blocks")
-	u.RawQuery = url.Values{
+	q := url.Values{
 		"index": {index},
 		"field": {field},
 		"view":  {view},
 		"shard": {strconv.FormatUint(shard, 10)},
-	}.Encode()
+	}
+	if checksum != "" {
+		q.Set("checksum", checksum)
+	}
+	u.RawQuery = q.Encode()
 
 	// Build request.
 	req, err := http.NewRequest("GET", u.String(), nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "creating request")
+		return nil, "", errors.Wrap(err, "creating request")
 	}
 
 	req.Header.Set("User-Agent", "pilosa/"+pilosa.Version)
@@ -868,18 +1030,23 @@ func (c *InternalClient) FragmentBlocks(ctx context.Context, uri *pilosa.URI, in
 	if err != nil {
 		// Return the appropriate error.
 		if resp != nil && resp.StatusCode == http.StatusNotFound {
-			return nil, pilosa.ErrFragmentNotFound
+			return nil, "", pilosa.ErrFragmentNotFound
 		}
-		return nil, err
+		return nil, "", err
 	}
 	defer resp.Body.Close()
 
 	// Decode response object.
 	var rsp getFragmentBlocksResponse
 	if err := json.NewDecoder(resp.Body).Decode(&rsp); err != nil {
-		return nil, errors.Wrap(err, "decoding")
+		return nil, "", errors.Wrap(err, "decoding")
+	}
+
+	// Nodes which predate configurable checksums do not report one.
+	if rsp.Checksum == "" {
+		rsp.Checksum = pilosa.ChecksumStandard
 	}
-	return rsp.Blocks, nil
+	return rsp.Blocks, rsp.Checksum, nil
 }
 
 // BlockData returns row/column id pairs for a block.
@@ -1035,12 +1202,27 @@ func (c *InternalClient) SendMessage(ctx context.Context, uri *pilosa.URI, msg [
 	return errors.Wrap(resp.Body.Close(), "closing response body")
 }
 
+// recordQueryFailure records a failed query to host with the circuit breaker.
+// Error responses below 500 show the peer is healthy. Queries canceled by the
+// caller are not recorded, but those which timed out are.
+func (c *InternalClient) recordQueryFailure(ctx context.Context, host string, resp *http.Response) {
+	if resp != nil && resp.StatusCode < 500 {
+		c.breaker.Success(host)
+	} else if ctx.Err() != context.Canceled {
+		c.breaker.Failure(host)
+	}
+}
+
 // executeRequest executes the given request and checks the Response. For
 // responses with non-2XX status, the body is read and closed, and an error is
 // returned. If the error is nil, the caller must ensure that the response body
 // is closed.
 func (c *InternalClient) executeRequest(req *http.Request) (*http.Response, error) {
 	tracing.GlobalTracer.InjectHTTPHeaders(req)
+	if epoch, ok := pilosa.TopologyEpochFromContext(req.Context()); ok {
+		req.Header.Set(TopologyEpochHeader, strconv.FormatUint(epoch, 10))
+	}
+	setProtocolHeaders(req.Header)
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		if resp != nil {
@@ -1048,6 +1230,10 @@ func (c *InternalClient) executeRequest(req *http.Request) (*http.Response, erro
 		}
 		return nil, errors.Wrap(err, "getting response")
 	}
+	if err := checkProtocolHeaders(resp.Header); err != nil {
+		resp.Body.Close()
+		return resp, errors.Wrap(err, req.URL.Host)
+	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		defer resp.Body.Close()
 		buf, err := ioutil.ReadAll(resp.Body)
@@ -1062,6 +1248,14 @@ func (c *InternalClient) executeRequest(req *http.Request) (*http.Response, erro
 		} else {
 			msg = string(buf)
 		}
+		// A conflict with a different epoch than the one the request was
+		// routed with means the topology changed under the request, which
+		// was rejected before being executed and can be retried.
+		if expected := req.Header.Get(TopologyEpochHeader); expected != "" && resp.StatusCode == http.StatusConflict {
+			if epoch := resp.Header.Get(TopologyEpochHeader); epoch != "" && epoch != expected {
+				return resp, errors.Wrapf(pilosa.ErrTopologyChanged, "server error %s: '%s'", resp.Status, strings.TrimSpace(msg))
+			}
+		}
 		return resp, errors.Errorf("server error %s: '%s'", resp.Status, msg)
 	}
 	return resp, nil
@@ -31,6 +31,7 @@ import (
 	"github.com/pilosa/pilosa/v2/pql"
 	"github.com/pilosa/pilosa/v2/server"
 	"github.com/pilosa/pilosa/v2/test"
+	"github.com/pkg/errors"
 )
 
 // Test distributed TopN Row count across 3 nodes.
@@ -394,6 +395,53 @@ func TestClient_Import(t *testing.T) {
 	}
 }
 
+// Ensure client can bulk import bits with column attributes.
+func TestClient_ImportShard(t *testing.T) {
+	cluster := test.MustRunCluster(t, 1)
+	defer cluster.Close()
+	cmd := cluster[0]
+	c := MustNewClient(cmd.URL(), http.GetHTTPClient(nil))
+
+	cmd.MustCreateIndex(t, "keyed", pilosa.IndexOptions{Keys: true})
+	cmd.MustCreateField(t, "keyed", "f", pilosa.OptFieldTypeDefault())
+
+	if err := c.ImportShard(context.Background(), &pilosa.ImportRequest{
+		Index:      "keyed",
+		Field:      "f",
+		RowIDs:     []uint64{1, 1},
+		ColumnKeys: []string{"alice", "bob"},
+		ColumnAttrs: []*pilosa.ColumnAttrSet{
+			{Key: "alice", Attrs: map[string]interface{}{"age": int64(30)}},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp := cmd.MustQuery(t, &pilosa.QueryRequest{
+		Index:       "keyed",
+		Query:       "Row(f=1)",
+		ColumnAttrs: true,
+	})
+	if keys := resp.Results[0].(*pilosa.Row).Keys; !reflect.DeepEqual(keys, []string{"alice", "bob"}) {
+		t.Fatalf("unexpected keys: %v", keys)
+	} else if len(resp.ColumnAttrSets) != 1 {
+		t.Fatalf("unexpected column attributes: %+v", resp.ColumnAttrSets)
+	} else if set := resp.ColumnAttrSets[0]; set.Key != "alice" || !reflect.DeepEqual(set.Attrs, map[string]interface{}{"age": int64(30)}) {
+		t.Fatalf("unexpected column attributes: %+v", set)
+	}
+
+	// Column IDs cannot be used in an index with string keys.
+	if err := c.ImportShard(context.Background(), &pilosa.ImportRequest{
+		Index: "keyed",
+		Field: "f",
+		ColumnAttrs: []*pilosa.ColumnAttrSet{
+			{ID: 3, Attrs: map[string]interface{}{"age": int64(30)}},
+		},
+	}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
 // Ensure client can bulk import data.
 func TestClient_ImportRoaring(t *testing.T) {
 	cluster := test.MustNewCluster(t, 2)
@@ -1045,9 +1093,11 @@ func TestClient_FragmentBlocks(t *testing.T) {
 	// Set a bit on a different shard.
 	hldr.SetBit("i", "f", 0, 1)
 	c := MustNewClient(cmd.URL(), http.GetHTTPClient(nil))
-	blocks, err := c.FragmentBlocks(context.Background(), nil, "i", "f", "standard", 0)
+	blocks, checksum, err := c.FragmentBlocks(context.Background(), nil, "i", "f", "standard", 0, pilosa.ChecksumStandard)
 	if err != nil {
 		t.Fatal(err)
+	} else if checksum != pilosa.ChecksumStandard {
+		t.Fatalf("unexpected checksum: %s", checksum)
 	} else if len(blocks) != 2 {
 		t.Fatalf("unexpected blocks: %s", spew.Sdump(blocks))
 	} else if blocks[0].ID != 0 {
@@ -1057,11 +1107,83 @@ func TestClient_FragmentBlocks(t *testing.T) {
 	}
 
 	// Verify data matches local blocks.
-	if a, err := cmd.API.FragmentBlocks(context.Background(), "i", "f", "standard", 0); err != nil {
+	if a, _, err := cmd.API.FragmentBlocks(context.Background(), "i", "f", "standard", 0, pilosa.ChecksumStandard); err != nil {
 		t.Fatal(err)
 	} else if !reflect.DeepEqual(a, blocks) {
 		t.Fatalf("blocks mismatch:\n\nexp=%s\n\ngot=%s\n\n", spew.Sdump(a), spew.Sdump(blocks))
 	}
+
+	// The node falls back to the standard checksum if it is not configured
+	// to use the requested one.
+	if a, checksum, err := c.FragmentBlocks(context.Background(), nil, "i", "f", "standard", 0, pilosa.ChecksumContainer); err != nil {
+		t.Fatal(err)
+	} else if checksum != pilosa.ChecksumStandard {
+		t.Fatalf("unexpected checksum: %s", checksum)
+	} else if !reflect.DeepEqual(a, blocks) {
+		t.Fatalf("blocks mismatch:\n\nexp=%s\n\ngot=%s\n\n", spew.Sdump(a), spew.Sdump(blocks))
+	}
+}
+
+// Ensure that requests rejected because they were routed with a stale
+// topology epoch return ErrTopologyChanged so that they can be retried.
+func TestClient_TopologyChanged(t *testing.T) {
+	cluster := test.MustRunCluster(t, 1)
+	defer cluster.Close()
+	cmd := cluster[0]
+	cluster.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+
+	c := MustNewClient(cmd.URL(), http.GetHTTPClient(nil))
+	uri := cmd.API.Node().URI
+	req := &pilosa.QueryRequest{Query: "Count(Row(f=1))", Remote: true}
+
+	ctx := pilosa.WithTopologyEpoch(context.Background(), cmd.API.TopologyEpoch())
+	if _, err := c.QueryNode(ctx, &uri, "i", req); err != nil {
+		t.Fatal(err)
+	}
+
+	// A sender which is ahead of the node is served.
+	ctx = pilosa.WithTopologyEpoch(context.Background(), cmd.API.TopologyEpoch()+1)
+	if _, err := c.QueryNode(ctx, &uri, "i", req); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx = pilosa.WithTopologyEpoch(context.Background(), cmd.API.TopologyEpoch()-1)
+	if _, err := c.QueryNode(ctx, &uri, "i", req); errors.Cause(err) != pilosa.ErrTopologyChanged {
+		t.Fatalf("expected topology changed error, got %v", err)
+	}
+
+	// Forwarded imports are checked too.
+	imp := &pilosa.ImportRequest{Index: "i", Field: "f", RowIDs: []uint64{1}, ColumnIDs: []uint64{1}}
+	if err := c.ImportShard(ctx, imp); errors.Cause(err) != pilosa.ErrTopologyChanged {
+		t.Fatalf("expected topology changed error, got %v", err)
+	}
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	cluster := test.MustRunCluster(t, 1)
+	defer cluster.Close()
+	cmd := cluster[0]
+	cluster.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+
+	breaker := pilosa.NewCircuitBreaker(1, time.Hour)
+	c := http.NewInternalClientFromURI(&cmd.API.Node().URI, http.GetHTTPClient(nil), http.OptInternalClientCircuitBreaker(breaker))
+	req := &pilosa.QueryRequest{Query: "Count(Row(f=1))", Remote: true}
+
+	down, err := pilosa.NewURIFromAddress("localhost:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.QueryNode(context.Background(), down, "i", req); err == nil || errors.Cause(err) == pilosa.ErrCircuitOpen {
+		t.Fatalf("expected connection error, got %v", err)
+	} else if _, err := c.QueryNode(context.Background(), down, "i", req); errors.Cause(err) != pilosa.ErrCircuitOpen {
+		t.Fatalf("expected open breaker, got %v", err)
+	}
+
+	// Other peers are not affected.
+	uri := cmd.API.Node().URI
+	if _, err := c.QueryNode(context.Background(), &uri, "i", req); err != nil {
+		t.Fatal(err)
+	}
 }
 
 // Client represents a test wrapper for pilosa.Client.
@@ -0,0 +1,157 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime/pprof"
+	"time"
+
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+)
+
+// diagnosticsLogTailSize is the maximum number of bytes from the end of the
+// log file included in a diagnostics bundle.
+const diagnosticsLogTailSize = 1 << 20
+
+// handleGetDiagnostics handles GET /diagnostics requests. The response is a
+// gzipped tarball containing a goroutine dump, a heap profile, the redacted
+// server config, the cluster status, and the tail of the log file.
+//
+// Because the bundle exposes internals of the node it is only served to
+// clients which present a verified TLS client certificate.
+func (h *Handler) handleGetDiagnostics(w http.ResponseWriter, r *http.Request) {
+	if !verifiedClient(r) {
+		http.Error(w, "diagnostics require a verified client certificate", http.StatusForbidden)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := h.writeDiagnostics(&buf, r); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="pilosa-diagnostics-%s.tar.gz"`, h.api.Node().ID))
+	if _, err := buf.WriteTo(w); err != nil {
+		h.logger.Printf("write diagnostics response error: %s", err)
+	}
+}
+
+// verifiedClient returns true if the client of r presented a TLS client
+// certificate which the server verified.
+func verifiedClient(r *http.Request) bool {
+	return r.TLS != nil && len(r.TLS.VerifiedChains) > 0
+}
+
+// writeDiagnostics writes the diagnostics bundle to w.
+func (h *Handler) writeDiagnostics(w io.Writer, r *http.Request) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+
+	add := func(name string, fn func(w io.Writer) error) error {
+		var buf bytes.Buffer
+		if err := fn(&buf); err != nil {
+			return errors.Wrapf(err, "collecting %s", name)
+		}
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(buf.Len()),
+			ModTime: now,
+		}); err != nil {
+			return errors.Wrapf(err, "writing %s header", name)
+		}
+		_, err := buf.WriteTo(tw)
+		return errors.Wrapf(err, "writing %s", name)
+	}
+
+	if err := add("goroutine.txt", func(w io.Writer) error {
+		return pprof.Lookup("goroutine").WriteTo(w, 2)
+	}); err != nil {
+		return err
+	}
+	if err := add("heap.pprof", func(w io.Writer) error {
+		return pprof.Lookup("heap").WriteTo(w, 0)
+	}); err != nil {
+		return err
+	}
+	if err := add("status.json", func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(getStatusResponse{
+			State:   h.api.State(),
+			Nodes:   h.api.Hosts(r.Context()),
+			LocalID: h.api.Node().ID,
+		})
+	}); err != nil {
+		return err
+	}
+	if h.diagnosticsConfig != nil {
+		if err := add("config.toml", func(w io.Writer) error {
+			buf, err := toml.Marshal(h.diagnosticsConfig)
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(buf)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+	if h.diagnosticsLogPath != "" {
+		if err := add("log.txt", func(w io.Writer) error {
+			return tailFile(w, h.diagnosticsLogPath, diagnosticsLogTailSize)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return errors.Wrap(err, "closing tar writer")
+	}
+	return errors.Wrap(gz.Close(), "closing gzip writer")
+}
+
+// tailFile copies at most n bytes from the end of the file at path to w.
+func tailFile(w io.Writer, path string, n int64) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if offset := fi.Size() - n; offset > 0 {
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+	}
+	_, err = io.Copy(w, f)
+	return err
+}
@@ -0,0 +1,26 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.21
+// +build go1.21
+
+package http
+
+import "net/http"
+
+// enableFullDuplex lets the handler write the response to an HTTP/1 request
+// while still reading its body. It returns false if w does not support it.
+func enableFullDuplex(w http.ResponseWriter) bool {
+	return http.NewResponseController(w).EnableFullDuplex() == nil
+}
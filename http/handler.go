@@ -15,14 +15,18 @@
 package http
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"crypto/tls"
+	"encoding/binary"
 	"encoding/json"
 	"expvar"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"math"
+	"mime"
 	"net"
 	"net/http"
 	_ "net/http/pprof" // Imported for its side-effect of registering pprof endpoints with the server.
@@ -40,6 +44,7 @@ import (
 	"github.com/pilosa/pilosa/v2/tracing"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"golang.org/x/sync/errgroup"
 )
 
 // Handler represents an HTTP handler.
@@ -53,10 +58,34 @@ type Handler struct {
 
 	api *pilosa.API
 
-	ln net.Listener
+	lns []net.Listener
+
+	// adminLn, if set, serves the admin routes, which are then not served
+	// by lns.
+	adminLn      net.Listener
+	adminHandler http.Handler
+	adminServer  *http.Server
+
+	allowedOrigins []string
 
 	closeTimeout time.Duration
 
+	// maxBodyBytes limits the size of request bodies. Zero means
+	// no limit.
+	maxBodyBytes int64
+
+	// defaultIndex is the index of query and import requests made to the
+	// routes without an index.
+	defaultIndex string
+
+	// Included in /diagnostics bundles if set.
+	diagnosticsConfig  interface{}
+	diagnosticsLogPath string
+
+	// logTail holds the recent lines of the server log served by
+	// /logs/tail. The endpoint is disabled if it is nil.
+	logTail *logger.LogTail
+
 	server *http.Server
 }
 
@@ -73,6 +102,23 @@ var externalPrefixFlag = map[string]bool{
 	"version": true,
 }
 
+// TopologyEpochHeader is the header carrying the topology epoch. Clients set
+// it on requests routed with a particular topology, and every response
+// carries the epoch known to the node which handled it.
+const TopologyEpochHeader = "X-Pilosa-Topology-Epoch"
+
+// ProtocolVersionHeader and MinProtocolVersionHeader are the headers carrying
+// the highest and lowest protocol versions spoken by the node which sent an
+// internal request, or which handled it.
+const (
+	ProtocolVersionHeader    = "X-Pilosa-Protocol-Version"
+	MinProtocolVersionHeader = "X-Pilosa-Min-Protocol-Version"
+)
+
+// AllowUnboundedHeader is the header which, set to "true", runs a query which
+// safe mode would reject.
+const AllowUnboundedHeader = "X-Pilosa-Allow-Unbounded"
+
 type errorResponse struct {
 	Error string `json:"error"`
 }
@@ -82,10 +128,7 @@ type handlerOption func(s *Handler) error
 
 func OptHandlerAllowedOrigins(origins []string) handlerOption {
 	return func(h *Handler) error {
-		h.Handler = handlers.CORS(
-			handlers.AllowedOrigins(origins),
-			handlers.AllowedHeaders([]string{"Content-Type"}),
-		)(h.Handler)
+		h.allowedOrigins = origins
 		return nil
 	}
 }
@@ -104,9 +147,22 @@ func OptHandlerLogger(logger logger.Logger) handlerOption {
 	}
 }
 
-func OptHandlerListener(ln net.Listener) handlerOption {
+// OptHandlerListener sets the listeners the handler serves. Each listener
+// is served by its own accept loop.
+func OptHandlerListener(lns ...net.Listener) handlerOption {
+	return func(h *Handler) error {
+		h.lns = lns
+		return nil
+	}
+}
+
+// OptHandlerAdminListener sets a listener which serves the admin routes,
+// such as resizing the cluster, anti-entropy and diagnostics, instead of the
+// listeners set by OptHandlerListener. Without it, or with a nil listener,
+// every route is served by those listeners.
+func OptHandlerAdminListener(ln net.Listener) handlerOption {
 	return func(h *Handler) error {
-		h.ln = ln
+		h.adminLn = ln
 		return nil
 	}
 }
@@ -120,13 +176,53 @@ func OptHandlerCloseTimeout(d time.Duration) handlerOption {
 	}
 }
 
+// OptHandlerMaxBodyBytes limits the size of request bodies. Larger
+// requests are rejected with 413 Request Entity Too Large. A limit of zero
+// disables the check.
+func OptHandlerMaxBodyBytes(n int64) handlerOption {
+	return func(h *Handler) error {
+		if n < 0 {
+			return errors.New("max body bytes must not be negative")
+		}
+		h.maxBodyBytes = n
+		return nil
+	}
+}
+
+// OptHandlerDefaultIndex sets the index used by query and import requests
+// which omit the index from their path.
+func OptHandlerDefaultIndex(name string) handlerOption {
+	return func(h *Handler) error {
+		h.defaultIndex = name
+		return nil
+	}
+}
+
+// OptHandlerDiagnostics sets the config and the log file which are included
+// in /diagnostics bundles. The config should already have secrets redacted.
+func OptHandlerDiagnostics(config interface{}, logPath string) handlerOption {
+	return func(h *Handler) error {
+		h.diagnosticsConfig = config
+		h.diagnosticsLogPath = logPath
+		return nil
+	}
+}
+
+// OptHandlerLogTail sets the buffer of recent log lines which are served by
+// /logs/tail.
+func OptHandlerLogTail(t *logger.LogTail) handlerOption {
+	return func(h *Handler) error {
+		h.logTail = t
+		return nil
+	}
+}
+
 // NewHandler returns a new instance of Handler with a default logger.
 func NewHandler(opts ...handlerOption) (*Handler, error) {
 	handler := &Handler{
 		logger:       logger.NopLogger,
 		closeTimeout: time.Second * 30,
 	}
-	handler.Handler = newRouter(handler)
 	handler.populateValidators()
 
 	for _, opt := range opts {
@@ -140,22 +236,55 @@ func NewHandler(opts ...handlerOption) (*Handler, error) {
 		return nil, errors.New("must pass OptHandlerAPI")
 	}
 
-	if handler.ln == nil {
+	if len(handler.lns) == 0 {
 		return nil, errors.New("must pass OptHandlerListener")
 	}
 
+	handler.Handler = handler.withCORS(newRouter(handler))
 	handler.server = &http.Server{Handler: handler}
+	if handler.adminLn != nil {
+		handler.adminHandler = handler.withCORS(newAdminRouter(handler))
+		handler.adminServer = &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler.serve(handler.adminHandler, w, r)
+		})}
+	}
 
 	return handler, nil
 }
 
+// withCORS wraps next to allow cross-origin requests from the allowed
+// origins, if any.
+func (h *Handler) withCORS(next http.Handler) http.Handler {
+	if len(h.allowedOrigins) == 0 {
+		return next
+	}
+	return handlers.CORS(
+		handlers.AllowedOrigins(h.allowedOrigins),
+		handlers.AllowedHeaders([]string{"Content-Type"}),
+	)(next)
+}
+
+// Serve accepts connections on each of the handler's listeners, including the
+// admin listener, until the handler is closed.
 func (h *Handler) Serve() error {
-	err := h.server.Serve(h.ln)
-	if err != nil && err.Error() != "http: Server closed" {
-		h.logger.Printf("HTTP handler terminated with error: %s\n", err)
-		return errors.Wrap(err, "serve http")
+	var eg errgroup.Group
+	serve := func(server *http.Server, ln net.Listener) {
+		eg.Go(func() error {
+			err := server.Serve(ln)
+			if err != nil && err.Error() != "http: Server closed" {
+				h.logger.Printf("HTTP handler terminated with error: %s\n", err)
+				return errors.Wrap(err, "serve http")
+			}
+			return nil
+		})
 	}
-	return nil
+	for _, ln := range h.lns {
+		serve(h.server, ln)
+	}
+	if h.adminLn != nil {
+		serve(h.adminServer, h.adminLn)
+	}
+	return eg.Wait()
 }
 
 // Close tries to cleanly shutdown the HTTP server, and failing that, after a
@@ -163,42 +292,86 @@ func (h *Handler) Serve() error {
 func (h *Handler) Close() error {
 	deadlineCtx, cancelFunc := context.WithDeadline(context.Background(), time.Now().Add(h.closeTimeout))
 	defer cancelFunc()
-	err := h.server.Shutdown(deadlineCtx)
-	if err != nil {
-		err = h.server.Close()
+	err := shutdown(deadlineCtx, h.server)
+	if h.adminServer != nil {
+		if aerr := shutdown(deadlineCtx, h.adminServer); err == nil {
+			err = aerr
+		}
 	}
 	return errors.Wrap(err, "shutdown/close http server")
 }
 
+func shutdown(ctx context.Context, server *http.Server) error {
+	if err := server.Shutdown(ctx); err != nil {
+		return server.Close()
+	}
+	return nil
+}
+
 func (h *Handler) populateValidators() {
 	h.validators = map[string]*queryValidationSpec{}
 	h.validators["Home"] = queryValidationSpecRequired()
+	h.validators["PostClusterAntiEntropySync"] = queryValidationSpecRequired().Optional("index", "shard", "remote")
+	h.validators["PostInternalAntiEntropySync"] = queryValidationSpecRequired().Optional("index", "shard")
 	h.validators["PostClusterResizeAbort"] = queryValidationSpecRequired()
 	h.validators["PostClusterResizeRemoveNode"] = queryValidationSpecRequired()
 	h.validators["PostClusterResizeSetCoordinator"] = queryValidationSpecRequired()
+	h.validators["GetClusterShardDistribution"] = queryValidationSpecRequired()
+	h.validators["GetClusterTopology"] = queryValidationSpecRequired()
+	h.validators["GetQuarantinedFragments"] = queryValidationSpecRequired()
+	h.validators["PostFragmentsCompact"] = queryValidationSpecRequired("index").Optional("field", "view", "shard")
+	h.validators["GetDiagnostics"] = queryValidationSpecRequired()
+	h.validators["GetLogsTail"] = queryValidationSpecRequired().Optional("lines", "follow")
+	h.validators["GetChanges"] = queryValidationSpecRequired().Optional("from", "index", "field")
 	h.validators["GetExport"] = queryValidationSpecRequired("index", "field", "shard")
 	h.validators["GetIndexes"] = queryValidationSpecRequired()
 	h.validators["GetIndex"] = queryValidationSpecRequired()
 	h.validators["PostIndex"] = queryValidationSpecRequired()
 	h.validators["DeleteIndex"] = queryValidationSpecRequired()
+	h.validators["PatchIndex"] = queryValidationSpecRequired()
+	h.validators["PostIndexUndelete"] = queryValidationSpecRequired()
+	h.validators["PostIndexRename"] = queryValidationSpecRequired()
+	h.validators["PostFieldRename"] = queryValidationSpecRequired()
+	h.validators["GetIndexTombstones"] = queryValidationSpecRequired()
+	h.validators["GetIndexAliases"] = queryValidationSpecRequired()
+	h.validators["GetSelfHeal"] = queryValidationSpecRequired()
+	h.validators["GetQueries"] = queryValidationSpecRequired()
+	h.validators["DeleteQuery"] = queryValidationSpecRequired()
+	h.validators["PostSelfHeal"] = queryValidationSpecRequired()
+	h.validators["GetReplication"] = queryValidationSpecRequired()
+	h.validators["PostReplicationPromote"] = queryValidationSpecRequired()
+	h.validators["GetInternalSchema"] = queryValidationSpecRequired()
+	h.validators["PostIndexAlias"] = queryValidationSpecRequired()
+	h.validators["DeleteIndexAlias"] = queryValidationSpecRequired()
 	h.validators["GetTranslateData"] = queryValidationSpecRequired("offset")
 	h.validators["PostTranslateKeys"] = queryValidationSpecRequired()
 	h.validators["PostField"] = queryValidationSpecRequired()
 	h.validators["DeleteField"] = queryValidationSpecRequired()
-	h.validators["PostImport"] = queryValidationSpecRequired().Optional("clear", "ignoreKeyCheck")
+	h.validators["GetRowColumns"] = queryValidationSpecRequired().Optional("start", "end", "format")
+	h.validators["PostImport"] = queryValidationSpecRequired().Optional("clear", "ignoreKeyCheck", "conflictPolicy")
+	h.validators["PostImportBool"] = queryValidationSpecRequired().Optional("clear", "ignoreKeyCheck", "conflictPolicy")
 	h.validators["PostImportRoaring"] = queryValidationSpecRequired().Optional("remote", "clear")
-	h.validators["PostQuery"] = queryValidationSpecRequired().Optional("shards", "columnAttrs", "excludeRowAttrs", "excludeColumns")
+	h.validators["PostImportSession"] = queryValidationSpecRequired().Optional("clear")
+	h.validators["PostImportStream"] = queryValidationSpecRequired().Optional("clear", "conflictPolicy", "ackInterval")
+	h.validators["GetImportSession"] = queryValidationSpecRequired()
+	h.validators["PostImportSessionChunk"] = queryValidationSpecRequired()
+	h.validators["PostImportSessionCommit"] = queryValidationSpecRequired()
+	h.validators["DeleteImportSession"] = queryValidationSpecRequired()
+	h.validators["PostQuery"] = queryValidationSpecRequired().Optional("shards", "columnAttrs", "excludeRowAttrs", "excludeColumns", "limit", "cursor", "maxResultRows")
 	h.validators["GetInfo"] = queryValidationSpecRequired()
 	h.validators["RecalculateCaches"] = queryValidationSpecRequired()
 	h.validators["GetSchema"] = queryValidationSpecRequired()
 	h.validators["PostSchema"] = queryValidationSpecRequired().Optional("remote")
+	h.validators["PostSchemaBulk"] = queryValidationSpecRequired()
 	h.validators["GetStatus"] = queryValidationSpecRequired()
+	h.validators["GetUsage"] = queryValidationSpecRequired().Optional("index")
 	h.validators["GetVersion"] = queryValidationSpecRequired()
 	h.validators["PostClusterMessage"] = queryValidationSpecRequired()
 	h.validators["GetFragmentBlockData"] = queryValidationSpecRequired()
-	h.validators["GetFragmentBlocks"] = queryValidationSpecRequired("index", "field", "view", "shard")
+	h.validators["GetFragmentBlocks"] = queryValidationSpecRequired("index", "field", "view", "shard").Optional("checksum")
 	h.validators["GetFragmentData"] = queryValidationSpecRequired("index", "field", "view", "shard")
 	h.validators["GetFragmentNodes"] = queryValidationSpecRequired("shard", "index")
+	h.validators["GetShardNodes"] = queryValidationSpecRequired("index").Optional("shard", "column", "key")
 	h.validators["PostIndexAttrDiff"] = queryValidationSpecRequired()
 	h.validators["PostFieldAttrDiff"] = queryValidationSpecRequired()
 	h.validators["GetNodes"] = queryValidationSpecRequired()
@@ -226,6 +399,69 @@ func (h *Handler) queryArgValidator(next http.Handler) http.Handler {
 	})
 }
 
+// checkTopologyEpoch rejects requests which were routed with an older
+// topology epoch than the one known to this node with 409 Conflict, so that
+// the client refetches the topology rather than being served from an
+// outdated shard map. Requests routed with a newer epoch are served: the
+// sender learned of a topology change before this node did.
+func (h *Handler) checkTopologyEpoch(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		epoch := h.api.TopologyEpoch()
+		w.Header().Set(TopologyEpochHeader, strconv.FormatUint(epoch, 10))
+
+		if v := r.Header.Get(TopologyEpochHeader); v != "" {
+			expected, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				http.Error(w, "invalid topology epoch: "+v, http.StatusBadRequest)
+				return
+			} else if expected < epoch {
+				http.Error(w, fmt.Sprintf("stale topology epoch: request was routed with epoch %d but this node is at epoch %d, refetch the cluster topology", expected, epoch), http.StatusConflict)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// checkProtocolVersion rejects internal requests from nodes which speak no
+// protocol version in common with this node with 400 Bad Request. Every
+// response carries the versions this node speaks, so that the sender can
+// check them too.
+func (h *Handler) checkProtocolVersion(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		setProtocolHeaders(w.Header())
+		if err := checkProtocolHeaders(r.Header); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// setProtocolHeaders sets the protocol versions spoken by this node in h.
+func setProtocolHeaders(h http.Header) {
+	h.Set(ProtocolVersionHeader, strconv.Itoa(pilosa.ProtocolVersion))
+	h.Set(MinProtocolVersionHeader, strconv.Itoa(pilosa.MinProtocolVersion))
+}
+
+// checkProtocolHeaders returns ErrIncompatibleProtocol if the protocol
+// versions in h, sent by another node, have none in common with those of
+// this node. Nodes which predate versioning send none, and speak version 1.
+func checkProtocolHeaders(h http.Header) error {
+	var versions [2]int
+	for i, name := range []string{MinProtocolVersionHeader, ProtocolVersionHeader} {
+		if v := h.Get(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return errors.Errorf("invalid protocol version: %s", v)
+			}
+			versions[i] = n
+		}
+	}
+	_, err := pilosa.NegotiateProtocol(versions[0], versions[1])
+	return err
+}
+
 func (h *Handler) extractTracing(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		span, ctx := tracing.GlobalTracer.ExtractHTTPHeaders(r)
@@ -270,61 +506,132 @@ func (h *Handler) collectStats(next http.Handler) http.Handler {
 	})
 }
 
-// newRouter creates a new mux http router.
+// newRouter creates a new mux http router. It serves the admin routes too,
+// unless the handler has an admin listener.
 func newRouter(handler *Handler) *mux.Router {
 	router := mux.NewRouter()
+	if handler.adminLn == nil {
+		addAdminRoutes(router, handler)
+	}
 	router.HandleFunc("/", handler.handleHome).Methods("GET").Name("Home")
-	router.HandleFunc("/cluster/resize/abort", handler.handlePostClusterResizeAbort).Methods("POST").Name("PostClusterResizeAbort")
-	router.HandleFunc("/cluster/resize/remove-node", handler.handlePostClusterResizeRemoveNode).Methods("POST").Name("PostClusterResizeRemoveNode")
-	router.HandleFunc("/cluster/resize/set-coordinator", handler.handlePostClusterResizeSetCoordinator).Methods("POST").Name("PostClusterResizeSetCoordinator")
-	router.PathPrefix("/debug/pprof/").Handler(http.DefaultServeMux).Methods("GET")
-	router.Handle("/debug/vars", expvar.Handler()).Methods("GET")
 	router.Handle("/metrics", promhttp.Handler())
+	router.HandleFunc("/changes", handler.handleGetChanges).Methods("GET").Name("GetChanges")
 	router.HandleFunc("/export", handler.handleGetExport).Methods("GET").Name("GetExport")
+	router.HandleFunc("/field/{field}/import", handler.handlePostImport).Methods("POST").Name("PostImport")
+	router.HandleFunc("/field/{field}/import-bool", handler.handlePostImportBool).Methods("POST").Name("PostImportBool")
+	router.HandleFunc("/field/{field}/import-roaring/{shard}", handler.handlePostImportRoaring).Methods("POST").Name("PostImportRoaring")
+	router.HandleFunc("/field/{field}/import-session", handler.handlePostImportSession).Methods("POST").Name("PostImportSession")
+	router.HandleFunc("/field/{field}/import-stream", handler.handlePostImportStream).Methods("POST").Name("PostImportStream")
 	router.HandleFunc("/index", handler.handleGetIndexes).Methods("GET").Name("GetIndexes")
 	router.HandleFunc("/index", handler.handlePostIndex).Methods("POST").Name("PostIndex")
 	router.HandleFunc("/index/", handler.handlePostIndex).Methods("POST").Name("PostIndex")
 	router.HandleFunc("/index/{index}", handler.handleGetIndex).Methods("GET").Name("GetIndex")
 	router.HandleFunc("/index/{index}", handler.handlePostIndex).Methods("POST").Name("PostIndex")
 	router.HandleFunc("/index/{index}", handler.handleDeleteIndex).Methods("DELETE").Name("DeleteIndex")
+	router.HandleFunc("/index/{index}", handler.handlePatchIndex).Methods("PATCH").Name("PatchIndex")
 	//router.HandleFunc("/index/{index}/field", handler.handleGetFields).Methods("GET") // Not implemented.
 	router.HandleFunc("/index/{index}/field/{field}", handler.handlePostField).Methods("POST").Name("PostField")
 	router.HandleFunc("/index/{index}/field", handler.handlePostField).Methods("POST").Name("PostField")
 	router.HandleFunc("/index/{index}/field/", handler.handlePostField).Methods("POST").Name("PostField")
 	router.HandleFunc("/index/{index}/field/{field}", handler.handleDeleteField).Methods("DELETE").Name("DeleteField")
 	router.HandleFunc("/index/{index}/field/{field}/import", handler.handlePostImport).Methods("POST").Name("PostImport")
+	router.HandleFunc("/index/{index}/field/{field}/import-bool", handler.handlePostImportBool).Methods("POST").Name("PostImportBool")
 	router.HandleFunc("/index/{index}/field/{field}/import-roaring/{shard}", handler.handlePostImportRoaring).Methods("POST").Name("PostImportRoaring")
+	router.HandleFunc("/index/{index}/field/{field}/import-session", handler.handlePostImportSession).Methods("POST").Name("PostImportSession")
+	router.HandleFunc("/index/{index}/field/{field}/import-stream", handler.handlePostImportStream).Methods("POST").Name("PostImportStream")
+	router.HandleFunc("/index/{index}/field/{field}/row/{row}/columns", handler.handleGetRowColumns).Methods("GET").Name("GetRowColumns")
 	router.HandleFunc("/index/{index}/query", handler.handlePostQuery).Methods("POST").Name("PostQuery")
+	router.HandleFunc("/index/{index}/undelete", handler.handlePostIndexUndelete).Methods("POST").Name("PostIndexUndelete")
+	router.HandleFunc("/index-alias", handler.handleGetIndexAliases).Methods("GET").Name("GetIndexAliases")
+	router.HandleFunc("/index-alias/{alias}", handler.handlePostIndexAlias).Methods("POST").Name("PostIndexAlias")
+	router.HandleFunc("/index-alias/{alias}", handler.handleDeleteIndexAlias).Methods("DELETE").Name("DeleteIndexAlias")
+	router.HandleFunc("/index-tombstones", handler.handleGetIndexTombstones).Methods("GET").Name("GetIndexTombstones")
+	router.HandleFunc("/import-session/{id}", handler.handleGetImportSession).Methods("GET").Name("GetImportSession")
+	router.HandleFunc("/import-session/{id}", handler.handleDeleteImportSession).Methods("DELETE").Name("DeleteImportSession")
+	router.HandleFunc("/import-session/{id}/chunk/{chunk}", handler.handlePostImportSessionChunk).Methods("POST").Name("PostImportSessionChunk")
+	router.HandleFunc("/import-session/{id}/commit", handler.handlePostImportSessionCommit).Methods("POST").Name("PostImportSessionCommit")
 	router.HandleFunc("/info", handler.handleGetInfo).Methods("GET").Name("GetInfo")
-	router.HandleFunc("/recalculate-caches", handler.handleRecalculateCaches).Methods("POST").Name("RecalculateCaches")
+	router.HandleFunc("/query", handler.handlePostQuery).Methods("POST").Name("PostQuery")
+	router.HandleFunc("/queries", handler.handleGetQueries).Methods("GET").Name("GetQueries")
+	router.HandleFunc("/queries/{id}", handler.handleDeleteQuery).Methods("DELETE").Name("DeleteQuery")
+	router.HandleFunc("/replication", handler.handleGetReplication).Methods("GET").Name("GetReplication")
 	router.HandleFunc("/schema", handler.handleGetSchema).Methods("GET").Name("GetSchema")
 	router.HandleFunc("/schema", handler.handlePostSchema).Methods("POST").Name("PostSchema")
+	router.HandleFunc("/schema/bulk", handler.handlePostSchemaBulk).Methods("POST").Name("PostSchemaBulk")
 	router.HandleFunc("/status", handler.handleGetStatus).Methods("GET").Name("GetStatus")
+	router.HandleFunc("/usage", handler.handleGetUsage).Methods("GET").Name("GetUsage")
 	router.HandleFunc("/version", handler.handleGetVersion).Methods("GET").Name("GetVersion")
 
 	// /internal endpoints are for internal use only; they may change at any time.
 	// DO NOT rely on these for external applications!
+	router.HandleFunc("/internal/cluster/anti-entropy/sync", handler.handlePostInternalAntiEntropySync).Methods("POST").Name("PostInternalAntiEntropySync")
 	router.HandleFunc("/internal/cluster/message", handler.handlePostClusterMessage).Methods("POST").Name("PostClusterMessage")
 	router.HandleFunc("/internal/fragment/block/data", handler.handleGetFragmentBlockData).Methods("GET").Name("GetFragmentBlockData")
 	router.HandleFunc("/internal/fragment/blocks", handler.handleGetFragmentBlocks).Methods("GET").Name("GetFragmentBlocks")
 	router.HandleFunc("/internal/fragment/data", handler.handleGetFragmentData).Methods("GET").Name("GetFragmentData")
 	router.HandleFunc("/internal/fragment/nodes", handler.handleGetFragmentNodes).Methods("GET").Name("GetFragmentNodes")
+	router.HandleFunc("/internal/shard-nodes", handler.handleGetShardNodes).Methods("GET").Name("GetShardNodes")
 	router.HandleFunc("/internal/index/{index}/attr/diff", handler.handlePostIndexAttrDiff).Methods("POST").Name("PostIndexAttrDiff")
 	router.HandleFunc("/internal/translate/data", handler.handlePostTranslateData).Methods("POST").Name("PostTranslateData")
 	router.HandleFunc("/internal/translate/keys", handler.handlePostTranslateKeys).Methods("POST").Name("PostTranslateKeys")
 	router.HandleFunc("/internal/index/{index}/field/{field}/attr/diff", handler.handlePostFieldAttrDiff).Methods("POST").Name("PostFieldAttrDiff")
 	router.HandleFunc("/internal/index/{index}/field/{field}/remote-available-shards/{shardID}", handler.handleDeleteRemoteAvailableShard).Methods("DELETE")
 	router.HandleFunc("/internal/nodes", handler.handleGetNodes).Methods("GET").Name("GetNodes")
+	router.HandleFunc("/internal/schema", handler.handleGetInternalSchema).Methods("GET").Name("GetInternalSchema")
 	router.HandleFunc("/internal/shards/max", handler.handleGetShardsMax).Methods("GET").Name("GetShardsMax") // TODO: deprecate, but it's being used by the client
 
+	useMiddleware(router, handler)
+	return router
+}
+
+// newAdminRouter creates a mux http router serving only the admin routes,
+// for the admin listener.
+func newAdminRouter(handler *Handler) *mux.Router {
+	router := mux.NewRouter()
+	addAdminRoutes(router, handler)
+	useMiddleware(router, handler)
+	return router
+}
+
+// addAdminRoutes adds the routes which operate the cluster, rather than
+// read or write data, to router.
+func addAdminRoutes(router *mux.Router, handler *Handler) {
+	router.HandleFunc("/cluster/anti-entropy/sync", handler.handlePostClusterAntiEntropySync).Methods("POST").Name("PostClusterAntiEntropySync")
+	router.HandleFunc("/cluster/resize/abort", handler.handlePostClusterResizeAbort).Methods("POST").Name("PostClusterResizeAbort")
+	router.HandleFunc("/cluster/resize/remove-node", handler.handlePostClusterResizeRemoveNode).Methods("POST").Name("PostClusterResizeRemoveNode")
+	router.HandleFunc("/cluster/resize/set-coordinator", handler.handlePostClusterResizeSetCoordinator).Methods("POST").Name("PostClusterResizeSetCoordinator")
+	router.HandleFunc("/cluster/shard-distribution", handler.handleGetClusterShardDistribution).Methods("GET").Name("GetClusterShardDistribution")
+	router.HandleFunc("/cluster/topology", handler.handleGetClusterTopology).Methods("GET").Name("GetClusterTopology")
+	router.HandleFunc("/diagnostics", handler.handleGetDiagnostics).Methods("GET").Name("GetDiagnostics")
+	router.HandleFunc("/logs/tail", handler.handleGetLogsTail).Methods("GET").Name("GetLogsTail")
+	router.PathPrefix("/debug/pprof/").Handler(http.DefaultServeMux).Methods("GET")
+	router.Handle("/debug/vars", expvar.Handler()).Methods("GET")
+	router.HandleFunc("/fragments/compact", handler.handlePostFragmentsCompact).Methods("POST").Name("PostFragmentsCompact")
+	router.HandleFunc("/fragments/quarantined", handler.handleGetQuarantinedFragments).Methods("GET").Name("GetQuarantinedFragments")
+	router.HandleFunc("/index/{index}/rename", handler.handlePostIndexRename).Methods("POST").Name("PostIndexRename")
+	router.HandleFunc("/index/{index}/field/{field}/rename", handler.handlePostFieldRename).Methods("POST").Name("PostFieldRename")
+	router.HandleFunc("/recalculate-caches", handler.handleRecalculateCaches).Methods("POST").Name("RecalculateCaches")
+	router.HandleFunc("/replication/promote", handler.handlePostReplicationPromote).Methods("POST").Name("PostReplicationPromote")
+	router.HandleFunc("/self-heal", handler.handleGetSelfHeal).Methods("GET").Name("GetSelfHeal")
+	router.HandleFunc("/self-heal", handler.handlePostSelfHeal).Methods("POST").Name("PostSelfHeal")
+}
+
+func useMiddleware(router *mux.Router, handler *Handler) {
 	router.Use(handler.queryArgValidator)
+	router.Use(handler.limitBody)
+	router.Use(handler.checkTopologyEpoch)
+	router.Use(handler.checkProtocolVersion)
 	router.Use(handler.extractTracing)
 	router.Use(handler.collectStats)
-	return router
 }
 
 // ServeHTTP handles an HTTP request.
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.serve(h.Handler, w, r)
+}
+
+// serve handles an HTTP request with next, recovering from panics.
+func (h *Handler) serve(next http.Handler, w http.ResponseWriter, r *http.Request) {
 	defer func() {
 		if err := recover(); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -335,7 +642,7 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
-	h.Handler.ServeHTTP(w, r)
+	next.ServeHTTP(w, r)
 }
 
 // successResponse is a general success/error struct for http responses.
@@ -355,16 +662,25 @@ func (r *successResponse) check(err error) (statusCode int) {
 
 	cause := errors.Cause(err)
 
-	// Determine HTTP status code based on the error type.
-	switch cause.(type) {
-	case pilosa.BadRequestError:
-		statusCode = http.StatusBadRequest
-	case pilosa.ConflictError:
-		statusCode = http.StatusConflict
-	case pilosa.NotFoundError:
-		statusCode = http.StatusNotFound
+	// Determine HTTP status code based on the error type. Sentinel errors
+	// are checked first, since NotFoundError is an interface which any
+	// error satisfies.
+	switch cause {
+	case pilosa.ErrIndexRenaming, pilosa.ErrNoQuorum, pilosa.ErrShuttingDown:
+		statusCode = http.StatusServiceUnavailable
 	default:
-		statusCode = http.StatusInternalServerError
+		switch cause.(type) {
+		case bodyTooLargeError:
+			statusCode = http.StatusRequestEntityTooLarge
+		case pilosa.BadRequestError:
+			statusCode = http.StatusBadRequest
+		case pilosa.ConflictError:
+			statusCode = http.StatusConflict
+		case pilosa.NotFoundError:
+			statusCode = http.StatusNotFound
+		default:
+			statusCode = http.StatusInternalServerError
+		}
 	}
 
 	r.Success = false
@@ -424,6 +740,27 @@ func validHeaderAcceptJSON(header http.Header) bool {
 	return true
 }
 
+// delimitedProtobufContentType is the media type of a stream of
+// length-delimited protobuf messages.
+const delimitedProtobufContentType = "application/x-protobuf; delimited=true"
+
+// validHeaderAcceptDelimitedProtobuf returns true if the client accepts a
+// stream of length-delimited protobuf messages. The stream must be asked for
+// with the delimited parameter: a plain "application/x-protobuf" still gets a
+// single message, which is what existing clients, including other nodes,
+// expect.
+func validHeaderAcceptDelimitedProtobuf(header http.Header) bool {
+	for _, v := range header["Accept"] {
+		for _, v := range strings.Split(v, ",") {
+			typ, params, err := mime.ParseMediaType(v)
+			if err == nil && typ == "application/x-protobuf" && params["delimited"] == "true" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // handleGetSchema handles GET /schema requests.
 func (h *Handler) handleGetSchema(w http.ResponseWriter, r *http.Request) {
 	if !validHeaderAcceptJSON(r.Header) {
@@ -437,6 +774,51 @@ func (h *Handler) handleGetSchema(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleGetQuarantinedFragments handles GET /fragments/quarantined requests.
+func (h *Handler) handleGetQuarantinedFragments(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	frags := h.api.QuarantinedFragments(r.Context())
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{"fragments": frags}); err != nil {
+		h.logger.Printf("write quarantined fragments response error: %s", err)
+	}
+}
+
+// handlePostFragmentsCompact handles POST /fragments/compact requests. It
+// compacts the matching fragments on this node and responds with the bytes
+// reclaimed.
+func (h *Handler) handlePostFragmentsCompact(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	q := r.URL.Query()
+	shards, err := parseUint64Slice(q.Get("shard"))
+	if err != nil {
+		http.Error(w, "invalid shard argument", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.api.CompactFragments(r.Context(), q.Get("index"), q.Get("field"), q.Get("view"), shards)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case pilosa.BadRequestError:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case pilosa.NotFoundError:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		h.logger.Printf("write compaction response error: %s", err)
+	}
+}
+
 func (h *Handler) handlePostSchema(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
 	remoteStr := q.Get("remote")
@@ -447,7 +829,7 @@ func (h *Handler) handlePostSchema(w http.ResponseWriter, r *http.Request) {
 
 	schema := &pilosa.Schema{}
 	if err := json.NewDecoder(r.Body).Decode(schema); err != nil {
-		http.Error(w, fmt.Sprintf("decoding request as JSON Pilosa schema: %v", err), http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("decoding request as JSON Pilosa schema: %v", err), bodyErrorStatus(err, http.StatusBadRequest))
 		return
 	}
 
@@ -458,6 +840,93 @@ func (h *Handler) handlePostSchema(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// handlePostSchemaBulk handles POST /schema/bulk requests.
+func (h *Handler) handlePostSchemaBulk(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	resp := successResponse{h: h}
+
+	var req postSchemaBulkRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		if !isBodyTooLarge(err) {
+			err = pilosa.NewBadRequestError(errors.Wrap(err, "decoding request"))
+		}
+		resp.write(w, err)
+		return
+	}
+
+	defs := make([]pilosa.IndexDefinition, 0, len(req.Indexes))
+	for _, idx := range req.Indexes {
+		def := pilosa.IndexDefinition{Name: idx.Name, Options: idx.Options}
+		for _, f := range idx.Fields {
+			// Invalid options fail only their field, which is reported
+			// in the results like any other field which can't be created.
+			var opts []pilosa.FieldOption
+			if err := f.Options.validate(); err != nil {
+				opts = []pilosa.FieldOption{func(*pilosa.FieldOptions) error { return err }}
+			} else {
+				opts = f.Options.functionalOptions()
+			}
+			def.Fields = append(def.Fields, pilosa.FieldDefinition{Name: f.Name, Options: opts})
+		}
+		defs = append(defs, def)
+	}
+
+	results, err := h.api.CreateSchema(r.Context(), defs)
+	if err != nil {
+		resp.write(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(postSchemaBulkResponse{Results: results}); err != nil {
+		h.logger.Printf("write bulk schema response error: %s", err)
+	}
+}
+
+type postSchemaBulkRequest struct {
+	Indexes []postSchemaBulkIndex `json:"indexes"`
+}
+
+type postSchemaBulkIndex struct {
+	Name    string              `json:"name"`
+	Options pilosa.IndexOptions `json:"options"`
+	Fields  []struct {
+		Name    string       `json:"name"`
+		Options fieldOptions `json:"options"`
+	} `json:"fields"`
+}
+
+// _postSchemaBulkIndex is necessary to avoid recursion while decoding.
+type _postSchemaBulkIndex postSchemaBulkIndex
+
+// UnmarshalJSON decodes an index of a bulk schema request, defaulting its
+// options like those of a new index.
+func (p *postSchemaBulkIndex) UnmarshalJSON(b []byte) error {
+	_p := _postSchemaBulkIndex{
+		Options: pilosa.IndexOptions{
+			Keys:           false,
+			TrackExistence: true,
+		},
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&_p); err != nil {
+		return err
+	} else if _p.Options.PlacementName != "" {
+		return errors.New("unknown field \"placementName\"")
+	}
+	*p = postSchemaBulkIndex(_p)
+	return nil
+}
+
+type postSchemaBulkResponse struct {
+	Results []pilosa.CreateSchemaResult `json:"results"`
+}
+
 // handleGetStatus handles GET /status requests.
 func (h *Handler) handleGetStatus(w http.ResponseWriter, r *http.Request) {
 	if !validHeaderAcceptJSON(r.Header) {
@@ -468,6 +937,15 @@ func (h *Handler) handleGetStatus(w http.ResponseWriter, r *http.Request) {
 		State:   h.api.State(),
 		Nodes:   h.api.Hosts(r.Context()),
 		LocalID: h.api.Node().ID,
+		Epoch:   h.api.TopologyEpoch(),
+	}
+	status.CircuitBreakers = h.api.CircuitBreakers()
+	status.ProtocolVersion = h.api.ProtocolVersion()
+	if skews := h.api.ClockSkew(); len(skews) > 0 {
+		status.ClockSkew = make(map[string]string, len(skews))
+		for id, skew := range skews {
+			status.ClockSkew[id] = skew.String()
+		}
 	}
 	if err := json.NewEncoder(w).Encode(status); err != nil {
 		h.logger.Printf("write status response error: %s", err)
@@ -493,6 +971,30 @@ type getStatusResponse struct {
 	State   string         `json:"state"`
 	Nodes   []*pilosa.Node `json:"nodes"`
 	LocalID string         `json:"localID"`
+	Epoch   uint64         `json:"epoch"`
+
+	// ProtocolVersion is the protocol version negotiated with every node
+	// of the cluster, or zero if some node speaks no common version.
+	ProtocolVersion int `json:"protocolVersion"`
+
+	// ClockSkew holds the clock skew of the nodes whose clocks differ from
+	// the local node's by more than the clock skew threshold.
+	ClockSkew map[string]string `json:"clockSkew,omitempty"`
+
+	// CircuitBreakers holds the circuit breakers of the peers whose last
+	// queries from the local node failed.
+	CircuitBreakers []pilosa.CircuitBreakerStatus `json:"circuitBreakers,omitempty"`
+}
+
+// indexName returns the index in the path of r or, for the routes without
+// one, the default index. It returns a BadRequestError if neither is set.
+func (h *Handler) indexName(r *http.Request) (string, error) {
+	if name, ok := mux.Vars(r)["index"]; ok {
+		return name, nil
+	} else if h.defaultIndex != "" {
+		return h.defaultIndex, nil
+	}
+	return "", pilosa.NewBadRequestError(errors.New("index required: specify it in the path or configure a default index"))
 }
 
 // handlePostQuery handles /query requests.
@@ -500,7 +1002,7 @@ func (h *Handler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
 	// Parse incoming request.
 	req, err := h.readQueryRequest(r)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		w.WriteHeader(bodyErrorStatus(err, http.StatusBadRequest))
 		e := h.writeQueryResponse(w, r, &pilosa.QueryResponse{Err: err})
 		if e != nil {
 			h.logger.Printf("write query response error: %v (while trying to write another error: %v)", e, err)
@@ -508,13 +1010,26 @@ func (h *Handler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	// TODO: Remove
-	req.Index = mux.Vars(r)["index"]
+	if req.Index, err = h.indexName(r); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		if e := h.writeQueryResponse(w, r, &pilosa.QueryResponse{Err: err}); e != nil {
+			h.logger.Printf("write query response error: %v (while trying to write another error: %v)", e, err)
+		}
+		return
+	}
+	req.RemoteAddr = r.RemoteAddr
+	req.AllowUnbounded = r.Header.Get(AllowUnboundedHeader) == "true"
 
 	resp, err := h.api.Query(r.Context(), req)
+	if _, ok := errors.Cause(err).(pilosa.UnboundedQueryError); ok {
+		err = errors.Errorf("%s; or set the %s header to true to run it anyway", err, AllowUnboundedHeader)
+	}
 	if err != nil {
 		switch errors.Cause(err) {
 		case pilosa.ErrTooManyWrites:
 			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		case pilosa.ErrIndexRenaming, pilosa.ErrNoQuorum, pilosa.ErrMemoryLimit, pilosa.ErrShuttingDown:
+			w.WriteHeader(http.StatusServiceUnavailable)
 		case pilosa.ErrTranslateStoreReadOnly:
 			u := h.api.PrimaryReplicaNodeURL()
 			u.Path, u.RawQuery = r.URL.Path, r.URL.RawQuery
@@ -592,7 +1107,7 @@ type postIndexRequest struct {
 	Options pilosa.IndexOptions `json:"options"`
 }
 
-//_postIndexRequest is necessary to avoid recursion while decoding.
+// _postIndexRequest is necessary to avoid recursion while decoding.
 type _postIndexRequest postIndexRequest
 
 // Custom Unmarshal JSON to validate request body when creating a new index.
@@ -604,7 +1119,7 @@ func (p *postIndexRequest) UnmarshalJSON(b []byte) error {
 		return errors.Wrap(err, "unmarshalling unexpected values")
 	}
 
-	validIndexOptions := getValidOptions(pilosa.IndexOptions{})
+	validIndexOptions := getValidIndexOptions()
 	err := validateOptions(m, validIndexOptions)
 	if err != nil {
 		return err
@@ -625,6 +1140,19 @@ func (p *postIndexRequest) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// getValidIndexOptions returns the options a client can set on a new index.
+// The placement name is only set by renames.
+func getValidIndexOptions() []string {
+	options := getValidOptions(pilosa.IndexOptions{})
+	valid := options[:0]
+	for _, option := range options {
+		if option != "placementName" {
+			valid = append(valid, option)
+		}
+	}
+	return valid
+}
+
 func getValidOptions(option interface{}) []string {
 	validOptions := []string{}
 	val := reflect.ValueOf(option)
@@ -680,62 +1208,250 @@ func (h *Handler) handleDeleteIndex(w http.ResponseWriter, r *http.Request) {
 	resp.write(w, err)
 }
 
-// handlePostIndex handles POST /index request.
-func (h *Handler) handlePostIndex(w http.ResponseWriter, r *http.Request) {
+// handlePostIndexUndelete handles POST /index/<indexname>/undelete requests.
+func (h *Handler) handlePostIndexUndelete(w http.ResponseWriter, r *http.Request) {
 	if !validHeaderAcceptJSON(r.Header) {
 		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
 		return
 	}
-	indexName, ok := mux.Vars(r)["index"]
-	if !ok {
-		http.Error(w, "index name is required", http.StatusBadRequest)
-		return
-	}
+
+	indexName := mux.Vars(r)["index"]
 
 	resp := successResponse{h: h}
+	_, err := h.api.UndeleteIndex(r.Context(), indexName)
+	resp.write(w, err)
+}
 
-	// Decode request.
-	req := postIndexRequest{
-		Options: pilosa.IndexOptions{
-			Keys:           false,
-			TrackExistence: true,
-		},
+// renameRequest is the body of a request to rename an index or field.
+type renameRequest struct {
+	Name string `json:"name"`
+}
+
+// readRenameRequest decodes the new name of a rename request.
+func readRenameRequest(r *http.Request) (string, error) {
+	var req renameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if isBodyTooLarge(err) {
+			return "", err
+		}
+		return "", pilosa.NewBadRequestError(errors.Wrap(err, "decoding request"))
+	} else if req.Name == "" {
+		return "", pilosa.NewBadRequestError(errors.New("new name required"))
 	}
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if err != nil && err != io.EOF {
-		resp.write(w, err)
+	return req.Name, nil
+}
+
+// handlePostIndexRename handles POST /index/<indexname>/rename requests.
+func (h *Handler) handlePostIndexRename(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
 		return
 	}
-	_, err = h.api.CreateIndex(r.Context(), indexName, req.Options)
+	indexName := mux.Vars(r)["index"]
 
+	resp := successResponse{h: h}
+	newName, err := readRenameRequest(r)
+	if err == nil {
+		err = h.api.RenameIndex(r.Context(), indexName, newName)
+	}
 	resp.write(w, err)
 }
 
-// handlePostIndexAttrDiff handles POST /internal/index/attr/diff requests.
-func (h *Handler) handlePostIndexAttrDiff(w http.ResponseWriter, r *http.Request) {
+// handlePostFieldRename handles POST /index/<indexname>/field/<fieldname>/rename
+// requests.
+func (h *Handler) handlePostFieldRename(w http.ResponseWriter, r *http.Request) {
 	if !validHeaderAcceptJSON(r.Header) {
 		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
 		return
 	}
 	indexName := mux.Vars(r)["index"]
+	fieldName := mux.Vars(r)["field"]
 
-	// Decode request.
-	var req postIndexAttrDiffRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	resp := successResponse{h: h}
+	newName, err := readRenameRequest(r)
+	if err == nil {
+		err = h.api.RenameField(r.Context(), indexName, fieldName, newName)
+	}
+	resp.write(w, err)
+}
+
+// handleGetIndexTombstones handles GET /index-tombstones requests.
+func (h *Handler) handleGetIndexTombstones(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
 		return
 	}
 
-	attrs, err := h.api.IndexAttrDiff(r.Context(), indexName, req.Blocks)
+	tombstones, err := h.api.IndexTombstones(r.Context())
 	if err != nil {
-		if errors.Cause(err) == pilosa.ErrIndexNotFound {
-			http.Error(w, err.Error(), http.StatusNotFound)
-		} else {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-
+	if err := json.NewEncoder(w).Encode(getIndexTombstonesResponse{
+		Tombstones: tombstones,
+	}); err != nil {
+		h.logger.Printf("write index tombstones response error: %s", err)
+	}
+}
+
+type getIndexTombstonesResponse struct {
+	Tombstones []pilosa.IndexTombstone `json:"tombstones"`
+}
+
+// patchIndexRequest holds the index options which may be changed after
+// creation.
+type patchIndexRequest struct {
+	Options struct {
+		TimeQuantum *pilosa.TimeQuantum `json:"timeQuantum"`
+		Compression *string             `json:"compression"`
+	} `json:"options"`
+}
+
+// handlePatchIndex handles PATCH /index/<indexname> requests.
+func (h *Handler) handlePatchIndex(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	indexName := mux.Vars(r)["index"]
+
+	resp := successResponse{h: h}
+
+	// Decode request.
+	var req patchIndexRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		if !isBodyTooLarge(err) {
+			err = pilosa.NewBadRequestError(err)
+		}
+		resp.write(w, err)
+		return
+	}
+
+	var err error
+	if req.Options.TimeQuantum != nil {
+		err = h.api.SetIndexTimeQuantum(r.Context(), indexName, *req.Options.TimeQuantum)
+	}
+	if err == nil && req.Options.Compression != nil {
+		err = h.api.SetIndexCompression(r.Context(), indexName, *req.Options.Compression)
+	}
+	resp.write(w, err)
+}
+
+// handleGetIndexAliases handles GET /index-alias requests.
+func (h *Handler) handleGetIndexAliases(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(getIndexAliasesResponse{
+		Aliases: h.api.IndexAliases(r.Context()),
+	}); err != nil {
+		h.logger.Printf("write index aliases response error: %s", err)
+	}
+}
+
+type getIndexAliasesResponse struct {
+	Aliases map[string]string `json:"aliases"`
+}
+
+type postIndexAliasRequest struct {
+	Index string `json:"index"`
+}
+
+// handlePostIndexAlias handles POST /index-alias/<alias> requests.
+func (h *Handler) handlePostIndexAlias(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	alias := mux.Vars(r)["alias"]
+
+	var req postIndexAliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "decoding request "+err.Error(), bodyErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	resp := successResponse{h: h}
+	if req.Index == "" {
+		resp.write(w, pilosa.NewBadRequestError(pilosa.ErrIndexRequired))
+		return
+	}
+	err := h.api.SetIndexAlias(r.Context(), alias, req.Index)
+	resp.write(w, err)
+}
+
+// handleDeleteIndexAlias handles DELETE /index-alias/<alias> requests.
+func (h *Handler) handleDeleteIndexAlias(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	alias := mux.Vars(r)["alias"]
+
+	resp := successResponse{h: h}
+	err := h.api.DeleteIndexAlias(r.Context(), alias)
+	resp.write(w, err)
+}
+
+// handlePostIndex handles POST /index request.
+func (h *Handler) handlePostIndex(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	indexName, ok := mux.Vars(r)["index"]
+	if !ok {
+		http.Error(w, "index name is required", http.StatusBadRequest)
+		return
+	}
+
+	resp := successResponse{h: h}
+
+	// Decode request.
+	req := postIndexRequest{
+		Options: pilosa.IndexOptions{
+			Keys:           false,
+			TrackExistence: true,
+		},
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil && err != io.EOF {
+		resp.write(w, err)
+		return
+	}
+	_, err = h.api.CreateIndex(r.Context(), indexName, req.Options)
+
+	resp.write(w, err)
+}
+
+// handlePostIndexAttrDiff handles POST /internal/index/attr/diff requests.
+func (h *Handler) handlePostIndexAttrDiff(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	indexName := mux.Vars(r)["index"]
+
+	// Decode request.
+	var req postIndexAttrDiffRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), bodyErrorStatus(err, http.StatusBadRequest))
+		return
+	}
+
+	attrs, err := h.api.IndexAttrDiff(r.Context(), indexName, req.Blocks)
+	if err != nil {
+		if errors.Cause(err) == pilosa.ErrIndexNotFound {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
 	// Encode response.
 	if err := json.NewEncoder(w).Encode(postIndexAttrDiffResponse{
 		Attrs: attrs,
@@ -789,35 +1505,16 @@ func (h *Handler) handlePostField(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Convert json options into functional options.
-	var fos []pilosa.FieldOption
-	switch req.Options.Type {
-	case pilosa.FieldTypeSet:
-		fos = append(fos, pilosa.OptFieldTypeSet(*req.Options.CacheType, *req.Options.CacheSize))
-	case pilosa.FieldTypeInt:
-		if req.Options.Min == nil {
-			min := int64(math.MinInt64)
-			req.Options.Min = &min
-		}
-		if req.Options.Max == nil {
-			max := int64(math.MaxInt64)
-			req.Options.Max = &max
-		}
-		fos = append(fos, pilosa.OptFieldTypeInt(*req.Options.Min, *req.Options.Max))
-	case pilosa.FieldTypeTime:
-		fos = append(fos, pilosa.OptFieldTypeTime(*req.Options.TimeQuantum, req.Options.NoStandardView))
-	case pilosa.FieldTypeMutex:
-		fos = append(fos, pilosa.OptFieldTypeMutex(*req.Options.CacheType, *req.Options.CacheSize))
-	case pilosa.FieldTypeBool:
-		fos = append(fos, pilosa.OptFieldTypeBool())
-	}
-	if req.Options.Keys != nil {
-		if *req.Options.Keys {
-			fos = append(fos, pilosa.OptFieldKeys())
+	// A time field without a quantum inherits the index default, so one
+	// is only required when the index has none.
+	if req.Options.Type == pilosa.FieldTypeTime && req.Options.TimeQuantum == nil {
+		if index, err := h.api.Index(r.Context(), indexName); err == nil && index.Options().TimeQuantum == "" {
+			resp.write(w, pilosa.NewBadRequestError(errors.New("timeQuantum is required for field type time")))
+			return
 		}
 	}
 
-	_, err = h.api.CreateField(r.Context(), indexName, fieldName, fos...)
+	_, err = h.api.CreateField(r.Context(), indexName, fieldName, req.Options.functionalOptions()...)
 	if _, ok := err.(pilosa.BadRequestError); ok {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
@@ -838,6 +1535,7 @@ type fieldOptions struct {
 	Min            *int64              `json:"min,omitempty"`
 	Max            *int64              `json:"max,omitempty"`
 	TimeQuantum    *pilosa.TimeQuantum `json:"timeQuantum,omitempty"`
+	TimeUnit       *string             `json:"timeUnit,omitempty"`
 	Keys           *bool               `json:"keys,omitempty"`
 	NoStandardView bool                `json:"noStandardView,omitempty"`
 }
@@ -847,6 +1545,10 @@ func (o *fieldOptions) validate() error {
 	defaultCacheType := pilosa.DefaultCacheType
 	defaultCacheSize := uint32(pilosa.DefaultCacheSize)
 
+	if o.TimeUnit != nil && o.Type != pilosa.FieldTypeTimestamp {
+		return pilosa.NewBadRequestError(errors.Errorf("timeUnit does not apply to field type %s", o.Type))
+	}
+
 	switch o.Type {
 	case pilosa.FieldTypeSet, "":
 		// Because FieldTypeSet is the default, its arguments are
@@ -876,6 +1578,20 @@ func (o *fieldOptions) validate() error {
 		} else if o.TimeQuantum != nil {
 			return pilosa.NewBadRequestError(errors.New("timeQuantum does not apply to field type int"))
 		}
+	case pilosa.FieldTypeTimestamp:
+		if o.CacheType != nil {
+			return pilosa.NewBadRequestError(errors.New("cacheType does not apply to field type timestamp"))
+		} else if o.CacheSize != nil {
+			return pilosa.NewBadRequestError(errors.New("cacheSize does not apply to field type timestamp"))
+		} else if o.Min != nil {
+			return pilosa.NewBadRequestError(errors.New("min does not apply to field type timestamp"))
+		} else if o.Max != nil {
+			return pilosa.NewBadRequestError(errors.New("max does not apply to field type timestamp"))
+		} else if o.TimeQuantum != nil {
+			return pilosa.NewBadRequestError(errors.New("timeQuantum does not apply to field type timestamp"))
+		} else if o.TimeUnit != nil && *o.TimeUnit != "" && !pilosa.ValidTimeUnit(*o.TimeUnit) {
+			return pilosa.NewBadRequestError(errors.Errorf("invalid timeUnit: %q", *o.TimeUnit))
+		}
 	case pilosa.FieldTypeTime:
 		if o.CacheType != nil {
 			return pilosa.NewBadRequestError(errors.New("cacheType does not apply to field type time"))
@@ -885,8 +1601,6 @@ func (o *fieldOptions) validate() error {
 			return pilosa.NewBadRequestError(errors.New("min does not apply to field type time"))
 		} else if o.Max != nil {
 			return pilosa.NewBadRequestError(errors.New("max does not apply to field type time"))
-		} else if o.TimeQuantum == nil {
-			return pilosa.NewBadRequestError(errors.New("timeQuantum is required for field type time"))
 		}
 	case pilosa.FieldTypeMutex:
 		if o.CacheType == nil {
@@ -922,6 +1636,44 @@ func (o *fieldOptions) validate() error {
 	return nil
 }
 
+// functionalOptions converts validated options into functional options.
+func (o *fieldOptions) functionalOptions() []pilosa.FieldOption {
+	var fos []pilosa.FieldOption
+	switch o.Type {
+	case pilosa.FieldTypeSet:
+		fos = append(fos, pilosa.OptFieldTypeSet(*o.CacheType, *o.CacheSize))
+	case pilosa.FieldTypeInt:
+		min, max := int64(math.MinInt64), int64(math.MaxInt64)
+		if o.Min != nil {
+			min = *o.Min
+		}
+		if o.Max != nil {
+			max = *o.Max
+		}
+		fos = append(fos, pilosa.OptFieldTypeInt(min, max))
+	case pilosa.FieldTypeTimestamp:
+		var unit string
+		if o.TimeUnit != nil {
+			unit = *o.TimeUnit
+		}
+		fos = append(fos, pilosa.OptFieldTypeTimestamp(unit))
+	case pilosa.FieldTypeTime:
+		var q pilosa.TimeQuantum
+		if o.TimeQuantum != nil {
+			q = *o.TimeQuantum
+		}
+		fos = append(fos, pilosa.OptFieldTypeTime(q, o.NoStandardView))
+	case pilosa.FieldTypeMutex:
+		fos = append(fos, pilosa.OptFieldTypeMutex(*o.CacheType, *o.CacheSize))
+	case pilosa.FieldTypeBool:
+		fos = append(fos, pilosa.OptFieldTypeBool())
+	}
+	if o.Keys != nil && *o.Keys {
+		fos = append(fos, pilosa.OptFieldKeys())
+	}
+	return fos
+}
+
 // handleDeleteField handles DELETE /field request.
 func (h *Handler) handleDeleteField(w http.ResponseWriter, r *http.Request) {
 	if !validHeaderAcceptJSON(r.Header) {
@@ -965,7 +1717,7 @@ func (h *Handler) handlePostFieldAttrDiff(w http.ResponseWriter, r *http.Request
 	// Decode request.
 	var req postFieldAttrDiffRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), bodyErrorStatus(err, http.StatusBadRequest))
 		return
 	}
 
@@ -1039,18 +1791,40 @@ func (h *Handler) readURLQueryRequest(r *http.Request) (*pilosa.QueryRequest, er
 		return nil, errors.New("invalid shard argument")
 	}
 
+	// Parse page size.
+	var limit uint64
+	if s := q.Get("limit"); s != "" {
+		if limit, err = strconv.ParseUint(s, 10, 64); err != nil {
+			return nil, errors.New("invalid limit argument")
+		}
+	}
+
+	// Parse maximum number of result rows.
+	var maxResultRows uint64
+	if s := q.Get("maxResultRows"); s != "" {
+		if maxResultRows, err = strconv.ParseUint(s, 10, 64); err != nil {
+			return nil, errors.New("invalid maxResultRows argument")
+		}
+	}
+
 	return &pilosa.QueryRequest{
 		Query:           query,
 		Shards:          shards,
 		ColumnAttrs:     q.Get("columnAttrs") == "true",
 		ExcludeRowAttrs: q.Get("excludeRowAttrs") == "true",
 		ExcludeColumns:  q.Get("excludeColumns") == "true",
+		Limit:           limit,
+		Cursor:          q.Get("cursor"),
+		MaxResultRows:   maxResultRows,
 	}, nil
 }
 
 // writeQueryResponse writes the response from the executor to w.
 func (h *Handler) writeQueryResponse(w http.ResponseWriter, r *http.Request, resp *pilosa.QueryResponse) error {
-	if !validHeaderAcceptJSON(r.Header) {
+	if validHeaderAcceptDelimitedProtobuf(r.Header) {
+		w.Header().Set("Content-Type", delimitedProtobufContentType)
+		return h.writeDelimitedQueryResponse(w, resp)
+	} else if !validHeaderAcceptJSON(r.Header) {
 		w.Header().Set("Content-Type", "application/protobuf")
 		return h.writeProtobufQueryResponse(w, resp)
 	}
@@ -1068,6 +1842,39 @@ func (h *Handler) writeProtobufQueryResponse(w io.Writer, resp *pilosa.QueryResp
 	return nil
 }
 
+// writeDelimitedQueryResponse writes the response from the executor to w as a
+// stream of length-delimited protobuf QueryResponse messages. Each message
+// holds a single result, in order, and a final message holds the column
+// attributes and the error, if there are any.
+func (h *Handler) writeDelimitedQueryResponse(w io.Writer, resp *pilosa.QueryResponse) error {
+	for _, result := range resp.Results {
+		if err := h.writeDelimited(w, &pilosa.QueryResponse{Results: []interface{}{result}}); err != nil {
+			return err
+		}
+	}
+	if resp.Err != nil || len(resp.ColumnAttrSets) > 0 || resp.Page != nil || resp.Partial() || resp.Truncated || resp.AutoCreated {
+		return h.writeDelimited(w, &pilosa.QueryResponse{ColumnAttrSets: resp.ColumnAttrSets, Err: resp.Err, Page: resp.Page, UnavailableShards: resp.UnavailableShards, Truncated: resp.Truncated, AutoCreated: resp.AutoCreated})
+	}
+	return nil
+}
+
+// writeDelimited writes resp to w as protobuf, prefixed with its length as a
+// varint.
+func (h *Handler) writeDelimited(w io.Writer, resp pilosa.Message) error {
+	buf, err := h.api.Serializer.Marshal(resp)
+	if err != nil {
+		return errors.Wrap(err, "marshalling")
+	}
+	var prefix [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(prefix[:], uint64(len(buf)))
+	if _, err := w.Write(prefix[:n]); err != nil {
+		return errors.Wrap(err, "writing length")
+	} else if _, err := w.Write(buf); err != nil {
+		return errors.Wrap(err, "writing")
+	}
+	return nil
+}
+
 // writeJSONQueryResponse writes the response from the executor to w as JSON.
 func (h *Handler) writeJSONQueryResponse(w io.Writer, resp *pilosa.QueryResponse) error {
 	return json.NewEncoder(w).Encode(resp)
@@ -1083,22 +1890,32 @@ func (h *Handler) handlePostImport(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Not acceptable", http.StatusNotAcceptable)
 		return
 	}
-	indexName := mux.Vars(r)["index"]
+	indexName, err := h.indexName(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	fieldName := mux.Vars(r)["field"]
 
 	// If the clear flag is true, treat the import as clear bits.
 	q := r.URL.Query()
 	doClear := q.Get("clear") == "true"
 	doIgnoreKeyCheck := q.Get("ignoreKeyCheck") == "true"
+	var conflicts, set, cleared uint64
+	var autoCreated bool
 
 	opts := []pilosa.ImportOption{
 		pilosa.OptImportOptionsClear(doClear),
 		pilosa.OptImportOptionsIgnoreKeyCheck(doIgnoreKeyCheck),
+		pilosa.OptImportOptionsConflictPolicy(pilosa.ImportConflictPolicy(q.Get("conflictPolicy"))),
+		pilosa.OptImportOptionsConflicts(&conflicts),
+		pilosa.OptImportOptionsChanges(&set, &cleared),
+		pilosa.OptImportOptionsAutoCreated(&autoCreated),
 	}
 
 	// Get index and field type to determine how to handle the
-	// import data.
-	field, err := h.api.Field(r.Context(), indexName, fieldName)
+	// import data. A missing field is typed as it would be auto-created.
+	fieldType, err := h.api.ImportFieldType(r.Context(), indexName, fieldName)
 	if err != nil {
 		switch errors.Cause(err) {
 		case pilosa.ErrIndexNotFound:
@@ -1114,19 +1931,23 @@ func (h *Handler) handlePostImport(w http.ResponseWriter, r *http.Request) {
 	// Read entire body.
 	body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.writeBodyError(w, err)
 		return
 	}
 
 	// Unmarshal request based on field type.
-	if field.Type() == pilosa.FieldTypeInt {
-		// Field type: Int
+	resp := &pilosa.ImportResponse{}
+	if fieldType == pilosa.FieldTypeInt || fieldType == pilosa.FieldTypeTimestamp {
+		// Field type: Int, Timestamp
 		// Marshal into request object.
 		req := &pilosa.ImportValueRequest{}
 		if err := h.api.Serializer.Unmarshal(body, req); err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
+		if req.Index == "" {
+			req.Index = indexName
+		}
 
 		if err := h.api.ImportValue(r.Context(), req, opts...); err != nil {
 			switch errors.Cause(err) {
@@ -1145,29 +1966,282 @@ func (h *Handler) handlePostImport(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
+		if req.Index == "" {
+			req.Index = indexName
+		}
+
+		if err := h.api.Import(r.Context(), req, opts...); err != nil {
+			switch errors.Cause(err).(type) {
+			case pilosa.BadRequestError:
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			case pilosa.ConflictError:
+				http.Error(w, err.Error(), http.StatusConflict)
+				return
+			}
+			switch errors.Cause(err) {
+			case pilosa.ErrClusterDoesNotOwnShard:
+				http.Error(w, err.Error(), http.StatusPreconditionFailed)
+			default:
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+		resp.Bits = uint64(len(req.ColumnIDs))
+		resp.ColumnAttrs = uint64(len(req.ColumnAttrs))
+		resp.Conflicts = conflicts
+		resp.Set, resp.Cleared = set, cleared
+	}
+	resp.AutoCreated = autoCreated
+
+	// Marshal response object.
+	buf, e := h.api.Serializer.Marshal(resp)
+	if e != nil {
+		http.Error(w, fmt.Sprintf("marshal import response"), http.StatusInternalServerError)
+		return
+	}
+
+	// Write response.
+	_, err = w.Write(buf)
+	if err != nil {
+		h.logger.Printf("writing import response: %v", err)
+	}
+}
+
+// handlePostImportBool handles /import-bool requests.
+func (h *Handler) handlePostImportBool(w http.ResponseWriter, r *http.Request) {
+	// Verify that request is only communicating over protobufs.
+	if r.Header.Get("Content-Type") != "application/x-protobuf" {
+		http.Error(w, "Unsupported media type", http.StatusUnsupportedMediaType)
+		return
+	} else if r.Header.Get("Accept") != "application/x-protobuf" {
+		http.Error(w, "Not acceptable", http.StatusNotAcceptable)
+		return
+	}
+	indexName, err := h.indexName(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	q := r.URL.Query()
+	var conflicts, set, cleared uint64
+	opts := []pilosa.ImportOption{
+		pilosa.OptImportOptionsClear(q.Get("clear") == "true"),
+		pilosa.OptImportOptionsIgnoreKeyCheck(q.Get("ignoreKeyCheck") == "true"),
+		pilosa.OptImportOptionsConflictPolicy(pilosa.ImportConflictPolicy(q.Get("conflictPolicy"))),
+		pilosa.OptImportOptionsConflicts(&conflicts),
+		pilosa.OptImportOptionsChanges(&set, &cleared),
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		h.writeBodyError(w, err)
+		return
+	}
+	req := &pilosa.ImportBoolRequest{}
+	if err := h.api.Serializer.Unmarshal(body, req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Index == "" {
+		req.Index = indexName
+	}
+	if req.Field == "" {
+		req.Field = mux.Vars(r)["field"]
+	}
+
+	if err := h.api.ImportBool(r.Context(), req, opts...); err != nil {
+		switch errors.Cause(err).(type) {
+		case pilosa.BadRequestError:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		case pilosa.ConflictError:
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		case pilosa.NotFoundError:
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		switch errors.Cause(err) {
+		case pilosa.ErrClusterDoesNotOwnShard:
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	n := len(req.ColumnIDs)
+	if len(req.ColumnKeys) > 0 {
+		n = len(req.ColumnKeys)
+	}
+	buf, err := h.api.Serializer.Marshal(&pilosa.ImportResponse{
+		Bits:      uint64(n),
+		Conflicts: conflicts,
+		Set:       set,
+		Cleared:   cleared,
+	})
+	if err != nil {
+		http.Error(w, "marshal import response", http.StatusInternalServerError)
+		return
+	}
+	if _, err := w.Write(buf); err != nil {
+		h.logger.Printf("writing import response: %v", err)
+	}
+}
+
+// bodyTooLargeError is returned when reading a request body larger than the
+// handler's maximum body size of max bytes.
+type bodyTooLargeError struct {
+	max int64
+}
+
+func (e bodyTooLargeError) Error() string {
+	return fmt.Sprintf("request body exceeds the maximum of %d bytes", e.max)
+}
+
+// isBodyTooLarge returns true if err is caused by a bodyTooLargeError.
+func isBodyTooLarge(err error) bool {
+	_, ok := errors.Cause(err).(bodyTooLargeError)
+	return ok
+}
+
+// limitedBody is a request body which fails with a bodyTooLargeError once
+// more than max bytes have been read from it.
+type limitedBody struct {
+	io.ReadCloser
+	max int64
+	n   int64 // bytes which may still be read
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.n < 0 {
+		return 0, bodyTooLargeError{max: b.max}
+	}
+	// Read one byte past the limit, so that a body of exactly n bytes can
+	// be told apart from a longer one.
+	if int64(len(p)) > b.n+1 {
+		p = p[:b.n+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.n -= int64(n)
+	if b.n < 0 {
+		return n - 1, bodyTooLargeError{max: b.max}
+	}
+	return n, err
+}
+
+// limitBody restricts the request body to the handler's maximum body size.
+// Decoders reading from the body incrementally fail as soon as they read
+// past the limit. Import streams are not limited as a whole, since they
+// check the size of each of their batches.
+func (h *Handler) limitBody(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.maxBodyBytes > 0 && r.Body != nil && mux.CurrentRoute(r).GetName() != "PostImportStream" {
+			r.Body = &limitedBody{ReadCloser: r.Body, max: h.maxBodyBytes, n: h.maxBodyBytes}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// bodyErrorStatus returns the status code for err, returned while reading a
+// request body: 413 if the body exceeded the maximum size, otherwise code.
+func bodyErrorStatus(err error, code int) int {
+	if isBodyTooLarge(err) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return code
+}
+
+// writeBodyError writes the error returned while reading a request body,
+// using 413 if the body exceeded the maximum size.
+func (h *Handler) writeBodyError(w http.ResponseWriter, err error) {
+	http.Error(w, err.Error(), bodyErrorStatus(err, http.StatusBadRequest))
+}
+
+// handlePostImportSession handles POST /index/<index>/field/<field>/import-session requests.
+func (h *Handler) handlePostImportSession(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	indexName, err := h.indexName(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	fieldName := mux.Vars(r)["field"]
+	doClear := r.URL.Query().Get("clear") == "true"
+
+	s, err := h.api.CreateImportSession(r.Context(), indexName, fieldName, pilosa.OptImportOptionsClear(doClear))
+	h.writeImportSession(w, s, err)
+}
+
+// handleGetImportSession handles GET /import-session/<id> requests.
+func (h *Handler) handleGetImportSession(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	s, err := h.api.ImportSession(r.Context(), mux.Vars(r)["id"])
+	h.writeImportSession(w, s, err)
+}
 
-		if err := h.api.Import(r.Context(), req, opts...); err != nil {
-			switch errors.Cause(err) {
-			case pilosa.ErrClusterDoesNotOwnShard:
-				http.Error(w, err.Error(), http.StatusPreconditionFailed)
-			default:
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-			return
-		}
+// handlePostImportSessionChunk handles POST /import-session/<id>/chunk/<chunk>
+// requests. The body is encoded the same way as for /import.
+func (h *Handler) handlePostImportSessionChunk(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Content-Type") != "application/x-protobuf" {
+		http.Error(w, "Unsupported media type", http.StatusUnsupportedMediaType)
+		return
 	}
 
-	// Marshal response object.
-	buf, e := h.api.Serializer.Marshal(&pilosa.ImportResponse{Err: ""})
-	if e != nil {
-		http.Error(w, fmt.Sprintf("marshal import response"), http.StatusInternalServerError)
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		h.writeBodyError(w, err)
 		return
 	}
 
-	// Write response.
-	_, err = w.Write(buf)
+	err = h.api.AddImportSessionChunk(r.Context(), mux.Vars(r)["id"], mux.Vars(r)["chunk"], body)
+	if errors.Cause(err) == pilosa.ErrClusterDoesNotOwnShard {
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		return
+	}
+	resp := successResponse{h: h}
+	resp.write(w, err)
+}
+
+// handlePostImportSessionCommit handles POST /import-session/<id>/commit requests.
+func (h *Handler) handlePostImportSessionCommit(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	s, err := h.api.CommitImportSession(r.Context(), mux.Vars(r)["id"])
+	h.writeImportSession(w, s, err)
+}
+
+// handleDeleteImportSession handles DELETE /import-session/<id> requests.
+func (h *Handler) handleDeleteImportSession(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	resp := successResponse{h: h}
+	resp.write(w, h.api.AbortImportSession(r.Context(), mux.Vars(r)["id"]))
+}
+
+func (h *Handler) writeImportSession(w http.ResponseWriter, s *pilosa.ImportSession, err error) {
 	if err != nil {
-		h.logger.Printf("writing import response: %v", err)
+		resp := successResponse{h: h}
+		resp.write(w, err)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(s); err != nil {
+		h.logger.Printf("write import session response error: %s", err)
 	}
 }
 
@@ -1205,6 +2279,113 @@ func (h *Handler) handleGetExportCSV(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleGetRowColumns handles GET /index/{index}/field/{field}/row/{row}/columns
+// requests. The columns are streamed as they are read, either as NDJSON with
+// one column ID per line, or, with format=binary, as frames each holding a
+// uvarint count followed by that many uvarint column IDs, and terminated by
+// an empty frame. An error which occurs once the response has started is
+// written as a final JSON object line for NDJSON, and leaves a binary
+// response without its terminating frame.
+func (h *Handler) handleGetRowColumns(w http.ResponseWriter, r *http.Request) {
+	indexName, fieldName := mux.Vars(r)["index"], mux.Vars(r)["field"]
+	q := r.URL.Query()
+
+	var start, end uint64
+	var err error
+	if s := q.Get("start"); s != "" {
+		if start, err = strconv.ParseUint(s, 10, 64); err != nil {
+			http.Error(w, "start should be an unsigned integer", http.StatusBadRequest)
+			return
+		}
+	}
+	if s := q.Get("end"); s != "" {
+		if end, err = strconv.ParseUint(s, 10, 64); err != nil {
+			http.Error(w, "end should be an unsigned integer", http.StatusBadRequest)
+			return
+		}
+	}
+	format, contentType := q.Get("format"), "application/x-ndjson"
+	switch format {
+	case "", "ndjson":
+		format = "ndjson"
+	case "binary":
+		contentType = "application/octet-stream"
+	default:
+		http.Error(w, fmt.Sprintf("invalid format: %s", format), http.StatusBadRequest)
+		return
+	}
+
+	// Rows of fields with keys are always given by key, even if it looks
+	// like an ID.
+	var row interface{} = mux.Vars(r)["row"]
+	if field, err := h.api.Field(r.Context(), indexName, fieldName); err != nil || !field.Options().Keys {
+		if id, err := strconv.ParseUint(mux.Vars(r)["row"], 10, 64); err == nil {
+			row = id
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	var started bool
+	buf := make([]byte, binary.MaxVarintLen64)
+	err = h.api.RowColumns(r.Context(), indexName, fieldName, row, start, end, func(columns []uint64) error {
+		if !started {
+			w.Header().Set("Content-Type", contentType)
+			started = true
+		}
+
+		if format == "binary" {
+			if _, err := bw.Write(buf[:binary.PutUvarint(buf, uint64(len(columns)))]); err != nil {
+				return err
+			}
+			for _, col := range columns {
+				if _, err := bw.Write(buf[:binary.PutUvarint(buf, col)]); err != nil {
+					return err
+				}
+			}
+		} else {
+			for _, col := range columns {
+				if _, err := bw.Write(strconv.AppendUint(buf[:0], col, 10)); err != nil {
+					return err
+				} else if err := bw.WriteByte('\n'); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Flush each shard so that the client receives the columns as
+		// they are read.
+		if err := bw.Flush(); err != nil {
+			return err
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		return nil
+	})
+	if err != nil && !started {
+		resp := successResponse{h: h}
+		resp.write(w, err)
+		return
+	} else if err != nil {
+		h.logger.Printf("streaming columns of row %v of field %s: %s", row, fieldName, err)
+		if format == "ndjson" {
+			_ = json.NewEncoder(bw).Encode(map[string]interface{}{"error": err.Error()})
+		}
+		_ = bw.Flush()
+		return
+	}
+
+	if !started {
+		w.Header().Set("Content-Type", contentType)
+	}
+	if format == "binary" {
+		_ = bw.WriteByte(0)
+	}
+	if err := bw.Flush(); err != nil {
+		h.logger.Printf("write row columns response error: %s", err)
+	}
+}
+
 // handleGetFragmentNodes handles /internal/fragment/nodes requests.
 func (h *Handler) handleGetFragmentNodes(w http.ResponseWriter, r *http.Request) {
 	if !validHeaderAcceptJSON(r.Header) {
@@ -1234,6 +2415,58 @@ func (h *Handler) handleGetFragmentNodes(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// handleGetShardNodes handles /internal/shard-nodes requests. The shard is
+// given directly, or as a column or column key of which it is computed.
+func (h *Handler) handleGetShardNodes(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	q := r.URL.Query()
+	index := q.Get("index")
+
+	var set int
+	for _, arg := range []string{"shard", "column", "key"} {
+		if q.Get(arg) != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		http.Error(w, "exactly one of shard, column and key is required", http.StatusBadRequest)
+		return
+	}
+
+	var owners *pilosa.ShardOwners
+	var err error
+	switch {
+	case q.Get("shard") != "":
+		shard, perr := strconv.ParseUint(q.Get("shard"), 10, 64)
+		if perr != nil {
+			http.Error(w, "shard should be an unsigned integer", http.StatusBadRequest)
+			return
+		}
+		owners, err = h.api.ShardOwners(r.Context(), index, shard)
+	case q.Get("column") != "":
+		column, perr := strconv.ParseUint(q.Get("column"), 10, 64)
+		if perr != nil {
+			http.Error(w, "column should be an unsigned integer", http.StatusBadRequest)
+			return
+		}
+		owners, err = h.api.ColumnOwners(r.Context(), index, column, "")
+	default:
+		owners, err = h.api.ColumnOwners(r.Context(), index, 0, q.Get("key"))
+	}
+	if err != nil {
+		resp := successResponse{h: h}
+		resp.write(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(owners); err != nil {
+		h.logger.Printf("json write error: %s", err)
+	}
+}
+
 // handleGetNodes handles /internal/nodes requests.
 func (h *Handler) handleGetNodes(w http.ResponseWriter, r *http.Request) {
 	if !validHeaderAcceptJSON(r.Header) {
@@ -1287,7 +2520,7 @@ func (h *Handler) handleGetFragmentBlocks(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	blocks, err := h.api.FragmentBlocks(r.Context(), q.Get("index"), q.Get("field"), q.Get("view"), shard)
+	blocks, checksum, err := h.api.FragmentBlocks(r.Context(), q.Get("index"), q.Get("field"), q.Get("view"), shard, q.Get("checksum"))
 	if err != nil {
 		if errors.Cause(err) == pilosa.ErrFragmentNotFound {
 			http.Error(w, err.Error(), http.StatusNotFound)
@@ -1299,14 +2532,16 @@ func (h *Handler) handleGetFragmentBlocks(w http.ResponseWriter, r *http.Request
 
 	// Encode response.
 	if err := json.NewEncoder(w).Encode(getFragmentBlocksResponse{
-		Blocks: blocks,
+		Blocks:   blocks,
+		Checksum: checksum,
 	}); err != nil {
 		h.logger.Printf("block response encoding error: %s", err)
 	}
 }
 
 type getFragmentBlocksResponse struct {
-	Blocks []pilosa.FragmentBlock `json:"blocks"`
+	Blocks   []pilosa.FragmentBlock `json:"blocks"`
+	Checksum string                 `json:"checksum,omitempty"`
 }
 
 // handleGetFragmentData handles GET /internal/fragment/data requests.
@@ -1330,6 +2565,29 @@ func (h *Handler) handleGetFragmentData(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// handleGetUsage handles GET /usage requests. It responds with the storage
+// used by each index on this node.
+func (h *Handler) handleGetUsage(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	usage, err := h.api.Usage(r.Context(), r.URL.Query().Get("index"))
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case pilosa.NotFoundError:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	if err := json.NewEncoder(w).Encode(usage); err != nil {
+		h.logger.Printf("write usage response error: %s", err)
+	}
+}
+
 // handleGetVersion handles /version requests.
 func (h *Handler) handleGetVersion(w http.ResponseWriter, r *http.Request) {
 	if !validHeaderAcceptJSON(r.Header) {
@@ -1337,9 +2595,13 @@ func (h *Handler) handleGetVersion(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	err := json.NewEncoder(w).Encode(struct {
-		Version string `json:"version"`
+		Version            string `json:"version"`
+		ProtocolVersion    int    `json:"protocolVersion"`
+		MinProtocolVersion int    `json:"minProtocolVersion"`
 	}{
-		Version: h.api.Version(),
+		Version:            h.api.Version(),
+		ProtocolVersion:    pilosa.ProtocolVersion,
+		MinProtocolVersion: pilosa.MinProtocolVersion,
 	})
 	if err != nil {
 		h.logger.Printf("write version response error: %s", err)
@@ -1381,7 +2643,7 @@ func (h *Handler) handlePostClusterResizeSetCoordinator(w http.ResponseWriter, r
 	var req setCoordinatorRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
-		http.Error(w, "decoding request "+err.Error(), http.StatusBadRequest)
+		http.Error(w, "decoding request "+err.Error(), bodyErrorStatus(err, http.StatusBadRequest))
 		return
 	}
 
@@ -1403,6 +2665,195 @@ func (h *Handler) handlePostClusterResizeSetCoordinator(w http.ResponseWriter, r
 	}
 }
 
+// handleGetClusterShardDistribution handles GET /cluster/shard-distribution requests.
+func (h *Handler) handleGetClusterShardDistribution(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	dist, err := h.api.ShardDistribution(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(dist); err != nil {
+		h.logger.Printf("write shard distribution response error: %s", err)
+	}
+}
+
+// handleGetClusterTopology handles GET /cluster/topology requests.
+func (h *Handler) handleGetClusterTopology(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	topology, err := h.api.ClusterTopology(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(topology); err != nil {
+		h.logger.Printf("write topology response error: %s", err)
+	}
+}
+
+type antiEntropySyncResponse struct {
+	BitsReconciled int `json:"bitsReconciled"`
+}
+
+// handlePostClusterAntiEntropySync handles POST /cluster/anti-entropy/sync
+// requests. It returns once the requested fragments have been synced.
+func (h *Handler) handlePostClusterAntiEntropySync(w http.ResponseWriter, r *http.Request) {
+	h.syncAntiEntropy(w, r, r.URL.Query().Get("remote") == "true")
+}
+
+// handlePostInternalAntiEntropySync handles POST
+// /internal/cluster/anti-entropy/sync requests, which other nodes send to sync
+// their shards of a cluster-wide sync. Unlike /cluster/anti-entropy/sync, it
+// is served alongside the data routes and only syncs the local node.
+func (h *Handler) handlePostInternalAntiEntropySync(w http.ResponseWriter, r *http.Request) {
+	h.syncAntiEntropy(w, r, true)
+}
+
+func (h *Handler) syncAntiEntropy(w http.ResponseWriter, r *http.Request, remote bool) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	q := r.URL.Query()
+	shards, err := parseUint64Slice(q.Get("shard"))
+	if err != nil {
+		http.Error(w, "invalid shard argument", http.StatusBadRequest)
+		return
+	}
+
+	n, err := h.api.SyncAntiEntropy(r.Context(), q.Get("index"), shards, remote)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case pilosa.BadRequestError:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			switch errors.Cause(err) {
+			case pilosa.ErrIndexNotFound:
+				http.Error(w, err.Error(), http.StatusNotFound)
+			case pilosa.ErrAntiEntropyQueueFull:
+				http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			default:
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+		}
+		return
+	}
+	if err := json.NewEncoder(w).Encode(antiEntropySyncResponse{BitsReconciled: n}); err != nil {
+		h.logger.Printf("write anti-entropy sync response error: %s", err)
+	}
+}
+
+// handleGetSelfHeal handles GET /self-heal requests.
+func (h *Handler) handleGetSelfHeal(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(h.api.SelfHealStatus(r.Context())); err != nil {
+		h.logger.Printf("write self-heal response error: %s", err)
+	}
+}
+
+// handlePostSelfHeal handles POST /self-heal requests. It starts pulling the
+// shards missing on this node from their replicas and responds with the
+// initial progress.
+func (h *Handler) handlePostSelfHeal(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	status, err := h.api.SelfHeal(r.Context())
+	if err != nil {
+		if _, ok := errors.Cause(err).(pilosa.ConflictError); ok {
+			http.Error(w, err.Error(), http.StatusConflict)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		h.logger.Printf("write self-heal response error: %s", err)
+	}
+}
+
+// handleGetReplication handles GET /replication requests.
+func (h *Handler) handleGetReplication(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(h.api.ReplicationStatus(r.Context())); err != nil {
+		h.logger.Printf("write replication response error: %s", err)
+	}
+}
+
+// handlePostReplicationPromote handles POST /replication/promote requests. It
+// stops replication from the upstream cluster and makes the node writable.
+func (h *Handler) handlePostReplicationPromote(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	status, err := h.api.PromoteStandby(r.Context())
+	if err != nil {
+		if _, ok := errors.Cause(err).(pilosa.ConflictError); ok {
+			http.Error(w, err.Error(), http.StatusConflict)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		h.logger.Printf("write replication response error: %s", err)
+	}
+}
+
+// handleGetInternalSchema handles GET /internal/schema requests.
+func (h *Handler) handleGetInternalSchema(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(h.api.FullSchema(r.Context())); err != nil {
+		h.logger.Printf("write schema response error: %s", err)
+	}
+}
+
+// handleGetQueries handles GET /queries requests.
+func (h *Handler) handleGetQueries(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	queries, err := h.api.Queries(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(queries); err != nil {
+		h.logger.Printf("write queries response error: %s", err)
+	}
+}
+
+// handleDeleteQuery handles DELETE /queries/<id> requests. It cancels the
+// running query with the given ID.
+func (h *Handler) handleDeleteQuery(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	resp := successResponse{h: h}
+	resp.write(w, h.api.CancelQuery(r.Context(), mux.Vars(r)["id"]))
+}
+
 type setCoordinatorRequest struct {
 	ID string `json:"id"`
 }
@@ -1422,7 +2873,7 @@ func (h *Handler) handlePostClusterResizeRemoveNode(w http.ResponseWriter, r *ht
 	var req removeNodeRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), bodyErrorStatus(err, http.StatusBadRequest))
 		return
 	}
 
@@ -1508,7 +2959,7 @@ func (h *Handler) handlePostClusterMessage(w http.ResponseWriter, r *http.Reques
 	err := h.api.ClusterMessage(r.Context(), r.Body)
 	if err != nil {
 		// TODO this was the previous behavior, but perhaps not everything is a bad request
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), bodyErrorStatus(err, http.StatusBadRequest))
 	}
 
 	if err := json.NewEncoder(w).Encode(defaultClusterMessageResponse{}); err != nil {
@@ -1522,7 +2973,7 @@ func (h *Handler) handlePostTranslateData(w http.ResponseWriter, r *http.Request
 	// Parse offsets for all indexes and fields from POST body.
 	offsets := make(pilosa.TranslateOffsetMap)
 	if err := json.NewDecoder(r.Body).Decode(&offsets); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), bodyErrorStatus(err, http.StatusInternalServerError))
 		return
 	}
 
@@ -1630,7 +3081,11 @@ func (h *Handler) handlePostImportRoaring(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	indexName := mux.Vars(r)["index"]
+	indexName, err := h.indexName(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	fieldName := mux.Vars(r)["field"]
 
 	q := r.URL.Query()
@@ -1648,7 +3103,7 @@ func (h *Handler) handlePostImportRoaring(w http.ResponseWriter, r *http.Request
 	span.LogKV("bodySize", len(body))
 	span.Finish()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.writeBodyError(w, err)
 		return
 	}
 
@@ -1707,7 +3162,7 @@ func (h *Handler) handlePostTranslateKeys(w http.ResponseWriter, r *http.Request
 
 	buf, err := h.api.TranslateKeys(r.Body)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("translate keys: %v", err), http.StatusInternalServerError)
+		http.Error(w, fmt.Sprintf("translate keys: %v", err), bodyErrorStatus(err, http.StatusInternalServerError))
 	}
 
 	// Write response.
@@ -37,6 +37,7 @@ func TestPostIndexRequestUnmarshalJSON(t *testing.T) {
 		{json: `{"options": 4}`, err: "options is not map[string]interface{}"},
 		{json: `{"option": {}}`, err: "unknown key: option:map[]"},
 		{json: `{"options": {"badKey": "test"}}`, err: "unknown key: badKey:test"},
+		{json: `{"options": {"placementName": "i"}}`, err: "unknown key: placementName:i"},
 	}
 	for _, test := range tests {
 		actual := &postIndexRequest{}
@@ -145,7 +146,9 @@ func TestFieldOptionValidation(t *testing.T) {
 		{json: `{"options": {"type": "int", "min": 0, "max": 1000, "timeQuantum": "YMD"}}`, err: "timeQuantum does not apply to field type int"},
 
 		// FieldType: Time
-		{json: `{"options": {"type": "time"}}`, err: "timeQuantum is required for field type time"},
+		{json: `{"options": {"type": "time"}}`, expected: postFieldRequest{Options: fieldOptions{
+			Type: pilosa.FieldTypeTime,
+		}}},
 		{json: `{"options": {"type": "time", "timeQuantum": "YMD"}}`, expected: postFieldRequest{Options: fieldOptions{
 			Type:        pilosa.FieldTypeTime,
 			TimeQuantum: &timeQuantum,
@@ -154,6 +157,18 @@ func TestFieldOptionValidation(t *testing.T) {
 		{json: `{"options": {"type": "time", "timeQuantum": "YMD", "max": 1000}}`, err: "max does not apply to field type time"},
 		{json: `{"options": {"type": "time", "timeQuantum": "YMD", "cacheType": "ranked"}}`, err: "cacheType does not apply to field type time"},
 		{json: `{"options": {"type": "time", "timeQuantum": "YMD", "cacheSize": 1000}}`, err: "cacheSize does not apply to field type time"},
+
+		// FieldType: Timestamp
+		{json: `{"options": {"type": "timestamp"}}`, expected: postFieldRequest{Options: fieldOptions{
+			Type: pilosa.FieldTypeTimestamp,
+		}}},
+		{json: `{"options": {"type": "timestamp", "timeUnit": "ms"}}`, expected: postFieldRequest{Options: fieldOptions{
+			Type:     pilosa.FieldTypeTimestamp,
+			TimeUnit: stringPtr(pilosa.TimeUnitMilliseconds),
+		}}},
+		{json: `{"options": {"type": "timestamp", "timeUnit": "h"}}`, err: `invalid timeUnit: "h"`},
+		{json: `{"options": {"type": "timestamp", "min": 0}}`, err: "min does not apply to field type timestamp"},
+		{json: `{"options": {"type": "int", "min": 0, "max": 1000, "timeUnit": "s"}}`, err: "timeUnit does not apply to field type int"},
 	}
 	for i, test := range tests {
 		actual := &postFieldRequest{}
@@ -0,0 +1,180 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/pilosa/pilosa/v2"
+	"github.com/pkg/errors"
+)
+
+// defaultImportStreamAckInterval is the number of batches of a streaming
+// import applied between acknowledgments, unless the client sets its own.
+const defaultImportStreamAckInterval = 100
+
+// handlePostImportStream handles POST /index/<index>/field/<field>/import-stream
+// requests. The body is a stream of length-delimited protobuf import batches,
+// each encoded as for /import, whose columns may span several shards. Each
+// batch is split by shard and imported into the owning nodes before the next
+// is read. The response is a stream of length-delimited ImportResponse
+// messages, one every ackInterval batches if the connection is full duplex
+// and a last one at the end of the stream, counting the batches applied so
+// far. If a batch fails, the last
+// message holds the error, and counts only the batches applied before it.
+func (h *Handler) handlePostImportStream(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Content-Type") != delimitedProtobufContentType {
+		http.Error(w, "Unsupported media type", http.StatusUnsupportedMediaType)
+		return
+	} else if !validHeaderAcceptDelimitedProtobuf(r.Header) {
+		http.Error(w, "Not acceptable", http.StatusNotAcceptable)
+		return
+	}
+	indexName, err := h.indexName(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	fieldName := mux.Vars(r)["field"]
+
+	q := r.URL.Query()
+	ackInterval := uint64(defaultImportStreamAckInterval)
+	if s := q.Get("ackInterval"); s != "" {
+		if ackInterval, err = strconv.ParseUint(s, 10, 64); err != nil || ackInterval == 0 {
+			http.Error(w, "ackInterval should be a positive integer", http.StatusBadRequest)
+			return
+		}
+	}
+	var conflicts, set, cleared uint64
+	var autoCreated bool
+	opts := []pilosa.ImportOption{
+		pilosa.OptImportOptionsClear(q.Get("clear") == "true"),
+		pilosa.OptImportOptionsConflictPolicy(pilosa.ImportConflictPolicy(q.Get("conflictPolicy"))),
+		pilosa.OptImportOptionsConflicts(&conflicts),
+		pilosa.OptImportOptionsChanges(&set, &cleared),
+		pilosa.OptImportOptionsAutoCreated(&autoCreated),
+	}
+
+	fieldType, err := h.api.ImportFieldType(r.Context(), indexName, fieldName)
+	if err != nil {
+		switch errors.Cause(err) {
+		case pilosa.ErrIndexNotFound, pilosa.ErrFieldNotFound:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	values := fieldType == pilosa.FieldTypeInt || fieldType == pilosa.FieldTypeTimestamp
+
+	// Acknowledgments are written while the batches are still being read
+	// only over a full duplex connection. HTTP/2 streams always are, and
+	// HTTP/1 ones are once enabled, if the server supports it. Otherwise, a
+	// response written may close the body, so the only acknowledgment is the
+	// last one, written once the stream ends.
+	duplex := r.ProtoMajor >= 2 || enableFullDuplex(w)
+	w.Header().Set("Content-Type", delimitedProtobufContentType)
+
+	resp := &pilosa.ImportResponse{}
+	ack := func() error {
+		resp.Conflicts, resp.Set, resp.Cleared = conflicts, set, cleared
+		resp.AutoCreated = autoCreated
+		if err := h.writeDelimited(w, resp); err != nil {
+			return err
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		return nil
+	}
+
+	br := bufio.NewReader(r.Body)
+	for {
+		buf, err := h.readDelimited(br)
+		if err == io.EOF {
+			break
+		} else if err == nil {
+			err = h.importBatch(r, indexName, fieldName, values, buf, resp, opts)
+		}
+		if err != nil {
+			resp.Err = err.Error()
+			break
+		}
+		resp.Batches++
+		if duplex && resp.Batches%ackInterval == 0 {
+			if err := ack(); err != nil {
+				h.logger.Printf("writing import stream ack: %v", err)
+				return
+			}
+		}
+	}
+	if err := ack(); err != nil {
+		h.logger.Printf("writing import stream ack: %v", err)
+	}
+}
+
+// importBatch imports a batch of a streaming import, counting the bits and
+// column attributes it holds in resp.
+func (h *Handler) importBatch(r *http.Request, indexName, fieldName string, values bool, buf []byte, resp *pilosa.ImportResponse, opts []pilosa.ImportOption) error {
+	if values {
+		req := &pilosa.ImportValueRequest{}
+		if err := h.api.Serializer.Unmarshal(buf, req); err != nil {
+			return errors.Wrap(err, "unmarshalling batch")
+		}
+		req.Index, req.Field = indexName, fieldName
+		if err := h.api.ImportValueShards(r.Context(), req, opts...); err != nil {
+			return err
+		}
+		resp.Bits += uint64(len(req.Values))
+		return nil
+	}
+
+	req := &pilosa.ImportRequest{}
+	if err := h.api.Serializer.Unmarshal(buf, req); err != nil {
+		return errors.Wrap(err, "unmarshalling batch")
+	}
+	req.Index, req.Field = indexName, fieldName
+	bits := len(req.ColumnIDs) + len(req.ColumnKeys) // before keys are translated into ids
+	if err := h.api.ImportShards(r.Context(), req, opts...); err != nil {
+		return err
+	}
+	resp.Bits += uint64(bits)
+	resp.ColumnAttrs += uint64(len(req.ColumnAttrs))
+	return nil
+}
+
+// readDelimited reads a message prefixed with its length as a varint. It
+// returns io.EOF only at the end of a message. Messages are limited to the
+// maximum size of a request body.
+func (h *Handler) readDelimited(r *bufio.Reader) ([]byte, error) {
+	n, err := binary.ReadUvarint(r)
+	if err == io.EOF {
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, errors.Wrap(err, "reading length")
+	} else if h.maxBodyBytes > 0 && n > uint64(h.maxBodyBytes) {
+		return nil, errors.Errorf("batch of %d bytes exceeds the maximum of %d bytes", n, h.maxBodyBytes)
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, errors.Wrap(err, "reading batch")
+	}
+	return buf, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bufio"
+	"net/http"
+	"strconv"
+)
+
+// defaultLogTailLines is the number of log lines returned by /logs/tail when
+// the request does not specify it.
+const defaultLogTailLines = 100
+
+// handleGetLogsTail handles GET /logs/tail requests. The response is the last
+// lines of the server log as plain text, one line per log line. If follow is
+// set, lines logged afterwards are streamed until the client disconnects.
+//
+// Like diagnostics, logs are only served to clients which present a verified
+// TLS client certificate.
+func (h *Handler) handleGetLogsTail(w http.ResponseWriter, r *http.Request) {
+	if !verifiedClient(r) {
+		http.Error(w, "logs require a verified client certificate", http.StatusForbidden)
+		return
+	} else if h.logTail == nil {
+		http.Error(w, "log tail is disabled", http.StatusNotFound)
+		return
+	}
+
+	q := r.URL.Query()
+	n := defaultLogTailLines
+	if s := q.Get("lines"); s != "" {
+		v, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			http.Error(w, "invalid lines argument", http.StatusBadRequest)
+			return
+		}
+		n = int(v)
+	}
+	follow := q.Get("follow") == "true"
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	bw := bufio.NewWriter(w)
+	flush := func() error {
+		if err := bw.Flush(); err != nil {
+			return err
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		return nil
+	}
+	writeLine := func(line string) error {
+		if _, err := bw.WriteString(line); err != nil {
+			return err
+		}
+		return bw.WriteByte('\n')
+	}
+
+	if !follow {
+		for _, line := range h.logTail.Lines(n) {
+			if err := writeLine(line); err != nil {
+				return
+			}
+		}
+		_ = bw.Flush()
+		return
+	}
+
+	lines, ch, stop := h.logTail.Follow(n)
+	defer stop()
+	for _, line := range lines {
+		if err := writeLine(line); err != nil {
+			return
+		}
+	}
+	if err := flush(); err != nil {
+		return
+	}
+
+	// Stream new lines until the client disconnects, which cancels the
+	// request's context.
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case line := <-ch:
+			if err := writeLine(line); err != nil {
+				return
+			} else if err := flush(); err != nil {
+				return
+			}
+		}
+	}
+}
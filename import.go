@@ -0,0 +1,475 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+// importSessionsDir is the name of the directory, inside the holder's data
+// directory, in which chunks of open import sessions are staged.
+const importSessionsDir = ".import-sessions"
+
+// importSessionMetaFile is the name of the file, in the directory of a
+// session, which holds its importSessionMeta.
+const importSessionMetaFile = "session.json"
+
+// importSessionShardsDir is the name of the directory, in the directory of a
+// session, in which a commit stages the data of each shard.
+const importSessionShardsDir = "shards"
+
+// ImportSession describes a chunked import into a single field. Chunks are
+// staged on disk as they are received and are only applied to the field when
+// the session is committed.
+type ImportSession struct {
+	ID    string `json:"id"`
+	Index string `json:"index"`
+	Field string `json:"field"`
+	Clear bool   `json:"clear"`
+
+	// Chunks holds the IDs of the accepted chunks in the order received.
+	Chunks []string `json:"chunks"`
+
+	// Applied holds the IDs of the chunks which have been applied, which
+	// are all of them once the session is committed.
+	Applied []string `json:"applied"`
+
+	// Shards holds the shards which have been written to. A commit which
+	// fails partway can be retried and will resume with the first shard not
+	// listed here.
+	Shards []uint64 `json:"shards"`
+}
+
+// importSessionMeta is the state of a session which is saved next to its
+// staged chunks, so that the session survives a restart.
+type importSessionMeta struct {
+	ID     string   `json:"id"`
+	Index  string   `json:"index"`
+	Field  string   `json:"field"`
+	Clear  bool     `json:"clear"`
+	Values bool     `json:"values"` // chunks are ImportValueRequests rather than ImportRequests
+	Chunks []string `json:"chunks"`
+
+	// Staged holds the shards whose data a commit has staged, once every
+	// chunk has been prepared, and Applied the shards of those which have
+	// been written to.
+	Prepared bool     `json:"prepared"`
+	Staged   []uint64 `json:"staged"`
+	Applied  []uint64 `json:"applied"`
+}
+
+// importSession is the server side state of an ImportSession.
+type importSession struct {
+	mu sync.Mutex
+
+	meta     importSessionMeta
+	accepted map[string]struct{} // IDs of meta.Chunks
+	path     string
+	perm     os.FileMode // mode with which staged files are created
+	dirPerm  os.FileMode
+
+	// closed is set once the session has been committed or aborted.
+	closed bool
+}
+
+// status returns a snapshot of the session.
+func (s *importSession) status() *ImportSession {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	is := &ImportSession{
+		ID:      s.meta.ID,
+		Index:   s.meta.Index,
+		Field:   s.meta.Field,
+		Clear:   s.meta.Clear,
+		Chunks:  make([]string, len(s.meta.Chunks)),
+		Applied: []string{},
+		Shards:  make([]uint64, len(s.meta.Applied)),
+	}
+	copy(is.Chunks, s.meta.Chunks)
+	if s.closed {
+		is.Applied = is.Chunks
+	}
+	copy(is.Shards, s.meta.Applied)
+	return is
+}
+
+// addChunk stages the data of a chunk. Re-sending an already accepted chunk
+// is a no-op so that clients can safely retry. Chunks cannot be added once a
+// commit has started writing the data of the session.
+func (s *importSession) addChunk(chunk string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return newNotFoundError(ErrImportSessionNotFound, s.meta.ID)
+	} else if _, ok := s.accepted[chunk]; ok {
+		return nil
+	} else if len(s.meta.Applied) > 0 {
+		return newConflictError(ErrImportSessionPartlyCommitted)
+	}
+
+	// The chunk is only accepted once the metadata listing it is saved, so
+	// that a chunk file left by a crash is overwritten by the next chunk.
+	file := filepath.Join(s.path, strconv.Itoa(len(s.meta.Chunks)))
+	if err := ioutil.WriteFile(file, data, s.perm); err != nil {
+		return errors.Wrap(err, "writing chunk")
+	}
+	meta := s.meta
+	meta.Chunks = append(meta.Chunks[:len(meta.Chunks):len(meta.Chunks)], chunk)
+	meta.Prepared, meta.Staged = false, nil
+	if err := s.save(meta); err != nil {
+		return err
+	}
+	s.accepted[chunk] = struct{}{}
+	return nil
+}
+
+// commit applies the session, in two steps, and closes it.
+//
+// First the chunks are passed, in the order they were received, to prepare,
+// which decodes, validates and translates each one and stages the data it
+// writes to each shard with stage. Only one chunk is held in memory at a time,
+// and a bad chunk fails the commit before anything is written.
+//
+// Then the staged data of each shard is passed to apply, which writes it at
+// once, so that each shard of the import becomes visible as a whole. The
+// shards applied are saved, so that a commit which fails partway, or is
+// interrupted by a restart, can be retried and resumes with the next shard.
+// Applying a shard again is harmless, since imports are idempotent.
+func (s *importSession) commit(prepare func(data []byte, stage func(shard uint64, data []byte) error) error, apply func(shard uint64, data [][]byte) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return newNotFoundError(ErrImportSessionNotFound, s.meta.ID)
+	}
+
+	if !s.meta.Prepared {
+		staged, err := s.stage(prepare)
+		if err != nil {
+			return err
+		}
+		meta := s.meta
+		meta.Prepared, meta.Staged = true, staged
+		if err := s.save(meta); err != nil {
+			return err
+		}
+	}
+
+	applied := make(map[uint64]struct{}, len(s.meta.Applied))
+	for _, shard := range s.meta.Applied {
+		applied[shard] = struct{}{}
+	}
+	for _, shard := range s.meta.Staged {
+		if _, ok := applied[shard]; ok {
+			continue
+		}
+		data, err := s.readShard(shard)
+		if err != nil {
+			return errors.Wrapf(err, "reading staged shard %d", shard)
+		} else if err := apply(shard, data); err != nil {
+			return errors.Wrapf(err, "applying shard %d", shard)
+		}
+		meta := s.meta
+		meta.Applied = append(meta.Applied[:len(meta.Applied):len(meta.Applied)], shard)
+		if err := s.save(meta); err != nil {
+			return err
+		}
+	}
+	return s.close()
+}
+
+// stage passes each chunk to prepare, appending the data it stages for a
+// shard to the staging file of the shard. It returns the staged shards in
+// order. If prepare fails the staged data is discarded.
+func (s *importSession) stage(prepare func(data []byte, stage func(shard uint64, data []byte) error) error) ([]uint64, error) {
+	dir := filepath.Join(s.path, importSessionShardsDir)
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, errors.Wrap(err, "removing staged shards")
+	} else if err := os.MkdirAll(dir, s.dirPerm); err != nil {
+		return nil, errors.Wrap(err, "creating staged shards directory")
+	}
+
+	shards := make(map[uint64]struct{})
+	stage := func(shard uint64, data []byte) error {
+		shards[shard] = struct{}{}
+		return appendStagedData(filepath.Join(dir, strconv.FormatUint(shard, 10)), data, s.perm)
+	}
+	for i, chunk := range s.meta.Chunks {
+		data, err := ioutil.ReadFile(filepath.Join(s.path, strconv.Itoa(i)))
+		if err == nil {
+			err = prepare(data, stage)
+		}
+		if err != nil {
+			_ = os.RemoveAll(dir)
+			return nil, errors.Wrapf(err, "preparing chunk %s", chunk)
+		}
+	}
+
+	staged := make([]uint64, 0, len(shards))
+	for shard := range shards {
+		staged = append(staged, shard)
+	}
+	sort.Slice(staged, func(i, j int) bool { return staged[i] < staged[j] })
+	return staged, nil
+}
+
+// appendStagedData appends data, prefixed with its length, to the file at
+// path.
+func appendStagedData(path string, data []byte, perm os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, perm)
+	if err != nil {
+		return errors.Wrap(err, "opening staged shard")
+	}
+	buf := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(data))
+	buf = append(buf[:binary.PutUvarint(buf, uint64(len(data)))], data...)
+	if _, err := f.Write(buf); err != nil {
+		f.Close()
+		return errors.Wrap(err, "writing staged shard")
+	} else if err := f.Sync(); err != nil {
+		f.Close()
+		return errors.Wrap(err, "syncing staged shard")
+	}
+	return errors.Wrap(f.Close(), "closing staged shard")
+}
+
+// readShard returns the data staged for shard, in the order it was staged.
+func (s *importSession) readShard(shard uint64) ([][]byte, error) {
+	f, err := os.Open(filepath.Join(s.path, importSessionShardsDir, strconv.FormatUint(shard, 10)))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var a [][]byte
+	r := bufio.NewReader(f)
+	for {
+		n, err := binary.ReadUvarint(r)
+		if err == io.EOF {
+			return a, nil
+		} else if err != nil {
+			return nil, err
+		}
+		data := make([]byte, n)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		a = append(a, data)
+	}
+}
+
+// save replaces the saved metadata of the session with meta, and makes it the
+// metadata of the session. It must be called with the session lock held.
+func (s *importSession) save(meta importSessionMeta) error {
+	buf, err := json.Marshal(meta)
+	if err != nil {
+		return errors.Wrap(err, "marshalling session")
+	}
+	path := filepath.Join(s.path, importSessionMetaFile)
+	f, err := os.OpenFile(path+".tmp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, s.perm)
+	if err != nil {
+		return errors.Wrap(err, "creating session file")
+	}
+	if _, err := f.Write(buf); err != nil {
+		f.Close()
+		return errors.Wrap(err, "writing session file")
+	} else if err := f.Sync(); err != nil {
+		f.Close()
+		return errors.Wrap(err, "syncing session file")
+	} else if err := f.Close(); err != nil {
+		return errors.Wrap(err, "closing session file")
+	} else if err := os.Rename(path+".tmp", path); err != nil {
+		return errors.Wrap(err, "renaming session file")
+	}
+	s.meta = meta
+	return nil
+}
+
+// close marks the session as closed and removes its staged chunks. It must be
+// called with the session lock held.
+func (s *importSession) close() error {
+	s.closed = true
+	return errors.Wrap(os.RemoveAll(s.path), "removing staged chunks")
+}
+
+// importSessions holds the open import sessions of a node. Each session is
+// saved with its staged chunks in the data directory, and is reloaded when
+// the holder is opened, until it is committed or aborted.
+type importSessions struct {
+	mu       sync.Mutex
+	sessions map[string]*importSession
+}
+
+func newImportSessions() *importSessions {
+	return &importSessions{
+		sessions: make(map[string]*importSession),
+	}
+}
+
+// load reloads the sessions saved in the data directory of h. Directories
+// which do not hold a session, such as those of a session whose creation
+// was interrupted, and the sessions of fields which no longer exist, are
+// removed.
+func (m *importSessions) load(h *Holder) error {
+	dir := filepath.Join(h.Path, importSessionsDir)
+	fis, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "reading import sessions directory")
+	}
+
+	sessions := make(map[string]*importSession, len(fis))
+	for _, fi := range fis {
+		path := filepath.Join(dir, fi.Name())
+		s, err := loadImportSession(path, h)
+		if err != nil {
+			h.Logger.Printf("removing import session %s: %s", fi.Name(), err)
+			if err := os.RemoveAll(path); err != nil {
+				return errors.Wrap(err, "removing import session")
+			}
+			continue
+		}
+		sessions[s.meta.ID] = s
+	}
+
+	m.mu.Lock()
+	m.sessions = sessions
+	m.mu.Unlock()
+	return nil
+}
+
+// loadImportSession reads the session saved in the directory at path.
+func loadImportSession(path string, h *Holder) (*importSession, error) {
+	buf, err := ioutil.ReadFile(filepath.Join(path, importSessionMetaFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "reading session file")
+	}
+	var meta importSessionMeta
+	if err := json.Unmarshal(buf, &meta); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling session file")
+	} else if meta.ID != filepath.Base(path) {
+		return nil, errors.Errorf("session file of session %s", meta.ID)
+	} else if h.Field(meta.Index, meta.Field) == nil {
+		return nil, errors.Errorf("field %s/%s not found", meta.Index, meta.Field)
+	}
+
+	// Remove what an interrupted change left behind: the files of chunks
+	// which were not accepted, and the data staged by a commit which did
+	// not finish preparing.
+	fis, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading session directory")
+	}
+	for _, fi := range fis {
+		name := fi.Name()
+		if n, err := strconv.Atoi(name); err == nil && n >= 0 && n < len(meta.Chunks) {
+			continue
+		} else if name == importSessionMetaFile || (name == importSessionShardsDir && meta.Prepared) {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(path, name)); err != nil {
+			return nil, errors.Wrap(err, "removing stale file")
+		}
+	}
+
+	s := newImportSession(h, path, meta)
+	for _, chunk := range meta.Chunks {
+		s.accepted[chunk] = struct{}{}
+	}
+	return s, nil
+}
+
+func newImportSession(h *Holder, path string, meta importSessionMeta) *importSession {
+	return &importSession{
+		meta:     meta,
+		accepted: make(map[string]struct{}),
+		path:     path,
+		perm:     h.filePerm,
+		dirPerm:  h.dirPerm,
+	}
+}
+
+// create opens a new session which stages its chunks in the data directory
+// of h.
+func (m *importSessions) create(h *Holder, index, field string, values, clear bool) (*importSession, error) {
+	id := uuid.NewV4().String()
+	path := filepath.Join(h.Path, importSessionsDir, id)
+	if err := os.MkdirAll(path, h.dirPerm); err != nil {
+		return nil, errors.Wrap(err, "creating session directory")
+	}
+
+	s := newImportSession(h, path, importSessionMeta{})
+	if err := s.save(importSessionMeta{ID: id, Index: index, Field: field, Clear: clear, Values: values, Chunks: []string{}}); err != nil {
+		_ = os.RemoveAll(path)
+		return nil, err
+	}
+
+	m.mu.Lock()
+	m.sessions[id] = s
+	m.mu.Unlock()
+	return s, nil
+}
+
+// get returns the session with the given ID.
+func (m *importSessions) get(id string) (*importSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[id]
+	if !ok {
+		return nil, newNotFoundError(ErrImportSessionNotFound, id)
+	}
+	return s, nil
+}
+
+// remove forgets the session with the given ID.
+func (m *importSessions) remove(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sessions, id)
+}
+
+// abort closes a session without applying it. A session which a commit has
+// started writing cannot be aborted, since its data is partly visible; the
+// commit must be retried instead.
+func (m *importSessions) abort(id string) error {
+	s, err := m.get(id)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return newNotFoundError(ErrImportSessionNotFound, id)
+	} else if len(s.meta.Applied) > 0 {
+		return newConflictError(ErrImportSessionPartlyCommitted)
+	}
+	m.remove(id)
+	return s.close()
+}
@@ -0,0 +1,165 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// stageBytes stages each byte of a chunk in the shard of its value.
+func stageBytes(data []byte, stage func(uint64, []byte) error) error {
+	for _, b := range data {
+		if b == 'x' {
+			return errors.New("bad chunk")
+		} else if err := stage(uint64(b-'0'), []byte{b}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestImportSession_Commit(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	h.MustCreateFieldIfNotExists("i", "f")
+
+	applied := make(map[uint64][][]byte)
+	apply := func(shard uint64, data [][]byte) error {
+		if shard == 2 && applied[2] == nil {
+			applied[2] = [][]byte{}
+			return errors.New("unavailable")
+		}
+		applied[shard] = data
+		return nil
+	}
+
+	// A bad chunk fails the commit before any shard is applied.
+	bad, err := h.importSessions.create(h.Holder, "i", "f", false, false)
+	if err != nil {
+		t.Fatal(err)
+	} else if err := bad.addChunk("a", []byte("0")); err != nil {
+		t.Fatal(err)
+	} else if err := bad.addChunk("b", []byte("x")); err != nil {
+		t.Fatal(err)
+	}
+	if err := bad.commit(stageBytes, apply); err == nil {
+		t.Fatal("expected bad chunk error")
+	} else if len(applied) != 0 {
+		t.Fatalf("unexpected applied shards: %v", applied)
+	} else if err := h.importSessions.abort(bad.meta.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// The shards applied before a failure are kept, and the commit resumes
+	// with the failed shard.
+	s, err := h.importSessions.create(h.Holder, "i", "f", false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, data := range []string{"10", "2", "1"} {
+		if err := s.addChunk(strconv.Itoa(i), []byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.commit(stageBytes, apply); err == nil {
+		t.Fatal("expected apply error")
+	} else if st := s.status(); !reflect.DeepEqual(st.Shards, []uint64{0, 1}) || len(st.Applied) != 0 {
+		t.Fatalf("unexpected session after failed commit: %+v", st)
+	} else if !reflect.DeepEqual(applied[1], [][]byte{[]byte("1"), []byte("1")}) {
+		t.Fatalf("unexpected data of shard 1: %q", applied[1])
+	}
+	if err := s.addChunk("late", []byte("3")); !isConflict(err, ErrImportSessionPartlyCommitted) {
+		t.Fatalf("expected partly committed error, got %v", err)
+	} else if err := h.importSessions.abort(s.meta.ID); !isConflict(err, ErrImportSessionPartlyCommitted) {
+		t.Fatalf("expected partly committed error, got %v", err)
+	}
+
+	applied = map[uint64][][]byte{2: applied[2]}
+	if err := s.commit(stageBytes, apply); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(applied, map[uint64][][]byte{2: {[]byte("2")}}) {
+		t.Fatalf("unexpected resumed shards: %q", applied)
+	} else if st := s.status(); !reflect.DeepEqual(st.Applied, []string{"0", "1", "2"}) || !reflect.DeepEqual(st.Shards, []uint64{0, 1, 2}) {
+		t.Fatalf("unexpected committed session: %+v", st)
+	} else if _, err := os.Stat(s.path); !os.IsNotExist(err) {
+		t.Fatalf("expected session directory to be removed, got %v", err)
+	}
+}
+
+func TestImportSessions_Load(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	h.MustCreateFieldIfNotExists("i", "f")
+
+	s, err := h.importSessions.create(h.Holder, "i", "f", true, true)
+	if err != nil {
+		t.Fatal(err)
+	} else if err := s.addChunk("a", []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Leave behind a session without metadata, the file of a chunk which was
+	// not accepted, and the data staged by an unfinished commit.
+	dir := filepath.Join(h.Path, importSessionsDir)
+	if err := os.MkdirAll(filepath.Join(dir, "orphan"), 0700); err != nil {
+		t.Fatal(err)
+	} else if err := ioutil.WriteFile(filepath.Join(s.path, "1"), []byte("2"), 0600); err != nil {
+		t.Fatal(err)
+	} else if err := os.MkdirAll(filepath.Join(s.path, importSessionShardsDir), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := h.Holder.Close(); err != nil {
+		t.Fatal(err)
+	} else if err := h.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := h.importSessions.get(s.meta.ID)
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(r.meta, s.meta) {
+		t.Fatalf("unexpected reloaded session: %+v", r.meta)
+	} else if err := r.addChunk("a", []byte("1")); err != nil {
+		t.Fatal(err)
+	} else if len(r.meta.Chunks) != 1 {
+		t.Fatalf("expected accepted chunk to be kept, got %v", r.meta.Chunks)
+	}
+
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(fis) != 1 || fis[0].Name() != s.meta.ID {
+		t.Fatalf("expected orphaned session to be removed, got %d entries", len(fis))
+	}
+	for _, name := range []string{"1", importSessionShardsDir} {
+		if _, err := os.Stat(filepath.Join(r.path, name)); !os.IsNotExist(err) {
+			t.Fatalf("expected stale %s to be removed, got %v", name, err)
+		}
+	}
+}
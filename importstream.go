@@ -0,0 +1,123 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+
+	"github.com/pilosa/pilosa/v2/tracing"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// ImportShards imports the bits of req, whose columns may span several
+// shards, into the nodes owning each shard. It is used for the batches of
+// streaming imports, which clients do not split by shard. Imports with keys
+// are split by Import once their keys are translated.
+func (api *API) ImportShards(ctx context.Context, req *ImportRequest, opts ...ImportOption) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.ImportShards")
+	defer span.Finish()
+
+	if err := api.validate(apiImport); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+	options, err := setUpImportOptions(opts...)
+	if err != nil {
+		return errors.Wrap(err, "setting up import options")
+	} else if err := api.autoCreateImport(ctx, req.Index, req.Field, "", len(req.ColumnKeys) > 0, len(req.RowKeys) > 0, options); err != nil {
+		return err
+	}
+	index, field, err := api.indexField(req.Index, req.Field, req.Shard)
+	if err != nil {
+		return errors.Wrap(err, "getting index and field")
+	} else if index.Keys() || field.keys() {
+		return api.Import(ctx, req, opts...)
+	} else if len(req.RowIDs) != len(req.ColumnIDs) {
+		return NewBadRequestError(errors.Errorf("%d row ids for %d column ids", len(req.RowIDs), len(req.ColumnIDs)))
+	} else if len(req.Timestamps) > 0 && len(req.Timestamps) != len(req.ColumnIDs) {
+		return NewBadRequestError(errors.Errorf("%d timestamps for %d column ids", len(req.Timestamps), len(req.ColumnIDs)))
+	}
+
+	m := make(map[uint64]*ImportRequest)
+	shardReq := func(shard uint64) *ImportRequest {
+		if _, ok := m[shard]; !ok {
+			m[shard] = &ImportRequest{Index: req.Index, Field: req.Field, Shard: shard}
+		}
+		return m[shard]
+	}
+	for i, colID := range req.ColumnIDs {
+		r := shardReq(colID / ShardWidth)
+		r.RowIDs = append(r.RowIDs, req.RowIDs[i])
+		r.ColumnIDs = append(r.ColumnIDs, colID)
+		if len(req.Timestamps) > 0 {
+			r.Timestamps = append(r.Timestamps, req.Timestamps[i])
+		}
+	}
+	for _, set := range req.ColumnAttrs {
+		r := shardReq(set.ID / ShardWidth)
+		r.ColumnAttrs = append(r.ColumnAttrs, set)
+	}
+
+	ctx = WithTopologyEpoch(ctx, api.cluster.Epoch())
+	var eg errgroup.Group
+	for _, r := range m {
+		r := r
+		eg.Go(func() error {
+			return api.server.defaultClient.ImportShard(ctx, r, opts...)
+		})
+	}
+	return eg.Wait()
+}
+
+// ImportValueShards imports the values of req, whose columns may span
+// several shards, into the nodes owning each shard, like ImportShards.
+func (api *API) ImportValueShards(ctx context.Context, req *ImportValueRequest, opts ...ImportOption) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.ImportValueShards")
+	defer span.Finish()
+
+	if err := api.validate(apiImportValue); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+	options, err := setUpImportOptions(opts...)
+	if err != nil {
+		return errors.Wrap(err, "setting up import options")
+	} else if err := api.autoCreateImport(ctx, req.Index, req.Field, FieldTypeInt, len(req.ColumnKeys) > 0, false, options); err != nil {
+		return err
+	}
+	index, _, err := api.indexField(req.Index, req.Field, req.Shard)
+	if err != nil {
+		return errors.Wrap(err, "getting index and field")
+	} else if index.Keys() {
+		return api.ImportValue(ctx, req, opts...)
+	} else if len(req.Values) != len(req.ColumnIDs) {
+		return NewBadRequestError(errors.Errorf("%d values for %d column ids", len(req.Values), len(req.ColumnIDs)))
+	}
+
+	m := make(map[uint64][]FieldValue)
+	for i, colID := range req.ColumnIDs {
+		shard := colID / ShardWidth
+		m[shard] = append(m[shard], FieldValue{ColumnID: colID, Value: req.Values[i]})
+	}
+
+	ctx = WithTopologyEpoch(ctx, api.cluster.Epoch())
+	var eg errgroup.Group
+	for shard, vals := range m {
+		shard, vals := shard, vals
+		eg.Go(func() error {
+			return api.server.defaultClient.ImportValue(ctx, req.Index, req.Field, shard, vals, opts...)
+		})
+	}
+	return eg.Wait()
+}
@@ -40,13 +40,33 @@ type Index struct {
 	name string
 	keys bool // use string keys
 
+	// The modes with which directories and files are created.
+	dirPerm  os.FileMode
+	filePerm os.FileMode
+
 	// Existence tracking.
 	trackExistence bool
 	existenceFld   *Field
 
+	// Default time quantum for time fields created without one.
+	timeQuantum TimeQuantum
+
+	// Compression of the data files of the fragments of the index.
+	compression *fragmentCompression
+
+	// Durability of the writes to the index. It is fixed at creation.
+	durability string
+
+	// Name by which shards are placed on nodes, if not the index name. It is
+	// only set before the index is opened, or under the holder lock.
+	placement string
+
 	// Fields by name.
 	fields map[string]*Field
 
+	// Queries running against the index, which a rename waits for.
+	queries indexQueries
+
 	newAttrStore func(string) AttrStore
 
 	// Column attribute storage and cache.
@@ -59,6 +79,11 @@ type Index struct {
 
 	logger        logger.Logger
 	snapshotQueue chan *fragment
+	quarantine    *fragmentQuarantine
+	changes       *changeFeed
+
+	// Bumped on every write to the index. See queryCache.
+	generation *generation
 
 	// Used for notifying holder when a field is added.
 	holder *Holder
@@ -86,6 +111,10 @@ func NewIndex(path, name string) (*Index, error) {
 		Stats:          stats.NopStatsClient,
 		logger:         logger.NopLogger,
 		trackExistence: true,
+		generation:     &generation{},
+		compression:    &fragmentCompression{},
+		dirPerm:        DefaultDirPerm,
+		filePerm:       DefaultFilePerm,
 
 		OpenTranslateStore: OpenInMemTranslateStore,
 	}, nil
@@ -117,14 +146,41 @@ func (i *Index) options() IndexOptions {
 	return IndexOptions{
 		Keys:           i.keys,
 		TrackExistence: i.trackExistence,
+		TimeQuantum:    i.timeQuantum,
+		Compression:    i.compression.load(),
+		PlacementName:  i.placement,
+		Durability:     i.durability,
+	}
+}
+
+// setOptions applies the mutable options in opt to the index. Options which
+// are fixed at creation, such as keys, are ignored.
+func (i *Index) setOptions(opt IndexOptions) error {
+	if !opt.TimeQuantum.Valid() {
+		return ErrInvalidTimeQuantum
+	} else if !ValidCompression(opt.Compression) {
+		return ErrInvalidCompression
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	prev, prevCompression := i.timeQuantum, i.compression.load()
+	i.timeQuantum = opt.TimeQuantum
+	i.compression.store(opt.Compression)
+	if err := i.saveMeta(); err != nil {
+		i.timeQuantum = prev
+		i.compression.store(prevCompression)
+		return errors.Wrap(err, "saving meta")
 	}
+	return nil
 }
 
 // Open opens and initializes the index.
 func (i *Index) Open() (err error) {
 	// Ensure the path exists.
 	i.logger.Debugf("ensure index path exists: %s", i.path)
-	if err := os.MkdirAll(i.path, 0777); err != nil {
+	if err := os.MkdirAll(i.path, i.dirPerm); err != nil {
 		return errors.Wrap(err, "creating directory")
 	}
 
@@ -240,6 +296,10 @@ func (i *Index) loadMeta() error {
 	// Copy metadata fields.
 	i.keys = pb.Keys
 	i.trackExistence = pb.TrackExistence
+	i.timeQuantum = TimeQuantum(pb.TimeQuantum)
+	i.compression.store(pb.Compression)
+	i.placement = pb.PlacementName
+	i.durability = pb.Durability
 
 	return nil
 }
@@ -250,13 +310,17 @@ func (i *Index) saveMeta() error {
 	buf, err := proto.Marshal(&internal.IndexMeta{
 		Keys:           i.keys,
 		TrackExistence: i.trackExistence,
+		TimeQuantum:    string(i.timeQuantum),
+		Compression:    i.compression.load(),
+		PlacementName:  i.placement,
+		Durability:     i.durability,
 	})
 	if err != nil {
 		return errors.Wrap(err, "marshalling")
 	}
 
 	// Write to meta file.
-	if err := ioutil.WriteFile(filepath.Join(i.path, ".meta"), buf, 0666); err != nil {
+	if err := ioutil.WriteFile(filepath.Join(i.path, ".meta"), buf, i.filePerm); err != nil {
 		return errors.Wrap(err, "writing")
 	}
 
@@ -371,6 +435,11 @@ func (i *Index) CreateField(name string, opts ...FieldOption) (*Field, error) {
 		}
 	}
 
+	// Time fields without their own quantum inherit the index default.
+	if fo.Type == FieldTypeTime && fo.TimeQuantum == "" {
+		fo.TimeQuantum = i.timeQuantum
+	}
+
 	return i.createField(name, fo)
 }
 
@@ -398,6 +467,11 @@ func (i *Index) CreateFieldIfNotExists(name string, opts ...FieldOption) (*Field
 		}
 	}
 
+	// Time fields without their own quantum inherit the index default.
+	if fo.Type == FieldTypeTime && fo.TimeQuantum == "" {
+		fo.TimeQuantum = i.timeQuantum
+	}
+
 	return i.createField(name, fo)
 }
 
@@ -461,8 +535,15 @@ func (i *Index) newField(path, name string) (*Field, error) {
 	f.logger = i.logger
 	f.Stats = i.Stats
 	f.broadcaster = i.broadcaster
-	f.rowAttrStore = i.newAttrStore(filepath.Join(f.path, ".data"))
+	f.rowAttrStore = &generationAttrStore{AttrStore: i.newAttrStore(filepath.Join(f.path, ".data")), generation: i.generation}
 	f.snapshotQueue = i.snapshotQueue
+	f.quarantine = i.quarantine
+	f.changes = i.changes
+	f.durability = i.durability
+	f.generation = i.generation
+	f.compression = i.compression
+	f.dirPerm = i.dirPerm
+	f.filePerm = i.filePerm
 	f.OpenTranslateStore = i.OpenTranslateStore
 	return f, nil
 }
@@ -502,6 +583,7 @@ func (i *Index) DeleteField(name string) error {
 
 	// Remove reference.
 	delete(i.fields, name)
+	i.generation.bump()
 
 	return nil
 }
@@ -530,6 +612,25 @@ func (p indexInfoSlice) Less(i, j int) bool { return p[i].Name < p[j].Name }
 type IndexOptions struct {
 	Keys           bool `json:"keys"`
 	TrackExistence bool `json:"trackExistence"`
+
+	// TimeQuantum is the default time quantum for time fields created in
+	// the index without one. Changing it does not affect existing fields.
+	TimeQuantum TimeQuantum `json:"timeQuantum,omitempty"`
+
+	// Compression is the algorithm with which the data files of the
+	// fragments of the index are compressed on disk, or empty for none.
+	// Changing it affects each fragment the next time its data file is
+	// rewritten, on snapshot or compaction.
+	Compression string `json:"compression,omitempty"`
+
+	// PlacementName is the name by which the shards of the index are placed
+	// on nodes, if it differs from the name of the index. It is set when an
+	// index is first renamed, so that its shards stay on the same nodes.
+	PlacementName string `json:"placementName,omitempty"`
+
+	// Durability is when writes to the index reach the disk: async,
+	// flush or fsync. Empty is the default, flush. It is fixed at creation.
+	Durability string `json:"durability,omitempty"`
 }
 
 // hasTime returns true if a contains a non-nil time.
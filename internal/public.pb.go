@@ -26,6 +26,8 @@
 		TranslateKeysResponse
 		ImportRoaringRequestView
 		ImportRoaringRequest
+		QueryPage
+		ImportBoolRequest
 */
 package internal
 
@@ -193,8 +195,9 @@ func (m *GroupCount) GetCount() uint64 {
 }
 
 type ValCount struct {
-	Val   int64 `protobuf:"varint,1,opt,name=Val,proto3" json:"Val,omitempty"`
-	Count int64 `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"`
+	Val       int64  `protobuf:"varint,1,opt,name=Val,proto3" json:"Val,omitempty"`
+	Count     int64  `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"`
+	Timestamp string `protobuf:"bytes,3,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
 }
 
 func (m *ValCount) Reset()                    { *m = ValCount{} }
@@ -216,6 +219,13 @@ func (m *ValCount) GetCount() int64 {
 	return 0
 }
 
+func (m *ValCount) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
 type ColumnAttrSet struct {
 	ID    uint64  `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
 	Key   string  `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
@@ -327,6 +337,9 @@ type QueryRequest struct {
 	Remote          bool     `protobuf:"varint,5,opt,name=Remote,proto3" json:"Remote,omitempty"`
 	ExcludeRowAttrs bool     `protobuf:"varint,6,opt,name=ExcludeRowAttrs,proto3" json:"ExcludeRowAttrs,omitempty"`
 	ExcludeColumns  bool     `protobuf:"varint,7,opt,name=ExcludeColumns,proto3" json:"ExcludeColumns,omitempty"`
+	Limit           uint64   `protobuf:"varint,8,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	Cursor          string   `protobuf:"bytes,9,opt,name=Cursor,proto3" json:"Cursor,omitempty"`
+	MaxResultRows   uint64   `protobuf:"varint,10,opt,name=MaxResultRows,proto3" json:"MaxResultRows,omitempty"`
 }
 
 func (m *QueryRequest) Reset()                    { *m = QueryRequest{} }
@@ -376,10 +389,36 @@ func (m *QueryRequest) GetExcludeColumns() bool {
 	return false
 }
 
+func (m *QueryRequest) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *QueryRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *QueryRequest) GetMaxResultRows() uint64 {
+	if m != nil {
+		return m.MaxResultRows
+	}
+	return 0
+}
+
 type QueryResponse struct {
-	Err            string           `protobuf:"bytes,1,opt,name=Err,proto3" json:"Err,omitempty"`
-	Results        []*QueryResult   `protobuf:"bytes,2,rep,name=Results" json:"Results,omitempty"`
-	ColumnAttrSets []*ColumnAttrSet `protobuf:"bytes,3,rep,name=ColumnAttrSets" json:"ColumnAttrSets,omitempty"`
+	Err               string           `protobuf:"bytes,1,opt,name=Err,proto3" json:"Err,omitempty"`
+	Results           []*QueryResult   `protobuf:"bytes,2,rep,name=Results" json:"Results,omitempty"`
+	ColumnAttrSets    []*ColumnAttrSet `protobuf:"bytes,3,rep,name=ColumnAttrSets" json:"ColumnAttrSets,omitempty"`
+	Page              *QueryPage       `protobuf:"bytes,4,opt,name=Page" json:"Page,omitempty"`
+	Thresholds        []uint64         `protobuf:"varint,5,rep,packed,name=Thresholds" json:"Thresholds,omitempty"`
+	UnavailableShards []uint64         `protobuf:"varint,6,rep,packed,name=UnavailableShards" json:"UnavailableShards,omitempty"`
+	Truncated         bool             `protobuf:"varint,7,opt,name=Truncated,proto3" json:"Truncated,omitempty"`
+	AutoCreated       bool             `protobuf:"varint,8,opt,name=AutoCreated,proto3" json:"AutoCreated,omitempty"`
 }
 
 func (m *QueryResponse) Reset()                    { *m = QueryResponse{} }
@@ -408,6 +447,41 @@ func (m *QueryResponse) GetColumnAttrSets() []*ColumnAttrSet {
 	return nil
 }
 
+func (m *QueryResponse) GetPage() *QueryPage {
+	if m != nil {
+		return m.Page
+	}
+	return nil
+}
+
+func (m *QueryResponse) GetThresholds() []uint64 {
+	if m != nil {
+		return m.Thresholds
+	}
+	return nil
+}
+
+func (m *QueryResponse) GetUnavailableShards() []uint64 {
+	if m != nil {
+		return m.UnavailableShards
+	}
+	return nil
+}
+
+func (m *QueryResponse) GetTruncated() bool {
+	if m != nil {
+		return m.Truncated
+	}
+	return false
+}
+
+func (m *QueryResponse) GetAutoCreated() bool {
+	if m != nil {
+		return m.AutoCreated
+	}
+	return false
+}
+
 type QueryResult struct {
 	Type           uint32          `protobuf:"varint,6,opt,name=Type,proto3" json:"Type,omitempty"`
 	Row            *Row            `protobuf:"bytes,1,opt,name=Row" json:"Row,omitempty"`
@@ -418,6 +492,7 @@ type QueryResult struct {
 	RowIDs         []uint64        `protobuf:"varint,7,rep,packed,name=RowIDs" json:"RowIDs,omitempty"`
 	GroupCounts    []*GroupCount   `protobuf:"bytes,8,rep,name=GroupCounts" json:"GroupCounts,omitempty"`
 	RowIdentifiers *RowIdentifiers `protobuf:"bytes,9,opt,name=RowIdentifiers" json:"RowIdentifiers,omitempty"`
+	Approximate    bool            `protobuf:"varint,10,opt,name=Approximate,proto3" json:"Approximate,omitempty"`
 }
 
 func (m *QueryResult) Reset()                    { *m = QueryResult{} }
@@ -488,15 +563,23 @@ func (m *QueryResult) GetRowIdentifiers() *RowIdentifiers {
 	return nil
 }
 
+func (m *QueryResult) GetApproximate() bool {
+	if m != nil {
+		return m.Approximate
+	}
+	return false
+}
+
 type ImportRequest struct {
-	Index      string   `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
-	Field      string   `protobuf:"bytes,2,opt,name=Field,proto3" json:"Field,omitempty"`
-	Shard      uint64   `protobuf:"varint,3,opt,name=Shard,proto3" json:"Shard,omitempty"`
-	RowIDs     []uint64 `protobuf:"varint,4,rep,packed,name=RowIDs" json:"RowIDs,omitempty"`
-	ColumnIDs  []uint64 `protobuf:"varint,5,rep,packed,name=ColumnIDs" json:"ColumnIDs,omitempty"`
-	RowKeys    []string `protobuf:"bytes,7,rep,name=RowKeys" json:"RowKeys,omitempty"`
-	ColumnKeys []string `protobuf:"bytes,8,rep,name=ColumnKeys" json:"ColumnKeys,omitempty"`
-	Timestamps []int64  `protobuf:"varint,6,rep,packed,name=Timestamps" json:"Timestamps,omitempty"`
+	Index       string           `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
+	Field       string           `protobuf:"bytes,2,opt,name=Field,proto3" json:"Field,omitempty"`
+	Shard       uint64           `protobuf:"varint,3,opt,name=Shard,proto3" json:"Shard,omitempty"`
+	RowIDs      []uint64         `protobuf:"varint,4,rep,packed,name=RowIDs" json:"RowIDs,omitempty"`
+	ColumnIDs   []uint64         `protobuf:"varint,5,rep,packed,name=ColumnIDs" json:"ColumnIDs,omitempty"`
+	RowKeys     []string         `protobuf:"bytes,7,rep,name=RowKeys" json:"RowKeys,omitempty"`
+	ColumnKeys  []string         `protobuf:"bytes,8,rep,name=ColumnKeys" json:"ColumnKeys,omitempty"`
+	Timestamps  []int64          `protobuf:"varint,6,rep,packed,name=Timestamps" json:"Timestamps,omitempty"`
+	ColumnAttrs []*ColumnAttrSet `protobuf:"bytes,9,rep,name=ColumnAttrs" json:"ColumnAttrs,omitempty"`
 }
 
 func (m *ImportRequest) Reset()                    { *m = ImportRequest{} }
@@ -560,6 +643,13 @@ func (m *ImportRequest) GetTimestamps() []int64 {
 	return nil
 }
 
+func (m *ImportRequest) GetColumnAttrs() []*ColumnAttrSet {
+	if m != nil {
+		return m.ColumnAttrs
+	}
+	return nil
+}
+
 type ImportValueRequest struct {
 	Index      string   `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
 	Field      string   `protobuf:"bytes,2,opt,name=Field,proto3" json:"Field,omitempty"`
@@ -616,6 +706,62 @@ func (m *ImportValueRequest) GetValues() []int64 {
 	return nil
 }
 
+type ImportBoolRequest struct {
+	Index      string   `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
+	Field      string   `protobuf:"bytes,2,opt,name=Field,proto3" json:"Field,omitempty"`
+	Shard      uint64   `protobuf:"varint,3,opt,name=Shard,proto3" json:"Shard,omitempty"`
+	ColumnIDs  []uint64 `protobuf:"varint,4,rep,packed,name=ColumnIDs" json:"ColumnIDs,omitempty"`
+	ColumnKeys []string `protobuf:"bytes,5,rep,name=ColumnKeys" json:"ColumnKeys,omitempty"`
+	Values     []bool   `protobuf:"varint,6,rep,packed,name=Values" json:"Values,omitempty"`
+}
+
+func (m *ImportBoolRequest) Reset()                    { *m = ImportBoolRequest{} }
+func (m *ImportBoolRequest) String() string            { return proto.CompactTextString(m) }
+func (*ImportBoolRequest) ProtoMessage()               {}
+func (*ImportBoolRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{14} }
+
+func (m *ImportBoolRequest) GetIndex() string {
+	if m != nil {
+		return m.Index
+	}
+	return ""
+}
+
+func (m *ImportBoolRequest) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *ImportBoolRequest) GetShard() uint64 {
+	if m != nil {
+		return m.Shard
+	}
+	return 0
+}
+
+func (m *ImportBoolRequest) GetColumnIDs() []uint64 {
+	if m != nil {
+		return m.ColumnIDs
+	}
+	return nil
+}
+
+func (m *ImportBoolRequest) GetColumnKeys() []string {
+	if m != nil {
+		return m.ColumnKeys
+	}
+	return nil
+}
+
+func (m *ImportBoolRequest) GetValues() []bool {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
 type TranslateKeysRequest struct {
 	Index string   `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
 	Field string   `protobuf:"bytes,2,opt,name=Field,proto3" json:"Field,omitempty"`
@@ -625,7 +771,7 @@ type TranslateKeysRequest struct {
 func (m *TranslateKeysRequest) Reset()                    { *m = TranslateKeysRequest{} }
 func (m *TranslateKeysRequest) String() string            { return proto.CompactTextString(m) }
 func (*TranslateKeysRequest) ProtoMessage()               {}
-func (*TranslateKeysRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{14} }
+func (*TranslateKeysRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{15} }
 
 func (m *TranslateKeysRequest) GetIndex() string {
 	if m != nil {
@@ -655,7 +801,7 @@ type TranslateKeysResponse struct {
 func (m *TranslateKeysResponse) Reset()                    { *m = TranslateKeysResponse{} }
 func (m *TranslateKeysResponse) String() string            { return proto.CompactTextString(m) }
 func (*TranslateKeysResponse) ProtoMessage()               {}
-func (*TranslateKeysResponse) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{15} }
+func (*TranslateKeysResponse) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{16} }
 
 func (m *TranslateKeysResponse) GetIDs() []uint64 {
 	if m != nil {
@@ -672,7 +818,7 @@ type ImportRoaringRequestView struct {
 func (m *ImportRoaringRequestView) Reset()                    { *m = ImportRoaringRequestView{} }
 func (m *ImportRoaringRequestView) String() string            { return proto.CompactTextString(m) }
 func (*ImportRoaringRequestView) ProtoMessage()               {}
-func (*ImportRoaringRequestView) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{16} }
+func (*ImportRoaringRequestView) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{17} }
 
 func (m *ImportRoaringRequestView) GetName() string {
 	if m != nil {
@@ -696,7 +842,7 @@ type ImportRoaringRequest struct {
 func (m *ImportRoaringRequest) Reset()                    { *m = ImportRoaringRequest{} }
 func (m *ImportRoaringRequest) String() string            { return proto.CompactTextString(m) }
 func (*ImportRoaringRequest) ProtoMessage()               {}
-func (*ImportRoaringRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{17} }
+func (*ImportRoaringRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{18} }
 
 func (m *ImportRoaringRequest) GetClear() bool {
 	if m != nil {
@@ -712,6 +858,30 @@ func (m *ImportRoaringRequest) GetViews() []*ImportRoaringRequestView {
 	return nil
 }
 
+type QueryPage struct {
+	More   bool   `protobuf:"varint,1,opt,name=More,proto3" json:"More,omitempty"`
+	Cursor string `protobuf:"bytes,2,opt,name=Cursor,proto3" json:"Cursor,omitempty"`
+}
+
+func (m *QueryPage) Reset()                    { *m = QueryPage{} }
+func (m *QueryPage) String() string            { return proto.CompactTextString(m) }
+func (*QueryPage) ProtoMessage()               {}
+func (*QueryPage) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{19} }
+
+func (m *QueryPage) GetMore() bool {
+	if m != nil {
+		return m.More
+	}
+	return false
+}
+
+func (m *QueryPage) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Row)(nil), "internal.Row")
 	proto.RegisterType((*RowIdentifiers)(nil), "internal.RowIdentifiers")
@@ -727,10 +897,12 @@ func init() {
 	proto.RegisterType((*QueryResult)(nil), "internal.QueryResult")
 	proto.RegisterType((*ImportRequest)(nil), "internal.ImportRequest")
 	proto.RegisterType((*ImportValueRequest)(nil), "internal.ImportValueRequest")
+	proto.RegisterType((*ImportBoolRequest)(nil), "internal.ImportBoolRequest")
 	proto.RegisterType((*TranslateKeysRequest)(nil), "internal.TranslateKeysRequest")
 	proto.RegisterType((*TranslateKeysResponse)(nil), "internal.TranslateKeysResponse")
 	proto.RegisterType((*ImportRoaringRequestView)(nil), "internal.ImportRoaringRequestView")
 	proto.RegisterType((*ImportRoaringRequest)(nil), "internal.ImportRoaringRequest")
+	proto.RegisterType((*QueryPage)(nil), "internal.QueryPage")
 }
 func (m *Row) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -973,6 +1145,12 @@ func (m *ValCount) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintPublic(dAtA, i, uint64(m.Count))
 	}
+	if len(m.Timestamp) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Timestamp)))
+		i += copy(dAtA[i:], m.Timestamp)
+	}
 	return i, nil
 }
 
@@ -1181,6 +1359,22 @@ func (m *QueryRequest) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if m.Limit != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Limit))
+	}
+	if len(m.Cursor) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Cursor)))
+		i += copy(dAtA[i:], m.Cursor)
+	}
+	if m.MaxResultRows != 0 {
+		dAtA[i] = 0x50
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.MaxResultRows))
+	}
 	return i, nil
 }
 
@@ -1229,6 +1423,70 @@ func (m *QueryResponse) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if m.Page != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Page.Size()))
+		n7, err := m.Page.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
+	if len(m.Thresholds) > 0 {
+		dAtA9 := make([]byte, len(m.Thresholds)*10)
+		var j8 int
+		for _, num := range m.Thresholds {
+			for num >= 1<<7 {
+				dAtA9[j8] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j8++
+			}
+			dAtA9[j8] = uint8(num)
+			j8++
+		}
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(j8))
+		i += copy(dAtA[i:], dAtA9[:j8])
+	}
+	if len(m.UnavailableShards) > 0 {
+		dAtA11 := make([]byte, len(m.UnavailableShards)*10)
+		var j10 int
+		for _, num := range m.UnavailableShards {
+			for num >= 1<<7 {
+				dAtA11[j10] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j10++
+			}
+			dAtA11[j10] = uint8(num)
+			j10++
+		}
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(j10))
+		i += copy(dAtA[i:], dAtA11[:j10])
+	}
+	if m.Truncated {
+		dAtA[i] = 0x38
+		i++
+		if m.Truncated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.AutoCreated {
+		dAtA[i] = 0x40
+		i++
+		if m.AutoCreated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -1251,11 +1509,11 @@ func (m *QueryResult) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintPublic(dAtA, i, uint64(m.Row.Size()))
-		n7, err := m.Row.MarshalTo(dAtA[i:])
+		n12, err := m.Row.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n7
+		i += n12
 	}
 	if m.N != 0 {
 		dAtA[i] = 0x10
@@ -1288,11 +1546,11 @@ func (m *QueryResult) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x2a
 		i++
 		i = encodeVarintPublic(dAtA, i, uint64(m.ValCount.Size()))
-		n8, err := m.ValCount.MarshalTo(dAtA[i:])
+		n13, err := m.ValCount.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n8
+		i += n13
 	}
 	if m.Type != 0 {
 		dAtA[i] = 0x30
@@ -1300,21 +1558,21 @@ func (m *QueryResult) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintPublic(dAtA, i, uint64(m.Type))
 	}
 	if len(m.RowIDs) > 0 {
-		dAtA10 := make([]byte, len(m.RowIDs)*10)
-		var j9 int
+		dAtA15 := make([]byte, len(m.RowIDs)*10)
+		var j14 int
 		for _, num := range m.RowIDs {
 			for num >= 1<<7 {
-				dAtA10[j9] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA15[j14] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j9++
+				j14++
 			}
-			dAtA10[j9] = uint8(num)
-			j9++
+			dAtA15[j14] = uint8(num)
+			j14++
 		}
 		dAtA[i] = 0x3a
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j9))
-		i += copy(dAtA[i:], dAtA10[:j9])
+		i = encodeVarintPublic(dAtA, i, uint64(j14))
+		i += copy(dAtA[i:], dAtA15[:j14])
 	}
 	if len(m.GroupCounts) > 0 {
 		for _, msg := range m.GroupCounts {
@@ -1332,11 +1590,21 @@ func (m *QueryResult) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4a
 		i++
 		i = encodeVarintPublic(dAtA, i, uint64(m.RowIdentifiers.Size()))
-		n11, err := m.RowIdentifiers.MarshalTo(dAtA[i:])
+		n16, err := m.RowIdentifiers.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n11
+		i += n16
+	}
+	if m.Approximate {
+		dAtA[i] = 0x50
+		i++
+		if m.Approximate {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
 	return i, nil
 }
@@ -1374,56 +1642,56 @@ func (m *ImportRequest) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintPublic(dAtA, i, uint64(m.Shard))
 	}
 	if len(m.RowIDs) > 0 {
-		dAtA13 := make([]byte, len(m.RowIDs)*10)
-		var j12 int
+		dAtA18 := make([]byte, len(m.RowIDs)*10)
+		var j17 int
 		for _, num := range m.RowIDs {
 			for num >= 1<<7 {
-				dAtA13[j12] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA18[j17] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j12++
+				j17++
 			}
-			dAtA13[j12] = uint8(num)
-			j12++
+			dAtA18[j17] = uint8(num)
+			j17++
 		}
 		dAtA[i] = 0x22
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j12))
-		i += copy(dAtA[i:], dAtA13[:j12])
+		i = encodeVarintPublic(dAtA, i, uint64(j17))
+		i += copy(dAtA[i:], dAtA18[:j17])
 	}
 	if len(m.ColumnIDs) > 0 {
-		dAtA15 := make([]byte, len(m.ColumnIDs)*10)
-		var j14 int
+		dAtA20 := make([]byte, len(m.ColumnIDs)*10)
+		var j19 int
 		for _, num := range m.ColumnIDs {
 			for num >= 1<<7 {
-				dAtA15[j14] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA20[j19] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j14++
+				j19++
 			}
-			dAtA15[j14] = uint8(num)
-			j14++
+			dAtA20[j19] = uint8(num)
+			j19++
 		}
 		dAtA[i] = 0x2a
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j14))
-		i += copy(dAtA[i:], dAtA15[:j14])
+		i = encodeVarintPublic(dAtA, i, uint64(j19))
+		i += copy(dAtA[i:], dAtA20[:j19])
 	}
 	if len(m.Timestamps) > 0 {
-		dAtA17 := make([]byte, len(m.Timestamps)*10)
-		var j16 int
+		dAtA22 := make([]byte, len(m.Timestamps)*10)
+		var j21 int
 		for _, num1 := range m.Timestamps {
 			num := uint64(num1)
 			for num >= 1<<7 {
-				dAtA17[j16] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA22[j21] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j16++
+				j21++
 			}
-			dAtA17[j16] = uint8(num)
-			j16++
+			dAtA22[j21] = uint8(num)
+			j21++
 		}
 		dAtA[i] = 0x32
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j16))
-		i += copy(dAtA[i:], dAtA17[:j16])
+		i = encodeVarintPublic(dAtA, i, uint64(j21))
+		i += copy(dAtA[i:], dAtA22[:j21])
 	}
 	if len(m.RowKeys) > 0 {
 		for _, s := range m.RowKeys {
@@ -1455,6 +1723,18 @@ func (m *ImportRequest) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
+	if len(m.ColumnAttrs) > 0 {
+		for _, msg := range m.ColumnAttrs {
+			dAtA[i] = 0x4a
+			i++
+			i = encodeVarintPublic(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	return i, nil
 }
 
@@ -1491,39 +1771,39 @@ func (m *ImportValueRequest) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintPublic(dAtA, i, uint64(m.Shard))
 	}
 	if len(m.ColumnIDs) > 0 {
-		dAtA19 := make([]byte, len(m.ColumnIDs)*10)
-		var j18 int
+		dAtA24 := make([]byte, len(m.ColumnIDs)*10)
+		var j23 int
 		for _, num := range m.ColumnIDs {
 			for num >= 1<<7 {
-				dAtA19[j18] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA24[j23] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j18++
+				j23++
 			}
-			dAtA19[j18] = uint8(num)
-			j18++
+			dAtA24[j23] = uint8(num)
+			j23++
 		}
 		dAtA[i] = 0x2a
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j18))
-		i += copy(dAtA[i:], dAtA19[:j18])
+		i = encodeVarintPublic(dAtA, i, uint64(j23))
+		i += copy(dAtA[i:], dAtA24[:j23])
 	}
 	if len(m.Values) > 0 {
-		dAtA21 := make([]byte, len(m.Values)*10)
-		var j20 int
+		dAtA26 := make([]byte, len(m.Values)*10)
+		var j25 int
 		for _, num1 := range m.Values {
 			num := uint64(num1)
 			for num >= 1<<7 {
-				dAtA21[j20] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA26[j25] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j20++
+				j25++
 			}
-			dAtA21[j20] = uint8(num)
-			j20++
+			dAtA26[j25] = uint8(num)
+			j25++
 		}
 		dAtA[i] = 0x32
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j20))
-		i += copy(dAtA[i:], dAtA21[:j20])
+		i = encodeVarintPublic(dAtA, i, uint64(j25))
+		i += copy(dAtA[i:], dAtA26[:j25])
 	}
 	if len(m.ColumnKeys) > 0 {
 		for _, s := range m.ColumnKeys {
@@ -1543,6 +1823,86 @@ func (m *ImportValueRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *ImportBoolRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImportBoolRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Index) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Index)))
+		i += copy(dAtA[i:], m.Index)
+	}
+	if len(m.Field) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Field)))
+		i += copy(dAtA[i:], m.Field)
+	}
+	if m.Shard != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Shard))
+	}
+	if len(m.ColumnIDs) > 0 {
+		dAtA28 := make([]byte, len(m.ColumnIDs)*10)
+		var j27 int
+		for _, num := range m.ColumnIDs {
+			for num >= 1<<7 {
+				dAtA28[j27] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j27++
+			}
+			dAtA28[j27] = uint8(num)
+			j27++
+		}
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(j27))
+		i += copy(dAtA[i:], dAtA28[:j27])
+	}
+	if len(m.ColumnKeys) > 0 {
+		for _, s := range m.ColumnKeys {
+			dAtA[i] = 0x2a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Values) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Values)))
+		for _, b := range m.Values {
+			if b {
+				dAtA[i] = 1
+			} else {
+				dAtA[i] = 0
+			}
+			i++
+		}
+	}
+	return i, nil
+}
+
 func (m *TranslateKeysRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1604,21 +1964,21 @@ func (m *TranslateKeysResponse) MarshalTo(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if len(m.IDs) > 0 {
-		dAtA23 := make([]byte, len(m.IDs)*10)
-		var j22 int
+		dAtA30 := make([]byte, len(m.IDs)*10)
+		var j29 int
 		for _, num := range m.IDs {
 			for num >= 1<<7 {
-				dAtA23[j22] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA30[j29] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j22++
+				j29++
 			}
-			dAtA23[j22] = uint8(num)
-			j22++
+			dAtA30[j29] = uint8(num)
+			j29++
 		}
 		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j22))
-		i += copy(dAtA[i:], dAtA23[:j22])
+		i = encodeVarintPublic(dAtA, i, uint64(j29))
+		i += copy(dAtA[i:], dAtA30[:j29])
 	}
 	return i, nil
 }
@@ -1693,6 +2053,40 @@ func (m *ImportRoaringRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *QueryPage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryPage) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.More {
+		dAtA[i] = 0x8
+		i++
+		if m.More {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Cursor) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Cursor)))
+		i += copy(dAtA[i:], m.Cursor)
+	}
+	return i, nil
+}
+
 func encodeVarintPublic(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -1803,6 +2197,10 @@ func (m *ValCount) Size() (n int) {
 	if m.Count != 0 {
 		n += 1 + sovPublic(uint64(m.Count))
 	}
+	l = len(m.Timestamp)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
 	return n
 }
 
@@ -1889,6 +2287,16 @@ func (m *QueryRequest) Size() (n int) {
 	if m.ExcludeColumns {
 		n += 2
 	}
+	if m.Limit != 0 {
+		n += 1 + sovPublic(uint64(m.Limit))
+	}
+	l = len(m.Cursor)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if m.MaxResultRows != 0 {
+		n += 1 + sovPublic(uint64(m.MaxResultRows))
+	}
 	return n
 }
 
@@ -1911,6 +2319,30 @@ func (m *QueryResponse) Size() (n int) {
 			n += 1 + l + sovPublic(uint64(l))
 		}
 	}
+	if m.Page != nil {
+		l = m.Page.Size()
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if len(m.Thresholds) > 0 {
+		l = 0
+		for _, e := range m.Thresholds {
+			l += sovPublic(uint64(e))
+		}
+		n += 1 + sovPublic(uint64(l)) + l
+	}
+	if len(m.UnavailableShards) > 0 {
+		l = 0
+		for _, e := range m.UnavailableShards {
+			l += sovPublic(uint64(e))
+		}
+		n += 1 + sovPublic(uint64(l)) + l
+	}
+	if m.Truncated {
+		n += 2
+	}
+	if m.AutoCreated {
+		n += 2
+	}
 	return n
 }
 
@@ -1957,6 +2389,9 @@ func (m *QueryResult) Size() (n int) {
 		l = m.RowIdentifiers.Size()
 		n += 1 + l + sovPublic(uint64(l))
 	}
+	if m.Approximate {
+		n += 2
+	}
 	return n
 }
 
@@ -2007,6 +2442,12 @@ func (m *ImportRequest) Size() (n int) {
 			n += 1 + l + sovPublic(uint64(l))
 		}
 	}
+	if len(m.ColumnAttrs) > 0 {
+		for _, e := range m.ColumnAttrs {
+			l = e.Size()
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -2047,6 +2488,39 @@ func (m *ImportValueRequest) Size() (n int) {
 	return n
 }
 
+func (m *ImportBoolRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Index)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if m.Shard != 0 {
+		n += 1 + sovPublic(uint64(m.Shard))
+	}
+	if len(m.ColumnIDs) > 0 {
+		l = 0
+		for _, e := range m.ColumnIDs {
+			l += sovPublic(uint64(e))
+		}
+		n += 1 + sovPublic(uint64(l)) + l
+	}
+	if len(m.ColumnKeys) > 0 {
+		for _, s := range m.ColumnKeys {
+			l = len(s)
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
+	if len(m.Values) > 0 {
+		n += 1 + sovPublic(uint64(len(m.Values))) + len(m.Values)*1
+	}
+	return n
+}
+
 func (m *TranslateKeysRequest) Size() (n int) {
 	var l int
 	_ = l
@@ -2109,6 +2583,19 @@ func (m *ImportRoaringRequest) Size() (n int) {
 	return n
 }
 
+func (m *QueryPage) Size() (n int) {
+	var l int
+	_ = l
+	if m.More {
+		n += 2
+	}
+	l = len(m.Cursor)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	return n
+}
+
 func sovPublic(x uint64) (n int) {
 	for {
 		n++
@@ -2846,6 +3333,35 @@ func (m *ValCount) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Timestamp = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -3454,6 +3970,73 @@ func (m *QueryRequest) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.ExcludeColumns = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cursor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cursor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxResultRows", wireType)
+			}
+			m.MaxResultRows = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxResultRows |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -3595,59 +4178,9 @@ func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPublic(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthPublic
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *QueryResult) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPublic
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: QueryResult: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QueryResult: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Row", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Page", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3671,30 +4204,277 @@ func (m *QueryResult) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Row == nil {
-				m.Row = &Row{}
+			if m.Page == nil {
+				m.Page = &QueryPage{}
 			}
-			if err := m.Row.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Page.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field N", wireType)
-			}
-			m.N = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPublic
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.N |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
+		case 5:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Thresholds = append(m.Thresholds, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPublic
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPublic
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Thresholds = append(m.Thresholds, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Thresholds", wireType)
+			}
+		case 6:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.UnavailableShards = append(m.UnavailableShards, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPublic
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPublic
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.UnavailableShards = append(m.UnavailableShards, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnavailableShards", wireType)
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Truncated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Truncated = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AutoCreated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AutoCreated = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Row", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Row == nil {
+				m.Row = &Row{}
+			}
+			if err := m.Row.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field N", wireType)
+			}
+			m.N = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.N |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
 				}
 			}
 		case 3:
@@ -3926,8 +4706,28 @@ func (m *QueryResult) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Approximate", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Approximate = bool(v != 0)
+		default:
+			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
 			if err != nil {
 				return err
@@ -4087,33 +4887,373 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 						break
 					}
 				}
-				if packedLen < 0 {
-					return ErrInvalidLengthPublic
+				if packedLen < 0 {
+					return ErrInvalidLengthPublic
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPublic
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.RowIDs = append(m.RowIDs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field RowIDs", wireType)
+			}
+		case 5:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.ColumnIDs = append(m.ColumnIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPublic
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPublic
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ColumnIDs = append(m.ColumnIDs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnIDs", wireType)
+			}
+		case 6:
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (int64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Timestamps = append(m.Timestamps, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPublic
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPublic
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (int64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Timestamps = append(m.Timestamps, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamps", wireType)
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RowKeys", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RowKeys = append(m.RowKeys, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnKeys", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ColumnKeys = append(m.ColumnKeys, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnAttrs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ColumnAttrs = append(m.ColumnAttrs, &ColumnAttrSet{})
+			if err := m.ColumnAttrs[len(m.ColumnAttrs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImportValueRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImportValueRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Index = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
 				}
-				postIndex := iNdEx + packedLen
-				if postIndex > l {
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+			}
+			m.Shard = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPublic
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.RowIDs = append(m.RowIDs, v)
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Shard |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field RowIDs", wireType)
 			}
 		case 5:
 			if wireType == 0 {
@@ -4194,7 +5334,7 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 						break
 					}
 				}
-				m.Timestamps = append(m.Timestamps, v)
+				m.Values = append(m.Values, v)
 			} else if wireType == 2 {
 				var packedLen int
 				for shift := uint(0); ; shift += 7 {
@@ -4234,41 +5374,12 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 							break
 						}
 					}
-					m.Timestamps = append(m.Timestamps, v)
+					m.Values = append(m.Values, v)
 				}
 			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamps", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
 			}
 		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RowKeys", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPublic
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPublic
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.RowKeys = append(m.RowKeys, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 8:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field ColumnKeys", wireType)
 			}
@@ -4318,7 +5429,7 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
+func (m *ImportBoolRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4341,10 +5452,10 @@ func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ImportValueRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ImportBoolRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImportValueRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ImportBoolRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -4424,7 +5535,7 @@ func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 5:
+		case 4:
 			if wireType == 0 {
 				var v uint64
 				for shift := uint(0); ; shift += 7 {
@@ -4486,9 +5597,38 @@ func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 			} else {
 				return fmt.Errorf("proto: wrong wireType = %d for field ColumnIDs", wireType)
 			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnKeys", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ColumnKeys = append(m.ColumnKeys, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		case 6:
 			if wireType == 0 {
-				var v int64
+				var v int
 				for shift := uint(0); ; shift += 7 {
 					if shift >= 64 {
 						return ErrIntOverflowPublic
@@ -4498,12 +5638,12 @@ func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 					}
 					b := dAtA[iNdEx]
 					iNdEx++
-					v |= (int64(b) & 0x7F) << shift
+					v |= (int(b) & 0x7F) << shift
 					if b < 0x80 {
 						break
 					}
 				}
-				m.Values = append(m.Values, v)
+				m.Values = append(m.Values, bool(v != 0))
 			} else if wireType == 2 {
 				var packedLen int
 				for shift := uint(0); ; shift += 7 {
@@ -4528,7 +5668,7 @@ func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 					return io.ErrUnexpectedEOF
 				}
 				for iNdEx < postIndex {
-					var v int64
+					var v int
 					for shift := uint(0); ; shift += 7 {
 						if shift >= 64 {
 							return ErrIntOverflowPublic
@@ -4538,45 +5678,16 @@ func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 						}
 						b := dAtA[iNdEx]
 						iNdEx++
-						v |= (int64(b) & 0x7F) << shift
+						v |= (int(b) & 0x7F) << shift
 						if b < 0x80 {
 							break
 						}
 					}
-					m.Values = append(m.Values, v)
+					m.Values = append(m.Values, bool(v != 0))
 				}
 			} else {
 				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
 			}
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ColumnKeys", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPublic
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPublic
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ColumnKeys = append(m.ColumnKeys, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -5058,6 +6169,105 @@ func (m *ImportRoaringRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *QueryPage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryPage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryPage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field More", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.More = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cursor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cursor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipPublic(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -5166,60 +6376,73 @@ var (
 func init() { proto.RegisterFile("public.proto", fileDescriptorPublic) }
 
 var fileDescriptorPublic = []byte{
-	// 880 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xcd, 0x8e, 0x1b, 0x45,
-	0x10, 0xa6, 0x3d, 0x63, 0x7b, 0x5c, 0x5e, 0x9b, 0xa8, 0xe5, 0x84, 0x11, 0x8a, 0x8c, 0x35, 0x42,
-	0x68, 0xb8, 0x6c, 0x24, 0x23, 0xa1, 0x9c, 0xf8, 0xd9, 0x78, 0x83, 0xac, 0xc0, 0x0a, 0x6a, 0x57,
-	0x46, 0x1c, 0x3b, 0x71, 0x27, 0x19, 0x69, 0x3c, 0x6d, 0x66, 0x7a, 0x70, 0xf6, 0x39, 0xb8, 0xf0,
-	0x08, 0x1c, 0x78, 0x90, 0x1c, 0x11, 0x4f, 0x00, 0xcb, 0x8b, 0xa0, 0xae, 0x9e, 0xde, 0x1e, 0x7b,
-	0x97, 0x08, 0xa1, 0xdc, 0xea, 0xab, 0xea, 0xaa, 0xa9, 0xaf, 0xfe, 0x6c, 0x38, 0xda, 0xd6, 0x4f,
-	0xf3, 0xec, 0xd9, 0xf1, 0xb6, 0x54, 0x5a, 0xf1, 0x28, 0x2b, 0xb4, 0x2c, 0x0b, 0x91, 0x27, 0x3f,
-	0x40, 0x80, 0x6a, 0xc7, 0x63, 0xe8, 0x3f, 0x52, 0x79, 0xbd, 0x29, 0xaa, 0x98, 0xcd, 0x82, 0x34,
-	0x44, 0x07, 0xf9, 0x87, 0xd0, 0xfd, 0x52, 0xeb, 0xb2, 0x8a, 0x3b, 0xb3, 0x20, 0x1d, 0xce, 0xc7,
-	0xc7, 0xce, 0xf5, 0xd8, 0xa8, 0xd1, 0x1a, 0x39, 0x87, 0xf0, 0x89, 0xbc, 0xac, 0xe2, 0x60, 0x16,
-	0xa4, 0x03, 0x24, 0x39, 0x79, 0x08, 0x63, 0x54, 0xbb, 0xe5, 0x5a, 0x16, 0x3a, 0x7b, 0x9e, 0x49,
-	0xfb, 0x0a, 0xd5, 0xce, 0x7d, 0x82, 0xe4, 0x6b, 0xcf, 0x4e, 0xcb, 0xf3, 0x33, 0x08, 0xbf, 0x15,
-	0x59, 0xc9, 0xc7, 0xd0, 0x59, 0x2e, 0x62, 0x36, 0x63, 0x69, 0x88, 0x9d, 0xe5, 0x82, 0x4f, 0xa0,
-	0xfb, 0x48, 0xd5, 0x85, 0x8e, 0x3b, 0xa4, 0xb2, 0x80, 0xdf, 0x81, 0xe0, 0x89, 0xbc, 0x8c, 0x83,
-	0x19, 0x4b, 0x07, 0x68, 0xc4, 0xe4, 0x0c, 0xa2, 0xc7, 0x99, 0xcc, 0xd7, 0x86, 0xd9, 0x04, 0xba,
-	0x24, 0x53, 0x98, 0x01, 0x5a, 0x60, 0xb4, 0x26, 0xb7, 0x85, 0x8b, 0x44, 0x80, 0xdf, 0x83, 0x1e,
-	0xaa, 0x9d, 0x0f, 0xd6, 0xa0, 0xe4, 0x6b, 0x80, 0xaf, 0x4a, 0x55, 0x6f, 0xed, 0xf7, 0x52, 0xe8,
-	0x12, 0x22, 0x1a, 0xc3, 0x39, 0xf7, 0x15, 0x71, 0x1f, 0x45, 0xfb, 0xe0, 0xf6, 0x7c, 0x93, 0x39,
-	0x44, 0x2b, 0x91, 0x5f, 0xe7, 0xbe, 0x12, 0x39, 0xe5, 0x16, 0xa0, 0x11, 0xf7, 0x7d, 0x02, 0xe7,
-	0xf3, 0x3d, 0x8c, 0x6c, 0x43, 0x4c, 0xb9, 0xcf, 0xa5, 0xbe, 0x51, 0x9a, 0xff, 0xd6, 0xa6, 0x9b,
-	0xa5, 0xfa, 0x95, 0x41, 0x68, 0x6c, 0xce, 0xc4, 0xae, 0x4d, 0xa6, 0x33, 0x17, 0x97, 0x5b, 0xd9,
-	0x24, 0x4f, 0x32, 0x9f, 0xc1, 0xf0, 0x5c, 0x97, 0x59, 0xf1, 0x62, 0x25, 0xf2, 0x5a, 0x36, 0x81,
-	0xda, 0x2a, 0xfe, 0x3e, 0x44, 0xcb, 0x42, 0x5b, 0x73, 0x48, 0x14, 0xae, 0x31, 0xbf, 0x0f, 0x83,
-	0x13, 0xa5, 0x72, 0x6b, 0xec, 0xce, 0x58, 0x1a, 0xa1, 0x57, 0xf0, 0x29, 0xc0, 0xe3, 0x5c, 0x89,
-	0xc6, 0xb7, 0x37, 0x63, 0x29, 0xc3, 0x96, 0x26, 0x79, 0x00, 0x7d, 0x93, 0xe9, 0x37, 0x62, 0xeb,
-	0xd9, 0xb2, 0x37, 0xb0, 0x4d, 0x5e, 0x33, 0x38, 0xfa, 0xae, 0x96, 0xe5, 0x25, 0xca, 0x1f, 0x6b,
-	0x59, 0x69, 0x53, 0x5b, 0xc2, 0x6e, 0x16, 0x08, 0x98, 0xae, 0x9f, 0xbf, 0x14, 0xe5, 0xda, 0xd6,
-	0x2e, 0xc4, 0x06, 0x19, 0xae, 0xbe, 0xe6, 0x15, 0x71, 0x8d, 0xb0, 0xad, 0xa2, 0x79, 0x91, 0x1b,
-	0xa5, 0x1d, 0x99, 0x06, 0xf1, 0x14, 0xde, 0x3d, 0x7d, 0xf5, 0x2c, 0xaf, 0xd7, 0x12, 0xd5, 0xce,
-	0x7a, 0xf7, 0xe8, 0xc1, 0xa1, 0x9a, 0x7f, 0x04, 0xe3, 0x46, 0xe5, 0xd6, 0xaf, 0x4f, 0x0f, 0x0f,
-	0xb4, 0xc9, 0xcf, 0x0c, 0x46, 0x0d, 0x95, 0x6a, 0xab, 0x8a, 0x4a, 0x9a, 0x7e, 0x9d, 0x96, 0xa5,
-	0xeb, 0xd7, 0x69, 0x59, 0xf2, 0x07, 0xd0, 0x47, 0x59, 0xd5, 0xb9, 0x76, 0x43, 0x70, 0xd7, 0x97,
-	0xc5, 0xf9, 0xd6, 0xb9, 0x46, 0xf7, 0x8a, 0x7f, 0x0e, 0xe3, 0xbd, 0xa1, 0xb2, 0xeb, 0x3b, 0x9c,
-	0xbf, 0xe7, 0xfd, 0xf6, 0xec, 0x78, 0xf0, 0x3c, 0xf9, 0xa3, 0x03, 0xc3, 0x56, 0x64, 0xfe, 0x01,
-	0x1d, 0x13, 0xca, 0x69, 0x38, 0x1f, 0xf9, 0x28, 0x66, 0x25, 0xe8, 0xcc, 0x1c, 0x01, 0x3b, 0x6b,
-	0xe6, 0x89, 0x9d, 0x99, 0x2e, 0x9a, 0x35, 0x77, 0x9f, 0x6d, 0x75, 0xd1, 0xa8, 0xd1, 0x1a, 0xe9,
-	0x34, 0xbd, 0x14, 0xc5, 0x0b, 0xb9, 0xa6, 0x79, 0x8a, 0xd0, 0x41, 0x7e, 0xec, 0x17, 0x89, 0x1a,
-	0xb0, 0xb7, 0x8b, 0xce, 0x82, 0x7e, 0xd9, 0xdc, 0x40, 0x9b, 0x5e, 0x8c, 0x9a, 0x81, 0xb6, 0x2b,
-	0xbf, 0x5c, 0x98, 0xc2, 0x53, 0xf3, 0x2d, 0xe2, 0x9f, 0xc2, 0xd0, 0xaf, 0x7c, 0x15, 0x47, 0x94,
-	0xe1, 0xc4, 0x87, 0xf7, 0x46, 0x6c, 0x3f, 0xe4, 0x5f, 0x1c, 0x1e, 0xbd, 0x78, 0x40, 0x99, 0xc5,
-	0x7b, 0xd5, 0x68, 0xd9, 0xf1, 0xe0, 0x7d, 0xf2, 0x17, 0x83, 0xd1, 0x72, 0xb3, 0x55, 0xa5, 0x6e,
-	0x8d, 0xed, 0xb2, 0x58, 0xcb, 0x57, 0x6e, 0x6c, 0x09, 0xf8, 0xc3, 0xd6, 0x39, 0x38, 0x6c, 0x34,
-	0xbe, 0x34, 0xae, 0x21, 0x5a, 0xd0, 0x62, 0x19, 0xee, 0xb1, 0xbc, 0x0f, 0x03, 0xdb, 0x52, 0x63,
-	0xea, 0x92, 0xc9, 0x2b, 0xcc, 0x42, 0x5e, 0x64, 0x1b, 0x59, 0x69, 0xb1, 0xd9, 0x9a, 0x09, 0x0e,
-	0xd2, 0x00, 0x5b, 0x1a, 0xd3, 0x19, 0x7b, 0x20, 0x6d, 0xf1, 0x06, 0xe8, 0xa0, 0xf1, 0xb4, 0x61,
-	0xc8, 0x18, 0x91, 0xb1, 0xa5, 0x49, 0x7e, 0x63, 0xc0, 0x2d, 0x47, 0x5a, 0xed, 0xb7, 0x47, 0xf4,
-	0xcd, 0x84, 0xee, 0x41, 0x8f, 0xbe, 0xe7, 0xc8, 0x34, 0xe8, 0x20, 0xdd, 0xfe, 0x8d, 0x74, 0x57,
-	0x30, 0xb9, 0x28, 0x45, 0x51, 0xe5, 0x42, 0x4b, 0xa3, 0xf8, 0x3f, 0xf9, 0xde, 0xf6, 0x0b, 0xf9,
-	0x31, 0xdc, 0x3d, 0x88, 0xeb, 0x97, 0xdb, 0x10, 0x08, 0x88, 0x80, 0x11, 0x93, 0x13, 0x88, 0x9b,
-	0xa1, 0x50, 0xc2, 0x1c, 0xdb, 0x26, 0x85, 0x55, 0x26, 0x77, 0x26, 0xf4, 0x99, 0xd8, 0xc8, 0x26,
-	0x0b, 0x92, 0x8d, 0x6e, 0x21, 0xb4, 0xa0, 0x1c, 0x8e, 0x90, 0xe4, 0xe4, 0x39, 0x4c, 0x6e, 0x8b,
-	0x41, 0x3f, 0x39, 0xb9, 0x14, 0xf6, 0x98, 0x44, 0x68, 0x01, 0x7f, 0x08, 0xdd, 0x9f, 0x32, 0xb9,
-	0x73, 0xc7, 0x24, 0xf1, 0x03, 0xfc, 0x6f, 0x89, 0xa0, 0x75, 0x38, 0xb9, 0xf3, 0xfa, 0x6a, 0xca,
-	0x7e, 0xbf, 0x9a, 0xb2, 0x3f, 0xaf, 0xa6, 0xec, 0x97, 0xbf, 0xa7, 0xef, 0x3c, 0xed, 0xd1, 0xdf,
-	0x8e, 0x4f, 0xfe, 0x09, 0x00, 0x00, 0xff, 0xff, 0x9f, 0x87, 0xba, 0x9b, 0x86, 0x08, 0x00, 0x00,
+	// 1073 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xef, 0x6e, 0xe3, 0x44,
+	0x10, 0xc7, 0x89, 0x93, 0x3a, 0x93, 0xa6, 0xdc, 0x2d, 0xbd, 0xc3, 0x42, 0xa7, 0x12, 0x59, 0x27,
+	0x08, 0x12, 0xea, 0x49, 0x41, 0x82, 0xe3, 0x0b, 0x70, 0xd7, 0xf4, 0x50, 0x74, 0xd7, 0xea, 0xd8,
+	0x96, 0x20, 0x3e, 0x6e, 0x9b, 0xbd, 0xd6, 0x92, 0xe3, 0x35, 0xeb, 0xf5, 0xa5, 0x7d, 0x13, 0x3e,
+	0xf0, 0x00, 0x48, 0xf0, 0x20, 0x88, 0x4f, 0x3c, 0x02, 0x2a, 0xaf, 0xc0, 0x03, 0xa0, 0x99, 0xf5,
+	0xc6, 0x8e, 0x5b, 0x2a, 0x84, 0xe0, 0xdb, 0xce, 0x5f, 0xcf, 0xfc, 0xf6, 0x37, 0xb3, 0x86, 0xcd,
+	0xac, 0x38, 0x49, 0xe2, 0xd3, 0xdd, 0x4c, 0x2b, 0xa3, 0x58, 0x10, 0xa7, 0x46, 0xea, 0x54, 0x24,
+	0xd1, 0xb7, 0xd0, 0xe6, 0x6a, 0xc9, 0x42, 0xd8, 0xd8, 0x53, 0x49, 0xb1, 0x48, 0xf3, 0xd0, 0x1b,
+	0xb6, 0x47, 0x3e, 0x77, 0x22, 0x7b, 0x08, 0x9d, 0x27, 0xc6, 0xe8, 0x3c, 0x6c, 0x0d, 0xdb, 0xa3,
+	0xfe, 0x78, 0x6b, 0xd7, 0x85, 0xee, 0xa2, 0x9a, 0x5b, 0x23, 0x63, 0xe0, 0x3f, 0x97, 0x97, 0x79,
+	0xd8, 0x1e, 0xb6, 0x47, 0x3d, 0x4e, 0xe7, 0xe8, 0x31, 0x6c, 0x71, 0xb5, 0x9c, 0xce, 0x65, 0x6a,
+	0xe2, 0x57, 0xb1, 0xb4, 0x5e, 0x5c, 0x2d, 0xdd, 0x27, 0xe8, 0xbc, 0x8a, 0x6c, 0xd5, 0x22, 0x3f,
+	0x03, 0xff, 0xa5, 0x88, 0x35, 0xdb, 0x82, 0xd6, 0x74, 0x12, 0x7a, 0x43, 0x6f, 0xe4, 0xf3, 0xd6,
+	0x74, 0xc2, 0xb6, 0xa1, 0xb3, 0xa7, 0x8a, 0xd4, 0x84, 0x2d, 0x52, 0x59, 0x81, 0xdd, 0x81, 0xf6,
+	0x73, 0x79, 0x19, 0xb6, 0x87, 0xde, 0xa8, 0xc7, 0xf1, 0x18, 0x1d, 0x42, 0xf0, 0x2c, 0x96, 0xc9,
+	0x1c, 0x3b, 0xdb, 0x86, 0x0e, 0x9d, 0x29, 0x4d, 0x8f, 0x5b, 0x01, 0xb5, 0x58, 0xdb, 0xc4, 0x65,
+	0x22, 0x81, 0xdd, 0x87, 0x2e, 0x57, 0xcb, 0x2a, 0x59, 0x29, 0x45, 0x2f, 0x00, 0xbe, 0xd4, 0xaa,
+	0xc8, 0xec, 0xf7, 0x46, 0xd0, 0x21, 0x89, 0xda, 0xe8, 0x8f, 0x59, 0x85, 0x88, 0xfb, 0x28, 0xb7,
+	0x0e, 0x37, 0xd7, 0x1b, 0xbd, 0x84, 0x60, 0x26, 0x92, 0x55, 0xed, 0x33, 0x91, 0x50, 0x6d, 0x6d,
+	0x8e, 0xc7, 0xf5, 0x98, 0xb6, 0xeb, 0xf1, 0x01, 0xf4, 0x8e, 0xe3, 0x85, 0xcc, 0x8d, 0x58, 0x64,
+	0x65, 0x71, 0x95, 0x22, 0xfa, 0x06, 0x06, 0xf6, 0xba, 0xf0, 0x32, 0x8e, 0xa4, 0xb9, 0x06, 0xdc,
+	0x3f, 0xbb, 0xc4, 0xeb, 0x40, 0xfe, 0xe8, 0x81, 0x8f, 0x36, 0x67, 0xf2, 0x56, 0x26, 0xbc, 0xb7,
+	0xe3, 0xcb, 0x4c, 0x96, 0xad, 0xd1, 0x99, 0x0d, 0xa1, 0x7f, 0x64, 0x74, 0x9c, 0x9e, 0xcd, 0x44,
+	0x52, 0xc8, 0x32, 0x51, 0x5d, 0xc5, 0xde, 0x81, 0x60, 0x9a, 0x1a, 0x6b, 0xf6, 0xa9, 0xc1, 0x95,
+	0x8c, 0x3d, 0x3e, 0x55, 0x2a, 0xb1, 0xc6, 0xce, 0xd0, 0x1b, 0x05, 0xbc, 0x52, 0xb0, 0x1d, 0x80,
+	0x67, 0x89, 0x12, 0x65, 0x6c, 0x77, 0xe8, 0x8d, 0x3c, 0x5e, 0xd3, 0x44, 0x8f, 0x60, 0x03, 0x2b,
+	0x3d, 0x10, 0x59, 0xd5, 0xad, 0x77, 0x4b, 0xb7, 0xd1, 0x0f, 0x2d, 0xd8, 0xfc, 0xaa, 0x90, 0xfa,
+	0x92, 0xcb, 0xef, 0x0a, 0x99, 0x1b, 0x44, 0x9e, 0x64, 0xc7, 0x14, 0x12, 0x90, 0x13, 0x47, 0xe7,
+	0x42, 0xcf, 0x2d, 0x76, 0x3e, 0x2f, 0x25, 0xec, 0xb5, 0xc2, 0x3c, 0xa7, 0x5e, 0x03, 0x5e, 0x57,
+	0x61, 0x24, 0x97, 0x0b, 0x65, 0x5c, 0x33, 0xa5, 0xc4, 0x46, 0xf0, 0xe6, 0xfe, 0xc5, 0x69, 0x52,
+	0xcc, 0x25, 0x57, 0x4b, 0x1b, 0xdd, 0x25, 0x87, 0xa6, 0x9a, 0xbd, 0x07, 0x5b, 0xa5, 0xca, 0x0d,
+	0xe7, 0x06, 0x39, 0x36, 0xb4, 0x58, 0xf9, 0x8b, 0x78, 0x11, 0x9b, 0x30, 0xb0, 0x3c, 0x23, 0x01,
+	0xbf, 0xbf, 0x57, 0xe8, 0x5c, 0xe9, 0xb0, 0x67, 0xd9, 0x6c, 0x25, 0xf6, 0x10, 0x06, 0x07, 0xe2,
+	0x82, 0xcb, 0xbc, 0x48, 0x0c, 0x8d, 0x23, 0x50, 0xd4, 0xba, 0x32, 0xfa, 0xb5, 0x05, 0x83, 0x12,
+	0x9e, 0x3c, 0x53, 0x69, 0x2e, 0x91, 0x03, 0xfb, 0x5a, 0x3b, 0x0e, 0xec, 0x6b, 0xcd, 0x1e, 0xc1,
+	0x86, 0x8d, 0x70, 0xc4, 0xba, 0x57, 0x41, 0xed, 0x62, 0x31, 0x9f, 0xf3, 0x62, 0x9f, 0xc3, 0xd6,
+	0x1a, 0x51, 0xed, 0xc2, 0xe8, 0x8f, 0xdf, 0xae, 0xe2, 0xd6, 0xec, 0xbc, 0xe1, 0xce, 0xde, 0xc7,
+	0xcd, 0x70, 0x66, 0xb9, 0xd3, 0x1f, 0xbf, 0xd5, 0xf8, 0x1c, 0x9a, 0x38, 0x39, 0x20, 0x5d, 0x8e,
+	0xcf, 0xb5, 0xcc, 0xcf, 0x55, 0x32, 0xcf, 0xc3, 0x0e, 0x5d, 0x5d, 0x4d, 0xc3, 0x3e, 0x84, 0xbb,
+	0x5f, 0xa7, 0xe2, 0xb5, 0x88, 0x13, 0x71, 0x92, 0xc8, 0xf2, 0x86, 0xbb, 0xe4, 0x76, 0xdd, 0x40,
+	0xe3, 0xa7, 0x8b, 0xf4, 0x54, 0x18, 0x39, 0x2f, 0xef, 0xa0, 0x52, 0x20, 0x15, 0x9e, 0x14, 0x46,
+	0xed, 0x69, 0x49, 0xf6, 0xc0, 0x52, 0xa1, 0xa6, 0x8a, 0xfe, 0x6c, 0x41, 0xbf, 0x06, 0x08, 0x7b,
+	0x97, 0xb6, 0x2e, 0x41, 0xd9, 0x1f, 0x0f, 0xaa, 0x2e, 0x70, 0x77, 0xa0, 0x85, 0x6d, 0x82, 0x77,
+	0x58, 0x8e, 0x96, 0x77, 0x88, 0x84, 0xc6, 0x7d, 0xe8, 0xd0, 0xaa, 0x11, 0x1a, 0xd5, 0xdc, 0x1a,
+	0x69, 0x87, 0x9f, 0x8b, 0xf4, 0x4c, 0xce, 0x09, 0x9e, 0x80, 0x3b, 0x91, 0xed, 0x56, 0x1b, 0x87,
+	0xb8, 0xb8, 0xb6, 0xb4, 0x9c, 0x85, 0xaf, 0x7c, 0x56, 0xb3, 0x8d, 0xb4, 0x1c, 0x94, 0xb3, 0x6d,
+	0x77, 0xe3, 0x74, 0x82, 0x1c, 0xa4, 0x39, 0xb0, 0x12, 0xfb, 0x18, 0xfa, 0xd5, 0x6e, 0xcc, 0xc3,
+	0x80, 0x2a, 0xdc, 0xae, 0xd2, 0x57, 0x46, 0x5e, 0x77, 0x64, 0x5f, 0x34, 0x5f, 0x07, 0x62, 0x69,
+	0x7f, 0x1c, 0xae, 0xa1, 0x51, 0xb3, 0xf3, 0x86, 0x3f, 0xc1, 0x9e, 0x65, 0x5a, 0x5d, 0xc4, 0x0b,
+	0x61, 0x64, 0x08, 0x25, 0xec, 0x95, 0x0a, 0x47, 0x7c, 0x30, 0x5d, 0x64, 0x4a, 0x9b, 0xda, 0x8c,
+	0x4f, 0xd3, 0xb9, 0xbc, 0x70, 0x33, 0x4e, 0x42, 0xf5, 0x46, 0xb4, 0x1a, 0x6f, 0x04, 0x5d, 0x3f,
+	0xcd, 0xb6, 0xcf, 0xad, 0x50, 0xc3, 0xc1, 0x5f, 0xc3, 0xe1, 0x01, 0xf4, 0x2c, 0x57, 0xa7, 0x13,
+	0xc7, 0xb7, 0x4a, 0x41, 0x74, 0x74, 0xeb, 0xda, 0xf2, 0xac, 0xcd, 0x6b, 0x1a, 0xbc, 0x3b, 0xfb,
+	0xd6, 0x58, 0x78, 0x7b, 0xdc, 0x89, 0x18, 0x69, 0xd3, 0x90, 0x31, 0x20, 0x63, 0x4d, 0xc3, 0x3e,
+	0x5d, 0xdf, 0x43, 0xbd, 0xdb, 0xe7, 0xa9, 0xee, 0x1b, 0xfd, 0xec, 0x01, 0xb3, 0xf0, 0xd0, 0x0a,
+	0xfd, 0xef, 0x30, 0xba, 0x1d, 0x8b, 0xfb, 0xd0, 0xa5, 0xef, 0x39, 0x1c, 0x4a, 0xa9, 0xd1, 0xe9,
+	0x46, 0xb3, 0xd3, 0xe8, 0x27, 0x0f, 0xee, 0xda, 0x72, 0xf1, 0x55, 0xf8, 0x9f, 0xaa, 0xf5, 0x6f,
+	0xb8, 0xb9, 0x5a, 0x55, 0x9d, 0x6b, 0xf8, 0xaf, 0x77, 0x13, 0xb8, 0x6e, 0xa2, 0x19, 0x6c, 0x1f,
+	0x6b, 0x91, 0xe6, 0x89, 0x30, 0x12, 0x1d, 0xff, 0x4d, 0xbd, 0x37, 0xfd, 0x55, 0x7d, 0x00, 0xf7,
+	0x1a, 0x79, 0xab, 0xf5, 0x3c, 0x9d, 0x58, 0x5f, 0x9f, 0xe3, 0x31, 0x7a, 0x0a, 0x61, 0xc9, 0x7e,
+	0x25, 0xf0, 0x09, 0x2e, 0x4b, 0x98, 0xc5, 0x72, 0x89, 0xa9, 0x0f, 0xc5, 0x42, 0x96, 0x55, 0xd0,
+	0x19, 0x75, 0x13, 0x61, 0x04, 0xd5, 0xb0, 0xc9, 0xe9, 0x1c, 0xbd, 0x82, 0xed, 0x9b, 0x72, 0xd0,
+	0x6f, 0x4a, 0x22, 0x85, 0x7d, 0x0e, 0x02, 0x6e, 0x05, 0xf6, 0x18, 0x3a, 0xaf, 0x63, 0xb9, 0x74,
+	0xcf, 0x41, 0x54, 0xd1, 0xf0, 0xef, 0x0a, 0xe1, 0x36, 0x20, 0xfa, 0x04, 0x7a, 0xab, 0x15, 0x8e,
+	0x85, 0x1c, 0x28, 0x2d, 0xcb, 0xdc, 0x74, 0xae, 0xbd, 0x66, 0xad, 0xfa, 0x6b, 0xf6, 0xf4, 0xce,
+	0x2f, 0x57, 0x3b, 0xde, 0x6f, 0x57, 0x3b, 0xde, 0xef, 0x57, 0x3b, 0xde, 0xf7, 0x7f, 0xec, 0xbc,
+	0x71, 0xd2, 0xa5, 0x7f, 0xdc, 0x8f, 0xfe, 0x1a, 0x00, 0xb3, 0x5b, 0x13, 0x30, 0xf3, 0x0a, 0x00,
+	0x00,
 }
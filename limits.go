@@ -0,0 +1,119 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"fmt"
+
+	"github.com/pilosa/pilosa/v2/syswrap"
+)
+
+// schemaLimits holds the limits checked before an index or field is created
+// or undeleted through the API. Schema changes broadcast by other nodes are not checked,
+// so that the schema stays the same across the cluster. A zero limit is not
+// checked.
+type schemaLimits struct {
+	maxIndexes        int
+	maxFieldsPerIndex int
+	maxOpenFiles      uint64
+}
+
+// SchemaLimitError is returned when creating an index or field would exceed
+// a schema limit.
+type SchemaLimitError struct {
+	// Limit describes what is limited, e.g. "indexes".
+	Limit string
+
+	// Count is the number in use and Max the limit.
+	Count uint64
+	Max   uint64
+}
+
+func (e SchemaLimitError) Error() string {
+	return fmt.Sprintf("schema limit reached: %d %s, limit is %d", e.Count, e.Limit, e.Max)
+}
+
+// checkIndex returns a SchemaLimitError if an index cannot be added to h.
+func (l *schemaLimits) checkIndex(h *Holder) error {
+	if l == nil {
+		return nil
+	}
+	if n := len(h.Indexes()); l.maxIndexes > 0 && n >= l.maxIndexes {
+		return SchemaLimitError{Limit: "indexes", Count: uint64(n), Max: uint64(l.maxIndexes)}
+	}
+	return l.checkOpenFiles()
+}
+
+// checkField returns a SchemaLimitError if a field cannot be added to idx.
+// The internal existence field is not counted.
+func (l *schemaLimits) checkField(idx *Index) error {
+	if l == nil {
+		return nil
+	}
+	if n := fieldCount(idx); l.maxFieldsPerIndex > 0 && n >= l.maxFieldsPerIndex {
+		return SchemaLimitError{Limit: fmt.Sprintf("fields in index %s", idx.Name()), Count: uint64(n), Max: uint64(l.maxFieldsPerIndex)}
+	}
+	return l.checkOpenFiles()
+}
+
+// checkSchema returns a SchemaLimitError if the indexes and fields of s
+// which do not exist in h cannot all be added to it. The count of the error
+// is the number there would be.
+func (l *schemaLimits) checkSchema(h *Holder, s *Schema) error {
+	if l == nil {
+		return nil
+	}
+	n := len(h.Indexes())
+	for _, ii := range s.Indexes {
+		idx := h.Index(ii.Name)
+		var fields int
+		if idx == nil {
+			n++
+		} else {
+			fields = fieldCount(idx)
+		}
+		for _, fi := range ii.Fields {
+			if fi.Name != existenceFieldName && (idx == nil || idx.Field(fi.Name) == nil) {
+				fields++
+			}
+		}
+		if l.maxFieldsPerIndex > 0 && fields > l.maxFieldsPerIndex {
+			return SchemaLimitError{Limit: fmt.Sprintf("fields in index %s", ii.Name), Count: uint64(fields), Max: uint64(l.maxFieldsPerIndex)}
+		}
+	}
+	if l.maxIndexes > 0 && n > l.maxIndexes {
+		return SchemaLimitError{Limit: "indexes", Count: uint64(n), Max: uint64(l.maxIndexes)}
+	}
+	return nil
+}
+
+// fieldCount returns the number of fields of idx, not counting the internal
+// existence field.
+func fieldCount(idx *Index) int {
+	var n int
+	for _, f := range idx.Fields() {
+		if f.Name() != existenceFieldName {
+			n++
+		}
+	}
+	return n
+}
+
+func (l *schemaLimits) checkOpenFiles() error {
+	if n := syswrap.FileCount(); l.maxOpenFiles > 0 && n >= l.maxOpenFiles {
+		return SchemaLimitError{Limit: "open files", Count: n, Max: l.maxOpenFiles}
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"sync/atomic"
 )
 
 // Ensure nopLogger implements interface.
@@ -84,6 +85,46 @@ func (vb *verboseLogger) Logger() *log.Logger {
 	return vb.logger
 }
 
+// LevelLogger is an implementation of Logger whose debug messages can be
+// switched on and off while it is in use.
+type LevelLogger struct {
+	logger  *log.Logger
+	verbose int32
+}
+
+// NewLevelLogger returns a LevelLogger which includes debug messages if
+// verbose is set.
+func NewLevelLogger(w io.Writer, verbose bool) *LevelLogger {
+	l := &LevelLogger{
+		logger: log.New(w, "", log.LstdFlags),
+	}
+	l.SetVerbose(verbose)
+	return l
+}
+
+func (l *LevelLogger) Printf(format string, v ...interface{}) {
+	l.logger.Printf(format, v...)
+}
+
+func (l *LevelLogger) Debugf(format string, v ...interface{}) {
+	if atomic.LoadInt32(&l.verbose) == 1 {
+		l.logger.Printf(format, v...)
+	}
+}
+
+// SetVerbose toggles whether debug messages are logged.
+func (l *LevelLogger) SetVerbose(verbose bool) {
+	var v int32
+	if verbose {
+		v = 1
+	}
+	atomic.StoreInt32(&l.verbose, v)
+}
+
+func (l *LevelLogger) Logger() *log.Logger {
+	return l.logger
+}
+
 // CaptureLogger is a logger that stores all the print and debug messages
 // it sees, useful for testing.
 type CaptureLogger struct {
@@ -0,0 +1,131 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"bytes"
+	"sync"
+)
+
+// followerBufferSize is the number of lines buffered for each follower of a
+// LogTail. Lines are dropped for followers which fall further behind.
+const followerBufferSize = 256
+
+// LogTail is an io.Writer which keeps the most recent lines written to it in
+// a ring buffer and sends new lines to its followers. It is meant to be
+// written to alongside the log output.
+type LogTail struct {
+	mu      sync.Mutex
+	lines   []string
+	next    int  // index in lines of the next line written
+	full    bool // true once lines has wrapped around
+	partial []byte
+
+	followers map[chan string]struct{}
+}
+
+// NewLogTail returns a LogTail which keeps the last size lines.
+func NewLogTail(size int) *LogTail {
+	return &LogTail{
+		lines:     make([]string, size),
+		followers: make(map[chan string]struct{}),
+	}
+}
+
+// Write splits p into lines, which are added to the buffer and sent to the
+// followers. A trailing incomplete line is held until it is completed. Write
+// never blocks on followers.
+func (t *LogTail) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	buf := p
+	for {
+		i := bytes.IndexByte(buf, '\n')
+		if i < 0 {
+			t.partial = append(t.partial, buf...)
+			break
+		}
+		line := string(t.partial) + string(buf[:i])
+		t.partial = t.partial[:0]
+		buf = buf[i+1:]
+
+		if len(t.lines) > 0 {
+			t.lines[t.next] = line
+			if t.next = (t.next + 1) % len(t.lines); t.next == 0 {
+				t.full = true
+			}
+		}
+		for ch := range t.followers {
+			select {
+			case ch <- line:
+			default:
+			}
+		}
+	}
+	return len(p), nil
+}
+
+// Lines returns the last n lines, oldest first, or every buffered line if
+// there are fewer than n or n is not positive.
+func (t *LogTail) Lines(n int) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.unprotectedLines(n)
+}
+
+func (t *LogTail) unprotectedLines(n int) []string {
+	size := t.next
+	if t.full {
+		size = len(t.lines)
+	}
+	if n <= 0 || n > size {
+		n = size
+	}
+
+	lines := make([]string, 0, n)
+	for i := t.next - n; i < t.next; i++ {
+		lines = append(lines, t.lines[(i+len(t.lines))%len(t.lines)])
+	}
+	return lines
+}
+
+// Follow returns the last n lines, as Lines does, and a channel which
+// receives every line written after them. The channel is closed by the
+// returned function, which must be called once the follower is done.
+func (t *LogTail) Follow(n int) ([]string, <-chan string, func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ch := make(chan string, followerBufferSize)
+	t.followers[ch] = struct{}{}
+
+	var once sync.Once
+	return t.unprotectedLines(n), ch, func() {
+		once.Do(func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			delete(t.followers, ch)
+			close(ch)
+		})
+	}
+}
+
+// Followers returns the number of followers.
+func (t *LogTail) Followers() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.followers)
+}
@@ -0,0 +1,151 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/logger"
+	"github.com/pilosa/pilosa/v2/stats"
+	"github.com/pkg/errors"
+)
+
+// ErrMemoryLimit is returned for read queries to a node whose memory use is
+// above the high watermark of its memory limit. Every read query is
+// rejected, not only expensive ones, since the memory a query needs is not
+// known until it runs: even a Count materializes a row of every shard.
+var ErrMemoryLimit = errors.New("node is near its memory limit")
+
+// Defaults of the memory limit. The watermarks are fractions of the limit.
+const (
+	defaultMemoryHighWatermark = 0.9
+	defaultMemoryLowWatermark  = 0.8
+	defaultMemoryCheckInterval = time.Second
+)
+
+// memoryFreeInterval is the minimum interval between the forced collections
+// which return freed memory to the OS while the memory use stays above the
+// high watermark, since each stops the world and scans the whole heap.
+const memoryFreeInterval = 30 * time.Second
+
+// memoryGuard samples the memory obtained from the OS by the Go runtime, and
+// rejects read queries once it passes the high watermark of a soft limit,
+// until it falls back below the low watermark. Writes are accepted, so that
+// what is written is not lost. On passing the watermark, and then at most
+// every memoryFreeInterval until it falls back, it returns freed memory
+// to the OS. Memory mapped by fragments is not counted. A nil memoryGuard
+// rejects nothing.
+type memoryGuard struct {
+	max       uint64
+	high, low uint64 // in bytes
+	interval  time.Duration
+
+	mu    sync.Mutex
+	over  bool // from passing high until falling below low
+	used  uint64
+	freed time.Time // when memory was last returned to the OS
+
+	readMemStats func(*runtime.MemStats)
+	freeOSMemory func()
+	now          func() time.Time
+	logger       logger.Logger
+	stats        stats.StatsClient
+}
+
+// newMemoryGuard returns a memoryGuard for a limit of max bytes, with
+// watermarks given as fractions of max, sampled every interval.
+func newMemoryGuard(max uint64, high, low float64, interval time.Duration) (*memoryGuard, error) {
+	if high <= 0 || high > 1 || low <= 0 || low > high {
+		return nil, errors.Errorf("invalid memory watermarks: high %v, low %v; need 0 < low <= high <= 1", high, low)
+	} else if interval <= 0 {
+		return nil, errors.Errorf("invalid memory check interval: %s", interval)
+	}
+	return &memoryGuard{
+		max:          max,
+		high:         uint64(float64(max) * high),
+		low:          uint64(float64(max) * low),
+		interval:     interval,
+		readMemStats: runtime.ReadMemStats,
+		freeOSMemory: debug.FreeOSMemory,
+		now:          time.Now,
+		logger:       logger.NopLogger,
+		stats:        stats.NopStatsClient,
+	}, nil
+}
+
+// sample reads the memory use of the runtime and updates whether it is over
+// the limit, returning freed memory to the OS if it just went over, or if it
+// is still over and memoryFreeInterval has passed since it last did.
+func (g *memoryGuard) sample() {
+	var m runtime.MemStats
+	g.readMemStats(&m)
+	used := m.Sys - m.HeapReleased
+	g.stats.Gauge("memoryUsed", float64(used), 1.0)
+
+	g.mu.Lock()
+	g.used = used
+	var free bool
+	now := g.now()
+	if !g.over && used >= g.high {
+		g.over, free = true, true
+		g.logger.Printf("WARNING: memory use %d bytes is above %d bytes, the high watermark of the %d byte limit; rejecting read queries", used, g.high, g.max)
+	} else if g.over && used <= g.low {
+		g.over = false
+		g.logger.Printf("memory use %d bytes is back below %d bytes; accepting read queries", used, g.low)
+	} else if g.over && now.Sub(g.freed) >= memoryFreeInterval {
+		free = true
+	}
+	if free {
+		g.freed = now
+	}
+	g.mu.Unlock()
+
+	if free {
+		g.freeOSMemory()
+	}
+}
+
+// validate returns ErrMemoryLimit while the memory use is over the limit, and
+// counts the rejection.
+func (g *memoryGuard) validate() error {
+	if g == nil {
+		return nil
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.over {
+		return nil
+	}
+	g.stats.Count("memoryRejectedQuery", 1, 1.0)
+	return errors.Wrapf(ErrMemoryLimit, "using %d bytes of %d", g.used, g.max)
+}
+
+// monitorMemory samples the memory use of the node until it closes.
+func (s *Server) monitorMemory() {
+	ticker := time.NewTicker(s.memory.interval)
+	defer ticker.Stop()
+
+	for {
+		s.memory.sample()
+		select {
+		case <-s.closing:
+			return
+		case <-ticker.C:
+		}
+	}
+}
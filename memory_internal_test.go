@@ -0,0 +1,85 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestMemoryGuard(t *testing.T) {
+	var g *memoryGuard
+	if err := g.validate(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newMemoryGuard(1000, 0.8, 0.9, time.Second); err == nil {
+		t.Fatal("expected error for low watermark above high")
+	} else if _, err := newMemoryGuard(1000, 1.5, 0.9, time.Second); err == nil {
+		t.Fatal("expected error for high watermark above 1")
+	}
+
+	g, err := newMemoryGuard(1000, 0.9, 0.8, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var used uint64
+	var freed int
+	g.readMemStats = func(m *runtime.MemStats) { m.Sys, m.HeapReleased = used+100, 100 }
+	g.freeOSMemory = func() { freed++ }
+	now := time.Unix(1000, 0)
+	g.now = func() time.Time { return now }
+
+	sample := func(u uint64, exp error) {
+		t.Helper()
+		used = u
+		g.sample()
+		if err := g.validate(); errors.Cause(err) != exp {
+			t.Fatalf("using %d: expected %v, got %v", u, exp, err)
+		}
+	}
+	sample(800, nil)
+	sample(900, ErrMemoryLimit)
+	if freed != 1 {
+		t.Fatalf("expected memory to be freed once, got %d", freed)
+	}
+
+	// Queries are rejected until the use falls below the low watermark,
+	// and memory is freed again only once the free interval passes.
+	sample(950, ErrMemoryLimit)
+	now = now.Add(memoryFreeInterval - time.Second)
+	sample(850, ErrMemoryLimit)
+	if freed != 1 {
+		t.Fatalf("expected memory to be freed once, got %d", freed)
+	}
+	now = now.Add(time.Second)
+	sample(850, ErrMemoryLimit)
+	if freed != 2 {
+		t.Fatalf("expected memory to be freed twice, got %d", freed)
+	}
+	sample(800, nil)
+	sample(850, nil)
+	if freed != 2 {
+		t.Fatalf("expected memory to be freed twice, got %d", freed)
+	}
+
+	// Passing the high watermark again frees memory at once.
+	sample(900, ErrMemoryLimit)
+	if freed != 3 {
+		t.Fatalf("expected memory to be freed three times, got %d", freed)
+	}
+}
@@ -29,6 +29,7 @@ type TranslateStore struct {
 	SetReadOnlyFunc   func(v bool)
 	TranslateKeyFunc  func(key string) (uint64, error)
 	TranslateKeysFunc func(keys []string) ([]uint64, error)
+	FindKeyFunc       func(key string) (uint64, error)
 	TranslateIDFunc   func(id uint64) (string, error)
 	TranslateIDsFunc  func(ids []uint64) ([]string, error)
 	ForceSetFunc      func(id uint64, key string) error
@@ -59,6 +60,10 @@ func (s *TranslateStore) TranslateKeys(keys []string) ([]uint64, error) {
 	return s.TranslateKeysFunc(keys)
 }
 
+func (s *TranslateStore) FindKey(key string) (uint64, error) {
+	return s.FindKeyFunc(key)
+}
+
 func (s *TranslateStore) TranslateID(id uint64) (string, error) {
 	return s.TranslateIDFunc(id)
 }
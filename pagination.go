@@ -0,0 +1,165 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+
+	"github.com/pilosa/pilosa/v2/pql"
+	"github.com/pkg/errors"
+)
+
+// QueryPage describes the page of a paginated TopN or GroupBy result.
+type QueryPage struct {
+	// More is true if results remain after the page.
+	More bool `json:"more"`
+	// Cursor identifies the last result of the page. It is set when More
+	// is true, and passed with the next request to get the next page.
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// queryCursor identifies a result of a paginated query by its position in
+// the ranking rather than its index so that a page starts after the last
+// result of the previous one even if results were added or removed since.
+type queryCursor struct {
+	// Count and ID identify a TopN pair.
+	Count uint64 `json:"count,omitempty"`
+	ID    uint64 `json:"id,omitempty"`
+	// Group holds the row IDs of a GroupBy group.
+	Group []uint64 `json:"group,omitempty"`
+}
+
+// encodeQueryCursor returns the opaque token for c.
+func encodeQueryCursor(c queryCursor) string {
+	buf, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// decodeQueryCursor parses a token returned by encodeQueryCursor.
+func decodeQueryCursor(s string) (*queryCursor, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "decoding cursor"))
+	}
+	var c queryCursor
+	if err := json.Unmarshal(buf, &c); err != nil {
+		return nil, NewBadRequestError(errors.Wrap(err, "decoding cursor"))
+	}
+	return &c, nil
+}
+
+// isPaginated returns true if req asks for a page of its result.
+func isPaginated(req *QueryRequest) bool {
+	return req.Limit > 0 || req.Cursor != ""
+}
+
+// validatePaginatedQuery returns a BadRequestError unless q can be paginated,
+// which requires a single TopN or GroupBy call.
+func validatePaginatedQuery(q *pql.Query) error {
+	if len(q.Calls) != 1 || (q.Calls[0].Name != "TopN" && q.Calls[0].Name != "GroupBy") {
+		return NewBadRequestError(errors.New("pagination requires a query with a single TopN or GroupBy call"))
+	}
+	return nil
+}
+
+// paginate returns resp with its result reduced to the at most limit pairs or
+// groups ranked after cursor. A limit of zero returns every remaining result
+// and a nil cursor starts from the first. Results are not modified since they
+// may be shared with the query cache.
+func paginate(resp QueryResponse, limit uint64, cursor *queryCursor) (QueryResponse, error) {
+	if len(resp.Results) != 1 {
+		return resp, nil
+	}
+
+	var page QueryPage
+	switch result := resp.Results[0].(type) {
+	case []Pair:
+		// Rank pairs with equal counts by ID so that pages are stable.
+		pairs := make([]Pair, len(result))
+		copy(pairs, result)
+		sort.SliceStable(pairs, func(i, j int) bool {
+			if pairs[i].Count != pairs[j].Count {
+				return pairs[i].Count > pairs[j].Count
+			}
+			return pairs[i].ID < pairs[j].ID
+		})
+
+		start := 0
+		if cursor != nil {
+			start = sort.Search(len(pairs), func(i int) bool {
+				return pairs[i].Count < cursor.Count || (pairs[i].Count == cursor.Count && pairs[i].ID > cursor.ID)
+			})
+		}
+		end := pageEnd(start, len(pairs), limit)
+		pairs = pairs[start:end]
+		if page.More = end < len(result); page.More {
+			last := pairs[len(pairs)-1]
+			page.Cursor = encodeQueryCursor(queryCursor{Count: last.Count, ID: last.ID})
+		}
+		resp.Results = []interface{}{pairs}
+
+	case []GroupCount:
+		// Groups are ordered by their row IDs.
+		start := 0
+		if cursor != nil {
+			start = sort.Search(len(result), func(i int) bool {
+				return compareGroupRowIDs(result[i].Group, cursor.Group) > 0
+			})
+		}
+		end := pageEnd(start, len(result), limit)
+		groups := result[start:end:end]
+		if page.More = end < len(result); page.More {
+			last := groups[len(groups)-1]
+			c := queryCursor{Group: make([]uint64, len(last.Group))}
+			for i, fr := range last.Group {
+				c.Group[i] = fr.RowID
+			}
+			page.Cursor = encodeQueryCursor(c)
+		}
+		resp.Results = []interface{}{groups}
+
+	default:
+		return resp, NewBadRequestError(errors.Errorf("cannot paginate result of type %T", result))
+	}
+
+	resp.Page = &page
+	return resp, nil
+}
+
+// pageEnd returns the end of the page of at most limit results which starts
+// at start, out of n.
+func pageEnd(start, n int, limit uint64) int {
+	if limit == 0 || uint64(n-start) <= limit {
+		return n
+	}
+	return start + int(limit)
+}
+
+// compareGroupRowIDs compares the row IDs of group with ids, in order.
+func compareGroupRowIDs(group []FieldRow, ids []uint64) int {
+	for i := range group {
+		if i >= len(ids) || group[i].RowID > ids[i] {
+			return 1
+		} else if group[i].RowID < ids[i] {
+			return -1
+		}
+	}
+	if len(ids) > len(group) {
+		return -1
+	}
+	return 0
+}
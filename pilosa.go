@@ -29,6 +29,11 @@ var (
 	ErrIndexExists   = errors.New("index already exists")
 	ErrIndexNotFound = errors.New("index not found")
 
+	ErrIndexTombstoneNotFound = errors.New("index tombstone not found")
+
+	ErrIndexAliasExists   = errors.New("index alias already exists")
+	ErrIndexAliasNotFound = errors.New("index alias not found")
+
 	// ErrFieldRequired is returned when no field is specified.
 	ErrFieldRequired = errors.New("field required")
 	ErrFieldExists   = errors.New("field already exists")
@@ -46,6 +51,7 @@ var (
 	ErrInvalidBetweenValue      = errors.New("invalid value for between operation")
 
 	ErrInvalidView      = errors.New("invalid view")
+	ErrViewNotFound     = errors.New("view not found")
 	ErrInvalidCacheType = errors.New("invalid cache type")
 
 	ErrName  = errors.New("invalid index or field name, must match [a-z][a-z0-9_-]* and contain at most 64 characters")
@@ -58,6 +64,31 @@ var (
 	ErrQueryTimeout     = errors.New("query timeout")
 	ErrTooManyWrites    = errors.New("too many write commands")
 
+	ErrQueryNotFound            = errors.New("query not found")
+	ErrQueryCancelledByOperator = errors.New("query cancelled by operator")
+
+	ErrImportSessionNotFound = errors.New("import session not found")
+
+	// ErrImportSessionPartlyCommitted is returned when changing or aborting
+	// an import session whose commit has applied some of its shards.
+	ErrImportSessionPartlyCommitted = errors.New("import session is partly committed, retry the commit")
+
+	// ErrColumnKeyNotFound is returned when a column key has no ID.
+	ErrColumnKeyNotFound = errors.New("column key not found")
+
+	// ErrIndexRenaming is returned for queries against an index, or a
+	// field of an index, which is being renamed.
+	ErrIndexRenaming = errors.New("index is being renamed, retry the request")
+
+	// ErrReadOnlyStandby is returned for writes to a standby which has not
+	// been promoted.
+	ErrReadOnlyStandby = errors.New("node is a read-only standby")
+
+	// ErrTopologyChanged is returned when a node rejects an internal request
+	// because it was routed with a different topology than the node's, e.g.
+	// because shard ownership just moved during a resize.
+	ErrTopologyChanged = errors.New("shard ownership changed")
+
 	// TODO(2.0) poorly named - used when a *node* doesn't own a shard. Probably
 	// we won't need this error at all by 2.0 though.
 	ErrClusterDoesNotOwnShard = errors.New("node does not own shard")
@@ -66,6 +97,10 @@ var (
 	ErrNodeNotCoordinator = errors.New("node is not the coordinator")
 	ErrResizeNotRunning   = errors.New("no resize job currently running")
 
+	// ErrAntiEntropyQueueFull is returned for anti-entropy syncs which are
+	// rescheduled because the anti-entropy queue is full.
+	ErrAntiEntropyQueueFull = errors.New("anti-entropy queue full, sync rescheduled")
+
 	ErrNotImplemented            = errors.New("not implemented")
 	ErrFieldsArgumentRequired    = errors.New("fields argument required")
 	ErrExpectedFieldListArgument = errors.New("expected field list argument")
@@ -129,8 +129,7 @@ func (q *Query) addVal(val interface{}) {
 		panic(fmt.Sprintf("addVal called with '%s' when lastField is empty", val))
 	}
 	if elem.inList {
-		list := elem.call.Args[elem.lastField].([]interface{})
-		elem.call.Args[elem.lastField] = append(list, val)
+		elem.appendListVal(val)
 		return
 	}
 	if elem.lastCond != ILLEGAL {
@@ -163,16 +162,7 @@ func (q *Query) addNumVal(val string) {
 		panic(fmt.Sprintf("%s: %s", intOutOfRangeError, err))
 	}
 	if elem.inList {
-		if elem.lastCond != ILLEGAL {
-			list := elem.call.Args[elem.lastField].(*Condition).Value.([]interface{})
-			elem.call.Args[elem.lastField] = &Condition{
-				Op:    elem.lastCond,
-				Value: append(list, ival),
-			}
-		} else {
-			list := elem.call.Args[elem.lastField].([]interface{})
-			elem.call.Args[elem.lastField] = append(list, ival)
-		}
+		elem.appendListVal(ival)
 		return
 	} else if elem.lastCond != ILLEGAL {
 		q.validateArgField(elem) // case 3
@@ -243,6 +233,27 @@ func (q *Query) WriteCallN() int {
 	return n
 }
 
+// Depth returns the maximum nesting depth of the calls of the query. A
+// top-level call has a depth of one.
+func (q *Query) Depth() int {
+	var depth int
+	for _, call := range q.Calls {
+		if d := call.depth(); d > depth {
+			depth = d
+		}
+	}
+	return depth
+}
+
+// CallN returns the number of calls in the query, nested calls included.
+func (q *Query) CallN() int {
+	var n int
+	for _, call := range q.Calls {
+		n += call.callN()
+	}
+	return n
+}
+
 // String returns a string representation of the query.
 func (q *Query) String() string {
 	a := make([]string, len(q.Calls))
@@ -259,6 +270,21 @@ type callStackElem struct {
 	inList    bool
 }
 
+// appendListVal appends val to the list being parsed, which is the value of
+// a condition if the list follows one.
+func (elem *callStackElem) appendListVal(val interface{}) {
+	if elem.lastCond != ILLEGAL {
+		list := elem.call.Args[elem.lastField].(*Condition).Value.([]interface{})
+		elem.call.Args[elem.lastField] = &Condition{
+			Op:    elem.lastCond,
+			Value: append(list, val),
+		}
+	} else {
+		list := elem.call.Args[elem.lastField].([]interface{})
+		elem.call.Args[elem.lastField] = append(list, val)
+	}
+}
+
 // Call represents a function call in the AST.
 type Call struct {
 	Name     string
@@ -390,6 +416,42 @@ func (c *Call) CallArg(key string) (*Call, bool, error) {
 	}
 }
 
+// subcalls calls fn with each call nested directly in c, as a child or as an
+// argument.
+func (c *Call) subcalls(fn func(*Call)) {
+	for _, child := range c.Children {
+		fn(child)
+	}
+	for _, v := range c.Args {
+		switch v := v.(type) {
+		case *Call:
+			fn(v)
+		case []interface{}:
+			for _, item := range v {
+				if call, ok := item.(*Call); ok {
+					fn(call)
+				}
+			}
+		}
+	}
+}
+
+func (c *Call) depth() int {
+	var depth int
+	c.subcalls(func(sub *Call) {
+		if d := sub.depth(); d > depth {
+			depth = d
+		}
+	})
+	return depth + 1
+}
+
+func (c *Call) callN() int {
+	n := 1
+	c.subcalls(func(sub *Call) { n += sub.callN() })
+	return n
+}
+
 // keys returns a list of argument keys in sorted order.
 func (c *Call) keys() []string {
 	a := make([]string, 0, len(c.Args))
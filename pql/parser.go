@@ -30,11 +30,36 @@ const timeFormat = "2006-01-02T15:04"
 const duplicateArgErrorMessage = "duplicate argument provided"
 const intOutOfRangeError = "integer is not in signed 64-bit range"
 
+// Limits bound the complexity of the queries accepted by a parser. A zero
+// limit is not checked.
+type Limits struct {
+	// MaxDepth is the maximum nesting depth of calls. A top-level call has a
+	// depth of one.
+	MaxDepth int
+
+	// MaxOps is the maximum number of calls in a query, nested calls
+	// included.
+	MaxOps int
+}
+
+// LimitError is returned for a query which exceeds a limit.
+type LimitError struct {
+	// Limit is the name of the limit, "depth" or "operations".
+	Limit string
+	Max   int
+}
+
+func (e LimitError) Error() string {
+	return fmt.Sprintf("query exceeds the maximum %s of %d", e.Limit, e.Max)
+}
+
 // parser represents a parser for the PQL language.
 type parser struct {
 	r io.Reader
 	//scanner *bufScanner
 	PQL
+
+	limits Limits
 }
 
 // NewParser returns a new instance of Parser.
@@ -45,6 +70,14 @@ func NewParser(r io.Reader) *parser {
 	}
 }
 
+// NewParserWithLimits returns a new instance of Parser which rejects queries
+// exceeding limits.
+func NewParserWithLimits(r io.Reader, limits Limits) *parser {
+	p := NewParser(r)
+	p.limits = limits
+	return p
+}
+
 // ParseString parses s into a query.
 func ParseString(s string) (*Query, error) {
 	return NewParser(strings.NewReader(s)).Parse()
@@ -56,6 +89,11 @@ func (p *parser) Parse() (*Query, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "reading buffer to parse")
 	}
+	// The parser recurses into nested calls, so the depth is checked before
+	// parsing to keep deeply nested input from exhausting the stack.
+	if p.limits.MaxDepth > 0 && nestingDepth(buf) > p.limits.MaxDepth {
+		return nil, LimitError{Limit: "depth", Max: p.limits.MaxDepth}
+	}
 	p.PQL = PQL{
 		Buffer: string(buf),
 	}
@@ -83,5 +121,34 @@ func (p *parser) Parse() (*Query, error) {
 		}
 	}
 
+	if p.limits.MaxOps > 0 && p.Query.CallN() > p.limits.MaxOps {
+		return nil, LimitError{Limit: "operations", Max: p.limits.MaxOps}
+	}
 	return &p.Query, nil
 }
+
+// nestingDepth returns the deepest nesting of parentheses in buf outside of
+// quoted strings, which is the nesting depth of the calls of a valid query.
+func nestingDepth(buf []byte) int {
+	var depth, max int
+	var quote byte
+	for i := 0; i < len(buf); i++ {
+		switch c := buf[i]; {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(':
+			if depth++; depth > max {
+				max = depth
+			}
+		case c == ')':
+			depth--
+		}
+	}
+	return max
+}
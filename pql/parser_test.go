@@ -16,6 +16,7 @@ package pql_test
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/pilosa/pilosa/v2/pql"
@@ -192,4 +193,51 @@ func TestParser_Parse(t *testing.T) {
 		}
 	})
 
+	// Parse with a condition on a list of strings.
+	t.Run("WithConditionStringList", func(t *testing.T) {
+		q, err := pql.ParseString(`Row(ts >< ["2020-01-01T00:00:00Z", 2020-02-01T00:00])`)
+		if err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(q.Calls[0],
+			&pql.Call{
+				Name: "Row",
+				Args: map[string]interface{}{
+					"ts": &pql.Condition{Op: pql.BETWEEN, Value: []interface{}{"2020-01-01T00:00:00Z", "2020-02-01T00:00"}},
+				},
+			},
+		) {
+			t.Fatalf("unexpected call: %#v", q.Calls[0])
+		}
+	})
+
+}
+
+// Ensure the parser rejects queries exceeding its limits.
+func TestParser_Limits(t *testing.T) {
+	limits := pql.Limits{MaxDepth: 3, MaxOps: 5}
+	for _, tt := range []struct {
+		query string
+		depth int
+		ops   int
+		err   string
+	}{
+		{query: `Count(Union(Row(f=1), Row(f=2)))`, depth: 3, ops: 4},
+		{query: `Count(Union(Row(f=1), Not(Row(f=2))))`, err: "query exceeds the maximum depth of 3"},
+		{query: `GroupBy(Rows(a), filter=Row(f=")))(((("))`, depth: 2, ops: 3},
+		{query: `Row(f=1) Row(f=2) Row(f=3) Row(f=4) Row(f=5) Row(f=6)`, err: "query exceeds the maximum operations of 5"},
+		{query: `Count(Intersect(Row(f=1), Row(f=2), Row(f=3), Row(f=4)))`, err: "query exceeds the maximum operations of 5"},
+		{query: strings.Repeat("Not(", 100000) + strings.Repeat(")", 100000), err: "query exceeds the maximum depth of 3"},
+	} {
+		q, err := pql.NewParserWithLimits(strings.NewReader(tt.query), limits).Parse()
+		if tt.err != "" {
+			if _, ok := err.(pql.LimitError); !ok || err.Error() != tt.err {
+				t.Errorf("parsing %.40s: expected %q, got %v", tt.query, tt.err, err)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("parsing %s: %v", tt.query, err)
+		} else if q.Depth() != tt.depth || q.CallN() != tt.ops {
+			t.Errorf("parsing %s: expected depth %d and %d operations, got %d and %d", tt.query, tt.depth, tt.ops, q.Depth(), q.CallN())
+		}
+	}
 }
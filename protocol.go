@@ -0,0 +1,138 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Versions of the protocol of the messages and internal requests exchanged by
+// the nodes of a cluster. A node speaks every version from
+// MinProtocolVersion to ProtocolVersion, and each pair of nodes uses the
+// highest version both speak. Nodes which predate versioning don't report a
+// version, and speak version 1.
+const (
+	ProtocolVersion    = 2
+	MinProtocolVersion = 1
+)
+
+// ErrIncompatibleProtocol is returned when two nodes speak no common version
+// of the protocol, or a message needs a newer version than a node speaks.
+var ErrIncompatibleProtocol = errors.New("incompatible protocol version")
+
+// messageProtocolVersions maps the types of the broadcast messages which
+// older nodes can't decode to the protocol version which introduced them.
+// Until every node speaks its version, a change carried by such a message is
+// refused before it is applied locally. ApplySchema is sent as the older
+// messages it replaced instead, and schema versions are not sent. New fields
+// of existing messages are ignored by older nodes, so they don't need a new
+// version.
+var messageProtocolVersions = map[byte]int{
+	messageTypeSetIndexAlias: 2,
+	messageTypeUpdateIndex:   2,
+	messageTypeUndeleteIndex: 2,
+	messageTypeApplySchema:   2,
+	messageTypeRename:        2,
+	messageTypeSchemaVersion: 2,
+}
+
+// negotiateProtocol returns the highest protocol version spoken by both a
+// node speaking versions min to max and a node speaking localMin to localMax.
+// A max of zero is a node which predates versioning.
+func negotiateProtocol(localMin, localMax, min, max int) (int, error) {
+	if max == 0 {
+		min, max = 1, 1
+	}
+	v := max
+	if v > localMax {
+		v = localMax
+	}
+	if v < min || v < localMin {
+		return 0, errors.Wrapf(ErrIncompatibleProtocol, "versions %d to %d and %d to %d", localMin, localMax, min, max)
+	}
+	return v, nil
+}
+
+// NegotiateProtocol returns the protocol version this node uses with a node
+// speaking versions min to max, as reported in its requests or responses.
+func NegotiateProtocol(min, max int) (int, error) {
+	return negotiateProtocol(MinProtocolVersion, ProtocolVersion, min, max)
+}
+
+// protocolVersion returns the protocol version this node uses with n.
+func (n *Node) protocolVersion() (int, error) {
+	return negotiateProtocol(MinProtocolVersion, ProtocolVersion, n.MinProtocolVersion, n.ProtocolVersion)
+}
+
+// protocolVersion returns the protocol version of the cluster: the lowest
+// version negotiated with its nodes. It is zero if some node speaks no common
+// version.
+func (c *cluster) protocolVersion() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	version := ProtocolVersion
+	if c.Static {
+		return version
+	}
+	for _, node := range c.nodes {
+		if node.ID == c.Node.ID {
+			continue
+		}
+		v, err := node.protocolVersion()
+		if err != nil {
+			return 0
+		} else if v < version {
+			version = v
+		}
+	}
+	return version
+}
+
+// checkMessageProtocol returns ErrIncompatibleProtocol if a node of the
+// cluster speaks a lower protocol version than messages of type typ need.
+// The nodes of a static cluster don't report their version, so they are not
+// checked.
+func (c *cluster) checkMessageProtocol(typ byte) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.Static {
+		return nil
+	}
+	for _, node := range c.nodes {
+		if node.ID == c.Node.ID {
+			continue
+		}
+		if err := checkMessageProtocol(node, typ); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkMessageProtocol returns ErrIncompatibleProtocol if node speaks a lower
+// protocol version than messages of type typ need.
+func checkMessageProtocol(node *Node, typ byte) error {
+	need, ok := messageProtocolVersions[typ]
+	if !ok {
+		return nil
+	}
+	if v, err := node.protocolVersion(); err != nil {
+		return errors.Wrapf(err, "node %s", node.ID)
+	} else if v < need {
+		return errors.Wrapf(ErrIncompatibleProtocol, "node %s speaks protocol version %d, the message needs version %d", node.ID, v, need)
+	}
+	return nil
+}
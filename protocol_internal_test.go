@@ -0,0 +1,175 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/pilosa/pilosa/v2/logger"
+	"github.com/pkg/errors"
+)
+
+func TestNegotiateProtocol(t *testing.T) {
+	for _, tt := range []struct {
+		localMin, localMax, min, max int
+		exp                          int
+	}{
+		{1, 2, 1, 2, 2},
+		{1, 2, 1, 3, 2},
+		{2, 3, 1, 2, 2},
+		{1, 2, 0, 0, 1}, // predates versioning
+		{2, 3, 0, 0, 0},
+		{1, 2, 3, 4, 0},
+	} {
+		v, err := negotiateProtocol(tt.localMin, tt.localMax, tt.min, tt.max)
+		if tt.exp == 0 {
+			if errors.Cause(err) != ErrIncompatibleProtocol {
+				t.Errorf("%v: expected incompatible protocol, got %d, %v", tt, v, err)
+			}
+		} else if err != nil || v != tt.exp {
+			t.Errorf("%v: expected %d, got %d, %v", tt, tt.exp, v, err)
+		}
+	}
+}
+
+func TestCluster_Protocol(t *testing.T) {
+	c := newCluster()
+	c.Node = &Node{ID: "node0", ProtocolVersion: ProtocolVersion, MinProtocolVersion: MinProtocolVersion}
+	c.Coordinator = "node0"
+	c.nodes = []*Node{c.Node, {ID: "node1", ProtocolVersion: ProtocolVersion, MinProtocolVersion: MinProtocolVersion}}
+
+	if v := c.protocolVersion(); v != ProtocolVersion {
+		t.Fatalf("unexpected protocol version: %d", v)
+	} else if err := c.checkMessageProtocol(messageTypeRename); err != nil {
+		t.Fatal(err)
+	}
+
+	// A node which predates versioning holds the cluster back to version 1,
+	// and messages it can't decode are refused.
+	c.nodes = append(c.nodes, &Node{ID: "node2"})
+	if v := c.protocolVersion(); v != 1 {
+		t.Fatalf("unexpected protocol version: %d", v)
+	} else if err := c.checkMessageProtocol(messageTypeRename); errors.Cause(err) != ErrIncompatibleProtocol {
+		t.Fatalf("expected incompatible protocol, got %v", err)
+	} else if err := c.checkMessageProtocol(messageTypeCreateIndex); err != nil {
+		t.Fatal(err)
+	}
+
+	// A node which speaks no common version can't join.
+	err := c.ReceiveEvent(&NodeEvent{Event: NodeJoin, Node: &Node{ID: "node3", ProtocolVersion: ProtocolVersion + 2, MinProtocolVersion: ProtocolVersion + 1}})
+	if errors.Cause(err) != ErrIncompatibleProtocol {
+		t.Fatalf("expected incompatible protocol, got %v", err)
+	}
+}
+
+// Ensure changes whose messages a node can't decode are refused before they
+// are applied, and schemas are sent to it as the messages it decodes.
+func TestAPI_Protocol(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	h.MustCreateIndexIfNotExists("i", IndexOptions{})
+
+	client := &messageRecorder{}
+	api := newRecordedAPI(h.Holder, client)
+	c := api.cluster
+	c.nodes[1].ProtocolVersion, c.nodes[1].MinProtocolVersion = 0, 0
+	ctx := context.Background()
+
+	if err := api.RenameIndex(ctx, "i", "j"); errors.Cause(err) != ErrIncompatibleProtocol {
+		t.Fatalf("expected incompatible protocol, got %v", err)
+	} else if h.Index("i") == nil {
+		t.Fatal("expected index not to be renamed")
+	} else if err := api.SetIndexAlias(ctx, "a", "i"); errors.Cause(err) != ErrIncompatibleProtocol {
+		t.Fatalf("expected incompatible protocol, got %v", err)
+	} else if aliases := h.IndexAliases(); len(aliases) != 0 {
+		t.Fatalf("expected alias not to be set, got %v", aliases)
+	} else if sent := client.take(); len(sent) != 0 {
+		t.Fatalf("unexpected messages: %v", sent)
+	}
+
+	defs := []IndexDefinition{{Name: "i", Fields: []FieldDefinition{{Name: "f"}}}, {Name: "k"}}
+	if _, err := api.CreateSchema(ctx, defs); err != nil {
+		t.Fatal(err)
+	} else if sent := client.take(); !reflect.DeepEqual(sent, []byte{messageTypeCreateField, messageTypeCreateIndex}) {
+		t.Fatalf("unexpected messages: %v", sent)
+	}
+
+	// Once the node is upgraded, schemas are sent in a single message.
+	c.nodes[1].ProtocolVersion, c.nodes[1].MinProtocolVersion = ProtocolVersion, MinProtocolVersion
+	defs = []IndexDefinition{{Name: "l"}}
+	if _, err := api.CreateSchema(ctx, defs); err != nil {
+		t.Fatal(err)
+	} else if sent := client.take(); !reflect.DeepEqual(sent, []byte{messageTypeApplySchema, messageTypeSchemaVersion}) {
+		t.Fatalf("unexpected messages: %v", sent)
+	}
+}
+
+// newRecordedAPI returns an API for h, coordinating a normal cluster of two
+// nodes of the current protocol version, which broadcasts with client.
+func newRecordedAPI(h *Holder, client *messageRecorder) *API {
+	c := newCluster()
+	c.Node = &Node{ID: "node0", URI: URI{Host: "node0"}, ProtocolVersion: ProtocolVersion, MinProtocolVersion: MinProtocolVersion}
+	c.Coordinator = "node0"
+	c.state = ClusterStateNormal
+	c.nodes = []*Node{c.Node, {ID: "node1", URI: URI{Host: "node1"}, ProtocolVersion: ProtocolVersion, MinProtocolVersion: MinProtocolVersion}}
+	s := &Server{
+		holder:        h,
+		cluster:       c,
+		uri:           c.Node.URI,
+		nodeID:        c.Node.ID,
+		serializer:    nopSerializer{},
+		defaultClient: client,
+		replicator:    newReplicator(),
+		logger:        logger.NopLogger,
+	}
+	return &API{holder: h, cluster: c, server: s}
+}
+
+// nopSerializer marshals every message to nothing.
+type nopSerializer struct{}
+
+func (nopSerializer) Marshal(Message) ([]byte, error) { return nil, nil }
+func (nopSerializer) Unmarshal([]byte, Message) error { return nil }
+
+// messageRecorder is an InternalClient which records the types of the
+// messages sent, failing to send them with err if it is set.
+type messageRecorder struct {
+	nopInternalClient
+	mu   sync.Mutex
+	sent []byte
+	err  error
+}
+
+func (r *messageRecorder) SendMessage(ctx context.Context, uri *URI, msg []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, msg[0])
+	return r.err
+}
+
+// take returns the types of the messages sent since it was last called.
+func (r *messageRecorder) take() []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sent := r.sent
+	r.sent = nil
+	return sent
+}
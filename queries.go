@@ -0,0 +1,129 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// QueryInfo describes a query which is being executed by a node.
+type QueryInfo struct {
+	ID    string    `json:"id"`
+	Index string    `json:"index"`
+	PQL   string    `json:"pql"`
+	Start time.Time `json:"start"`
+
+	// RemoteAddr is the address of the client which sent the query, if known.
+	RemoteAddr string `json:"remoteAddr,omitempty"`
+	// Remote is true if the query is part of a query coordinated by another
+	// node.
+	Remote bool `json:"remote"`
+}
+
+// runningQuery is a query registered with runningQueries.
+type runningQuery struct {
+	info   QueryInfo
+	cancel context.CancelFunc
+
+	// cancelled is set, under the lock of runningQueries, if the query was
+	// cancelled by an operator.
+	cancelled bool
+}
+
+// runningQueries holds the queries being executed by a node so that they can
+// be listed and cancelled.
+type runningQueries struct {
+	mu      sync.Mutex
+	queries map[string]*runningQuery
+}
+
+func newRunningQueries() *runningQueries {
+	return &runningQueries{
+		queries: make(map[string]*runningQuery),
+	}
+}
+
+// register records a query about to be executed. The returned context must be
+// used to execute the query so that it can be cancelled, and the query must be
+// deregistered once it completes.
+func (m *runningQueries) register(ctx context.Context, req *QueryRequest) (context.Context, *runningQuery) {
+	ctx, cancel := context.WithCancel(ctx)
+	q := &runningQuery{
+		info: QueryInfo{
+			ID:         uuid.NewV4().String(),
+			Index:      req.Index,
+			PQL:        req.Query,
+			Start:      time.Now(),
+			RemoteAddr: req.RemoteAddr,
+			Remote:     req.Remote,
+		},
+		cancel: cancel,
+	}
+
+	m.mu.Lock()
+	m.queries[q.info.ID] = q
+	m.mu.Unlock()
+	return ctx, q
+}
+
+// deregister forgets q, returning true if it was cancelled by an operator.
+func (m *runningQueries) deregister(q *runningQuery) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.queries, q.info.ID)
+	q.cancel()
+	return q.cancelled
+}
+
+// list returns the running queries, oldest first.
+func (m *runningQueries) list() []QueryInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	infos := make([]QueryInfo, 0, len(m.queries))
+	for _, q := range m.queries {
+		infos = append(infos, q.info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Start.Before(infos[j].Start)
+	})
+	return infos
+}
+
+// cancel cancels the context of the running query with the given ID.
+func (m *runningQueries) cancel(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	q, ok := m.queries[id]
+	if !ok {
+		return newNotFoundError(ErrQueryNotFound, id)
+	}
+	q.cancelled = true
+	q.cancel()
+	return nil
+}
+
+// cancelAll cancels the contexts of all the running queries.
+func (m *runningQueries) cancelAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, q := range m.queries {
+		q.cancel()
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestRunningQueries(t *testing.T) {
+	m := newRunningQueries()
+
+	ctx1, q1 := m.register(context.Background(), &QueryRequest{Index: "i", Query: "Count(Row(f=1))", RemoteAddr: "10.0.0.1:1234"})
+	_, q2 := m.register(context.Background(), &QueryRequest{Index: "j", Query: "Row(f=2)", Remote: true})
+
+	infos := m.list()
+	if len(infos) != 2 {
+		t.Fatalf("expected 2 queries, got %+v", infos)
+	} else if infos[0].ID != q1.info.ID || infos[0].PQL != "Count(Row(f=1))" || infos[0].RemoteAddr != "10.0.0.1:1234" {
+		t.Fatalf("unexpected first query: %+v", infos[0])
+	} else if infos[1].Index != "j" || !infos[1].Remote {
+		t.Fatalf("unexpected second query: %+v", infos[1])
+	}
+
+	if err := m.cancel(q1.info.ID); err != nil {
+		t.Fatal(err)
+	} else if ctx1.Err() != context.Canceled {
+		t.Fatalf("expected cancelled context, got %v", ctx1.Err())
+	} else if !m.deregister(q1) {
+		t.Fatal("expected query to be cancelled by operator")
+	}
+	if m.deregister(q2) {
+		t.Fatal("expected query not to be cancelled by operator")
+	}
+
+	if infos := m.list(); len(infos) != 0 {
+		t.Fatalf("expected no queries, got %+v", infos)
+	}
+	if err := m.cancel(q1.info.ID); errors.Cause(err) != ErrQueryNotFound {
+		t.Fatalf("expected query not found, got %v", err)
+	}
+}
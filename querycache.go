@@ -0,0 +1,183 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/lru"
+	"github.com/pilosa/pilosa/v2/pql"
+)
+
+// generation counts the writes to an index. A query result computed at one
+// generation is stale once the generation has moved on. A nil generation is
+// valid and ignores writes.
+type generation struct {
+	n uint64
+}
+
+// bump records a write.
+func (g *generation) bump() {
+	if g != nil {
+		atomic.AddUint64(&g.n, 1)
+	}
+}
+
+// load returns the current generation.
+func (g *generation) load() uint64 {
+	if g == nil {
+		return 0
+	}
+	return atomic.LoadUint64(&g.n)
+}
+
+// generationAttrStore wraps an AttrStore, bumping a generation whenever
+// attributes are written.
+type generationAttrStore struct {
+	AttrStore
+	generation *generation
+}
+
+// SetAttrs sets attribute values for a given ID.
+func (s *generationAttrStore) SetAttrs(id uint64, m map[string]interface{}) error {
+	defer s.generation.bump()
+	return s.AttrStore.SetAttrs(id, m)
+}
+
+// SetBulkAttrs sets attribute values for a set of IDs.
+func (s *generationAttrStore) SetBulkAttrs(m map[uint64]map[string]interface{}) error {
+	defer s.generation.bump()
+	return s.AttrStore.SetBulkAttrs(m)
+}
+
+// queryCache holds the responses of recent read-only queries. An entry is
+// only served while the index it was computed against has not been written
+// to, replaced, or, if a TTL is set, until the entry expires.
+type queryCache struct {
+	mu    sync.Mutex
+	cache *lru.Cache
+	ttl   time.Duration
+}
+
+// queryCacheEntry is a cached query response.
+type queryCacheEntry struct {
+	index      *Index
+	generation uint64
+	expires    time.Time
+	resp       QueryResponse
+}
+
+// newQueryCache returns a cache holding at most size responses, each for at
+// most ttl. A ttl of zero never expires entries.
+func newQueryCache(size int, ttl time.Duration) *queryCache {
+	return &queryCache{
+		cache: lru.New(size),
+		ttl:   ttl,
+	}
+}
+
+// get returns the cached response for key if it is still valid for idx.
+// Responses returned from the cache are shared and must not be modified.
+func (c *queryCache) get(key string, idx *Index, now time.Time) (QueryResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, ok := c.cache.Get(key)
+	if !ok {
+		return QueryResponse{}, false
+	}
+	e := v.(*queryCacheEntry)
+	if e.index != idx || e.generation != idx.generation.load() || (c.ttl > 0 && now.After(e.expires)) {
+		c.cache.Remove(key)
+		return QueryResponse{}, false
+	}
+	return e.resp, true
+}
+
+// add caches resp for key. gen must be the generation of idx read before the
+// query was executed so that writes made during execution invalidate it.
+func (c *queryCache) add(key string, idx *Index, gen uint64, resp QueryResponse, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.Add(key, &queryCacheEntry{
+		index:      idx,
+		generation: gen,
+		expires:    now.Add(c.ttl),
+		resp:       resp,
+	})
+}
+
+// queryCacheKey returns the key of a query against index. The query is
+// normalized by its string representation so that formatting does not
+// matter.
+func queryCacheKey(index string, q *pql.Query, req *QueryRequest) string {
+	return fmt.Sprintf("%s\x00%s\x00%v\x00%t%t%t%t\x00%d", index, q.String(), req.Shards,
+		req.Remote, req.ColumnAttrs, req.ExcludeRowAttrs, req.ExcludeColumns, req.MaxResultRows)
+}
+
+// isCacheableQuery returns true if the result of q depends only on the data
+// in the index. Mutating calls must be executed every time, and the results
+// of TopN depend on rank caches which are recalculated independently of
+// writes.
+func isCacheableQuery(q *pql.Query) bool {
+	for _, c := range q.Calls {
+		if !isCacheableCall(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func isCacheableCall(c *pql.Call) bool {
+	switch c.Name {
+	case "Set", "Clear", "ClearRow", "Store", "SetRowAttrs", "SetColumnAttrs", "TopN":
+		return false
+	}
+	for _, child := range c.Children {
+		if !isCacheableCall(child) {
+			return false
+		}
+	}
+	for _, arg := range c.Args {
+		if child, ok := arg.(*pql.Call); ok && !isCacheableCall(child) {
+			return false
+		}
+	}
+	return true
+}
+
+// cacheableQuery returns the key under which the response of req may be
+// cached, or false if it may not be. Responses are only cached when every
+// shard the query reads is owned by this node, so that every write which
+// could change the result is applied to the local index.
+func (api *API) cacheableQuery(idx *Index, q *pql.Query, req *QueryRequest) (string, bool) {
+	if api.server.queryCache == nil || !isCacheableQuery(q) {
+		return "", false
+	}
+
+	shards := req.Shards
+	if len(shards) == 0 {
+		shards = idx.AvailableShards().Slice()
+	}
+	for _, shard := range shards {
+		if !api.cluster.ownsShard(api.server.nodeID, idx.Name(), shard) {
+			return "", false
+		}
+	}
+	return queryCacheKey(idx.Name(), q, req), true
+}
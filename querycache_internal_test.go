@@ -0,0 +1,105 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/pql"
+)
+
+func TestQueryCache(t *testing.T) {
+	idx, err := NewIndex("/tmp/i", "i")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	resp := QueryResponse{Results: []interface{}{uint64(3)}}
+
+	t.Run("Generation", func(t *testing.T) {
+		c := newQueryCache(10, 0)
+		c.add("k", idx, idx.generation.load(), resp, now)
+		if got, ok := c.get("k", idx, now.Add(time.Hour)); !ok || got.Results[0] != uint64(3) {
+			t.Fatalf("expected hit, got %v %v", got, ok)
+		}
+
+		idx.generation.bump()
+		if _, ok := c.get("k", idx, now); ok {
+			t.Fatal("expected miss after write")
+		}
+	})
+
+	t.Run("IndexReplaced", func(t *testing.T) {
+		other, err := NewIndex("/tmp/i", "i")
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := newQueryCache(10, 0)
+		c.add("k", idx, idx.generation.load(), resp, now)
+		if _, ok := c.get("k", other, now); ok {
+			t.Fatal("expected miss for recreated index")
+		}
+	})
+
+	t.Run("TTL", func(t *testing.T) {
+		c := newQueryCache(10, time.Minute)
+		c.add("k", idx, idx.generation.load(), resp, now)
+		if _, ok := c.get("k", idx, now.Add(30*time.Second)); !ok {
+			t.Fatal("expected hit before expiry")
+		} else if _, ok := c.get("k", idx, now.Add(2*time.Minute)); ok {
+			t.Fatal("expected miss after expiry")
+		}
+	})
+
+	t.Run("Size", func(t *testing.T) {
+		c := newQueryCache(1, 0)
+		c.add("a", idx, idx.generation.load(), resp, now)
+		c.add("b", idx, idx.generation.load(), resp, now)
+		if _, ok := c.get("a", idx, now); ok {
+			t.Fatal("expected oldest entry to be evicted")
+		} else if _, ok := c.get("b", idx, now); !ok {
+			t.Fatal("expected hit")
+		}
+	})
+}
+
+func TestIsCacheableQuery(t *testing.T) {
+	for _, tt := range []struct {
+		query     string
+		cacheable bool
+	}{
+		{query: "Count(Row(f=1))", cacheable: true},
+		{query: "Row(f=1)\nIntersect(Row(f=1), Row(g=2))", cacheable: true},
+		{query: "GroupBy(Rows(f), filter=Row(g=1))", cacheable: true},
+		{query: "Set(1, f=1)", cacheable: false},
+		{query: "Count(Row(f=1))\nClear(1, f=1)", cacheable: false},
+		{query: "Store(Row(f=1), g=2)", cacheable: false},
+		{query: "ClearRow(f=1)", cacheable: false},
+		{query: `SetRowAttrs(f, 1, x="y")`, cacheable: false},
+		{query: `SetColumnAttrs(1, x="y")`, cacheable: false},
+		{query: "TopN(f, n=2)", cacheable: false},
+		{query: "Options(TopN(f, n=2), shards=[0])", cacheable: false},
+	} {
+		q, err := pql.NewParser(strings.NewReader(tt.query)).Parse()
+		if err != nil {
+			t.Fatalf("parsing %q: %v", tt.query, err)
+		}
+		if got := isCacheableQuery(q); got != tt.cacheable {
+			t.Errorf("%q: expected cacheable=%v, got %v", tt.query, tt.cacheable, got)
+		}
+	}
+}
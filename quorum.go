@@ -0,0 +1,169 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNoQuorum is returned for writes to a node which has not yet heard from a
+// quorum of the nodes of its cluster since it started.
+var ErrNoQuorum = errors.New("node has not reached a quorum of the cluster's nodes")
+
+// quorumLogInterval is how often the progress of the wait for a quorum is
+// logged.
+var quorumLogInterval = 10 * time.Second
+
+// startQuorum records the nodes a node hears from over gossip after it
+// starts, so that it can wait for a majority of the nodes of its topology
+// before completing Open, and refuse writes until then. A node restarted
+// apart from the rest of its cluster would otherwise serve on its own.
+type startQuorum struct {
+	timeout time.Duration // zero waits indefinitely
+
+	mu      sync.Mutex
+	nodes   map[string]struct{}
+	changed chan struct{} // closed when a new node is heard from
+	reached chan struct{} // closed once the quorum is reached
+}
+
+func newStartQuorum(timeout time.Duration) *startQuorum {
+	return &startQuorum{
+		timeout: timeout,
+		nodes:   make(map[string]struct{}),
+		changed: make(chan struct{}),
+		reached: make(chan struct{}),
+	}
+}
+
+// heard records that the node with the given ID is reachable.
+func (q *startQuorum) heard(nodeID string) {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, ok := q.nodes[nodeID]; ok {
+		return
+	}
+	q.nodes[nodeID] = struct{}{}
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+// has returns true if the node with the given ID was heard from.
+func (q *startQuorum) has(nodeID string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	_, ok := q.nodes[nodeID]
+	return ok
+}
+
+// next returns a channel which is closed when a new node is heard from.
+func (q *startQuorum) next() <-chan struct{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.changed
+}
+
+// validate returns ErrNoQuorum until the quorum is reached.
+func (q *startQuorum) validate() error {
+	if q == nil {
+		return nil
+	}
+	select {
+	case <-q.reached:
+		return nil
+	default:
+		return ErrNoQuorum
+	}
+}
+
+// quorumStatus returns the number of nodes of the topology which the node has
+// heard from since it started, counting itself, and the number which makes a
+// quorum. The quorum is zero if the topology holds no other node.
+func (c *cluster) quorumStatus() (heard, quorum int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.Topology.mu.RLock()
+	defer c.Topology.mu.RUnlock()
+
+	ids := c.Topology.nodeIDs
+	if len(ids) < 2 || c.quorum == nil {
+		return 0, 0
+	}
+	for _, id := range ids {
+		if id == c.Node.ID || c.quorum.has(id) {
+			heard++
+		}
+	}
+	return heard, len(ids)/2 + 1
+}
+
+// waitForQuorum waits up to the quorum timeout for the node to hear from a
+// majority of the nodes of its topology, logging its progress. If the quorum
+// is not reached in time, the node opens regardless but keeps refusing writes
+// until it is. A node with clustering disabled, or whose topology holds no
+// other node, does not wait.
+func (s *Server) waitForQuorum() {
+	if _, quorum := s.cluster.quorumStatus(); s.clusterDisabled || quorum == 0 {
+		close(s.quorum.reached)
+		return
+	}
+
+	var timeout <-chan time.Time
+	if s.quorum.timeout > 0 {
+		timer := time.NewTimer(s.quorum.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	if s.awaitQuorum(timeout) {
+		return
+	}
+
+	s.logger.Printf("no quorum of the cluster's nodes after %s, refusing writes until it is reached", s.quorum.timeout)
+	s.wg.Add(1)
+	go func() { defer s.wg.Done(); s.awaitQuorum(nil) }()
+}
+
+// awaitQuorum waits for the quorum until timeout fires or the server closes.
+// It returns true, once the quorum is marked reached, if it was reached.
+func (s *Server) awaitQuorum(timeout <-chan time.Time) bool {
+	ticker := time.NewTicker(quorumLogInterval)
+	defer ticker.Stop()
+
+	for {
+		next := s.quorum.next()
+		heard, quorum := s.cluster.quorumStatus()
+		if heard >= quorum {
+			s.logger.Printf("reached a quorum of %d nodes", heard)
+			close(s.quorum.reached)
+			return true
+		}
+
+		select {
+		case <-next:
+		case <-ticker.C:
+			s.logger.Printf("waiting for a quorum: heard from %d nodes, %d needed", heard, quorum)
+		case <-timeout:
+			return false
+		case <-s.closing:
+			return false
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/logger"
+)
+
+func TestServer_WaitForQuorum(t *testing.T) {
+	newQuorumServer := func(timeout time.Duration, nodeIDs ...string) *Server {
+		s := &Server{closing: make(chan struct{}), cluster: newCluster(), logger: logger.NopLogger}
+		if err := OptServerRequireQuorumOnStart(true, timeout)(s); err != nil {
+			t.Fatal(err)
+		}
+		s.cluster.Node = &Node{ID: "node0"}
+		s.cluster.Topology = &Topology{nodeIDs: nodeIDs}
+		return s
+	}
+
+	t.Run("SingleNode", func(t *testing.T) {
+		s := newQuorumServer(time.Hour, "node0")
+		s.waitForQuorum()
+		if err := s.quorum.validate(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Reached", func(t *testing.T) {
+		s := newQuorumServer(time.Hour, "node0", "node1", "node2")
+		if err := s.quorum.validate(); err != ErrNoQuorum {
+			t.Fatalf("expected no quorum, got %v", err)
+		}
+
+		done := make(chan struct{})
+		go func() { defer close(done); s.waitForQuorum() }()
+		// A node which is not in the topology does not count.
+		if err := s.cluster.ReceiveEvent(&NodeEvent{Event: NodeJoin, Node: &Node{ID: "node3"}}); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-done:
+			t.Fatal("expected to wait for a quorum")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		if err := s.cluster.ReceiveEvent(&NodeEvent{Event: NodeJoin, Node: &Node{ID: "node2"}}); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("expected quorum to be reached")
+		}
+		if heard, quorum := s.cluster.quorumStatus(); heard != 2 || quorum != 2 {
+			t.Fatalf("unexpected quorum status: %d/%d", heard, quorum)
+		} else if err := s.quorum.validate(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		s := newQuorumServer(10*time.Millisecond, "node0", "node1", "node2")
+		s.waitForQuorum()
+		if err := s.quorum.validate(); err != ErrNoQuorum {
+			t.Fatalf("expected no quorum, got %v", err)
+		}
+
+		// Writes are refused until the quorum is reached after opening.
+		s.cluster.quorum.heard("node1")
+		select {
+		case <-s.quorum.reached:
+		case <-time.After(time.Second):
+			t.Fatal("expected quorum to be reached")
+		}
+		close(s.closing)
+		s.wg.Wait()
+	})
+}
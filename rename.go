@@ -0,0 +1,312 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// renameDrainTimeout is how long a rename waits for the queries running
+// against the index to complete before canceling them.
+var renameDrainTimeout = 10 * time.Second
+
+// indexQueries tracks the queries running against an index, so that a rename
+// of the index, or of one of its fields, can refuse new queries and wait for
+// the running ones. Queries refused or canceled by a rename fail with
+// ErrIndexRenaming, which tells the client to retry.
+type indexQueries struct {
+	mu       sync.Mutex
+	renaming bool
+	running  map[*indexQuery]struct{}
+	drained  chan struct{} // closed once no query runs during a rename
+}
+
+type indexQuery struct {
+	cancel   context.CancelFunc
+	canceled bool
+}
+
+// begin registers a query. It returns the context in which to run the query,
+// and a function to call once it completes which returns true if the query
+// was canceled by a rename.
+func (q *indexQueries) begin(ctx context.Context) (context.Context, func() bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.renaming {
+		return ctx, nil, ErrIndexRenaming
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	iq := &indexQuery{cancel: cancel}
+	if q.running == nil {
+		q.running = make(map[*indexQuery]struct{})
+	}
+	q.running[iq] = struct{}{}
+
+	return ctx, func() bool {
+		cancel()
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		delete(q.running, iq)
+		if len(q.running) == 0 && q.drained != nil {
+			close(q.drained)
+			q.drained = nil
+		}
+		return iq.canceled
+	}, nil
+}
+
+// pause refuses new queries and waits up to timeout for the running queries
+// to complete, then cancels the remaining ones and waits for them to return.
+// It returns ErrIndexRenaming if a rename is already in progress.
+func (q *indexQueries) pause(timeout time.Duration) error {
+	q.mu.Lock()
+	if q.renaming {
+		q.mu.Unlock()
+		return ErrIndexRenaming
+	}
+	q.renaming = true
+	if len(q.running) == 0 {
+		q.mu.Unlock()
+		return nil
+	}
+	drained := make(chan struct{})
+	q.drained = drained
+	q.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-drained:
+		return nil
+	case <-timer.C:
+	}
+
+	q.mu.Lock()
+	for iq := range q.running {
+		iq.canceled = true
+		iq.cancel()
+	}
+	q.mu.Unlock()
+	<-drained
+	return nil
+}
+
+// resume accepts queries again after pause.
+func (q *indexQueries) resume() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.renaming = false
+}
+
+// RenameIndex renames an index, and moves its directory, to newName. Aliases
+// of the index are repointed at the new name. The queries running against the
+// index are given renameDrainTimeout to complete before they are canceled.
+func (h *Holder) RenameIndex(name, newName string) error {
+	if err := validateName(newName); err != nil {
+		return NewBadRequestError(err)
+	}
+
+	index := h.Index(name)
+	if index == nil {
+		return newNotFoundError(ErrIndexNotFound, name)
+	} else if err := h.checkRename(newName); err != nil {
+		return err
+	}
+
+	// The queries are drained before taking the holder lock, which they
+	// need to look up the index. The renamed index is reopened as a new
+	// Index, so queries which still hold the old one are refused.
+	if err := index.queries.pause(renameDrainTimeout); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.index(name) != index {
+		index.queries.resume()
+		return newNotFoundError(ErrIndexNotFound, name)
+	} else if err := h.checkRenameLocked(newName); err != nil {
+		index.queries.resume()
+		return err
+	}
+
+	// The shards of the index stay on the nodes they were placed on by
+	// its original name.
+	if err := index.pinPlacement(); err != nil {
+		index.queries.resume()
+		return err
+	}
+	if err := index.Close(); err != nil {
+		index.queries.resume()
+		return errors.Wrap(err, "closing")
+	}
+	renamed, err := h.moveIndex(name, newName)
+	if err != nil {
+		// Reopen the index under its old name.
+		if oerr := index.Open(); oerr != nil {
+			h.Logger.Printf("reopening index %s after failed rename: %s", name, oerr)
+		}
+		index.queries.resume()
+		return err
+	}
+	delete(h.indexes, name)
+	h.indexes[newName] = renamed
+
+	var repointed bool
+	for alias, target := range h.aliases {
+		if target == name {
+			h.aliases[alias] = newName
+			repointed = true
+		}
+	}
+	// The rename is committed, and the aliases are saved with the next
+	// change if they cannot be now.
+	if repointed {
+		if err := h.saveAliases(); err != nil {
+			h.Logger.Printf("saving aliases after renaming index %s: %s", name, err)
+		}
+	}
+
+	// Restart replication.
+	go h.refreshTranslateStoreReplicator()
+
+	h.Logger.Printf("renamed index %s to %s", name, newName)
+	return nil
+}
+
+// checkRename returns a ConflictError if newName is taken by an index or an
+// alias.
+func (h *Holder) checkRename(newName string) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.checkRenameLocked(newName)
+}
+
+func (h *Holder) checkRenameLocked(newName string) error {
+	if h.index(newName) != nil {
+		return newConflictError(ErrIndexExists)
+	} else if _, ok := h.aliases[newName]; ok {
+		return newConflictError(ErrIndexAliasExists)
+	}
+	return nil
+}
+
+// moveIndex moves the directory of the closed index name to newName, and
+// opens it. The directory is moved back if it cannot be opened.
+func (h *Holder) moveIndex(name, newName string) (*Index, error) {
+	if _, err := os.Stat(h.IndexPath(newName)); err == nil {
+		return nil, newConflictError(errors.Errorf("index directory %s already exists", h.IndexPath(newName)))
+	}
+	if err := os.Rename(h.IndexPath(name), h.IndexPath(newName)); err != nil {
+		return nil, errors.Wrap(err, "moving index directory")
+	}
+
+	index, err := h.newIndex(h.IndexPath(newName), newName)
+	if err == nil {
+		err = index.Open()
+	}
+	if err != nil {
+		if merr := os.Rename(h.IndexPath(newName), h.IndexPath(name)); merr != nil {
+			h.Logger.Printf("moving back index directory %s: %s", name, merr)
+		}
+		return nil, errors.Wrap(err, "opening renamed index")
+	}
+	return index, nil
+}
+
+// pinPlacement records the name of the index as its placement name, unless
+// it was renamed before.
+func (i *Index) pinPlacement() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.placement != "" {
+		return nil
+	}
+	i.placement = i.name
+	if err := i.saveMeta(); err != nil {
+		i.placement = ""
+		return errors.Wrap(err, "saving meta")
+	}
+	return nil
+}
+
+// RenameField renames a field, and moves its directory, to newName. The
+// queries running against the index are given renameDrainTimeout to complete
+// before they are canceled. The existence field cannot be renamed.
+func (i *Index) RenameField(name, newName string) error {
+	if err := validateName(newName); err != nil {
+		return NewBadRequestError(err)
+	} else if name == existenceFieldName || newName == existenceFieldName {
+		return NewBadRequestError(errors.Errorf("cannot rename the %s field", existenceFieldName))
+	}
+
+	if err := i.queries.pause(renameDrainTimeout); err != nil {
+		return err
+	}
+	defer i.queries.resume()
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	f := i.field(name)
+	if f == nil {
+		return newNotFoundError(ErrFieldNotFound, name)
+	} else if i.field(newName) != nil {
+		return newConflictError(ErrFieldExists)
+	} else if _, err := os.Stat(i.fieldPath(newName)); err == nil {
+		return newConflictError(errors.Errorf("field directory %s already exists", i.fieldPath(newName)))
+	}
+
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "closing")
+	}
+	if err := os.Rename(i.fieldPath(name), i.fieldPath(newName)); err != nil {
+		if oerr := f.Open(); oerr != nil {
+			i.logger.Printf("reopening field %s after failed rename: %s", name, oerr)
+		}
+		return errors.Wrap(err, "moving field directory")
+	}
+
+	renamed, err := i.newField(i.fieldPath(newName), newName)
+	if err == nil {
+		err = renamed.Open()
+	}
+	if err != nil {
+		if merr := os.Rename(i.fieldPath(newName), i.fieldPath(name)); merr != nil {
+			i.logger.Printf("moving back field directory %s/%s: %s", i.name, name, merr)
+		} else if oerr := f.Open(); oerr != nil {
+			i.logger.Printf("reopening field %s after failed rename: %s", name, oerr)
+		}
+		return errors.Wrap(err, "opening renamed field")
+	}
+	delete(i.fields, name)
+	i.fields[newName] = renamed
+	i.generation.bump()
+
+	// Update replication, if needed.
+	if i.holder != nil {
+		go i.holder.refreshTranslateStoreReplicator()
+	}
+
+	i.logger.Printf("renamed field %s/%s to %s", i.name, name, newName)
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/logger"
+	"github.com/pkg/errors"
+)
+
+func TestHolder_RenameIndex(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	h.SetBit("i", "f", 1, 10)
+	h.MustCreateIndexIfNotExists("j", IndexOptions{})
+	if err := h.SetIndexAlias("a", "i"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Renaming to the name of an index or alias is rejected.
+	if err := h.RenameIndex("i", "j"); errors.Cause(err) != (ConflictError{ErrIndexExists}) {
+		t.Fatalf("expected index exists, got %v", err)
+	} else if err := h.RenameIndex("i", "a"); errors.Cause(err) != (ConflictError{ErrIndexAliasExists}) {
+		t.Fatalf("expected alias exists, got %v", err)
+	} else if err := h.RenameIndex("x", "y"); errors.Cause(err) != ErrIndexNotFound {
+		t.Fatalf("expected index not found, got %v", err)
+	}
+
+	assertRenamed := func() {
+		t.Helper()
+		if h.Index("i") != nil {
+			t.Fatal("expected old index to be gone")
+		} else if idx := h.Index("k"); idx == nil {
+			t.Fatal("expected renamed index")
+		} else if cols := h.Row("k", "f", 1).Columns(); !reflect.DeepEqual(cols, []uint64{10}) {
+			t.Fatalf("unexpected columns: %v", cols)
+		} else if aliases := h.IndexAliases(); aliases["a"] != "k" {
+			t.Fatalf("unexpected aliases: %v", aliases)
+		} else if name := h.placementName("k"); name != "i" {
+			t.Fatalf("unexpected placement name: %s", name)
+		}
+	}
+	if err := h.RenameIndex("i", "k"); err != nil {
+		t.Fatal(err)
+	}
+	assertRenamed()
+
+	// The rename is kept across restarts.
+	if err := h.Holder.Close(); err != nil {
+		t.Fatal(err)
+	} else if err := h.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+	assertRenamed()
+}
+
+func TestIndex_RenameField(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	h.SetBit("i", "f", 1, 10)
+	h.MustCreateFieldIfNotExists("i", "g")
+	idx := h.Index("i")
+
+	if err := idx.RenameField("f", "g"); errors.Cause(err) != (ConflictError{ErrFieldExists}) {
+		t.Fatalf("expected field exists, got %v", err)
+	} else if err := idx.RenameField("x", "y"); errors.Cause(err) != ErrFieldNotFound {
+		t.Fatalf("expected field not found, got %v", err)
+	} else if err := idx.RenameField(existenceFieldName, "y"); err == nil {
+		t.Fatal("expected error renaming the existence field")
+	}
+
+	if err := idx.RenameField("f", "h"); err != nil {
+		t.Fatal(err)
+	} else if idx.Field("f") != nil {
+		t.Fatal("expected old field to be gone")
+	} else if cols := h.Row("i", "h", 1).Columns(); !reflect.DeepEqual(cols, []uint64{10}) {
+		t.Fatalf("unexpected columns: %v", cols)
+	}
+}
+
+// Ensure renames wait for the imports running against the index.
+func TestAPI_ImportIndexField_Rename(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	h.SetBit("i", "f", 1, 10)
+	api := &API{holder: h.Holder, server: &Server{logger: logger.NopLogger}}
+
+	_, _, _, end, err := api.importIndexField(context.Background(), "i", "f", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	renamed := make(chan error)
+	go func() { renamed <- h.Index("i").RenameField("f", "g") }()
+	select {
+	case err := <-renamed:
+		t.Fatalf("expected rename to wait for the import, got %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if end() {
+		t.Fatal("expected import not to be canceled")
+	} else if err := <-renamed; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, _, _, err := api.importIndexField(context.Background(), "i", "f", 0); errors.Cause(err) != ErrFieldNotFound {
+		t.Fatalf("expected field not found, got %v", err)
+	}
+}
+
+func TestIndexQueries_Pause(t *testing.T) {
+	var q indexQueries
+
+	// A query which completes before the timeout is not canceled.
+	_, end, err := q.begin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		if end() {
+			t.Error("expected query not to be canceled")
+		}
+	}()
+	if err := q.pause(time.Minute); err != nil {
+		t.Fatal(err)
+	} else if _, _, err := q.begin(context.Background()); err != ErrIndexRenaming {
+		t.Fatalf("expected renaming error, got %v", err)
+	} else if err := q.pause(time.Minute); err != ErrIndexRenaming {
+		t.Fatalf("expected renaming error, got %v", err)
+	}
+	q.resume()
+
+	// A query still running after the timeout is canceled.
+	ctx, end, err := q.begin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	canceled := make(chan bool)
+	go func() {
+		<-ctx.Done()
+		canceled <- end()
+	}()
+	if err := q.pause(10 * time.Millisecond); err != nil {
+		t.Fatal(err)
+	} else if !<-canceled {
+		t.Fatal("expected query to be canceled")
+	}
+}
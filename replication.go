@@ -0,0 +1,392 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/pql"
+	"github.com/pkg/errors"
+)
+
+// promotedFile is the marker file, in the data directory, recording that a
+// standby was promoted.
+const promotedFile = ".promoted"
+
+// Replication states.
+const (
+	ReplicationStateDisabled = "DISABLED"
+	ReplicationStateStandby  = "STANDBY"
+	ReplicationStatePromoted = "PROMOTED"
+)
+
+// ReplicationStatus reports the progress of replication from the upstream
+// cluster of a standby.
+type ReplicationStatus struct {
+	State    string `json:"state"`
+	Upstream string `json:"upstream,omitempty"`
+
+	// LastSync is the time at which the last successful replication round
+	// started; every change made upstream before then has been pulled. Lag is
+	// the time elapsed since, and is empty until the first round succeeds.
+	LastSync time.Time `json:"lastSync"`
+	Lag      string    `json:"lag,omitempty"`
+
+	// Fragments is the number of fragments pulled from upstream.
+	Fragments int `json:"fragments"`
+
+	// Error holds the error which failed the last replication round, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// replicator keeps a warm standby in sync with its upstream cluster. Every
+// interval, each node of the standby pulls the upstream schema and every
+// fragment of the shards it owns whose blocks differ from the upstream copy.
+// The standby refuses writes until it is promoted, which stops replication.
+type replicator struct {
+	mu       sync.Mutex
+	upstream *URI
+	interval time.Duration
+
+	promoted bool
+	promote  chan struct{} // closed on promotion
+	wg       sync.WaitGroup
+
+	lastSync  time.Time
+	fragments int
+	err       error
+}
+
+// newReplicator returns a replicator which does not replicate until an
+// upstream is set.
+func newReplicator() *replicator {
+	return &replicator{
+		interval: time.Minute,
+		promote:  make(chan struct{}),
+	}
+}
+
+// standby returns true if the node is replicating from an upstream cluster
+// and must refuse writes.
+func (r *replicator) standby() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.upstream != nil && !r.promoted
+}
+
+// Status returns the progress of replication.
+func (r *replicator) Status() ReplicationStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.upstream == nil {
+		return ReplicationStatus{State: ReplicationStateDisabled}
+	}
+
+	status := ReplicationStatus{
+		State:     ReplicationStateStandby,
+		Upstream:  r.upstream.String(),
+		LastSync:  r.lastSync,
+		Fragments: r.fragments,
+	}
+	if r.promoted {
+		status.State = ReplicationStatePromoted
+	}
+	if !r.lastSync.IsZero() {
+		status.Lag = time.Since(r.lastSync).Round(time.Millisecond).String()
+	}
+	if r.err != nil {
+		status.Error = r.err.Error()
+	}
+	return status
+}
+
+// monitorReplication runs a replication round every interval, once the
+// cluster has settled, until the server closes or the standby is promoted.
+// The caller must have added it to the replicator's wait group.
+func (s *Server) monitorReplication() {
+	r := s.replicator
+	defer r.wg.Done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.closing:
+		case <-r.promote:
+		case <-ctx.Done():
+		}
+		cancel()
+	}()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for s.cluster.State() != ClusterStateNormal {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+
+	for {
+		start := time.Now()
+		n, err := s.replicate(ctx, r.upstream)
+
+		r.mu.Lock()
+		r.fragments += n
+		if ctx.Err() != nil {
+			r.mu.Unlock()
+			return
+		} else if err != nil {
+			r.err = err
+			s.logger.Printf("replication: %s", err)
+		} else {
+			r.err = nil
+			r.lastSync = start
+		}
+		r.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// replicate pulls the schema of the upstream cluster, then every fragment of
+// the shards this node owns which differs from the upstream copy. It returns
+// the number of fragments pulled.
+func (s *Server) replicate(ctx context.Context, upstream *URI) (int, error) {
+	schema, err := s.defaultClient.FullSchemaNode(ctx, upstream)
+	if err != nil {
+		return 0, errors.Wrap(err, "pulling schema")
+	}
+	if err := s.applyUpstreamSchema(schema); err != nil {
+		return 0, errors.Wrap(err, "applying schema")
+	}
+
+	maxShards, err := s.defaultClient.MaxShardByIndexNode(ctx, upstream)
+	if err != nil {
+		return 0, errors.Wrap(err, "pulling max shards")
+	}
+
+	var n int
+	for _, ii := range schema.Indexes {
+		maxShard, ok := maxShards[ii.Name]
+		if !ok {
+			continue
+		}
+		for shard := uint64(0); shard <= maxShard; shard++ {
+			if !s.cluster.ownsShard(s.nodeID, ii.Name, shard) {
+				continue
+			}
+			nodes, err := s.defaultClient.FragmentNodesNode(ctx, upstream, ii.Name, shard)
+			if err != nil {
+				return n, errors.Wrapf(err, "getting upstream nodes of shard %d of index %s", shard, ii.Name)
+			}
+			for _, fi := range ii.Fields {
+				for _, vi := range fi.Views {
+					ok, err := s.replicateFragment(ctx, nodes, ii.Name, fi.Name, vi.Name, shard)
+					if err != nil {
+						return n, errors.Wrapf(err, "replicating index %s field %s view %s shard %d", ii.Name, fi.Name, vi.Name, shard)
+					} else if ok {
+						n++
+					}
+				}
+			}
+		}
+	}
+	return n, nil
+}
+
+// applyUpstreamSchema makes the local schema match schema, deleting the
+// indexes, fields, and aliases which were deleted upstream. Changes are not
+// broadcast, since every node of the standby pulls the schema itself.
+func (s *Server) applyUpstreamSchema(schema *Schema) error {
+	created := false
+	for _, ii := range schema.Indexes {
+		idx := s.holder.Index(ii.Name)
+		if idx == nil {
+			created = true
+			continue
+		}
+		for _, fi := range ii.Fields {
+			if idx.Field(fi.Name) == nil {
+				created = true
+			}
+		}
+	}
+
+	if err := s.holder.applyFullSchema(schema); err != nil {
+		return err
+	}
+
+	// The translate stores of new indexes and fields are only replicated
+	// once replication restarts.
+	if created {
+		s.cluster.mu.RLock()
+		upstreamNode := s.cluster.upstream
+		s.cluster.mu.RUnlock()
+		if upstreamNode != nil {
+			return errors.Wrap(s.cluster.setUpstream(upstreamNode), "restarting translate store replication")
+		}
+	}
+	return nil
+}
+
+// replicateFragment pulls a fragment from the first upstream node which holds
+// it, unless the local fragment has the same blocks. It returns true if the
+// fragment was pulled.
+func (s *Server) replicateFragment(ctx context.Context, nodes []*Node, index, field, view string, shard uint64) (bool, error) {
+	f := s.holder.Field(index, field)
+	if f == nil {
+		return false, newNotFoundError(ErrFieldNotFound, field)
+	}
+
+	for _, node := range nodes {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		blocks, _, err := s.defaultClient.FragmentBlocks(ctx, &node.URI, index, field, view, shard, ChecksumStandard)
+		if err == ErrFragmentNotFound {
+			continue
+		} else if err != nil {
+			return false, errors.Wrapf(err, "getting blocks from %s", node.ID)
+		}
+
+		v, err := f.createViewIfNotExists(view)
+		if err != nil {
+			return false, errors.Wrap(err, "creating view")
+		}
+		if frag := v.Fragment(shard); frag != nil && blocksEqual(frag.Blocks(), blocks) {
+			return false, nil
+		}
+
+		rd, err := s.defaultClient.RetrieveShardFromURI(ctx, index, field, view, shard, node.URI)
+		if err == ErrFragmentNotFound {
+			continue
+		} else if err != nil {
+			return false, errors.Wrapf(err, "retrieving from %s", node.ID)
+		}
+		defer rd.Close()
+
+		frag, err := v.CreateFragmentIfNotExists(shard)
+		if err != nil {
+			return false, errors.Wrap(err, "creating fragment")
+		}
+		if _, err := frag.ReadFrom(rd); err != nil {
+			return false, errors.Wrap(err, "copying fragment")
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// blocksEqual returns true if a and b hold the same blocks with the same
+// checksums.
+func blocksEqual(a, b []FragmentBlock) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ID != b[i].ID || !bytes.Equal(a[i].Checksum, b[i].Checksum) {
+			return false
+		}
+	}
+	return true
+}
+
+// promoteStandby stops replication and makes the node writable. It returns a
+// ConflictError if the node is not a standby.
+func (s *Server) promoteStandby() (ReplicationStatus, error) {
+	r := s.replicator
+	r.mu.Lock()
+	if r.upstream == nil {
+		r.mu.Unlock()
+		return ReplicationStatus{}, newConflictError(errors.New("node is not a standby"))
+	} else if r.promoted {
+		r.mu.Unlock()
+		return ReplicationStatus{}, newConflictError(errors.New("standby already promoted"))
+	}
+	r.promoted = true
+	close(r.promote)
+	r.mu.Unlock()
+
+	// Wait for a running round to stop so that nothing is pulled once the
+	// node accepts writes.
+	r.wg.Wait()
+	if err := s.cluster.setUpstream(nil); err != nil {
+		return ReplicationStatus{}, errors.Wrap(err, "stopping translate store replication")
+	}
+
+	// Record the promotion so that the node stays writable once restarted,
+	// even though it is still configured with an upstream.
+	if err := ioutil.WriteFile(filepath.Join(s.holder.Path, promotedFile), nil, s.holder.filePerm); err != nil {
+		return ReplicationStatus{}, errors.Wrap(err, "recording promotion")
+	}
+	s.logger.Printf("replication: promoted standby of %s", r.upstream)
+	return r.Status(), nil
+}
+
+// loadPromotion marks a standby promoted if it was promoted before the node
+// restarted. It is called before the server is opened.
+func (s *Server) loadPromotion() error {
+	r := s.replicator
+	if r.upstream == nil {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(s.holder.Path, promotedFile)); os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "checking promotion")
+	}
+	r.promoted = true
+	close(r.promote)
+	s.cluster.upstream = nil
+	return nil
+}
+
+// isWriteQuery returns true if q has a call which writes data, including
+// calls nested in others, such as Options(Set(...)).
+func isWriteQuery(q *pql.Query) bool {
+	for _, c := range q.Calls {
+		if isWriteCall(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWriteCall returns true if c or any of its children writes data.
+func isWriteCall(c *pql.Call) bool {
+	switch c.Name {
+	case "Set", "Clear", "ClearRow", "Store", "SetRowAttrs", "SetColumnAttrs":
+		return true
+	}
+	for _, child := range c.Children {
+		if isWriteCall(child) {
+			return true
+		}
+	}
+	return false
+}
@@ -2920,6 +2920,29 @@ func (c *Container) WriteTo(w io.Writer) (n int64, err error) {
 	}
 }
 
+// WriteCanonicalTo writes the values of c to w in a form which depends only on
+// the values, not on the type of the container: as an array if c holds at
+// most ArrayMaxSize values, and as a bitmap otherwise.
+func (c *Container) WriteCanonicalTo(w io.Writer) (n int64, err error) {
+	if c.N() == 0 {
+		return 0, nil
+	}
+	if c.N() <= ArrayMaxSize {
+		if c.isRun() {
+			c = c.Clone().runToArray()
+		} else if c.isBitmap() {
+			c = c.Clone().bitmapToArray()
+		}
+		return c.arrayWriteTo(w)
+	}
+	if c.isRun() {
+		c = c.Clone().runToBitmap()
+	} else if c.isArray() {
+		c = c.Clone().arrayToBitmap()
+	}
+	return c.bitmapWriteTo(w)
+}
+
 func (c *Container) arrayWriteTo(w io.Writer) (n int64, err error) {
 	statsHit("Container/arrayWriteTo")
 	array := c.array()
@@ -3118,6 +3141,11 @@ func flipRun(b *Container) *Container {
 	return flipBitmap(x)
 }
 
+// IntersectionAny returns true if a and b have any value in common.
+func IntersectionAny(a, b *Container) bool {
+	return intersectionCount(a, b) > 0
+}
+
 func intersectionCount(a, b *Container) int32 {
 	if a.N() == maxContainerVal+1 {
 		return b.N()
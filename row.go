@@ -326,6 +326,39 @@ func (r *Row) Columns() []uint64 {
 	return a
 }
 
+// truncate removes all but the lowest n columns of the row. It returns true
+// if any column was removed.
+func (r *Row) truncate(n uint64) bool {
+	for i := range r.segments {
+		s := &r.segments[i]
+		if s.n <= n {
+			n -= s.n
+			continue
+		}
+
+		// Keep the lowest columns of this segment, and drop the segments
+		// which follow it.
+		data := roaring.NewSliceBitmap()
+		itr := s.data.Iterator()
+		for v, eof := itr.Next(); !eof && n > 0; v, eof = itr.Next() {
+			data.DirectAdd(v)
+			n--
+		}
+		*s = rowSegment{
+			data:     data,
+			shard:    s.shard,
+			n:        data.Count(),
+			writable: true,
+		}
+		if s.n == 0 {
+			i--
+		}
+		r.segments = r.segments[:i+1]
+		return true
+	}
+	return false
+}
+
 // rowSegment holds a subset of a row.
 // This could point to a mmapped roaring bitmap or an in-memory bitmap. The
 // width of the segment will always match the shard width.
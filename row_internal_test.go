@@ -0,0 +1,61 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRow_Truncate(t *testing.T) {
+	columns := []uint64{1, 2, ShardWidth, ShardWidth + 1, 3 * ShardWidth}
+	for _, tt := range []struct {
+		n   uint64
+		exp []uint64
+	}{
+		{n: 0, exp: []uint64{}},
+		{n: 1, exp: columns[:1]},
+		{n: 2, exp: columns[:2]},
+		{n: 3, exp: columns[:3]},
+		{n: 5, exp: columns},
+		{n: 10, exp: columns},
+	} {
+		r := NewRow(columns...)
+		truncated := r.truncate(tt.n)
+		if got := r.Columns(); !reflect.DeepEqual(got, tt.exp) {
+			t.Fatalf("truncate(%d): unexpected columns: %v", tt.n, got)
+		} else if truncated != (len(tt.exp) < len(columns)) {
+			t.Fatalf("truncate(%d): unexpected truncated: %t", tt.n, truncated)
+		} else if r.Count() != uint64(len(tt.exp)) {
+			t.Fatalf("truncate(%d): unexpected count: %d", tt.n, r.Count())
+		}
+
+		// A truncated row can still be written to.
+		r.SetBit(2 * ShardWidth)
+	}
+}
+
+func TestTruncateResults(t *testing.T) {
+	results := []interface{}{NewRow(1, 2), RowIDs{1, 2, 3}, uint64(10)}
+	if truncateResults(results, 0) {
+		t.Fatal("expected no truncation without a limit")
+	} else if truncateResults(results, 3) {
+		t.Fatal("expected no truncation under the limit")
+	} else if !truncateResults(results, 2) {
+		t.Fatal("expected truncation")
+	} else if !reflect.DeepEqual(results, []interface{}{NewRow(1, 2), RowIDs{1, 2}, uint64(10)}) {
+		t.Fatalf("unexpected results: %v", results)
+	}
+}
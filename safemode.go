@@ -0,0 +1,170 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/pilosa/pilosa/v2/pql"
+)
+
+// safeMode holds the limits above which a query scanning an entire field
+// without a bound is rejected. A zero limit is not checked.
+type safeMode struct {
+	maxShards int
+	maxRows   uint64
+}
+
+// UnboundedQueryError is returned when safe mode rejects a query containing
+// a call which scans every row of a field without a bound, across more
+// shards or rows than safe mode allows.
+type UnboundedQueryError struct {
+	// Call is the rejected call.
+	Call string
+
+	// Shards and Rows are the estimated number of shards and rows the call
+	// would scan.
+	Shards int
+	Rows   uint64
+
+	// Bound describes the bound missing from the call.
+	Bound string
+}
+
+func (e UnboundedQueryError) Error() string {
+	return fmt.Sprintf("query rejected by safe mode: %s scans an estimated %d rows across %d shards without a bound; %s", e.Call, e.Rows, e.Shards, e.Bound)
+}
+
+// check returns an UnboundedQueryError if a call of q scans an entire field
+// and the scan exceeds the limits. shards are the shards the query is
+// restricted to, if any.
+func (m *safeMode) check(idx *Index, q *pql.Query, shards []uint64) error {
+	shardN := len(shards)
+	if shardN == 0 {
+		shardN = int(idx.AvailableShards().Count())
+	}
+	for _, c := range q.Calls {
+		if err := m.checkCall(idx, c, shardN); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCall checks c and the calls it contains.
+func (m *safeMode) checkCall(idx *Index, c *pql.Call, shardN int) error {
+	if fields, bound := unboundedScan(c); len(fields) > 0 {
+		rows := uint64(1)
+		for _, name := range fields {
+			rows = saturatingMul(rows, idx.estimatedRows(name))
+		}
+		if (m.maxShards > 0 && shardN > m.maxShards) || (m.maxRows > 0 && rows > m.maxRows) {
+			return UnboundedQueryError{Call: c.String(), Shards: shardN, Rows: rows, Bound: bound}
+		}
+	}
+
+	for _, child := range c.Children {
+		// The Rows calls of a GroupBy are bounded by the GroupBy.
+		if c.Name == "GroupBy" && child.Name == "Rows" {
+			continue
+		}
+		if err := m.checkCall(idx, child, shardN); err != nil {
+			return err
+		}
+	}
+	for _, arg := range c.Args {
+		if child, ok := arg.(*pql.Call); ok {
+			if err := m.checkCall(idx, child, shardN); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// unboundedScan returns the fields whose rows c scans entirely, and how to
+// bound the call. It returns no fields for calls which are bounded.
+func unboundedScan(c *pql.Call) (fields []string, bound string) {
+	switch c.Name {
+	case "TopN":
+		if len(c.Children) > 0 || c.Args["ids"] != nil {
+			return nil, ""
+		}
+		field, _ := c.Args["_field"].(string)
+		return []string{field}, "add a filter, e.g. TopN(field, Row(...)), or the ids argument"
+	case "Rows":
+		if c.Args["limit"] != nil || c.Args["column"] != nil {
+			return nil, ""
+		}
+		return []string{rowsField(c)}, "add the limit or column argument"
+	case "GroupBy":
+		if c.Args["filter"] != nil {
+			return nil, ""
+		}
+		for _, child := range c.Children {
+			if child.Name == "Rows" && child.Args["limit"] == nil && child.Args["column"] == nil {
+				fields = append(fields, rowsField(child))
+			}
+		}
+		if len(fields) == 0 {
+			return nil, ""
+		}
+		return fields, "add the filter argument or limit the Rows() calls"
+	}
+	return nil, ""
+}
+
+// rowsField returns the field of a Rows call.
+func rowsField(c *pql.Call) string {
+	if field, ok := c.Args["field"].(string); ok {
+		return field
+	}
+	field, _ := c.Args["_field"].(string)
+	return field
+}
+
+// estimatedRows returns an upper bound of the number of rows of the field,
+// from the highest row ID of its local fragments. It is zero for unknown
+// fields. Other nodes are not asked, so on a multi-node cluster it is too low
+// if the highest rows are only set in shards held by other nodes; the shard
+// limit, which uses the available shards of the whole cluster, is exact.
+func (i *Index) estimatedRows(name string) uint64 {
+	f := i.Field(name)
+	if f == nil {
+		return 0
+	}
+	v := f.view(viewStandard)
+	if v == nil {
+		return 0
+	}
+	var n uint64
+	for _, frag := range v.allFragments() {
+		frag.mu.RLock()
+		if frag.maxRowID+1 > n {
+			n = frag.maxRowID + 1
+		}
+		frag.mu.RUnlock()
+	}
+	return n
+}
+
+// saturatingMul returns a*b, or the maximum uint64 if it overflows.
+func saturatingMul(a, b uint64) uint64 {
+	if a != 0 && b > math.MaxUint64/a {
+		return math.MaxUint64
+	}
+	return a * b
+}
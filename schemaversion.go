@@ -0,0 +1,168 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pilosa/pilosa/v2/internal"
+	"github.com/pkg/errors"
+)
+
+// schemaVersionsFile is the name of the file, inside the holder's data
+// directory, in which the schema version vector is stored.
+const schemaVersionsFile = ".schema-versions"
+
+// The schema version vector holds, for each node, the number of schema
+// changes made through that node which are reflected in the local schema.
+// Nodes gossip their vectors so that a node which missed the broadcast of a
+// change, e.g. because it was briefly down, sees a newer version and pulls
+// the schema from a node which has it.
+
+// SchemaVersions returns a copy of the schema version vector.
+func (h *Holder) SchemaVersions() map[string]uint64 {
+	h.schemaVersionsMu.Lock()
+	defer h.schemaVersionsMu.Unlock()
+	m := make(map[string]uint64, len(h.schemaVersions))
+	for id, v := range h.schemaVersions {
+		m[id] = v
+	}
+	return m
+}
+
+// bumpSchemaVersion records a schema change made through the given node and
+// returns its version.
+func (h *Holder) bumpSchemaVersion(nodeID string) (uint64, error) {
+	h.schemaVersionsMu.Lock()
+	defer h.schemaVersionsMu.Unlock()
+	h.schemaVersions[nodeID]++
+	return h.schemaVersions[nodeID], h.saveSchemaVersions()
+}
+
+// advanceSchemaVersion records that the schema change with version v made
+// through the given node has been applied. The version is only raised if
+// every earlier change made through the node was applied too; otherwise the
+// missed changes are pulled once gossip shows the node's newer version.
+func (h *Holder) advanceSchemaVersion(nodeID string, v uint64) error {
+	h.schemaVersionsMu.Lock()
+	defer h.schemaVersionsMu.Unlock()
+	if h.schemaVersions[nodeID]+1 != v {
+		return nil
+	}
+	h.schemaVersions[nodeID] = v
+	return h.saveSchemaVersions()
+}
+
+// mergeSchemaVersions raises each version in the local vector to the one in
+// vv, once the schema changes vv describes have been applied.
+func (h *Holder) mergeSchemaVersions(vv map[string]uint64) error {
+	h.schemaVersionsMu.Lock()
+	defer h.schemaVersionsMu.Unlock()
+
+	var changed bool
+	for id, v := range vv {
+		if v > h.schemaVersions[id] {
+			h.schemaVersions[id] = v
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return h.saveSchemaVersions()
+}
+
+// loadSchemaVersions reads the schema version vector from the data directory.
+func (h *Holder) loadSchemaVersions() error {
+	var pb internal.SchemaVersions
+
+	buf, err := ioutil.ReadFile(filepath.Join(h.Path, schemaVersionsFile))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "reading")
+	} else if err := proto.Unmarshal(buf, &pb); err != nil {
+		return errors.Wrap(err, "unmarshalling")
+	}
+
+	h.schemaVersionsMu.Lock()
+	defer h.schemaVersionsMu.Unlock()
+	h.schemaVersions = make(map[string]uint64, len(pb.Versions))
+	for id, v := range pb.Versions {
+		h.schemaVersions[id] = v
+	}
+	return nil
+}
+
+// saveSchemaVersions writes the schema version vector to the data directory.
+// The caller must hold h.schemaVersionsMu.
+func (h *Holder) saveSchemaVersions() error {
+	buf, err := proto.Marshal(&internal.SchemaVersions{Versions: h.schemaVersions})
+	if err != nil {
+		return errors.Wrap(err, "marshalling")
+	}
+	if err := ioutil.WriteFile(filepath.Join(h.Path, schemaVersionsFile), buf, h.filePerm); err != nil {
+		return errors.Wrap(err, "writing")
+	}
+	return nil
+}
+
+// sameSchema returns true if a and b have the same indexes, fields, options
+// and aliases. Views are ignored, since they are created as data is written.
+func sameSchema(a, b *Schema) bool {
+	strip := func(s *Schema) ([]byte, error) {
+		indexes := make([]*IndexInfo, len(s.Indexes))
+		for i, ii := range s.Indexes {
+			c := *ii
+			c.Fields = make([]*FieldInfo, len(ii.Fields))
+			for j, fi := range ii.Fields {
+				c.Fields[j] = &FieldInfo{Name: fi.Name, Options: fi.Options}
+			}
+			indexes[i] = &c
+		}
+		sort.Sort(indexInfoSlice(indexes))
+		aliases := s.Aliases
+		if len(aliases) == 0 {
+			aliases = nil
+		}
+		return json.Marshal(&Schema{Indexes: indexes, Aliases: aliases})
+	}
+	abuf, err := strip(a)
+	if err != nil {
+		return false
+	}
+	bbuf, err := strip(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(abuf, bbuf)
+}
+
+// newerSchemaVersions returns true if remote reflects a schema change which
+// local does not.
+func newerSchemaVersions(local, remote map[string]uint64) bool {
+	for id, v := range remote {
+		if v > local[id] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,277 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Self-heal states.
+const (
+	SelfHealStateIdle    = "IDLE"
+	SelfHealStateRunning = "RUNNING"
+	SelfHealStateDone    = "DONE"
+	SelfHealStateFailed  = "FAILED"
+)
+
+// selfHealConcurrency is the number of shards pulled from replicas at once.
+const selfHealConcurrency = 4
+
+// SelfHealStatus reports the progress of the most recent self-heal.
+type SelfHealStatus struct {
+	State    string    `json:"state"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+
+	// MissingShards is the number of shards owned by this node for which it
+	// holds no data.
+	MissingShards int `json:"missingShards"`
+	// HealedShards is the number of missing shards which have been pulled,
+	// and FailedShards the number which could not be.
+	HealedShards int `json:"healedShards"`
+	FailedShards int `json:"failedShards"`
+	// Fragments is the number of fragments pulled from replicas.
+	Fragments int `json:"fragments"`
+
+	// Error holds the last error encountered while pulling a shard.
+	Error string `json:"error,omitempty"`
+}
+
+// selfHealer pulls the shards a node should own, but holds no data for, from
+// their replicas. It is meant for rebuilding a node whose data directory was
+// lost, which is faster than waiting for anti-entropy to repopulate it.
+type selfHealer struct {
+	mu     sync.Mutex
+	status SelfHealStatus
+}
+
+// indexShard identifies a shard of an index.
+type indexShard struct {
+	index string
+	shard uint64
+}
+
+// Status returns the progress of the most recent self-heal.
+func (h *selfHealer) Status() SelfHealStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.status.State == "" {
+		return SelfHealStatus{State: SelfHealStateIdle}
+	}
+	return h.status
+}
+
+// missingShards returns the available shards which this node owns but holds
+// no fragments for, along with the total number of shards it owns.
+func (s *Server) missingShards() (missing []indexShard, owned int) {
+	for _, idx := range s.holder.Indexes() {
+		fields := idx.Fields()
+		for _, shard := range idx.AvailableShards().Slice() {
+			if !s.cluster.ownsShard(s.nodeID, idx.Name(), shard) {
+				continue
+			}
+			owned++
+			if !hasShard(fields, shard) {
+				missing = append(missing, indexShard{index: idx.Name(), shard: shard})
+			}
+		}
+	}
+	return missing, owned
+}
+
+// hasShard returns true if any view of fields holds a fragment for shard.
+func hasShard(fields []*Field, shard uint64) bool {
+	for _, f := range fields {
+		for _, v := range f.views() {
+			if v.Fragment(shard) != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// startSelfHeal pulls the missing shards of this node from their replicas
+// in the background. It returns a ConflictError if a self-heal is already
+// running.
+func (s *Server) startSelfHeal() (SelfHealStatus, error) {
+	h := s.selfHealer
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.status.State == SelfHealStateRunning {
+		return h.status, newConflictError(errors.New("self-heal already running"))
+	}
+
+	missing, _ := s.missingShards()
+	h.status = SelfHealStatus{
+		State:         SelfHealStateRunning,
+		Started:       time.Now(),
+		MissingShards: len(missing),
+	}
+	s.logger.Printf("self-heal: pulling %d missing shards", len(missing))
+
+	s.wg.Add(1)
+	go func() { defer s.wg.Done(); s.selfHeal(missing) }()
+	return h.status, nil
+}
+
+// selfHeal pulls each of the given shards from their replicas, recording
+// progress in the self-healer's status.
+func (s *Server) selfHeal(missing []indexShard) {
+	h := s.selfHealer
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.closing:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ch := make(chan indexShard)
+	var wg sync.WaitGroup
+	for i := 0; i < selfHealConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for is := range ch {
+				n, err := s.pullShard(ctx, is.index, is.shard)
+
+				h.mu.Lock()
+				h.status.Fragments += n
+				if err != nil {
+					h.status.FailedShards++
+					h.status.Error = err.Error()
+					s.logger.Printf("self-heal: shard %d of index %s: %s", is.shard, is.index, err)
+				} else {
+					h.status.HealedShards++
+				}
+				h.mu.Unlock()
+			}
+		}()
+	}
+	for _, is := range missing {
+		select {
+		case ch <- is:
+		case <-ctx.Done():
+		}
+	}
+	close(ch)
+	wg.Wait()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.status.Finished = time.Now()
+	if h.status.FailedShards > 0 || ctx.Err() != nil {
+		h.status.State = SelfHealStateFailed
+	} else {
+		h.status.State = SelfHealStateDone
+	}
+	s.logger.Printf("self-heal: %s, healed %d of %d shards (%d fragments)",
+		h.status.State, h.status.HealedShards, h.status.MissingShards, h.status.Fragments)
+}
+
+// pullShard copies every fragment of shard from the first replica which has
+// it. It returns the number of fragments copied.
+func (s *Server) pullShard(ctx context.Context, index string, shard uint64) (int, error) {
+	idx := s.holder.Index(index)
+	if idx == nil {
+		return 0, newNotFoundError(ErrIndexNotFound, index)
+	}
+	replicas := Nodes(s.cluster.ShardNodes(index, shard)).FilterID(s.nodeID)
+	if len(replicas) == 0 {
+		return 0, errors.New("no replicas")
+	}
+
+	var n int
+	for _, f := range idx.Fields() {
+		for _, v := range f.views() {
+			ok, err := s.pullFragment(ctx, replicas, f, v, shard)
+			if err != nil {
+				return n, errors.Wrapf(err, "pulling field %s view %s", f.Name(), v.name)
+			} else if ok {
+				n++
+			}
+		}
+	}
+	if n == 0 {
+		return 0, errors.New("no replica holds data for the shard")
+	}
+	return n, nil
+}
+
+// pullFragment copies the fragment of v for shard from the first replica
+// which has it. It returns false if none of the replicas has the fragment.
+func (s *Server) pullFragment(ctx context.Context, replicas []*Node, f *Field, v *view, shard uint64) (bool, error) {
+	var lastErr error
+	for _, node := range replicas {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		rd, err := s.defaultClient.RetrieveShardFromURI(ctx, f.index, f.Name(), v.name, shard, node.URI)
+		if err == ErrFragmentNotFound {
+			continue
+		} else if err != nil {
+			lastErr = errors.Wrapf(err, "retrieving from %s", node.ID)
+			continue
+		}
+
+		// Create the local fragment only once the data is available so that
+		// fragments which none of the replicas hold are not created empty.
+		if err := func() error {
+			defer rd.Close()
+			frag, err := v.CreateFragmentIfNotExists(shard)
+			if err != nil {
+				return errors.Wrap(err, "creating fragment")
+			}
+			_, err = frag.ReadFrom(rd)
+			return errors.Wrap(err, "copying fragment")
+		}(); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	return false, lastErr
+}
+
+// monitorSelfHeal waits for the cluster to settle after startup and starts
+// a self-heal if the fraction of owned shards missing on this node is at
+// least the self-heal threshold.
+func (s *Server) monitorSelfHeal() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for s.cluster.State() != ClusterStateNormal {
+		select {
+		case <-s.closing:
+			return
+		case <-ticker.C:
+		}
+	}
+
+	missing, owned := s.missingShards()
+	if owned == 0 || float64(len(missing))/float64(owned) < s.selfHealThreshold {
+		return
+	}
+	s.logger.Printf("self-heal: %d of %d owned shards are missing", len(missing), owned)
+	if _, err := s.startSelfHeal(); err != nil {
+		s.logger.Printf("self-heal: %s", err)
+	}
+}
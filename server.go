@@ -28,8 +28,10 @@ import (
 	"time"
 
 	"github.com/pilosa/pilosa/v2/logger"
+	"github.com/pilosa/pilosa/v2/pql"
 	"github.com/pilosa/pilosa/v2/roaring"
 	"github.com/pilosa/pilosa/v2/stats"
+	"github.com/pilosa/pilosa/v2/tracing"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 )
@@ -65,12 +67,38 @@ type Server struct { // nolint: maligned
 
 	nodeID              string
 	uri                 URI
+	zone                string
 	antiEntropyInterval time.Duration
+	antiEntropyChecksum string
+	antiEntropyReset    chan struct{} // signals a change of antiEntropyInterval
+	antiEntropyMu       sync.Mutex    // protects antiEntropyInterval after Open
+	antiEntropyQueue    *syncQueue
+	antiEntropyRetry    time.Duration // delay between runs of rescheduled syncs
+	bootstrap           *ClusterTopology
 	metricInterval      time.Duration
+	usageInterval       time.Duration
 	diagnosticInterval  time.Duration
 	maxWritesPerRequest int
+	ownerChangeRetries  int
+	ownerChangeBackoff  time.Duration
+	ownerChangeMax      time.Duration
 	isCoordinator       bool
 	syncer              holderSyncer
+	queryCache          *queryCache
+	safeMode            *safeMode
+	partialResults      bool
+	maxResultRows       uint64
+	autoCreateFieldType string
+	queryLimits         pql.Limits
+	warmup              *warmupLog
+	limits              *schemaLimits
+	selfHealer          *selfHealer
+	selfHealThreshold   float64
+	replicator          *replicator
+	clockSkew           *clockSkewDetector
+	breaker             *CircuitBreaker
+	quorum              *startQuorum
+	memory              *memoryGuard
 
 	defaultClient InternalClient
 	dataDir       string
@@ -131,6 +159,40 @@ func OptServerAntiEntropyInterval(interval time.Duration) ServerOption {
 	}
 }
 
+// OptServerAntiEntropyChecksum is a functional option on Server
+// used to set the algorithm of the block checksums compared by anti-entropy.
+func OptServerAntiEntropyChecksum(checksum string) ServerOption {
+	return func(s *Server) error {
+		switch checksum {
+		case ChecksumStandard, ChecksumContainer:
+			s.antiEntropyChecksum = checksum
+			return nil
+		default:
+			return errors.Errorf("invalid anti-entropy checksum: %q", checksum)
+		}
+	}
+}
+
+// OptServerAntiEntropyQueue is a functional option on Server used to bound
+// the number of anti-entropy syncs which are running or waiting to run. A
+// sync requested while the queue is full waits up to timeout for room under
+// the "block" policy, or is rescheduled immediately under the "drop" policy.
+// A size of zero does not bound the queue.
+func OptServerAntiEntropyQueue(size int, policy string, timeout time.Duration) ServerOption {
+	return func(s *Server) error {
+		switch policy {
+		case AntiEntropyQueueBlock, AntiEntropyQueueDrop:
+		default:
+			return errors.Errorf("invalid anti-entropy queue policy: %q", policy)
+		}
+		if size < 0 {
+			return errors.Errorf("invalid anti-entropy queue size: %d", size)
+		}
+		s.antiEntropyQueue = newSyncQueue(size, policy, timeout)
+		return nil
+	}
+}
+
 // OptServerLongQueryTime is a functional option on Server
 // used to set long query duration.
 func OptServerLongQueryTime(dur time.Duration) ServerOption {
@@ -140,6 +202,16 @@ func OptServerLongQueryTime(dur time.Duration) ServerOption {
 	}
 }
 
+// OptServerDeadRoutingDelay is a functional option on Server
+// used to set how long a node which gossip reports dead keeps
+// receiving queries before it is removed from routing.
+func OptServerDeadRoutingDelay(dur time.Duration) ServerOption {
+	return func(s *Server) error {
+		s.cluster.deadRoutingDelay = dur
+		return nil
+	}
+}
+
 // OptServerMaxWritesPerRequest is a functional option on Server
 // used to set the maximum number of writes allowed per request.
 func OptServerMaxWritesPerRequest(n int) ServerOption {
@@ -149,6 +221,37 @@ func OptServerMaxWritesPerRequest(n int) ServerOption {
 	}
 }
 
+// OptServerOwnerChangeRetries is a functional option on Server
+// used to set the number of times internal shard requests rejected
+// because shard ownership changed are retried against the new owners.
+func OptServerOwnerChangeRetries(n int) ServerOption {
+	return func(s *Server) error {
+		s.ownerChangeRetries = n
+		return nil
+	}
+}
+
+// OptServerOwnerChangeBackoff is a functional option on Server
+// used to set the delay before the first retry of an internal shard
+// request rejected because shard ownership changed. The delay doubles
+// on each subsequent retry.
+func OptServerOwnerChangeBackoff(d time.Duration) ServerOption {
+	return func(s *Server) error {
+		s.ownerChangeBackoff = d
+		return nil
+	}
+}
+
+// OptServerOwnerChangeMaxBackoff is a functional option on Server
+// used to set the maximum delay between retries of an internal shard
+// request rejected because shard ownership changed.
+func OptServerOwnerChangeMaxBackoff(d time.Duration) ServerOption {
+	return func(s *Server) error {
+		s.ownerChangeMax = d
+		return nil
+	}
+}
+
 // OptServerMetricInterval is a functional option on Server
 // used to set the interval between metric samples.
 func OptServerMetricInterval(dur time.Duration) ServerOption {
@@ -158,6 +261,16 @@ func OptServerMetricInterval(dur time.Duration) ServerOption {
 	}
 }
 
+// OptServerUsageInterval is a functional option on Server
+// used to set the interval between storage usage samples.
+// An interval of zero disables sampling.
+func OptServerUsageInterval(dur time.Duration) ServerOption {
+	return func(s *Server) error {
+		s.usageInterval = dur
+		return nil
+	}
+}
+
 // OptServerSystemInfo is a functional option on Server
 // used to set the system information source.
 func OptServerSystemInfo(si SystemInfo) ServerOption {
@@ -186,6 +299,15 @@ func OptServerInternalClient(c InternalClient) ServerOption {
 	}
 }
 
+// OptServerCircuitBreaker sets the circuit breaker of the internal client,
+// whose state is reported in the status of the node.
+func OptServerCircuitBreaker(b *CircuitBreaker) ServerOption {
+	return func(s *Server) error {
+		s.breaker = b
+		return nil
+	}
+}
+
 func OptServerExecutorPoolSize(size int) ServerOption {
 	return func(s *Server) error {
 		s.executorPoolSize = size
@@ -228,6 +350,25 @@ func OptServerURI(uri *URI) ServerOption {
 	}
 }
 
+// OptServerZone is a functional option on Server
+// used to set the zone of the node.
+func OptServerZone(zone string) ServerOption {
+	return func(s *Server) error {
+		s.zone = zone
+		return nil
+	}
+}
+
+// OptServerBootstrapTopology is a functional option on Server used to
+// bootstrap a new node from the topology exported from another cluster. The
+// node must be listed in the topology with its URI.
+func OptServerBootstrapTopology(t *ClusterTopology) ServerOption {
+	return func(s *Server) error {
+		s.bootstrap = t
+		return nil
+	}
+}
+
 // OptServerClusterDisabled tells the server whether to use a static cluster with the
 // defined hosts. Mostly used for testing.
 func OptServerClusterDisabled(disabled bool, hosts []string) ServerOption {
@@ -293,21 +434,344 @@ func OptServerOpenTranslateReader(fn OpenTranslateReaderFunc) ServerOption {
 	}
 }
 
+// OptServerSkipCorruptFragments is a functional option on Server
+// used to quarantine fragments which fail to open instead of failing
+// to start.
+func OptServerSkipCorruptFragments(skip bool) ServerOption {
+	return func(s *Server) error {
+		s.holder.skipCorruptFragments = skip
+		return nil
+	}
+}
+
+// OptServerChangeFeedBufferSize is a functional option on Server
+// used to set the number of change events held for the change feed.
+// A size of zero disables the feed.
+func OptServerChangeFeedBufferSize(size int) ServerOption {
+	return func(s *Server) error {
+		s.holder.changes = newChangeFeed(size)
+		return nil
+	}
+}
+
+// OptServerFlushInterval is a functional option on Server
+// used to set the interval at which fragments are flushed to disk.
+func OptServerFlushInterval(interval time.Duration) ServerOption {
+	return func(s *Server) error {
+		if interval <= 0 {
+			return errors.Errorf("flush interval must be positive: %s", interval)
+		}
+		s.holder.flushInterval = interval
+		return nil
+	}
+}
+
+// OptServerFsyncOnFlush is a functional option on Server
+// used to control whether fragment flushes fsync the data files.
+func OptServerFsyncOnFlush(fsync bool) ServerOption {
+	return func(s *Server) error {
+		s.holder.fsyncOnFlush = fsync
+		return nil
+	}
+}
+
+// OptServerDirPerm is a functional option on Server
+// used to set the mode with which data directories are created.
+func OptServerDirPerm(perm os.FileMode) ServerOption {
+	return func(s *Server) error {
+		if perm&^os.ModePerm != 0 || perm&0700 != 0700 {
+			return errors.Errorf("invalid directory mode, it must include 0700: %#o", perm)
+		}
+		s.holder.dirPerm = perm
+		s.cluster.dirPerm = perm
+		return nil
+	}
+}
+
+// OptServerFilePerm is a functional option on Server
+// used to set the mode with which data files are created, including those
+// which are otherwise only readable by their owner.
+func OptServerFilePerm(perm os.FileMode) ServerOption {
+	return func(s *Server) error {
+		if perm&^os.ModePerm != 0 || perm&0600 != 0600 {
+			return errors.Errorf("invalid file mode, it must include 0600: %#o", perm)
+		}
+		s.holder.filePerm = perm
+		s.holder.privateFilePerm = perm
+		s.cluster.filePerm = perm
+		return nil
+	}
+}
+
+// OptServerTombstoneGracePeriod is a functional option on Server
+// used to set how long deleted indexes are kept before they are removed.
+// A zero period removes deleted indexes immediately.
+func OptServerTombstoneGracePeriod(d time.Duration) ServerOption {
+	return func(s *Server) error {
+		if d < 0 {
+			return errors.Errorf("tombstone grace period must not be negative: %s", d)
+		}
+		s.holder.tombstoneGracePeriod = d
+		return nil
+	}
+}
+
+// OptServerCompactionThreshold is a functional option on Server
+// used to set the fraction of its size which compaction must reclaim from
+// a fragment for it to be compacted automatically. Zero disables automatic
+// compaction.
+func OptServerCompactionThreshold(threshold float64) ServerOption {
+	return func(s *Server) error {
+		if threshold < 0 || threshold >= 1 {
+			return errors.Errorf("compaction threshold must be at least 0 and less than 1: %v", threshold)
+		}
+		s.holder.compactionThreshold = threshold
+		return nil
+	}
+}
+
+// OptServerCompactionInterval is a functional option on Server
+// used to set the interval at which fragments are checked for automatic
+// compaction.
+func OptServerCompactionInterval(interval time.Duration) ServerOption {
+	return func(s *Server) error {
+		if interval <= 0 {
+			return errors.Errorf("compaction interval must be positive: %s", interval)
+		}
+		s.holder.compactionInterval = interval
+		return nil
+	}
+}
+
+// OptServerTombstoneReapInterval is a functional option on Server
+// used to set the interval at which expired tombstones are removed.
+func OptServerTombstoneReapInterval(interval time.Duration) ServerOption {
+	return func(s *Server) error {
+		if interval <= 0 {
+			return errors.Errorf("tombstone reap interval must be positive: %s", interval)
+		}
+		s.holder.tombstoneReapInterval = interval
+		return nil
+	}
+}
+
+// OptServerQueryLimits is a functional option on Server
+// used to reject queries whose calls are nested deeper than maxDepth, or
+// which have more than maxOps calls. A zero limit is not checked.
+func OptServerQueryLimits(maxDepth, maxOps int) ServerOption {
+	return func(s *Server) error {
+		if maxDepth < 0 || maxOps < 0 {
+			return errors.Errorf("query limits must not be negative: max depth %d, max ops %d", maxDepth, maxOps)
+		}
+		s.queryLimits = pql.Limits{MaxDepth: maxDepth, MaxOps: maxOps}
+		return nil
+	}
+}
+
+// OptServerQueryPartialResults is a functional option on Server
+// used to return the results of the available shards, instead of an error,
+// when no node can serve some shards of a query.
+func OptServerQueryPartialResults(enabled bool) ServerOption {
+	return func(s *Server) error {
+		s.partialResults = enabled
+		return nil
+	}
+}
+
+// OptServerQueryMaxResultRows is a functional option on Server
+// used to truncate the rows or columns returned for each call of a query
+// to max, and flag the response as truncated. Zero disables the limit.
+func OptServerQueryMaxResultRows(max uint64) ServerOption {
+	return func(s *Server) error {
+		s.maxResultRows = max
+		return nil
+	}
+}
+
+// OptServerWarmup is a functional option on Server
+// used to record a sample of the read queries executed by the node to path,
+// and to replay them on startup, for at most timeout, before the node reports
+// ready. sampleRate is the fraction of queries recorded, and maxQueries the
+// number of most recent queries kept for replay.
+func OptServerWarmup(path string, sampleRate float64, maxQueries int, timeout time.Duration) ServerOption {
+	return func(s *Server) error {
+		if sampleRate <= 0 || sampleRate > 1 {
+			return errors.Errorf("warmup sample rate must be in (0, 1]: %v", sampleRate)
+		} else if maxQueries <= 0 {
+			return errors.Errorf("warmup max queries must be positive: %d", maxQueries)
+		}
+		s.warmup = newWarmupLog(path, sampleRate, maxQueries, timeout)
+		return nil
+	}
+}
+
+// OptServerQuerySafeMode is a functional option on Server
+// used to reject queries which scan an entire field without a bound across
+// more than maxShards shards or an estimated maxRows rows. A zero limit is
+// not checked.
+func OptServerQuerySafeMode(maxShards int, maxRows uint64) ServerOption {
+	return func(s *Server) error {
+		if maxShards < 0 {
+			return errors.Errorf("safe mode max shards must not be negative: %d", maxShards)
+		}
+		s.safeMode = &safeMode{maxShards: maxShards, maxRows: maxRows}
+		return nil
+	}
+}
+
+// OptServerLimits is a functional option on Server
+// used to limit the growth of the schema: indexes and fields created through
+// the API are rejected once there are maxIndexes indexes, maxFieldsPerIndex
+// fields in the index, or maxOpenFiles open data files. A zero limit is not
+// checked.
+func OptServerLimits(maxIndexes, maxFieldsPerIndex int, maxOpenFiles uint64) ServerOption {
+	return func(s *Server) error {
+		if maxIndexes < 0 || maxFieldsPerIndex < 0 {
+			return errors.Errorf("schema limits must not be negative: max indexes %d, max fields per index %d", maxIndexes, maxFieldsPerIndex)
+		}
+		s.limits = &schemaLimits{maxIndexes: maxIndexes, maxFieldsPerIndex: maxFieldsPerIndex, maxOpenFiles: maxOpenFiles}
+		return nil
+	}
+}
+
+// OptServerQueryCache is a functional option on Server
+// used to cache the results of up to size read-only queries for at most ttl.
+// A size of zero disables the cache and a ttl of zero never expires entries.
+func OptServerQueryCache(size int, ttl time.Duration) ServerOption {
+	return func(s *Server) error {
+		if size < 0 {
+			return errors.Errorf("query cache size must not be negative: %d", size)
+		} else if ttl < 0 {
+			return errors.Errorf("query cache ttl must not be negative: %s", ttl)
+		}
+		s.queryCache = nil
+		if size > 0 {
+			s.queryCache = newQueryCache(size, ttl)
+		}
+		return nil
+	}
+}
+
+// OptServerSelfHealThreshold is a functional option on Server
+// used to start a self-heal at startup when at least the given fraction of
+// the shards owned by the node are missing. A threshold of zero disables it.
+func OptServerSelfHealThreshold(threshold float64) ServerOption {
+	return func(s *Server) error {
+		if threshold < 0 || threshold > 1 {
+			return errors.Errorf("self-heal threshold must be between 0 and 1: %v", threshold)
+		}
+		s.selfHealThreshold = threshold
+		return nil
+	}
+}
+
+// OptServerClockSkewThreshold is a functional option on Server
+// used to set the difference between the clocks of the node and of
+// a remote node above which a warning is logged. A threshold of
+// zero disables the check.
+func OptServerClockSkewThreshold(threshold time.Duration) ServerOption {
+	return func(s *Server) error {
+		s.clockSkew.threshold = threshold
+		return nil
+	}
+}
+
+// OptServerRequireQuorumOnStart is a functional option on Server
+// used to make the node wait, up to timeout, to hear from a quorum of
+// the nodes of its cluster before it completes opening, and refuse
+// writes until it has. A timeout of zero waits indefinitely.
+func OptServerRequireQuorumOnStart(require bool, timeout time.Duration) ServerOption {
+	return func(s *Server) error {
+		if require {
+			s.quorum = newStartQuorum(timeout)
+			s.cluster.quorum = s.quorum
+		}
+		return nil
+	}
+}
+
+// OptServerAutoCreate is a functional option on Server used to create
+// the missing indexes and fields written to, instead of failing the write.
+// Fields are created with fieldType, or as set fields if it is empty.
+func OptServerAutoCreate(fieldType string) ServerOption {
+	return func(s *Server) error {
+		if fieldType == "" {
+			fieldType = FieldTypeSet
+		} else if !validAutoCreateFieldType(fieldType) {
+			return errors.Wrapf(ErrInvalidAutoCreateFieldType, "%q", fieldType)
+		}
+		s.autoCreateFieldType = fieldType
+		return nil
+	}
+}
+
+// OptServerMaxMemory is a functional option on Server used to set a
+// soft limit of max bytes on the memory used by the node. Read queries
+// are rejected once the memory use, sampled every interval, passes the
+// high watermark, until it falls below the low one. The watermarks are
+// fractions of max. A max of zero disables the limit.
+func OptServerMaxMemory(max uint64, high, low float64, interval time.Duration) ServerOption {
+	return func(s *Server) error {
+		if max == 0 {
+			s.memory = nil
+			return nil
+		}
+		g, err := newMemoryGuard(max, high, low, interval)
+		if err != nil {
+			return err
+		}
+		s.memory = g
+		return nil
+	}
+}
+
+// OptServerReplicationUpstream is a functional option on Server
+// used to make the node a read-only standby of the cluster at upstream,
+// pulling its schema and data every interval. An empty upstream disables
+// replication.
+func OptServerReplicationUpstream(upstream string, interval time.Duration) ServerOption {
+	return func(s *Server) error {
+		if upstream == "" {
+			return nil
+		} else if interval <= 0 {
+			return errors.Errorf("replication interval must be positive: %s", interval)
+		}
+		uri, err := NewURIFromAddress(upstream)
+		if err != nil {
+			return errors.Wrap(err, "parsing replication upstream")
+		}
+		s.replicator.upstream = uri
+		s.replicator.interval = interval
+		s.cluster.upstream = &Node{ID: "upstream", URI: *uri}
+		return nil
+	}
+}
+
 // NewServer returns a new instance of Server.
 func NewServer(opts ...ServerOption) (*Server, error) {
 	s := &Server{
-		closing:       make(chan struct{}),
-		cluster:       newCluster(),
-		holder:        NewHolder(),
-		diagnostics:   newDiagnosticsCollector(defaultDiagnosticServer),
-		systemInfo:    newNopSystemInfo(),
-		defaultClient: nopInternalClient{},
+		closing:          make(chan struct{}),
+		antiEntropyReset: make(chan struct{}, 1),
+		cluster:          newCluster(),
+		holder:           NewHolder(),
+		diagnostics:      newDiagnosticsCollector(defaultDiagnosticServer),
+		systemInfo:       newNopSystemInfo(),
+		defaultClient:    nopInternalClient{},
+		selfHealer:       &selfHealer{},
+		replicator:       newReplicator(),
+		clockSkew:        newClockSkewDetector(),
 
 		gcNotifier: NopGCNotifier,
 
 		antiEntropyInterval: time.Minute * 10,
+		antiEntropyChecksum: ChecksumStandard,
+		antiEntropyQueue:    newSyncQueue(defaultAntiEntropyQueueSize, AntiEntropyQueueBlock, defaultAntiEntropyQueueTimeout),
+		antiEntropyRetry:    defaultAntiEntropyRetryDelay,
 		metricInterval:      0,
 		diagnosticInterval:  0,
+		ownerChangeRetries:  3,
+		ownerChangeBackoff:  100 * time.Millisecond,
+		ownerChangeMax:      5 * time.Second,
 
 		logger: logger.NopLogger,
 	}
@@ -341,10 +805,29 @@ func NewServer(opts ...ServerOption) (*Server, error) {
 	s.holder.Logger = s.logger
 	s.holder.Stats.SetLogger(s.logger)
 
+	s.clockSkew.logger = s.logger
+	if s.memory != nil {
+		s.memory.logger = s.logger
+		s.memory.stats = s.holder.Stats
+	}
+	if s.breaker != nil {
+		s.breaker.logger = s.logger
+	}
+
 	s.cluster.Path = path
 	s.cluster.logger = s.logger
 	s.cluster.holder = s.holder
 
+	if err := s.loadPromotion(); err != nil {
+		return nil, err
+	}
+
+	if s.bootstrap != nil {
+		if err := s.bootstrapTopology(); err != nil {
+			return nil, errors.Wrap(err, "bootstrapping topology")
+		}
+	}
+
 	// Get or create NodeID.
 	s.nodeID = s.loadNodeID()
 	if s.isCoordinator {
@@ -357,6 +840,10 @@ func NewServer(opts ...ServerOption) (*Server, error) {
 		URI:           s.uri,
 		IsCoordinator: s.cluster.Coordinator == s.nodeID,
 		State:         nodeStateDown,
+		Zone:          s.zone,
+
+		ProtocolVersion:    ProtocolVersion,
+		MinProtocolVersion: MinProtocolVersion,
 	}
 	s.cluster.Node = node
 	if s.clusterDisabled {
@@ -372,9 +859,14 @@ func NewServer(opts ...ServerOption) (*Server, error) {
 	s.executor.Holder = s.holder
 	s.executor.Node = node
 	s.executor.Cluster = s.cluster
-	s.executor.MaxWritesPerRequest = s.maxWritesPerRequest
+	s.executor.OwnerChangeRetries = s.ownerChangeRetries
+	s.executor.OwnerChangeBackoff = s.ownerChangeBackoff
+	s.executor.OwnerChangeMaxBackoff = s.ownerChangeMax
+	s.executor.PartialResults = s.partialResults
+	s.executor.MaxResultRows = s.maxResultRows
 	s.cluster.broadcaster = s
 	s.cluster.maxWritesPerRequest = s.maxWritesPerRequest
+	s.cluster.stats = s.holder.Stats.WithTags("Cluster")
 	s.holder.broadcaster = s
 
 	err = s.cluster.setup()
@@ -423,6 +915,10 @@ func (s *Server) Open() error {
 		log.Println(errors.Wrap(err, "logging startup"))
 	}
 
+	if s.quorum != nil {
+		s.waitForQuorum()
+	}
+
 	// Open Cluster management.
 	if err := s.cluster.waitForStarted(); err != nil {
 		return errors.Wrap(err, "opening Cluster")
@@ -432,6 +928,11 @@ func (s *Server) Open() error {
 	if err := s.holder.Open(); err != nil {
 		return errors.Wrap(err, "opening Holder")
 	}
+	if s.warmup != nil {
+		if err := s.openWarmup(); err != nil {
+			return errors.Wrap(err, "warming up")
+		}
+	}
 	if err := s.cluster.setNodeState(nodeStateReady); err != nil {
 		return errors.Wrap(err, "setting nodeState")
 	}
@@ -448,12 +949,33 @@ func (s *Server) Open() error {
 	s.syncer.Cluster = s.cluster
 	s.syncer.Closing = s.closing
 	s.syncer.Stats = s.holder.Stats.WithTags("HolderSyncer")
+	s.syncer.Checksum = s.antiEntropyChecksum
+	s.syncer.Queue = s.antiEntropyQueue
+	s.antiEntropyQueue.stats = s.syncer.Stats
 
 	// Start background monitoring.
-	s.wg.Add(3)
+	s.wg.Add(4)
 	go func() { defer s.wg.Done(); s.monitorAntiEntropy() }()
+	go func() { defer s.wg.Done(); s.monitorAntiEntropyRetries() }()
 	go func() { defer s.wg.Done(); s.monitorRuntime() }()
 	go func() { defer s.wg.Done(); s.monitorDiagnostics() }()
+	if s.usageInterval > 0 {
+		s.wg.Add(1)
+		go func() { defer s.wg.Done(); s.monitorUsage() }()
+	}
+	if s.selfHealThreshold > 0 {
+		s.wg.Add(1)
+		go func() { defer s.wg.Done(); s.monitorSelfHeal() }()
+	}
+	if s.memory != nil {
+		s.wg.Add(1)
+		go func() { defer s.wg.Done(); s.monitorMemory() }()
+	}
+	if s.replicator.standby() {
+		s.wg.Add(1)
+		s.replicator.wg.Add(1)
+		go func() { defer s.wg.Done(); s.monitorReplication() }()
+	}
 
 	return nil
 }
@@ -474,6 +996,9 @@ func (s *Server) Close() error {
 	if s.holder != nil {
 		errh = s.holder.Close()
 	}
+	if err := s.warmup.close(); err != nil {
+		s.logger.Printf("%v", err)
+	}
 	// prefer to return holder error over cluster
 	// error. This order is somewhat arbitrary. It would be better if we had
 	// some way to combine all the errors, but probably not important enough to
@@ -488,6 +1013,30 @@ func (s *Server) Close() error {
 
 }
 
+// openWarmup replays the queries recorded by the warmup log, then starts
+// recording. The replay stops once the warmup timeout has elapsed.
+func (s *Server) openWarmup() error {
+	s.warmup.filePerm = s.holder.filePerm
+	queries, err := s.warmup.open()
+	if err != nil {
+		return err
+	}
+	if len(queries) == 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+	if s.warmup.timeout > 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, s.warmup.timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	replayed, skipped := s.executor.replayWarmup(ctx, queries)
+	s.logger.Printf("warmup: replayed %d queries, skipped %d, in %s", replayed, skipped, time.Since(start))
+	return nil
+}
+
 // loadNodeID gets NodeID from disk, or creates a new value.
 // If server.NodeID is already set, a new ID is not created.
 func (s *Server) loadNodeID() string {
@@ -511,16 +1060,67 @@ func (s *Server) SyncData() error {
 	return errors.Wrap(s.syncer.SyncHolder(), "syncing holder")
 }
 
+// SetAntiEntropyInterval changes the interval at which anti-entropy runs. An
+// interval of zero disables it.
+func (s *Server) SetAntiEntropyInterval(interval time.Duration) {
+	s.antiEntropyMu.Lock()
+	s.antiEntropyInterval = interval
+	s.antiEntropyMu.Unlock()
+
+	select {
+	case s.antiEntropyReset <- struct{}{}:
+	default:
+	}
+}
+
+// SetLongQueryTime changes the threshold above which queries are logged as
+// long running.
+func (s *Server) SetLongQueryTime(d time.Duration) {
+	s.cluster.mu.Lock()
+	defer s.cluster.mu.Unlock()
+	s.cluster.longQueryTime = d
+}
+
+// SetMaxWritesPerRequest changes the maximum number of write commands a
+// query may contain. Zero disables the limit.
+func (s *Server) SetMaxWritesPerRequest(n int) {
+	s.cluster.mu.Lock()
+	defer s.cluster.mu.Unlock()
+	s.cluster.maxWritesPerRequest = n
+}
+
 func (s *Server) monitorAntiEntropy() {
-	if s.antiEntropyInterval == 0 || s.cluster.ReplicaN <= 1 {
+	if s.cluster.ReplicaN <= 1 {
 		return // anti entropy disabled
 	}
 	s.cluster.initializeAntiEntropy()
 
-	ticker := time.NewTicker(s.antiEntropyInterval)
-	defer ticker.Stop()
-
-	s.logger.Printf("holder sync monitor initializing (%s interval)", s.antiEntropyInterval)
+	// The ticker is replaced whenever the interval changes. A nil ticker
+	// channel never fires, which disables anti-entropy.
+	var ticker *time.Ticker
+	var tickC <-chan time.Time
+	resetTicker := func() {
+		if ticker != nil {
+			ticker.Stop()
+			ticker, tickC = nil, nil
+		}
+		s.antiEntropyMu.Lock()
+		interval := s.antiEntropyInterval
+		s.antiEntropyMu.Unlock()
+		if interval == 0 {
+			s.logger.Printf("holder sync monitor disabled")
+			return
+		}
+		ticker = time.NewTicker(interval)
+		tickC = ticker.C
+		s.logger.Printf("holder sync monitor initializing (%s interval)", interval)
+	}
+	resetTicker()
+	defer func() {
+		if ticker != nil {
+			ticker.Stop()
+		}
+	}()
 
 	// Initialize syncer with local holder and remote client.
 	for {
@@ -530,7 +1130,10 @@ func (s *Server) monitorAntiEntropy() {
 			return
 		case <-s.cluster.abortAntiEntropyCh: // receive here so we don't block resizing
 			continue
-		case <-ticker.C:
+		case <-s.antiEntropyReset:
+			resetTicker()
+			continue
+		case <-tickC:
 			s.holder.Stats.Count("AntiEntropy", 1, 1.0)
 		}
 		t := time.Now()
@@ -556,7 +1159,7 @@ func (s *Server) monitorAntiEntropy() {
 		// other.
 		for {
 			select {
-			case <-ticker.C:
+			case <-tickC:
 				continue
 			default:
 			}
@@ -565,6 +1168,40 @@ func (s *Server) monitorAntiEntropy() {
 	}
 }
 
+// monitorAntiEntropyRetries periodically runs the anti-entropy syncs which
+// were rescheduled because the anti-entropy queue was full. A retry which
+// does not fit in the queue either is rescheduled again.
+func (s *Server) monitorAntiEntropyRetries() {
+	if s.antiEntropyRetry <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.antiEntropyRetry)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.closing:
+			return
+		case <-ticker.C:
+		}
+		if s.cluster.State() == ClusterStateResizing {
+			continue // retry once the resize is done.
+		}
+		s.retryAntiEntropy()
+	}
+}
+
+// retryAntiEntropy runs the rescheduled anti-entropy syncs.
+func (s *Server) retryAntiEntropy() {
+	for _, req := range s.antiEntropyQueue.takeRetries() {
+		if _, err := s.syncer.syncHolder(req.index, req.shards, req.primaryOnly); err == ErrAntiEntropyQueueFull {
+			s.logger.Debugf("anti-entropy retry rescheduled: index=%q", req.index)
+		} else if err != nil {
+			s.logger.Printf("anti-entropy retry error: index=%q, err=%s", req.index, err)
+		}
+	}
+}
+
 // receiveMessage represents an implementation of BroadcastHandler.
 func (s *Server) receiveMessage(m Message) error {
 	switch obj := m.(type) {
@@ -586,6 +1223,53 @@ func (s *Server) receiveMessage(m Message) error {
 		if err := s.holder.DeleteIndex(obj.Index); err != nil {
 			return err
 		}
+	case *UndeleteIndexMessage:
+		if _, err := s.holder.UndeleteIndex(obj.Index); err != nil {
+			return err
+		}
+	case *ApplySchemaMessage:
+		if err := s.holder.applySchema(obj.Schema, nil); err != nil {
+			return err
+		}
+	case *RenameMessage:
+		// A rename already applied, such as the rollback of a rename this
+		// node did not apply, is ignored.
+		if obj.Field == "" {
+			if s.holder.Index(obj.Index) == nil && s.holder.Index(obj.NewName) != nil {
+				return nil
+			} else if err := s.holder.RenameIndex(obj.Index, obj.NewName); err != nil {
+				return err
+			}
+		} else {
+			idx := s.holder.Index(obj.Index)
+			if idx == nil {
+				return fmt.Errorf("local index not found: %s", obj.Index)
+			} else if idx.Field(obj.Field) == nil && idx.Field(obj.NewName) != nil {
+				return nil
+			} else if err := idx.RenameField(obj.Field, obj.NewName); err != nil {
+				return err
+			}
+		}
+	case *SchemaVersionMessage:
+		if err := s.holder.advanceSchemaVersion(obj.Node, obj.Version); err != nil {
+			return err
+		}
+	case *UpdateIndexMessage:
+		idx := s.holder.Index(obj.Index)
+		if idx == nil {
+			return fmt.Errorf("local index not found: %s", obj.Index)
+		}
+		if err := idx.setOptions(*obj.Meta); err != nil {
+			return err
+		}
+	case *SetIndexAliasMessage:
+		if obj.Index == "" {
+			if err := s.holder.DeleteIndexAlias(obj.Alias); err != nil {
+				return err
+			}
+		} else if err := s.holder.SetIndexAlias(obj.Alias, obj.Index); err != nil {
+			return err
+		}
 	case *CreateFieldMessage:
 		idx := s.holder.Index(obj.Index)
 		if idx == nil {
@@ -664,7 +1348,20 @@ func (s *Server) receiveMessage(m Message) error {
 
 // SendSync represents an implementation of Broadcaster.
 func (s *Server) SendSync(m Message) error {
+	return s.SendSyncContext(context.Background(), m)
+}
+
+// SendSyncContext is SendSync with a context, which carries the trace of the
+// broadcast to the receiving nodes. The broadcast is not cancelled with ctx,
+// so that a client disconnecting cannot leave the cluster inconsistent.
+func (s *Server) SendSyncContext(ctx context.Context, m Message) error {
+	span, ctx := tracing.StartSpanFromContext(detachedContext{ctx}, "Server.SendSync")
+	defer span.Finish()
+
 	var eg errgroup.Group
+	if err := s.cluster.checkMessageProtocol(getMessageType(m)); err != nil {
+		return err
+	}
 	msg, err := s.serializer.Marshal(m)
 	if err != nil {
 		return fmt.Errorf("marshaling message: %v", err)
@@ -679,13 +1376,21 @@ func (s *Server) SendSync(m Message) error {
 		}
 
 		eg.Go(func() error {
-			return s.defaultClient.SendMessage(context.Background(), &node.URI, msg)
+			return s.defaultClient.SendMessage(ctx, &node.URI, msg)
 		})
 	}
 
 	return eg.Wait()
 }
 
+// detachedContext carries the values of a context, such as its trace, but
+// not its deadline or cancellation.
+type detachedContext struct{ context.Context }
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
 // SendAsync represents an implementation of Broadcaster.
 func (s *Server) SendAsync(m Message) error {
 	return ErrNotImplemented
@@ -693,6 +1398,9 @@ func (s *Server) SendAsync(m Message) error {
 
 // SendTo represents an implementation of Broadcaster.
 func (s *Server) SendTo(to *Node, m Message) error {
+	if err := checkMessageProtocol(to, getMessageType(m)); err != nil {
+		return err
+	}
 	msg, err := s.serializer.Marshal(m)
 	if err != nil {
 		return fmt.Errorf("marshaling message: %v", err)
@@ -709,6 +1417,10 @@ func (s *Server) node() Node {
 
 // handleRemoteStatus receives incoming NodeStatus from remote nodes.
 func (s *Server) handleRemoteStatus(pb Message) {
+	if ns := pb.(*NodeStatus); ns.Node != nil && ns.Node.ID != s.nodeID {
+		s.clockSkew.observe(ns.Node.ID, ns.Time)
+	}
+
 	// Ignore NodeStatus messages until the cluster is in a Normal state.
 	if s.cluster.State() != ClusterStateNormal {
 		return
@@ -725,6 +1437,44 @@ func (s *Server) handleRemoteStatus(pb Message) {
 	}()
 }
 
+// pullSchema applies the schema of the node of ns, whose schema version
+// vector shows changes this node has not seen.
+//
+// If the remote vector also covers every change this node has seen, the
+// remote schema is the newer one and replaces the local schema: deletes,
+// alias and option changes, and undeletes are applied as well as creations.
+// Otherwise both nodes have changes the other lacks, and only the missing
+// indexes and fields are created. The remote versions are merged only if the
+// local schema then matches the remote one, so that a node never believes
+// it has applied changes it has not.
+func (s *Server) pullSchema(ns *NodeStatus) error {
+	local := s.holder.SchemaVersions()
+	schema, err := s.defaultClient.FullSchemaNode(context.Background(), &ns.Node.URI)
+	if err != nil {
+		return errors.Wrap(err, "getting schema")
+	}
+
+	if !newerSchemaVersions(ns.SchemaVersions, local) {
+		if err := s.holder.applyFullSchema(schema); err != nil {
+			return errors.Wrap(err, "applying schema")
+		}
+	} else {
+		if err := s.holder.applySchema(schema, nil); err != nil {
+			return errors.Wrap(err, "applying schema")
+		}
+		if !sameSchema(&Schema{Indexes: s.holder.Schema(), Aliases: s.holder.IndexAliases()}, schema) {
+			s.logger.Printf("pulled concurrent schema changes from %s, versions not merged", ns.Node.ID)
+			return nil
+		}
+	}
+
+	if err := s.holder.mergeSchemaVersions(ns.SchemaVersions); err != nil {
+		return errors.Wrap(err, "merging schema versions")
+	}
+	s.logger.Printf("pulled schema from %s", ns.Node.ID)
+	return nil
+}
+
 func (s *Server) mergeRemoteStatus(ns *NodeStatus) error {
 	// Ignore status updates from self.
 	if s.nodeID == ns.Node.ID {
@@ -732,10 +1482,18 @@ func (s *Server) mergeRemoteStatus(ns *NodeStatus) error {
 	}
 
 	// Sync schema.
-	if err := s.holder.applySchema(ns.Schema); err != nil {
+	if err := s.holder.applySchema(ns.Schema, nil); err != nil {
 		return errors.Wrap(err, "applying schema")
 	}
 
+	// Pull the schema from the remote node if it has seen schema changes
+	// which this node has not, e.g. because it missed their broadcast.
+	if newerSchemaVersions(s.holder.SchemaVersions(), ns.SchemaVersions) {
+		if err := s.pullSchema(ns); err != nil {
+			return errors.Wrapf(err, "pulling schema from %s", ns.Node.ID)
+		}
+	}
+
 	// Sync available shards.
 	for _, is := range ns.Indexes {
 		for _, fs := range is.Fields {
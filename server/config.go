@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"os"
 	"runtime"
 	"strconv"
 	"strings"
@@ -63,9 +64,25 @@ type Config struct {
 	// SetRowAttrs & SetColumnAttrs.
 	MaxWritesPerRequest int `toml:"max-writes-per-request"`
 
+	// DefaultIndex is the index of query and import requests which omit
+	// the index from their path.
+	DefaultIndex string `toml:"default-index"`
+
 	// LogPath configures where Pilosa will write logs.
 	LogPath string `toml:"log-path"`
 
+	// LogTailLines is the number of recent log lines kept in memory and
+	// served by /logs/tail. Zero disables the endpoint.
+	LogTailLines int `toml:"log-tail-lines"`
+
+	// DirPerm and FilePerm are the octal modes, e.g. "0700", with which
+	// directories and files are created in the data directory. FilePerm is
+	// also the mode of the log file. Both are subject to the umask. When
+	// empty, directories are created with 0777, and files with 0666 except
+	// for the node ID, the startup log and the log file, which get 0600.
+	DirPerm  string `toml:"dir-perm"`
+	FilePerm string `toml:"file-perm"`
+
 	// Verbose toggles verbose logging which can be useful for debugging.
 	Verbose bool `toml:"verbose"`
 
@@ -73,6 +90,19 @@ type Config struct {
 	Handler struct {
 		// CORS Allowed Origins
 		AllowedOrigins []string `toml:"allowed-origins"`
+
+		// MaxBodyBytes limits the size of request bodies, and of each
+		// batch of an import stream. Larger requests are rejected with
+		// 413. Zero disables the limit.
+		MaxBodyBytes int64 `toml:"max-body-bytes"`
+
+		// ListenerCount is the number of listeners, each with its own accept
+		// loop, bound to the bind address with SO_REUSEPORT.
+		ListenerCount int `toml:"listener-count"`
+
+		// ListenerBacklog is the length of each listener's queue of pending
+		// connections. Zero uses the operating system default.
+		ListenerBacklog int `toml:"listener-backlog"`
 	} `toml:"handler"`
 
 	// MaxMapCount puts an in-process limit on the number of mmaps. After this
@@ -87,9 +117,70 @@ type Config struct {
 	// lots of fragments.
 	MaxFileCount uint64 `toml:"max-file-count"`
 
+	// MaxMemory is a soft limit, in bytes, on the memory obtained from the
+	// OS by the Go runtime. Once the memory use passes the high watermark
+	// of the limit, read queries are rejected with 503 until it falls
+	// below the low watermark. Zero disables the limit.
+	MaxMemory uint64 `toml:"max-memory"`
+
+	// AutoCreate creates the missing indexes and fields written to by
+	// imports and Set() or Store() calls, instead of failing the write.
+	// Fields are created with AutoCreateFieldType. It should stay off in
+	// production, so that a misspelled name fails rather than creating a
+	// stray index.
+	AutoCreate          bool   `toml:"auto-create"`
+	AutoCreateFieldType string `toml:"auto-create-field-type"`
+
+	// Shutdown configures the stages of the shutdown of the node. It
+	// refuses new writes and waits up to WriteTimeout for those in flight,
+	// refuses new queries and waits up to QueryTimeout for those in flight
+	// before cancelling them, waits up to FlushTimeout for the fragments
+	// to be flushed, and waits up to ListenerTimeout for the HTTP requests
+	// still open before closing the listeners.
+	Shutdown struct {
+		WriteTimeout    toml.Duration `toml:"write-timeout"`
+		QueryTimeout    toml.Duration `toml:"query-timeout"`
+		FlushTimeout    toml.Duration `toml:"flush-timeout"`
+		ListenerTimeout toml.Duration `toml:"listener-timeout"`
+	} `toml:"shutdown"`
+
+	Memory struct {
+		// HighWatermark and LowWatermark are fractions of MaxMemory.
+		HighWatermark float64 `toml:"high-watermark"`
+		LowWatermark  float64 `toml:"low-watermark"`
+		// CheckInterval is how often the memory use is sampled.
+		CheckInterval toml.Duration `toml:"check-interval"`
+	} `toml:"memory"`
+
+	// Limits bound the growth of the schema. Indexes and fields created
+	// through the API are rejected once a limit is reached. Zero disables
+	// a limit.
+	Limits struct {
+		MaxIndexes        int `toml:"max-indexes"`
+		MaxFieldsPerIndex int `toml:"max-fields-per-index"`
+		// MaxOpenFiles is the number of open fragment files above which
+		// no index or field can be created. MaxFileCount should be set
+		// above it, or files are closed first.
+		MaxOpenFiles uint64 `toml:"max-open-files"`
+	} `toml:"limits"`
+
 	// TLS
 	TLS TLSConfig `toml:"tls"`
 
+	Admin struct {
+		// Port is the port, on the host of Bind, on which the admin
+		// endpoints, such as resizing the cluster, anti-entropy and
+		// diagnostics, are served instead of the main port. Zero serves
+		// them on the main port.
+		Port int `toml:"port"`
+
+		// TLS replaces the TLS configuration of the main port for the admin
+		// port when its certificate is set, e.g. to require client
+		// certificates on the admin port only. The admin port then serves
+		// HTTPS even if the main port serves HTTP.
+		TLS TLSConfig `toml:"tls"`
+	} `toml:"admin"`
+
 	// WorkerPoolSize controls how many goroutines are created for
 	// processing queries. Defaults to runtime.NumCPU(). It is
 	// intentionally not defined as a flag... only exposed here so
@@ -104,6 +195,13 @@ type Config struct {
 	// don't exhaust the goroutine limit.
 	ImportWorkerPoolSize int
 
+	Node struct {
+		// Zone is the failure domain, e.g. the rack, of the node. The
+		// replicas of a shard are placed in distinct zones when the nodes
+		// declare at least as many zones as there are replicas.
+		Zone string `toml:"zone"`
+	} `toml:"node"`
+
 	Cluster struct {
 		// Disabled controls whether clustering functionality is enabled.
 		Disabled    bool     `toml:"disabled"`
@@ -112,6 +210,38 @@ type Config struct {
 		Hosts       []string `toml:"hosts"`
 		// TODO(2.0) move this out of cluster. (why is it here??)
 		LongQueryTime toml.Duration `toml:"long-query-time"`
+		// SelfHealThreshold is the fraction of owned shards which must be
+		// missing at startup for the node to pull them from their replicas.
+		// Zero disables the automatic self-heal.
+		SelfHealThreshold float64 `toml:"self-heal-threshold"`
+		// OwnerChangeRetries is the number of times internal shard
+		// requests rejected because shard ownership changed are retried
+		// against the new owners. OwnerChangeBackoff is the delay before
+		// the first retry, which doubles on each subsequent one up to
+		// OwnerChangeMaxBackoff.
+		OwnerChangeRetries    int           `toml:"owner-change-retries"`
+		OwnerChangeBackoff    toml.Duration `toml:"owner-change-backoff"`
+		OwnerChangeMaxBackoff toml.Duration `toml:"owner-change-max-backoff"`
+		// ClockSkewThreshold is the difference between the clocks of the
+		// node and of a remote node above which a warning is logged. Zero
+		// disables the check.
+		ClockSkewThreshold toml.Duration `toml:"clock-skew-threshold"`
+		// BootstrapTopology is the path of a topology document, exported
+		// from another cluster, from which a new node takes its ID, zone,
+		// coordinator role and cluster membership.
+		BootstrapTopology string `toml:"bootstrap-topology"`
+		// BreakerThreshold is the number of consecutive failed or timed
+		// out queries to a node after which queries to it are
+		// short-circuited, and routed to replicas, for BreakerCooldown.
+		// Zero disables the circuit breaker.
+		BreakerThreshold int           `toml:"breaker-threshold"`
+		BreakerCooldown  toml.Duration `toml:"breaker-cooldown"`
+		// RequireQuorumOnStart makes the node wait, up to QuorumTimeout,
+		// to hear from a majority of the nodes of its cluster before it
+		// opens, and refuse writes until it has. Zero QuorumTimeout
+		// waits indefinitely.
+		RequireQuorumOnStart bool          `toml:"require-quorum-on-start"`
+		QuorumTimeout        toml.Duration `toml:"quorum-timeout"`
 	} `toml:"cluster"`
 
 	// Gossip config is based around memberlist.Config.
@@ -123,16 +253,127 @@ type Config struct {
 		PrimaryURL string `toml:"primary-url"`
 	} `toml:"translation"`
 
+	Index struct {
+		// SkipCorruptFragments causes fragments which cannot be opened to be
+		// moved aside and reported rather than preventing startup.
+		SkipCorruptFragments bool `toml:"skip-corrupt-fragments"`
+
+		// FlushInterval is how often fragments which have been written to
+		// are flushed to disk.
+		FlushInterval toml.Duration `toml:"flush-interval"`
+
+		// FsyncOnFlush controls whether flushes fsync the fragment files.
+		// Disabling it trades durability on power loss for throughput.
+		FsyncOnFlush bool `toml:"fsync-on-flush"`
+
+		// TombstoneGracePeriod is how long the data of a deleted index is
+		// kept, during which the index can be undeleted. Zero removes
+		// deleted indexes immediately.
+		TombstoneGracePeriod toml.Duration `toml:"tombstone-grace-period"`
+
+		// TombstoneReapInterval is how often expired tombstones are removed.
+		TombstoneReapInterval toml.Duration `toml:"tombstone-reap-interval"`
+
+		// CompactionThreshold is the fraction of its size which compaction
+		// must reclaim from a fragment for it to be compacted automatically.
+		// Zero disables automatic compaction.
+		CompactionThreshold float64 `toml:"compaction-threshold"`
+
+		// CompactionInterval is how often fragments are checked for
+		// automatic compaction.
+		CompactionInterval toml.Duration `toml:"compaction-interval"`
+	} `toml:"index"`
+
 	AntiEntropy struct {
 		Interval toml.Duration `toml:"interval"`
+		// Checksum is the algorithm of the block checksums compared
+		// between replicas, "standard" or "container".
+		Checksum string `toml:"checksum"`
+		// QueueSize is the maximum number of syncs which are running or
+		// waiting to run. Zero does not bound the queue.
+		QueueSize int `toml:"queue-size"`
+		// QueuePolicy decides what happens to a sync requested while the
+		// queue is full, "block" or "drop".
+		QueuePolicy string `toml:"queue-policy"`
+		// QueueTimeout is how long the "block" policy waits for room in
+		// the queue before rescheduling a sync.
+		QueueTimeout toml.Duration `toml:"queue-timeout"`
 	} `toml:"anti-entropy"`
 
+	Query struct {
+		// Cache holds the results of read-only queries until the index is
+		// written to.
+		Cache struct {
+			// Size is the maximum number of cached results. Zero disables
+			// the cache.
+			Size int `toml:"size"`
+			// TTL is the maximum age of a cached result. Zero keeps results
+			// until they are invalidated or evicted.
+			TTL toml.Duration `toml:"ttl"`
+		} `toml:"cache"`
+		// SafeMode rejects queries which scan every row of a field without
+		// a bound, unless the request overrides it.
+		SafeMode struct {
+			Enabled bool `toml:"enabled"`
+			// MaxShards and MaxRows are the number of shards and the
+			// estimated number of rows above which an unbounded scan is
+			// rejected. Zero disables the limit.
+			MaxShards int    `toml:"max-shards"`
+			MaxRows   uint64 `toml:"max-rows"`
+		} `toml:"safe-mode"`
+		// PartialResults returns the results of the available shards when
+		// no node can serve some shards of a query, instead of failing it.
+		PartialResults bool `toml:"partial-results"`
+		// MaxDepth is the maximum nesting depth of the calls of a query,
+		// and MaxOps its maximum number of calls. Zero disables a limit.
+		MaxDepth int `toml:"max-depth"`
+		MaxOps   int `toml:"max-ops"`
+		// MaxResultRows is the maximum number of rows or columns returned
+		// for each call of a query. Longer results are truncated, and the
+		// response flagged as truncated. Zero disables the limit.
+		MaxResultRows uint64 `toml:"max-result-rows"`
+	} `toml:"query"`
+
+	// Warmup records a sample of the read queries executed by the node and
+	// replays them on startup, before the node reports ready.
+	Warmup struct {
+		// Path is the file the queries are recorded to. Empty disables
+		// the warmup.
+		Path string `toml:"path"`
+		// SampleRate is the fraction of queries recorded.
+		SampleRate float64 `toml:"sample-rate"`
+		// MaxQueries is the number of most recent queries kept for replay.
+		MaxQueries int `toml:"max-queries"`
+		// Timeout bounds the time spent replaying queries on startup.
+		Timeout toml.Duration `toml:"timeout"`
+	} `toml:"warmup"`
+
+	Replication struct {
+		// Upstream is the URL of a node of the primary cluster which this
+		// cluster is a read-only standby of. Empty disables replication.
+		Upstream string `toml:"upstream"`
+		// Interval is how often the schema and data are pulled from
+		// upstream.
+		Interval toml.Duration `toml:"interval"`
+	} `toml:"replication"`
+
+	// ChangeFeed holds the bits set and cleared on the node, for streaming
+	// to consumers at /changes.
+	ChangeFeed struct {
+		// BufferSize is the number of most recent changes held. Zero
+		// disables the feed.
+		BufferSize int `toml:"buffer-size"`
+	} `toml:"change-feed"`
+
 	Metric struct {
 		// Service can be statsd, expvar, or none.
 		Service string `toml:"service"`
 		// Host tells the statsd client where to write.
 		Host         string        `toml:"host"`
 		PollInterval toml.Duration `toml:"poll-interval"`
+		// UsageInterval is the interval between samples of the storage used
+		// by each field. Zero disables sampling.
+		UsageInterval toml.Duration `toml:"usage-interval"`
 		// Diagnostics toggles sending some limited diagnostic information to
 		// Pilosa's developers.
 		Diagnostics bool `toml:"diagnostics"`
@@ -146,6 +387,9 @@ type Config struct {
 		SamplerParam float64 `toml:"sampler-param"`
 		// AgentHostPort is the host:port of the local agent.
 		AgentHostPort string `toml:"agent-host-port"`
+		// CollectorEndpoint is the URL of a collector to which spans are
+		// sent over HTTP instead of to the agent.
+		CollectorEndpoint string `toml:"collector-endpoint"`
 	} `toml:"tracing"`
 
 	Profile struct {
@@ -162,6 +406,7 @@ func NewConfig() *Config {
 		DataDir:             "~/.pilosa",
 		Bind:                ":10101",
 		MaxWritesPerRequest: 5000,
+		LogTailLines:        1000,
 
 		// We default these Max File/Map counts very high. This is basically a
 		// backwards compatibility thing where we don't want to cause different
@@ -177,11 +422,51 @@ func NewConfig() *Config {
 		ImportWorkerPoolSize: runtime.NumCPU(),
 	}
 
+	// Shutdown config.
+	c.Shutdown.WriteTimeout = toml.Duration(10 * time.Second)
+	c.Shutdown.QueryTimeout = toml.Duration(30 * time.Second)
+	c.Shutdown.FlushTimeout = toml.Duration(time.Minute)
+	c.Shutdown.ListenerTimeout = toml.Duration(30 * time.Second)
+
+	// AutoCreate config.
+	c.AutoCreateFieldType = "set"
+
+	// Memory config.
+	c.Memory.HighWatermark = 0.9
+	c.Memory.LowWatermark = 0.8
+	c.Memory.CheckInterval = toml.Duration(time.Second)
+
+	// Limits config.
+	c.Limits.MaxIndexes = 10000
+	c.Limits.MaxFieldsPerIndex = 10000
+	c.Limits.MaxOpenFiles = 900000
+
+	// Handler config.
+	c.Handler.MaxBodyBytes = 1 << 30
+	c.Handler.ListenerCount = 1
+
+	// Query config.
+	c.Query.SafeMode.MaxShards = 100
+	c.Query.SafeMode.MaxRows = 100000
+	c.Query.MaxDepth = 100
+	c.Query.MaxOps = 10000
+
+	// Warmup config.
+	c.Warmup.SampleRate = 0.01
+	c.Warmup.MaxQueries = 1000
+	c.Warmup.Timeout = toml.Duration(time.Minute)
+
 	// Cluster config.
 	c.Cluster.Disabled = false
 	c.Cluster.ReplicaN = 1
 	c.Cluster.Hosts = []string{}
 	c.Cluster.LongQueryTime = toml.Duration(time.Minute)
+	c.Cluster.OwnerChangeRetries = 3
+	c.Cluster.OwnerChangeBackoff = toml.Duration(100 * time.Millisecond)
+	c.Cluster.OwnerChangeMaxBackoff = toml.Duration(5 * time.Second)
+	c.Cluster.ClockSkewThreshold = toml.Duration(time.Minute)
+	c.Cluster.BreakerCooldown = toml.Duration(30 * time.Second)
+	c.Cluster.QuorumTimeout = toml.Duration(5 * time.Minute)
 
 	// Gossip config.
 	c.Gossip.Port = "14000"
@@ -194,8 +479,21 @@ func NewConfig() *Config {
 	c.Gossip.Nodes = 3
 	c.Gossip.ToTheDeadTime = toml.Duration(30 * time.Second)
 
+	// Index config.
+	c.Index.FlushInterval = toml.Duration(time.Minute)
+	c.Index.FsyncOnFlush = true
+	c.Index.TombstoneReapInterval = toml.Duration(time.Minute)
+	c.Index.CompactionInterval = toml.Duration(time.Hour)
+
 	// AntiEntropy config.
 	c.AntiEntropy.Interval = toml.Duration(10 * time.Minute)
+	c.AntiEntropy.Checksum = "standard"
+	c.AntiEntropy.QueueSize = 16
+	c.AntiEntropy.QueuePolicy = "block"
+	c.AntiEntropy.QueueTimeout = toml.Duration(time.Minute)
+
+	// Replication config.
+	c.Replication.Interval = toml.Duration(time.Minute)
 
 	// Metric config.
 	c.Metric.Service = "none"
@@ -212,12 +510,40 @@ func NewConfig() *Config {
 	return c
 }
 
+// redactedValue replaces config values which should not leave the node.
+const redactedValue = "[REDACTED]"
+
+// redacted returns a copy of the config with secrets, such as key file paths,
+// replaced so that it can be shared in diagnostics.
+func (cfg *Config) redacted() Config {
+	c := *cfg
+	if c.TLS.CertificateKeyPath != "" {
+		c.TLS.CertificateKeyPath = redactedValue
+	}
+	if c.Gossip.Key != "" {
+		c.Gossip.Key = redactedValue
+	}
+	return c
+}
+
+// parsePerm parses an octal file mode such as "0600".
+func parsePerm(s string) (os.FileMode, error) {
+	v, err := strconv.ParseUint(s, 8, 32)
+	if err != nil {
+		return 0, errors.Errorf("invalid mode: %q", s)
+	} else if os.FileMode(v)&^os.ModePerm != 0 {
+		return 0, errors.Errorf("invalid mode: %q, only permission bits may be set", s)
+	}
+	return os.FileMode(v), nil
+}
+
 // validateAddrs controls the address fields in the Config object
 // and fills in any blanks.
 // The addresses fields must be guaranteed by the caller to either be
 // completely empty, or have both a host part and a port part
 // separated by a colon. In the latter case either can be empty to
 // indicate it's left unspecified.
+
 func (cfg *Config) validateAddrs(ctx context.Context) error {
 	// Validate the advertise address.
 	advScheme, advHost, advPort, err := validateAdvertiseAddr(ctx, cfg.Advertise, cfg.Bind)
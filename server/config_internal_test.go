@@ -154,3 +154,21 @@ func TestConfig_validateAddrs(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_redacted(t *testing.T) {
+	c := NewConfig()
+	c.TLS.CertificatePath = "/etc/pilosa/pilosa.crt"
+	c.TLS.CertificateKeyPath = "/etc/pilosa/pilosa.key"
+	c.Gossip.Key = "/etc/pilosa/gossip.key"
+
+	r := c.redacted()
+	if r.TLS.CertificateKeyPath != redactedValue {
+		t.Fatalf("expected key path to be redacted, got %q", r.TLS.CertificateKeyPath)
+	} else if r.Gossip.Key != redactedValue {
+		t.Fatalf("expected gossip key to be redacted, got %q", r.Gossip.Key)
+	} else if r.TLS.CertificatePath != c.TLS.CertificatePath {
+		t.Fatalf("expected certificate path to be kept, got %q", r.TLS.CertificatePath)
+	} else if c.TLS.CertificateKeyPath != "/etc/pilosa/pilosa.key" {
+		t.Fatalf("expected original config to be unchanged, got %q", c.TLS.CertificateKeyPath)
+	}
+}
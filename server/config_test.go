@@ -15,12 +15,17 @@
 package server_test
 
 import (
+	"context"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/pilosa/pilosa/v2"
 	"github.com/pilosa/pilosa/v2/server"
+	"github.com/pilosa/pilosa/v2/test"
 	"github.com/pilosa/pilosa/v2/toml"
+	"github.com/pkg/errors"
 )
 
 func Test_NewConfig(t *testing.T) {
@@ -62,3 +67,60 @@ func TestDuration(t *testing.T) {
 		t.Fatalf("Unexpected marshalled value %v", v)
 	}
 }
+
+// Ensure a reload only applies settings which can be changed at runtime.
+func TestCommand_Reload(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+	m := c[0]
+
+	if err := m.Reload(); err == nil {
+		t.Fatal("expected error without ReloadConfig")
+	}
+
+	dataDir := m.Config.DataDir
+	var cfg server.Config
+	m.ReloadConfig = func() (*server.Config, error) { return &cfg, nil }
+
+	cfg = *m.Config
+	cfg.Cluster.LongQueryTime = toml.Duration(2 * time.Minute)
+	cfg.DataDir = "/tmp/elsewhere"
+	if err := m.Reload(); err != nil {
+		t.Fatal(err)
+	} else if d := m.API.LongQueryTime(); d != 2*time.Minute {
+		t.Fatalf("unexpected long query time: %s", d)
+	} else if m.Config.DataDir != dataDir {
+		t.Fatalf("unexpected data dir: %s", m.Config.DataDir)
+	}
+
+	// A reloaded write limit applies to the next query.
+	cfg = *m.Config
+	cfg.MaxWritesPerRequest = 2
+	if _, err := m.API.CreateIndex(context.Background(), "i", pilosa.IndexOptions{}); err != nil {
+		t.Fatal(err)
+	} else if _, err := m.API.CreateField(context.Background(), "i", "f"); err != nil {
+		t.Fatal(err)
+	} else if err := m.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: "Set(1, f=1) Set(2, f=1) Set(3, f=1)"}); errors.Cause(err) != pilosa.ErrTooManyWrites {
+		t.Fatalf("expected too many writes, got %v", err)
+	} else if _, err := m.API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: "Set(1, f=1) Set(2, f=1)"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// An invalid setting rejects the whole reload.
+	cfg = *m.Config
+	cfg.Cluster.LongQueryTime = toml.Duration(3 * time.Minute)
+	cfg.AntiEntropy.Interval = -1
+	if err := m.Reload(); err == nil || !strings.Contains(err.Error(), "anti-entropy interval must not be negative") {
+		t.Fatalf("unexpected error: %v", err)
+	} else if d := m.API.LongQueryTime(); d != 2*time.Minute {
+		t.Fatalf("unexpected long query time: %s", d)
+	}
+
+	m.ReloadConfig = func() (*server.Config, error) { return nil, errors.New("marker") }
+	if err := m.Reload(); err == nil || !strings.Contains(err.Error(), "marker") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
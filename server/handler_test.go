@@ -15,8 +15,14 @@
 package server_test
 
 import (
+	"archive/tar"
+	"bufio"
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -26,6 +32,7 @@ import (
 	gohttp "net/http"
 	"net/http/httptest"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -258,6 +265,209 @@ func TestHandler_Endpoints(t *testing.T) {
 
 	})
 
+	t.Run("Import with column attributes", func(t *testing.T) {
+		if _, err := i0.CreateFieldIfNotExists("attrs-field", pilosa.OptFieldTypeDefault()); err != nil {
+			t.Fatal(err)
+		}
+		importAttrs := func(req *pilosa.ImportRequest) (*httptest.ResponseRecorder, pilosa.ImportResponse) {
+			ser := proto.Serializer{}
+			data, err := ser.Marshal(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := httptest.NewRecorder()
+			httpReq := test.MustNewHTTPRequest("POST", "/index/i0/field/attrs-field/import", bytes.NewBuffer(data))
+			httpReq.Header.Set("Content-Type", "application/x-protobuf")
+			httpReq.Header.Set("Accept", "application/x-protobuf")
+			h.ServeHTTP(w, httpReq)
+			var resp pilosa.ImportResponse
+			if w.Code == gohttp.StatusOK {
+				if err := ser.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+			}
+			return w, resp
+		}
+
+		// Bits and attributes.
+		w, resp := importAttrs(&pilosa.ImportRequest{
+			Index:     "i0",
+			Field:     "attrs-field",
+			RowIDs:    []uint64{1, 1},
+			ColumnIDs: []uint64{100, 101},
+			ColumnAttrs: []*pilosa.ColumnAttrSet{
+				{ID: 100, Attrs: map[string]interface{}{"name": "a"}},
+			},
+		})
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		} else if resp.Bits != 2 || resp.ColumnAttrs != 1 {
+			t.Fatalf("unexpected response: %+v", resp)
+		} else if cols := hldr.Row("i0", "attrs-field", 1).Columns(); !reflect.DeepEqual(cols, []uint64{100, 101}) {
+			t.Fatalf("unexpected columns: %v", cols)
+		}
+
+		// Attributes only.
+		w, resp = importAttrs(&pilosa.ImportRequest{
+			Index: "i0",
+			Field: "attrs-field",
+			ColumnAttrs: []*pilosa.ColumnAttrSet{
+				{ID: 101, Attrs: map[string]interface{}{"name": "b", "age": int64(3)}},
+			},
+		})
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		} else if resp.Bits != 0 || resp.ColumnAttrs != 1 {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+		for id, exp := range map[uint64]map[string]interface{}{
+			100: {"name": "a"},
+			101: {"name": "b", "age": int64(3)},
+		} {
+			if attrs, err := i0.ColumnAttrStore().Attrs(id); err != nil {
+				t.Fatal(err)
+			} else if !reflect.DeepEqual(attrs, exp) {
+				t.Fatalf("unexpected attributes of column %d: %v", id, attrs)
+			}
+		}
+
+		// Attributes outside the shard of the import.
+		w, _ = importAttrs(&pilosa.ImportRequest{
+			Index: "i0",
+			Field: "attrs-field",
+			ColumnAttrs: []*pilosa.ColumnAttrSet{
+				{ID: pilosa.ShardWidth, Attrs: map[string]interface{}{"name": "c"}},
+			},
+		})
+		if w.Code != gohttp.StatusBadRequest {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Import mutex conflict policy", func(t *testing.T) {
+		if _, err := i0.CreateFieldIfNotExists("mutex-field", pilosa.OptFieldTypeMutex(pilosa.CacheTypeNone, 0)); err != nil {
+			t.Fatal(err)
+		}
+		importMutex := func(policy string, rowIDs, columnIDs []uint64) (*httptest.ResponseRecorder, pilosa.ImportResponse) {
+			ser := proto.Serializer{}
+			data, err := ser.Marshal(&pilosa.ImportRequest{Index: "i0", Field: "mutex-field", RowIDs: rowIDs, ColumnIDs: columnIDs})
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := httptest.NewRecorder()
+			httpReq := test.MustNewHTTPRequest("POST", "/index/i0/field/mutex-field/import?conflictPolicy="+policy, bytes.NewBuffer(data))
+			httpReq.Header.Set("Content-Type", "application/x-protobuf")
+			httpReq.Header.Set("Accept", "application/x-protobuf")
+			h.ServeHTTP(w, httpReq)
+			var resp pilosa.ImportResponse
+			if w.Code == gohttp.StatusOK {
+				if err := ser.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+			}
+			return w, resp
+		}
+		row := func(rowID uint64) string {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i0/query", strings.NewReader(fmt.Sprintf("Row(mutex-field=%d)", rowID))))
+			return w.Body.String()
+		}
+
+		if w, resp := importMutex("", []uint64{1, 1}, []uint64{10, 11}); w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		} else if resp.Conflicts != 0 {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+
+		if w, _ := importMutex("error", []uint64{2}, []uint64{10}); w.Code != gohttp.StatusConflict {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+		if w, resp := importMutex("first-write-wins", []uint64{2, 2}, []uint64{10, 12}); w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		} else if resp.Conflicts != 1 {
+			t.Fatalf("unexpected response: %+v", resp)
+		} else if body := row(1); body != `{"results":[{"attrs":{},"columns":[10,11]}]}`+"\n" {
+			t.Fatalf("unexpected body: %s", body)
+		}
+		if w, resp := importMutex("last-write-wins", []uint64{2}, []uint64{10}); w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		} else if resp.Conflicts != 1 {
+			t.Fatalf("unexpected response: %+v", resp)
+		} else if body := row(2); body != `{"results":[{"attrs":{},"columns":[10,12]}]}`+"\n" {
+			t.Fatalf("unexpected body: %s", body)
+		}
+
+		if w, _ := importMutex("newest", []uint64{2}, []uint64{10}); w.Code != gohttp.StatusBadRequest {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Changes disabled", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/changes?from=1", nil))
+		if w.Code != gohttp.StatusBadRequest {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		} else if body := w.Body.String(); !strings.Contains(body, pilosa.ErrChangeFeedDisabled.Error()) {
+			t.Fatalf("unexpected body: %s", body)
+		}
+	})
+
+	t.Run("Import bool", func(t *testing.T) {
+		if _, err := i0.CreateFieldIfNotExists("bool-field", pilosa.OptFieldTypeBool()); err != nil {
+			t.Fatal(err)
+		}
+		importBool := func(field string, columnIDs []uint64, values []bool) (*httptest.ResponseRecorder, pilosa.ImportResponse) {
+			ser := proto.Serializer{}
+			data, err := ser.Marshal(&pilosa.ImportBoolRequest{Index: "i0", Field: field, ColumnIDs: columnIDs, Values: values})
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := httptest.NewRecorder()
+			httpReq := test.MustNewHTTPRequest("POST", "/index/i0/field/"+field+"/import-bool", bytes.NewBuffer(data))
+			httpReq.Header.Set("Content-Type", "application/x-protobuf")
+			httpReq.Header.Set("Accept", "application/x-protobuf")
+			h.ServeHTTP(w, httpReq)
+			var resp pilosa.ImportResponse
+			if w.Code == gohttp.StatusOK {
+				if err := ser.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+			}
+			return w, resp
+		}
+		row := func(value bool) string {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i0/query", strings.NewReader(fmt.Sprintf("Row(bool-field=%t)", value))))
+			return w.Body.String()
+		}
+
+		// Without values, every column is set to true.
+		if w, resp := importBool("bool-field", []uint64{1, 2, 3}, nil); w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		} else if resp.Bits != 3 || resp.Set != 3 || resp.Cleared != 0 {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+
+		// Setting a column to false clears its true value.
+		if w, resp := importBool("bool-field", []uint64{2, 3, 4}, []bool{false, true, false}); w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		} else if resp.Bits != 3 || resp.Set != 2 || resp.Cleared != 1 {
+			t.Fatalf("unexpected response: %+v", resp)
+		} else if body := row(true); body != `{"results":[{"attrs":{},"columns":[1,3]}]}`+"\n" {
+			t.Fatalf("unexpected body: %s", body)
+		} else if body := row(false); body != `{"results":[{"attrs":{},"columns":[2,4]}]}`+"\n" {
+			t.Fatalf("unexpected body: %s", body)
+		}
+
+		if w, _ := importBool("bool-field", []uint64{1, 2}, []bool{true}); w.Code != gohttp.StatusBadRequest {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		} else if w, _ := importBool("f0", []uint64{1}, nil); w.Code != gohttp.StatusBadRequest {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		} else if w, _ := importBool("missing", []uint64{1}, nil); w.Code != gohttp.StatusNotFound {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+	})
+
 	t.Run("Status", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/status", nil))
@@ -287,11 +497,555 @@ func TestHandler_Endpoints(t *testing.T) {
 	hldr.SetBit("i0", "f0", 30, (1*pilosa.ShardWidth)+2)
 	hldr.SetBit("i0", "f0", 30, (3*pilosa.ShardWidth)+4)
 
-	hldr.SetBit("i0", "f0", 31, 1)
+	hldr.SetBit("i0", "f0", 31, 1)
+
+	hldr.SetBit("i1", "f1", 40, (0*pilosa.ShardWidth)+1)
+	hldr.SetBit("i1", "f1", 40, (0*pilosa.ShardWidth)+2)
+	hldr.SetBit("i1", "f1", 40, (0*pilosa.ShardWidth)+8)
+
+	t.Run("Shard distribution", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/cluster/shard-distribution", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		}
+		var dist pilosa.ShardDistribution
+		if err := json.NewDecoder(w.Body).Decode(&dist); err != nil {
+			t.Fatal(err)
+		} else if len(dist.Nodes) != 1 || dist.Balance != 1 {
+			t.Fatalf("unexpected distribution: %+v", dist)
+		} else if counts := dist.Nodes[0].Indexes["i0"]; counts.Primary != 3 || counts.Replica != 0 {
+			t.Fatalf("unexpected i0 counts: %+v", counts)
+		} else if counts := dist.Nodes[0].Indexes["i1"]; counts.Primary != 1 {
+			t.Fatalf("unexpected i1 counts: %+v", counts)
+		}
+	})
+
+	t.Run("Topology", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/cluster/topology", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		}
+		topology, err := pilosa.DecodeClusterTopology(w.Body)
+		if err != nil {
+			t.Fatal(err)
+		} else if topology.ReplicaN != 1 || len(topology.Nodes) != 1 {
+			t.Fatalf("unexpected topology: %+v", topology)
+		} else if n := topology.Nodes[0]; n.ID != cmd.Server.NodeID() || !n.IsCoordinator || n.URI != cmd.API.Node().URI {
+			t.Fatalf("unexpected node: %+v", n)
+		}
+	})
+
+	t.Run("ShardNodes", func(t *testing.T) {
+		if _, err := cmd.API.CreateIndex(context.Background(), "idx-owners", pilosa.IndexOptions{Keys: true}); err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			if err := cmd.API.DeleteIndex(context.Background(), "idx-owners"); err != nil {
+				t.Fatal(err)
+			}
+		}()
+
+		get := func(query string) (int, *pilosa.ShardOwners) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/internal/shard-nodes?index=idx-owners&"+query, nil))
+			var owners pilosa.ShardOwners
+			if w.Code == gohttp.StatusOK {
+				if err := json.Unmarshal(w.Body.Bytes(), &owners); err != nil {
+					t.Fatalf("decoding %s: %v", w.Body.String(), err)
+				}
+			}
+			return w.Code, &owners
+		}
+
+		if code, owners := get("shard=3"); code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d", code)
+		} else if owners.Shard != 3 || owners.Primary == nil || owners.Primary.ID != cmd.Server.NodeID() || len(owners.Replicas) != 0 {
+			t.Fatalf("unexpected owners: %+v", owners)
+		} else if topology, err := cmd.API.ClusterTopology(context.Background()); err != nil {
+			t.Fatal(err)
+		} else if owners.Epoch != topology.Epoch {
+			t.Fatalf("unexpected epoch: %d", owners.Epoch)
+		}
+
+		if code, owners := get(fmt.Sprintf("column=%d", 2*pilosa.ShardWidth+1)); code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d", code)
+		} else if owners.Shard != 2 || owners.Column == nil || *owners.Column != 2*pilosa.ShardWidth+1 {
+			t.Fatalf("unexpected owners: %+v", owners)
+		}
+
+		// Keys are looked up without being created.
+		if code, _ := get("key=abc"); code != gohttp.StatusNotFound {
+			t.Fatalf("unexpected status code for a new key: %d", code)
+		} else if _, err := cmd.Server.Holder().Index("idx-owners").TranslateStore().TranslateKey("abc"); err != nil {
+			t.Fatal(err)
+		}
+		if code, owners := get("key=abc"); code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d", code)
+		} else if owners.Key != "abc" || owners.Column == nil || owners.Shard != *owners.Column/pilosa.ShardWidth {
+			t.Fatalf("unexpected owners: %+v", owners)
+		}
+
+		for query, exp := range map[string]int{
+			"":                 gohttp.StatusBadRequest,
+			"shard=1&column=2": gohttp.StatusBadRequest,
+			"shard=x":          gohttp.StatusBadRequest,
+		} {
+			if code, _ := get(query); code != exp {
+				t.Fatalf("unexpected status code for %q: %d", query, code)
+			}
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/internal/shard-nodes?index=nope&shard=1", nil))
+		if w.Code != gohttp.StatusNotFound {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		}
+	})
+
+	t.Run("Rename", func(t *testing.T) {
+		if _, err := cmd.API.CreateIndex(context.Background(), "idx-rename", pilosa.IndexOptions{}); err != nil {
+			t.Fatal(err)
+		} else if _, err := cmd.API.CreateField(context.Background(), "idx-rename", "f"); err != nil {
+			t.Fatal(err)
+		} else if _, err := cmd.API.CreateIndex(context.Background(), "idx-taken", pilosa.IndexOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			for _, name := range []string{"idx-renamed", "idx-taken"} {
+				if err := cmd.API.DeleteIndex(context.Background(), name); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}()
+
+		post := func(path, body string) int {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, test.MustNewHTTPRequest("POST", path, strings.NewReader(body)))
+			return w.Code
+		}
+		for _, tt := range []struct {
+			path, body string
+			exp        int
+		}{
+			{"/index/idx-rename/rename", `{"name":"idx-taken"}`, gohttp.StatusConflict},
+			{"/index/idx-rename/rename", `{}`, gohttp.StatusBadRequest},
+			{"/index/nope/rename", `{"name":"other"}`, gohttp.StatusNotFound},
+			{"/index/idx-rename/rename", `{"name":"idx-renamed"}`, gohttp.StatusOK},
+			{"/index/idx-renamed/field/f/rename", `{"name":"g"}`, gohttp.StatusOK},
+			{"/index/idx-renamed/field/f/rename", `{"name":"h"}`, gohttp.StatusNotFound},
+		} {
+			if code := post(tt.path, tt.body); code != tt.exp {
+				t.Fatalf("POST %s %s: unexpected status code: %d", tt.path, tt.body, code)
+			}
+		}
+		if cmd.Server.Holder().Field("idx-renamed", "g") == nil {
+			t.Fatal("expected renamed field")
+		}
+	})
+
+	t.Run("Diagnostics", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/diagnostics", nil))
+		if w.Code != gohttp.StatusForbidden {
+			t.Fatalf("expected unauthenticated request to be forbidden, got: %d", w.Code)
+		}
+
+		w = httptest.NewRecorder()
+		r := test.MustNewHTTPRequest("GET", "/diagnostics", nil)
+		r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}}
+		h.ServeHTTP(w, r)
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+		}
+
+		gz, err := gzip.NewReader(w.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		tr := tar.NewReader(gz)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			names = append(names, hdr.Name)
+		}
+		if exp := []string{"goroutine.txt", "heap.pprof", "status.json", "config.toml"}; !reflect.DeepEqual(names, exp) {
+			t.Fatalf("unexpected bundle contents: %v", names)
+		}
+	})
+
+	t.Run("Logs tail", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/logs/tail", nil))
+		if w.Code != gohttp.StatusForbidden {
+			t.Fatalf("expected unauthenticated request to be forbidden, got: %d", w.Code)
+		}
+
+		w = httptest.NewRecorder()
+		r := test.MustNewHTTPRequest("GET", "/logs/tail?lines=0", nil)
+		r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}}
+		h.ServeHTTP(w, r)
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+		} else if body := w.Body.String(); !strings.Contains(body, "build time") {
+			t.Fatalf("expected startup line in log tail: %s", body)
+		}
+
+		// Follow the log while a config reload logs a line.
+		srv := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+			r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}}
+			h.ServeHTTP(w, r)
+		}))
+		defer srv.Close()
+		resp, err := gohttp.Get(srv.URL + "/logs/tail?lines=1&follow=true")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		br := bufio.NewReader(resp.Body)
+		if _, err := br.ReadString('\n'); err != nil {
+			t.Fatalf("reading last line: %v", err)
+		}
+
+		cmd.ReloadConfig = func() (*server.Config, error) {
+			c := *cmd.Config
+			return &c, nil
+		}
+		if err := cmd.Reload(); err != nil {
+			t.Fatal(err)
+		}
+		if line, err := br.ReadString('\n'); err != nil {
+			t.Fatalf("reading followed line: %v", err)
+		} else if !strings.Contains(line, "config reload: no settings changed") {
+			t.Fatalf("unexpected followed line: %s", line)
+		}
+	})
+
+	t.Run("Quarantined fragments", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/fragments/quarantined", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		} else if body := w.Body.String(); body != `{"fragments":[]}`+"\n" {
+			t.Fatalf("unexpected body: %s", body)
+		}
+	})
+
+	t.Run("Compact fragments", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/fragments/compact?index=i0&field=f0&view=standard&shard=1,2", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+		var result pilosa.CompactionResult
+		if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+			t.Fatal(err)
+		} else if len(result.Fragments) != 1 || result.Fragments[0].Shard != 1 || result.Fragments[0].BytesAfter > result.Fragments[0].BytesBefore {
+			t.Fatalf("unexpected compaction: %+v", result)
+		} else if result.BytesReclaimed != result.Fragments[0].BytesBefore-result.Fragments[0].BytesAfter {
+			t.Fatalf("unexpected bytes reclaimed: %+v", result)
+		}
+		if n := hldr.Row("i0", "f0", 30).Count(); n != 3 {
+			t.Fatalf("unexpected count after compaction: %d", n)
+		}
+
+		for url, code := range map[string]int{
+			"/fragments/compact":                             gohttp.StatusBadRequest,
+			"/fragments/compact?index=i0&view=std":           gohttp.StatusBadRequest,
+			"/fragments/compact?index=i0&shard=x":            gohttp.StatusBadRequest,
+			"/fragments/compact?index=nope":                  gohttp.StatusNotFound,
+			"/fragments/compact?index=i0&field=nope":         gohttp.StatusNotFound,
+			"/fragments/compact?index=i0&field=f0&view=nope": gohttp.StatusNotFound,
+		} {
+			w = httptest.NewRecorder()
+			h.ServeHTTP(w, test.MustNewHTTPRequest("POST", url, nil))
+			if w.Code != code {
+				t.Fatalf("%s: expected status code %d, got %d %s", url, code, w.Code, w.Body.String())
+			}
+		}
+	})
+
+	t.Run("Usage", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/usage?index=i0", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+		var usage map[string]pilosa.IndexUsage
+		if err := json.NewDecoder(w.Body).Decode(&usage); err != nil {
+			t.Fatal(err)
+		} else if len(usage) != 1 {
+			t.Fatalf("unexpected indexes: %+v", usage)
+		}
+		iu := usage["i0"]
+		fu := iu.Fields["f0"]
+		if fu.Fragments == 0 || fu.DiskBytes == 0 || fu.MemoryBytes == 0 {
+			t.Fatalf("unexpected field usage: %+v", fu)
+		}
+		var total pilosa.StorageUsage
+		for _, fu := range iu.Fields {
+			total.Fragments += fu.Fragments
+			total.DiskBytes += fu.DiskBytes
+			total.MemoryBytes += fu.MemoryBytes
+		}
+		if iu.StorageUsage != total {
+			t.Fatalf("expected index usage %+v, got %+v", total, iu.StorageUsage)
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/usage?index=nope", nil))
+		if w.Code != gohttp.StatusNotFound {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Anti-entropy sync", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/cluster/anti-entropy/sync?index=i0&shard=0,1", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		} else if body := w.Body.String(); body != `{"bitsReconciled":0}`+"\n" {
+			t.Fatalf("unexpected body: %s", body)
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/cluster/anti-entropy/sync?index=nope", nil))
+		if w.Code != gohttp.StatusNotFound {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/cluster/anti-entropy/sync?shard=x", nil))
+		if w.Code != gohttp.StatusBadRequest {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Index tombstones", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/index-tombstones", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		} else if body := w.Body.String(); body != `{"tombstones":[]}`+"\n" {
+			t.Fatalf("unexpected body: %s", body)
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/nope/undelete", nil))
+		if w.Code != gohttp.StatusNotFound {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i0/undelete", nil))
+		if w.Code != gohttp.StatusConflict {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Self-heal", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/self-heal", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+		var status pilosa.SelfHealStatus
+		if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil {
+			t.Fatal(err)
+		} else if status.State != pilosa.SelfHealStateIdle {
+			t.Fatalf("unexpected state: %s", status.State)
+		}
+	})
+
+	t.Run("Replication", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/replication", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+		var status pilosa.ReplicationStatus
+		if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil {
+			t.Fatal(err)
+		} else if status.State != pilosa.ReplicationStateDisabled {
+			t.Fatalf("unexpected state: %s", status.State)
+		}
+
+		// Only standbys can be promoted.
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/replication/promote", nil))
+		if w.Code != gohttp.StatusConflict {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/internal/schema", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+		var schema pilosa.Schema
+		if err := json.Unmarshal(w.Body.Bytes(), &schema); err != nil {
+			t.Fatal(err)
+		}
+		for _, ii := range schema.Indexes {
+			if idx := cmd.Server.Holder().Index(ii.Name); idx == nil || len(ii.Fields) != len(idx.Fields()) {
+				t.Fatalf("unexpected fields of index %s: %v", ii.Name, ii.Fields)
+			}
+		}
+	})
+
+	t.Run("Queries", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/queries", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		} else if body := w.Body.String(); body != "[]\n" {
+			t.Fatalf("unexpected body: %q", body)
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("DELETE", "/queries/unknown", nil))
+		if w.Code != gohttp.StatusNotFound {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Schema bulk", func(t *testing.T) {
+		body := `{"indexes": [{"name": "bulk", "options": {"keys": true}, "fields": [{"name": "f", "options": {"type": "int", "min": 0, "max": 10}}, {"name": "_f"}]}]}`
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/schema/bulk", strings.NewReader(body)))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+		var resp struct {
+			Results []pilosa.CreateSchemaResult `json:"results"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		} else if len(resp.Results) != 3 || !resp.Results[0].Created || !resp.Results[1].Created || resp.Results[2].Error == "" {
+			t.Fatalf("unexpected results: %+v", resp.Results)
+		} else if f := holder.Field("bulk", "f"); f == nil || f.Type() != pilosa.FieldTypeInt {
+			t.Fatalf("expected int field bulk/f, got %v", f)
+		} else if opts := holder.Index("bulk").Options(); !opts.Keys || !opts.TrackExistence {
+			t.Fatalf("unexpected index options: %+v", opts)
+		}
+
+		// Invalid field options fail only their field.
+		body = `{"indexes": [{"name": "bulk2", "fields": [{"name": "f", "options": {"type": "set", "min": 0}}, {"name": "g"}]}]}`
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/schema/bulk", strings.NewReader(body)))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+		resp.Results = nil
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		} else if len(resp.Results) != 3 || !resp.Results[0].Created || resp.Results[1].Created || resp.Results[1].Error == "" || !resp.Results[2].Created {
+			t.Fatalf("unexpected results: %+v", resp.Results)
+		} else if holder.Field("bulk2", "f") != nil || holder.Field("bulk2", "g") == nil {
+			t.Fatal("expected only field bulk2/g to be created")
+		}
+
+		// Unknown index options, and the placement name which only renames
+		// set, reject the whole request.
+		for _, options := range []string{`{"unknown": true}`, `{"placementName": "other"}`} {
+			body = `{"indexes": [{"name": "bulk3", "options": ` + options + `}]}`
+			w = httptest.NewRecorder()
+			h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/schema/bulk", strings.NewReader(body)))
+			if w.Code != gohttp.StatusBadRequest {
+				t.Fatalf("unexpected status code for %s: %d %s", options, w.Code, w.Body.String())
+			} else if holder.Index("bulk3") != nil {
+				t.Fatal("expected index bulk3 not to be created")
+			}
+		}
+
+		for _, name := range []string{"bulk", "bulk2"} {
+			if err := cmd.API.DeleteIndex(context.Background(), name); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+
+	t.Run("Row columns", func(t *testing.T) {
+		ctx := context.Background()
+		if _, err := cmd.API.CreateIndex(ctx, "rc", pilosa.IndexOptions{}); err != nil {
+			t.Fatal(err)
+		} else if _, err := cmd.API.CreateField(ctx, "rc", "f"); err != nil {
+			t.Fatal(err)
+		} else if _, err := cmd.API.Query(ctx, &pilosa.QueryRequest{Index: "rc", Query: fmt.Sprintf("Set(3, f=1) Set(%d, f=1)", pilosa.ShardWidth+300)}); err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			if err := cmd.API.DeleteIndex(ctx, "rc"); err != nil {
+				t.Fatal(err)
+			}
+		}()
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/index/rc/field/f/row/1/columns", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		} else if body, exp := w.Body.String(), fmt.Sprintf("3\n%d\n", pilosa.ShardWidth+300); body != exp {
+			t.Fatalf("unexpected body: %q, expected %q", body, exp)
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/index/rc/field/f/row/1/columns?format=binary&start=4", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+		exp := make([]byte, binary.MaxVarintLen64+2)
+		exp[0] = 1
+		exp = exp[:1+binary.PutUvarint(exp[1:], pilosa.ShardWidth+300)+1]
+		if body := w.Body.Bytes(); !bytes.Equal(body, exp) {
+			t.Fatalf("unexpected body: %x, expected %x", body, exp)
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/index/rc/field/unknown/row/1/columns", nil))
+		if w.Code != gohttp.StatusNotFound {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Topology epoch", func(t *testing.T) {
+		epoch := strconv.FormatUint(cmd.API.TopologyEpoch(), 10)
+
+		w := httptest.NewRecorder()
+		r := test.MustNewHTTPRequest("GET", "/status", nil)
+		r.Header.Set(http.TopologyEpochHeader, epoch)
+		h.ServeHTTP(w, r)
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		} else if v := w.Header().Get(http.TopologyEpochHeader); v != epoch {
+			t.Fatalf("unexpected epoch header: %q", v)
+		}
+
+		// A sender which is ahead of this node is served.
+		w = httptest.NewRecorder()
+		r = test.MustNewHTTPRequest("GET", "/status", nil)
+		r.Header.Set(http.TopologyEpochHeader, strconv.FormatUint(cmd.API.TopologyEpoch()+1, 10))
+		h.ServeHTTP(w, r)
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
 
-	hldr.SetBit("i1", "f1", 40, (0*pilosa.ShardWidth)+1)
-	hldr.SetBit("i1", "f1", 40, (0*pilosa.ShardWidth)+2)
-	hldr.SetBit("i1", "f1", 40, (0*pilosa.ShardWidth)+8)
+		// A stale sender is rejected.
+		if cmd.API.TopologyEpoch() == 0 {
+			t.Fatal("expected a topology epoch above 0")
+		}
+		w = httptest.NewRecorder()
+		r = test.MustNewHTTPRequest("GET", "/status", nil)
+		r.Header.Set(http.TopologyEpochHeader, strconv.FormatUint(cmd.API.TopologyEpoch()-1, 10))
+		h.ServeHTTP(w, r)
+		if w.Code != gohttp.StatusConflict {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		}
+	})
 
 	t.Run("Max Shard", func(t *testing.T) {
 		w := httptest.NewRecorder()
@@ -482,6 +1236,48 @@ func TestHandler_Endpoints(t *testing.T) {
 		}
 	})
 
+	t.Run("Delimited protobuf", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := test.MustNewHTTPRequest("POST", "/index/i0/query?columnAttrs=true", strings.NewReader("Count(Row(f0=30)) Row(f0=30)"))
+		r.Header.Set("Accept", "application/x-protobuf; delimited=true")
+		h.ServeHTTP(w, r)
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		} else if ct := w.Header().Get("Content-Type"); ct != "application/x-protobuf; delimited=true" {
+			t.Fatalf("unexpected header: %q", ct)
+		}
+
+		var resps []pilosa.QueryResponse
+		body := bufio.NewReader(w.Body)
+		for {
+			n, err := binary.ReadUvarint(body)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			buf := make([]byte, n)
+			if _, err := io.ReadFull(body, buf); err != nil {
+				t.Fatal(err)
+			}
+			var resp pilosa.QueryResponse
+			if err := cmd.API.Serializer.Unmarshal(buf, &resp); err != nil {
+				t.Fatal(err)
+			}
+			resps = append(resps, resp)
+		}
+
+		if len(resps) != 3 {
+			t.Fatalf("unexpected number of messages: %d", len(resps))
+		} else if n, ok := resps[0].Results[0].(uint64); !ok || n != 3 {
+			t.Fatalf("unexpected count: %#v", resps[0].Results)
+		} else if columns := resps[1].Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(columns, []uint64{pilosa.ShardWidth + 1, pilosa.ShardWidth + 2, (3 * pilosa.ShardWidth) + 4}) {
+			t.Fatalf("unexpected columns: %+v", columns)
+		} else if len(resps[2].Results) != 0 || len(resps[2].ColumnAttrSets) != 2 {
+			t.Fatalf("unexpected final message: %+v", resps[2])
+		}
+	})
+
 	t.Run("Query Pairs JSON", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i0/query", strings.NewReader(`TopN(f0, n=2)`)))
@@ -544,6 +1340,25 @@ func TestHandler_Endpoints(t *testing.T) {
 		}
 	})
 
+	t.Run("Query without index", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/query", strings.NewReader("Row(f0=30)")))
+		if w.Code != gohttp.StatusBadRequest {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		} else if body := w.Body.String(); !strings.Contains(body, "index required") {
+			t.Fatalf("unexpected body: %q", body)
+		}
+
+		w = httptest.NewRecorder()
+		r := test.MustNewHTTPRequest("POST", "/field/f0/import", strings.NewReader(""))
+		r.Header.Set("Content-Type", "application/x-protobuf")
+		r.Header.Set("Accept", "application/x-protobuf")
+		h.ServeHTTP(w, r)
+		if w.Code != gohttp.StatusBadRequest {
+			t.Fatalf("unexpected import status code: %d", w.Code)
+		}
+	})
+
 	t.Run("Query int field unbounded", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		fieldName := "f-int-ubound"
@@ -675,6 +1490,97 @@ func TestHandler_Endpoints(t *testing.T) {
 		}
 	})
 
+	t.Run("index alias", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index-alias/a0", strings.NewReader(`{"index":"nope"}`)))
+		if w.Code != gohttp.StatusNotFound {
+			t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index-alias/a0", strings.NewReader(`{"index":"i0"}`)))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/index-alias", nil))
+		if body := w.Body.String(); body != `{"aliases":{"a0":"i0"}}`+"\n" {
+			t.Fatalf("unexpected body: %s", body)
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i0/query", strings.NewReader("Count(Row(f1=0))")))
+		expected := w.Body.String()
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/a0/query", strings.NewReader("Count(Row(f1=0))")))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+		} else if body := w.Body.String(); body != expected {
+			t.Fatalf("unexpected body: %s, expected: %s", body, expected)
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("DELETE", "/index-alias/a0", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("import session", func(t *testing.T) {
+		if _, err := i0.CreateFieldIfNotExists("sess", pilosa.OptFieldTypeDefault()); err != nil {
+			t.Fatal(err)
+		}
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i0/field/sess/import-session", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+		}
+		var sess pilosa.ImportSession
+		if err := json.NewDecoder(w.Body).Decode(&sess); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := proto.Serializer{}.Marshal(&pilosa.ImportRequest{RowIDs: []uint64{3}, ColumnIDs: []uint64{5}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		w = httptest.NewRecorder()
+		httpReq := test.MustNewHTTPRequest("POST", "/import-session/"+sess.ID+"/chunk/c0", bytes.NewBuffer(data))
+		httpReq.Header.Set("Content-Type", "application/x-protobuf")
+		h.ServeHTTP(w, httpReq)
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("GET", "/import-session/"+sess.ID, nil))
+		if err := json.NewDecoder(w.Body).Decode(&sess); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(sess.Chunks, []string{"c0"}) {
+			t.Fatalf("unexpected session: %+v", sess)
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/import-session/"+sess.ID+"/commit", nil))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i0/query", strings.NewReader("Row(sess=3)")))
+		if body := w.Body.String(); body != `{"results":[{"attrs":{},"columns":[5]}]}`+"\n" {
+			t.Fatalf("unexpected body: %s", body)
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("DELETE", "/import-session/"+sess.ID, nil))
+		if w.Code != gohttp.StatusNotFound {
+			t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+		}
+	})
+
 	t.Run("Field delete", func(t *testing.T) {
 		i := hldr.MustCreateIndexIfNotExists("i", pilosa.IndexOptions{})
 		if _, err := i.CreateFieldIfNotExists("f1", pilosa.OptFieldTypeDefault()); err != nil {
@@ -776,9 +1682,38 @@ func TestHandler_Endpoints(t *testing.T) {
 		version := strings.TrimPrefix(pilosa.Version, "v")
 		if w.Code != gohttp.StatusOK {
 			t.Fatalf("unexpected status code: %d", w.Code)
-		} else if w.Body.String() != `{"version":"`+version+`"}`+"\n" {
+		} else if w.Body.String() != fmt.Sprintf(`{"version":"%s","protocolVersion":%d,"minProtocolVersion":%d}`+"\n", version, pilosa.ProtocolVersion, pilosa.MinProtocolVersion) {
+			t.Fatalf("unexpected body: %q", w.Body.String())
+		} else if v := w.Header().Get(http.ProtocolVersionHeader); v != strconv.Itoa(pilosa.ProtocolVersion) {
+			t.Fatalf("unexpected protocol version header: %q", v)
+		} else if v := w.Header().Get(http.MinProtocolVersionHeader); v != strconv.Itoa(pilosa.MinProtocolVersion) {
+			t.Fatalf("unexpected min protocol version header: %q", v)
+		}
+
+		// Requests from nodes speaking no common version are refused.
+		w = httptest.NewRecorder()
+		r = test.MustNewHTTPRequest("GET", "/version", nil)
+		r.Header.Set(http.MinProtocolVersionHeader, strconv.Itoa(pilosa.ProtocolVersion+1))
+		r.Header.Set(http.ProtocolVersionHeader, strconv.Itoa(pilosa.ProtocolVersion+2))
+		h.ServeHTTP(w, r)
+		if w.Code != gohttp.StatusBadRequest {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		} else if !strings.Contains(w.Body.String(), pilosa.ErrIncompatibleProtocol.Error()) {
 			t.Fatalf("unexpected body: %q", w.Body.String())
 		}
+
+		// Nodes which predate versioning, and newer nodes which still speak
+		// this node's versions, are served.
+		for _, versions := range [][2]string{{"", ""}, {"", "1"}, {strconv.Itoa(pilosa.ProtocolVersion), strconv.Itoa(pilosa.ProtocolVersion + 1)}} {
+			w = httptest.NewRecorder()
+			r = test.MustNewHTTPRequest("GET", "/version", nil)
+			r.Header.Set(http.MinProtocolVersionHeader, versions[0])
+			r.Header.Set(http.ProtocolVersionHeader, versions[1])
+			h.ServeHTTP(w, r)
+			if w.Code != gohttp.StatusOK {
+				t.Fatalf("unexpected status code for versions %v: %d", versions, w.Code)
+			}
+		}
 	})
 
 	t.Run("Fragment Nodes", func(t *testing.T) {
@@ -940,6 +1875,50 @@ func TestHandler_Endpoints(t *testing.T) {
 		}
 	})
 
+	t.Run("index time quantum", func(t *testing.T) {
+		// a time field requires a quantum when the index has no default
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i0/field/tq", strings.NewReader(`{"options":{"type":"time"}}`)))
+		if w.Code != gohttp.StatusBadRequest {
+			t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+		}
+
+		// create index with a default time quantum
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/idx-tq", strings.NewReader(`{"options":{"timeQuantum":"YM"}}`)))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/idx-tq/field/f0", strings.NewReader(`{"options":{"type":"time"}}`)))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+		} else if q := holder.Field("idx-tq", "f0").TimeQuantum(); q != "YM" {
+			t.Fatalf("unexpected time quantum: %q", q)
+		}
+
+		// change the default
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("PATCH", "/index/idx-tq", strings.NewReader(`{"options":{"timeQuantum":"YMDH"}}`)))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+		} else if q := holder.Index("idx-tq").Options().TimeQuantum; q != "YMDH" {
+			t.Fatalf("unexpected index time quantum: %q", q)
+		} else if q := holder.Field("idx-tq", "f0").TimeQuantum(); q != "YM" {
+			t.Fatalf("existing field changed time quantum: %q", q)
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("PATCH", "/index/idx-tq", strings.NewReader(`{"options":{"keys":true}}`)))
+		if w.Code != gohttp.StatusBadRequest {
+			t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("DELETE", "/index/idx-tq", nil))
+	})
+
 	t.Run("translate keys", func(t *testing.T) {
 		// create index
 		w := httptest.NewRecorder()
@@ -1026,6 +2005,294 @@ func TestHandler_Endpoints(t *testing.T) {
 	})
 }
 
+func TestHandler_MaxBodyBytes(t *testing.T) {
+	cluster := test.MustRunCluster(t, 1, []server.CommandOption{
+		func(m *server.Command) error {
+			m.Config.Handler.MaxBodyBytes = 64
+			return nil
+		},
+	})
+	defer cluster.Close()
+	cmd := cluster[0]
+	h := cmd.Handler.(*http.Handler).Handler
+	cmd.MustCreateIndex(t, "i", pilosa.IndexOptions{})
+	cmd.MustCreateField(t, "i", "f")
+
+	ser := proto.Serializer{}
+	for _, tt := range []struct {
+		columns int
+		code    int
+	}{
+		{columns: 1, code: gohttp.StatusOK},
+		{columns: 100, code: gohttp.StatusRequestEntityTooLarge},
+	} {
+		req := &pilosa.ImportRequest{Index: "i", Field: "f"}
+		for i := 0; i < tt.columns; i++ {
+			req.RowIDs = append(req.RowIDs, 1)
+			req.ColumnIDs = append(req.ColumnIDs, uint64(i))
+		}
+		data, err := ser.Marshal(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		w := httptest.NewRecorder()
+		httpReq := test.MustNewHTTPRequest("POST", "/index/i/field/f/import", bytes.NewBuffer(data))
+		httpReq.Header.Set("Content-Type", "application/x-protobuf")
+		httpReq.Header.Set("Accept", "application/x-protobuf")
+		h.ServeHTTP(w, httpReq)
+		if w.Code != tt.code {
+			t.Fatalf("%d columns: expected status %d, got %d: %s", tt.columns, tt.code, w.Code, w.Body.String())
+		} else if tt.code == gohttp.StatusRequestEntityTooLarge && !strings.Contains(w.Body.String(), "maximum of 64 bytes") {
+			t.Fatalf("unexpected error: %s", w.Body.String())
+		}
+	}
+
+	// Other requests are limited too.
+	for _, tt := range []struct {
+		path string
+		body string
+		code int
+	}{
+		{path: "/index/i/query", body: "Count(Row(f=1))", code: gohttp.StatusOK},
+		{path: "/index/i/query", body: strings.Repeat("Count(Row(f=1)) ", 5), code: gohttp.StatusRequestEntityTooLarge},
+		{path: "/index/i/field/g", body: `{"options": {"type": "set", "cacheType": "ranked", "cacheSize": 100000}}`, code: gohttp.StatusRequestEntityTooLarge},
+		{path: "/index-alias/a", body: `{"index": "` + strings.Repeat("i", 64) + `"}`, code: gohttp.StatusRequestEntityTooLarge},
+		{path: "/schema", body: `{"indexes": [{"name": "` + strings.Repeat("j", 64) + `"}]}`, code: gohttp.StatusRequestEntityTooLarge},
+	} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", tt.path, strings.NewReader(tt.body)))
+		if w.Code != tt.code {
+			t.Fatalf("%s: expected status %d, got %d: %s", tt.path, tt.code, w.Code, w.Body.String())
+		} else if tt.code == gohttp.StatusRequestEntityTooLarge && !strings.Contains(w.Body.String(), "maximum of 64 bytes") {
+			t.Fatalf("%s: unexpected error: %s", tt.path, w.Body.String())
+		}
+	}
+}
+
+func TestHandler_ImportStream(t *testing.T) {
+	cluster := test.MustRunCluster(t, 2)
+	defer cluster.Close()
+	cmd := cluster[0]
+	cmd.MustCreateIndex(t, "i", pilosa.IndexOptions{})
+	cmd.MustCreateField(t, "i", "f")
+	cmd.MustCreateField(t, "i", "v", pilosa.OptFieldTypeInt(0, 100))
+
+	ser := proto.Serializer{}
+	delimited := func(m pilosa.Message) []byte {
+		data, err := ser.Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf := make([]byte, binary.MaxVarintLen64)
+		return append(buf[:binary.PutUvarint(buf, uint64(len(data)))], data...)
+	}
+	readAck := func(r *bufio.Reader) pilosa.ImportResponse {
+		t.Helper()
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf := make([]byte, n)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			t.Fatal(err)
+		}
+		var resp pilosa.ImportResponse
+		if err := ser.Unmarshal(buf, &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	// Each batch, spanning several shards, is acknowledged while the stream
+	// is still open, since the server enables full duplex. A batch which
+	// fails ends the stream.
+	pr, pw := io.Pipe()
+	req, err := gohttp.NewRequest("POST", cmd.URL()+"/index/i/field/f/import-stream?ackInterval=1", pr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf; delimited=true")
+	req.Header.Set("Accept", "application/x-protobuf; delimited=true")
+	resps := make(chan *gohttp.Response, 1)
+	go func() {
+		resp, err := gohttp.DefaultClient.Do(req)
+		if err != nil {
+			t.Error(err)
+			pr.Close()
+		}
+		resps <- resp
+	}()
+
+	var columns []uint64
+	for i := uint64(0); i < 6; i++ {
+		columns = append(columns, i*pilosa.ShardWidth+i)
+	}
+	if _, err := pw.Write(delimited(&pilosa.ImportRequest{RowIDs: []uint64{1, 1, 1}, ColumnIDs: columns[:3]})); err != nil {
+		t.Fatal(err)
+	}
+	resp := <-resps
+	if resp == nil {
+		t.FailNow()
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != gohttp.StatusOK {
+		t.Fatalf("unexpected status code: %d", resp.StatusCode)
+	}
+	br := bufio.NewReader(resp.Body)
+	if ack := readAck(br); ack.Batches != 1 || ack.Bits != 3 || ack.Set != 3 || ack.Err != "" {
+		t.Fatalf("unexpected ack: %+v", ack)
+	}
+	if _, err := pw.Write(delimited(&pilosa.ImportRequest{RowIDs: []uint64{1, 1, 1, 1}, ColumnIDs: columns[2:]})); err != nil {
+		t.Fatal(err)
+	}
+	if ack := readAck(br); ack.Batches != 2 || ack.Bits != 7 || ack.Set != 6 {
+		t.Fatalf("unexpected ack: %+v", ack)
+	}
+	if _, err := pw.Write([]byte{2, 0xff, 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	if ack := readAck(br); ack.Batches != 2 || ack.Err == "" {
+		t.Fatalf("unexpected ack: %+v", ack)
+	}
+	pw.Close()
+
+	res := cluster[1].MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: "Row(f=1)"})
+	if cols := res.Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(cols, columns) {
+		t.Fatalf("unexpected columns: %v", cols)
+	}
+
+	// Batches of int fields hold values.
+	h := cmd.Handler.(*http.Handler).Handler
+	w := httptest.NewRecorder()
+	r := test.MustNewHTTPRequest("POST", "/index/i/field/v/import-stream", bytes.NewReader(delimited(&pilosa.ImportValueRequest{ColumnIDs: columns[:2], Values: []int64{10, 20}})))
+	r.Header.Set("Content-Type", "application/x-protobuf; delimited=true")
+	r.Header.Set("Accept", "application/x-protobuf; delimited=true")
+	h.ServeHTTP(w, r)
+	if w.Code != gohttp.StatusOK {
+		t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+	} else if ack := readAck(bufio.NewReader(w.Body)); ack.Batches != 1 || ack.Bits != 2 || ack.Err != "" {
+		t.Fatalf("unexpected ack: %+v", ack)
+	}
+	res = cmd.MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: "Sum(field=v)"})
+	if sum := res.Results[0].(pilosa.ValCount); sum.Val != 30 || sum.Count != 2 {
+		t.Fatalf("unexpected sum: %+v", sum)
+	}
+
+	// Responses which are not full duplex, such as the recorder, are only
+	// written once the stream ends.
+	body := append(delimited(&pilosa.ImportRequest{RowIDs: []uint64{2}, ColumnIDs: columns[:1]}), delimited(&pilosa.ImportRequest{RowIDs: []uint64{2}, ColumnIDs: columns[1:2]})...)
+	w = httptest.NewRecorder()
+	r = test.MustNewHTTPRequest("POST", "/index/i/field/f/import-stream?ackInterval=1", bytes.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-protobuf; delimited=true")
+	r.Header.Set("Accept", "application/x-protobuf; delimited=true")
+	h.ServeHTTP(w, r)
+	br = bufio.NewReader(w.Body)
+	if w.Code != gohttp.StatusOK {
+		t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+	} else if ack := readAck(br); ack.Batches != 2 || ack.Bits != 2 || ack.Err != "" {
+		t.Fatalf("unexpected ack: %+v", ack)
+	} else if _, err := br.Peek(1); err != io.EOF {
+		t.Fatalf("expected a single ack, got %v", err)
+	}
+}
+
+func TestHandler_AutoCreate(t *testing.T) {
+	cluster := test.MustRunCluster(t, 1, []server.CommandOption{
+		func(m *server.Command) error {
+			m.Config.AutoCreate = true
+			return nil
+		},
+	})
+	defer cluster.Close()
+	cmd := cluster[0]
+	h := cmd.Handler.(*http.Handler).Handler
+
+	// Imports to a missing field create it, and tell so.
+	ser := proto.Serializer{}
+	data, err := ser.Marshal(&pilosa.ImportRequest{Index: "i", Field: "f", RowIDs: []uint64{1, 1}, ColumnIDs: []uint64{3, 7}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, exp := range []bool{true, false} {
+		w := httptest.NewRecorder()
+		r := test.MustNewHTTPRequest("POST", "/index/i/field/f/import", bytes.NewBuffer(data))
+		r.Header.Set("Content-Type", "application/x-protobuf")
+		r.Header.Set("Accept", "application/x-protobuf")
+		h.ServeHTTP(w, r)
+		var resp pilosa.ImportResponse
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+		} else if err := ser.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		} else if resp.AutoCreated != exp {
+			t.Fatalf("expected auto-created %t, got %t", exp, resp.AutoCreated)
+		}
+	}
+
+	for query, body := range map[string]string{
+		`Set(10, g="blue")`: `{"results":[true],"autoCreated":true}` + "\n",
+		"Row(f=1)":          `{"results":[{"attrs":{},"columns":[3,7]}]}` + "\n",
+	} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i/query", strings.NewReader(query)))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("%s: unexpected status code: %d %s", query, w.Code, w.Body.String())
+		} else if w.Body.String() != body {
+			t.Fatalf("%s: unexpected body: %s", query, w.Body.String())
+		}
+	}
+	if f := cmd.Server.Holder().Field("i", "g"); f == nil || !f.Options().Keys {
+		t.Fatalf("expected keyed field: %v", f)
+	}
+}
+
+func TestHandler_DefaultIndex(t *testing.T) {
+	cluster := test.MustRunCluster(t, 1, []server.CommandOption{
+		func(m *server.Command) error {
+			m.Config.DefaultIndex = "i"
+			return nil
+		},
+	})
+	defer cluster.Close()
+	cmd := cluster[0]
+	h := cmd.Handler.(*http.Handler).Handler
+	cmd.MustCreateIndex(t, "i", pilosa.IndexOptions{})
+	cmd.MustCreateField(t, "i", "f")
+	cmd.MustCreateIndex(t, "j", pilosa.IndexOptions{})
+	cmd.MustCreateField(t, "j", "f")
+
+	// Imports without an index go to the default one.
+	data, err := proto.Serializer{}.Marshal(&pilosa.ImportRequest{Field: "f", RowIDs: []uint64{1, 1}, ColumnIDs: []uint64{3, 7}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	r := test.MustNewHTTPRequest("POST", "/field/f/import", bytes.NewBuffer(data))
+	r.Header.Set("Content-Type", "application/x-protobuf")
+	r.Header.Set("Accept", "application/x-protobuf")
+	h.ServeHTTP(w, r)
+	if w.Code != gohttp.StatusOK {
+		t.Fatalf("unexpected status code: %d %s", w.Code, w.Body.String())
+	}
+
+	// Queries without an index run against the default one, and an index in
+	// the path overrides it.
+	for path, body := range map[string]string{
+		"/query":         `{"results":[{"attrs":{},"columns":[3,7]}]}` + "\n",
+		"/index/i/query": `{"results":[{"attrs":{},"columns":[3,7]}]}` + "\n",
+		"/index/j/query": `{"results":[{"attrs":{},"columns":[]}]}` + "\n",
+	} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", path, strings.NewReader("Row(f=1)")))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("%s: unexpected status code: %d %s", path, w.Code, w.Body.String())
+		} else if w.Body.String() != body {
+			t.Fatalf("%s: unexpected body: %s", path, w.Body.String())
+		}
+	}
+}
+
 func TestClusterTranslator(t *testing.T) {
 	cluster := make(test.Cluster, 2)
 	cluster[0] = test.NewCommandNode(true)
@@ -0,0 +1,94 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strconv"
+
+	"github.com/pilosa/pilosa/v2"
+	"github.com/pilosa/pilosa/v2/logger"
+	"github.com/pkg/errors"
+)
+
+// getListeners returns n listeners bound to the bind URI, each of which is
+// served by its own accept loop. More than one listener requires
+// SO_REUSEPORT so that the kernel distributes connections between them; on
+// platforms without it a single listener is returned. A positive backlog
+// sets the length of each listener's queue of pending connections in place
+// of the operating system default.
+func getListeners(uri pilosa.URI, tlsconf *tls.Config, n, backlog int, l logger.Logger) ([]net.Listener, error) {
+	if n < 1 {
+		return nil, errors.Errorf("listener count must be at least 1: %d", n)
+	} else if backlog < 0 {
+		return nil, errors.Errorf("listener backlog must not be negative: %d", backlog)
+	}
+	if n > 1 && !reusePortSupported {
+		l.Printf("SO_REUSEPORT is not supported on this platform, using a single listener instead of %d", n)
+		n = 1
+	}
+	if backlog > 0 && !backlogSupported {
+		l.Printf("setting the listener backlog is not supported on this platform, using the default")
+		backlog = 0
+	}
+	if n == 1 && backlog == 0 {
+		ln, err := getListener(uri, tlsconf)
+		if err != nil {
+			return nil, err
+		}
+		return []net.Listener{ln}, nil
+	}
+	if uri.Scheme != "http" && !(uri.Scheme == "https" && tlsconf != nil) {
+		return nil, errors.Errorf("unsupported scheme: %s", uri.Scheme)
+	}
+
+	var lc net.ListenConfig
+	if n > 1 {
+		lc.Control = reusePort
+	}
+	lns := make([]net.Listener, 0, n)
+	closeAll := func() {
+		for _, ln := range lns {
+			ln.Close()
+		}
+	}
+	addr := uri.HostPort()
+	for i := 0; i < n; i++ {
+		ln, err := lc.Listen(context.Background(), "tcp", addr)
+		if err != nil {
+			closeAll()
+			return nil, errors.Wrap(err, "net.Listen")
+		}
+		if backlog > 0 {
+			if err := setBacklog(ln.(*net.TCPListener), backlog); err != nil {
+				ln.Close()
+				closeAll()
+				return nil, errors.Wrap(err, "setting listener backlog")
+			}
+		}
+		// Bind the remaining listeners to the port the first one was given,
+		// in case it was allocated automatically.
+		if i == 0 {
+			addr = net.JoinHostPort(uri.Host, strconv.Itoa(ln.Addr().(*net.TCPAddr).Port))
+		}
+		if uri.Scheme == "https" {
+			ln = tls.NewListener(ln, tlsconf)
+		}
+		lns = append(lns, ln)
+	}
+	return lns, nil
+}
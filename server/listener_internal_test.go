@@ -0,0 +1,90 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net"
+	"testing"
+
+	"github.com/pilosa/pilosa/v2"
+	"github.com/pilosa/pilosa/v2/logger"
+)
+
+func TestGetListeners(t *testing.T) {
+	uri, err := pilosa.AddressWithDefaults("http://localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := getListeners(*uri, nil, 0, 0, logger.NopLogger); err == nil {
+		t.Fatal("expected error for zero listeners")
+	} else if _, err := getListeners(*uri, nil, 1, -1, logger.NopLogger); err == nil {
+		t.Fatal("expected error for negative backlog")
+	}
+
+	lns, err := getListeners(*uri, nil, 3, 0, logger.NopLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		for _, ln := range lns {
+			ln.Close()
+		}
+	}()
+
+	if !reusePortSupported {
+		if len(lns) != 1 {
+			t.Fatalf("expected a single listener, got %d", len(lns))
+		}
+		return
+	} else if len(lns) != 3 {
+		t.Fatalf("expected 3 listeners, got %d", len(lns))
+	}
+
+	// All listeners share the automatically allocated port.
+	port := lns[0].Addr().(*net.TCPAddr).Port
+	for _, ln := range lns[1:] {
+		if p := ln.Addr().(*net.TCPAddr).Port; p != port {
+			t.Fatalf("expected port %d, got %d", port, p)
+		}
+	}
+}
+
+func TestGetListeners_Backlog(t *testing.T) {
+	uri, err := pilosa.AddressWithDefaults("http://localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lns, err := getListeners(*uri, nil, 1, 16, logger.NopLogger)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(lns) != 1 {
+		t.Fatalf("expected a single listener, got %d", len(lns))
+	}
+	defer lns[0].Close()
+
+	// The listener still accepts connections after its backlog is set.
+	go func() {
+		if conn, err := lns[0].Accept(); err == nil {
+			conn.Close()
+		}
+	}()
+	conn, err := net.Dial("tcp", lns[0].Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+}
@@ -0,0 +1,209 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+)
+
+// reloadableSetting is a setting which can be changed without a restart.
+type reloadableSetting struct {
+	// validate checks the new value before any setting is applied.
+	validate func(c *Config) error
+	// apply makes the running server use the value from c.
+	apply func(m *Command, c *Config)
+}
+
+// reloadableSettings holds the settings, keyed by their name in the config
+// file, which Reload applies. All other settings require a restart.
+var reloadableSettings = map[string]reloadableSetting{
+	"verbose": {
+		apply: func(m *Command, c *Config) {
+			m.Config.Verbose = c.Verbose
+			m.logger.SetVerbose(c.Verbose)
+		},
+	},
+	"cluster.long-query-time": {
+		validate: func(c *Config) error {
+			if c.Cluster.LongQueryTime < 0 {
+				return errors.New("long-query-time must not be negative")
+			}
+			return nil
+		},
+		apply: func(m *Command, c *Config) {
+			m.Config.Cluster.LongQueryTime = c.Cluster.LongQueryTime
+			m.Server.SetLongQueryTime(time.Duration(c.Cluster.LongQueryTime))
+		},
+	},
+	"max-writes-per-request": {
+		validate: func(c *Config) error {
+			if c.MaxWritesPerRequest < 0 {
+				return errors.New("max-writes-per-request must not be negative")
+			}
+			return nil
+		},
+		apply: func(m *Command, c *Config) {
+			m.Config.MaxWritesPerRequest = c.MaxWritesPerRequest
+			m.Server.SetMaxWritesPerRequest(c.MaxWritesPerRequest)
+		},
+	},
+	"anti-entropy.interval": {
+		validate: func(c *Config) error {
+			if c.AntiEntropy.Interval < 0 {
+				return errors.New("anti-entropy interval must not be negative")
+			}
+			return nil
+		},
+		apply: func(m *Command, c *Config) {
+			m.Config.AntiEntropy.Interval = c.AntiEntropy.Interval
+			m.Server.SetAntiEntropyInterval(time.Duration(c.AntiEntropy.Interval))
+		},
+	},
+	"profile.block-rate": {
+		apply: func(m *Command, c *Config) {
+			m.Config.Profile.BlockRate = c.Profile.BlockRate
+			runtime.SetBlockProfileRate(c.Profile.BlockRate)
+		},
+	},
+	"profile.mutex-fraction": {
+		apply: func(m *Command, c *Config) {
+			m.Config.Profile.MutexFraction = c.Profile.MutexFraction
+			runtime.SetMutexProfileFraction(c.Profile.MutexFraction)
+		},
+	},
+}
+
+// Reload reads the configuration with ReloadConfig and applies the settings
+// which can be changed at runtime. Changes to any other setting are logged and
+// ignored until the next restart. If the new configuration cannot be read or
+// is invalid the running configuration is left untouched.
+func (m *Command) Reload() error {
+	if m.ReloadConfig == nil {
+		return errors.New("config reload is not supported")
+	}
+	c, err := m.ReloadConfig()
+	if err != nil {
+		return errors.Wrap(err, "reading config")
+	}
+
+	applied, ignored, err := m.applyConfig(c)
+	if err != nil {
+		return errors.Wrap(err, "applying config")
+	}
+	for _, key := range applied {
+		m.logger.Printf("config reload: applied %s", key)
+	}
+	for _, key := range ignored {
+		m.logger.Printf("config reload: ignored %s, it requires a restart", key)
+	}
+	if len(applied) == 0 && len(ignored) == 0 {
+		m.logger.Printf("config reload: no settings changed")
+	}
+	return nil
+}
+
+// applyConfig applies the reloadable settings which differ between c and the
+// running config. It returns the changed keys which were applied and those
+// which were ignored because they require a restart. Nothing is applied if
+// any of the changed settings is invalid.
+func (m *Command) applyConfig(c *Config) (applied, ignored []string, err error) {
+	changed, err := configDiff(m.Config, c)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "comparing config")
+	}
+
+	for _, key := range changed {
+		setting, ok := reloadableSettings[key]
+		if !ok {
+			ignored = append(ignored, key)
+			continue
+		}
+		if setting.validate != nil {
+			if err := setting.validate(c); err != nil {
+				return nil, nil, errors.Wrap(err, key)
+			}
+		}
+		applied = append(applied, key)
+	}
+
+	for _, key := range applied {
+		reloadableSettings[key].apply(m, c)
+	}
+	return applied, ignored, nil
+}
+
+// configDiff returns the sorted config file keys whose values differ between
+// a and b.
+func configDiff(a, b *Config) ([]string, error) {
+	am, err := flattenConfig(a)
+	if err != nil {
+		return nil, err
+	}
+	bm, err := flattenConfig(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for k, v := range am {
+		if !reflect.DeepEqual(v, bm[k]) {
+			keys = append(keys, k)
+		}
+	}
+	for k := range bm {
+		if _, ok := am[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// flattenConfig returns the values of c keyed by their dotted names in the
+// config file.
+func flattenConfig(c *Config) (map[string]interface{}, error) {
+	buf, err := toml.Marshal(*c)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling config")
+	}
+	tree, err := toml.LoadBytes(buf)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading config")
+	}
+
+	m := make(map[string]interface{})
+	var flatten func(prefix string, v map[string]interface{})
+	flatten = func(prefix string, v map[string]interface{}) {
+		for k, v := range v {
+			if prefix != "" {
+				k = fmt.Sprintf("%s.%s", prefix, k)
+			}
+			if sub, ok := v.(map[string]interface{}); ok {
+				flatten(k, sub)
+			} else {
+				m[k] = v
+			}
+		}
+	}
+	flatten("", tree.ToMap())
+	return m, nil
+}
@@ -25,6 +25,7 @@ import (
 	"crypto/tls"
 	"io"
 	"log"
+	"math"
 	"math/rand"
 	"net"
 	"os"
@@ -48,12 +49,14 @@ import (
 	"github.com/pilosa/pilosa/v2/stats"
 	"github.com/pilosa/pilosa/v2/statsd"
 	"github.com/pilosa/pilosa/v2/syswrap"
+	"github.com/pilosa/pilosa/v2/toml"
 	"github.com/pkg/errors"
 )
 
 type loggerLogger interface {
 	logger.Logger
 	Logger() *log.Logger
+	SetVerbose(verbose bool)
 }
 
 // Command represents the state of the pilosa server command.
@@ -63,6 +66,11 @@ type Command struct {
 	// Configuration.
 	Config *Config
 
+	// ReloadConfig, if set, returns the current configuration. It is called
+	// when the process receives SIGHUP, after which the settings which can be
+	// changed at runtime are applied. See Reload.
+	ReloadConfig func() (*Config, error)
+
 	// Gossip transport
 	gossipTransport *gossip.Transport
 	gossipMemberSet io.Closer
@@ -79,9 +87,13 @@ type Command struct {
 	logOutput io.Writer
 	logger    loggerLogger
 
+	// logTail keeps the recent log lines if log tailing is enabled.
+	logTail *logger.LogTail
+
 	Handler      pilosa.Handler
 	API          *pilosa.API
-	ln           net.Listener
+	lns          []net.Listener
+	adminLn      net.Listener
 	listenURI    *pilosa.URI
 	closeTimeout time.Duration
 
@@ -200,21 +212,34 @@ func (m *Command) UpAndDown() (err error) {
 	return nil
 }
 
-// Wait waits for the server to be closed or interrupted.
+// Wait waits for the server to be closed or interrupted. SIGHUP reloads the
+// configuration while waiting.
 func (m *Command) Wait() error {
+	// SIGHUP reloads the settings which can be changed at runtime.
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
 	// First SIGKILL causes server to shut down gracefully.
 	c := make(chan os.Signal, 2)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-	select {
-	case sig := <-c:
-		m.logger.Printf("received signal '%s', gracefully shutting down...\n", sig.String())
-
-		// Second signal causes a hard shutdown.
-		go func() { <-c; os.Exit(1) }()
-		return errors.Wrap(m.Close(), "closing command")
-	case <-m.done:
-		m.logger.Printf("server closed externally")
-		return nil
+	for {
+		select {
+		case <-hup:
+			m.logger.Printf("received signal 'hangup', reloading config")
+			if err := m.Reload(); err != nil {
+				m.logger.Printf("config reload failed, keeping running config: %v", err)
+			}
+		case sig := <-c:
+			m.logger.Printf("received signal '%s', gracefully shutting down...\n", sig.String())
+
+			// Second signal causes a hard shutdown.
+			go func() { <-c; os.Exit(1) }()
+			return errors.Wrap(m.Close(), "closing command")
+		case <-m.done:
+			m.logger.Printf("server closed externally")
+			return nil
+		}
 	}
 }
 
@@ -230,6 +255,22 @@ func (m *Command) SetupServer() error {
 	if err != nil {
 		return errors.Wrap(err, "setting up logger")
 	}
+	if m.Config.LogTailLines > 0 {
+		m.logTail = logger.NewLogTail(m.Config.LogTailLines)
+		m.logger = logger.NewLevelLogger(m.logWriter(), m.Config.Verbose)
+	}
+
+	dirPerm, filePerm := pilosa.DefaultDirPerm, pilosa.DefaultFilePerm
+	if m.Config.DirPerm != "" {
+		if dirPerm, err = parsePerm(m.Config.DirPerm); err != nil {
+			return errors.Wrap(err, "parsing dir-perm")
+		}
+	}
+	if m.Config.FilePerm != "" {
+		if filePerm, err = parsePerm(m.Config.FilePerm); err != nil {
+			return errors.Wrap(err, "parsing file-perm")
+		}
+	}
 
 	productName := "Pilosa"
 	if pilosa.EnterpriseEnabled {
@@ -270,19 +311,25 @@ func (m *Command) SetupServer() error {
 		return errors.Wrap(err, "new stats client")
 	}
 
-	m.ln, err = getListener(*uri, TLSConfig)
+	m.lns, err = getListeners(*uri, TLSConfig, m.Config.Handler.ListenerCount, m.Config.Handler.ListenerBacklog, m.logger)
 	if err != nil {
 		return errors.Wrap(err, "getting listener")
 	}
 
 	// If port is 0, get auto-allocated port from listener
 	if uri.Port == 0 {
-		uri.SetPort(uint16(m.ln.Addr().(*net.TCPAddr).Port))
+		uri.SetPort(uint16(m.lns[0].Addr().(*net.TCPAddr).Port))
 	}
 
 	// Save listenURI for later reference.
 	m.listenURI = uri
 
+	if m.Config.Admin.Port != 0 {
+		if m.adminLn, err = m.adminListener(*uri, TLSConfig); err != nil {
+			return errors.Wrap(err, "getting admin listener")
+		}
+	}
+
 	c := http.GetHTTPClient(TLSConfig)
 
 	// Get advertise address as uri.
@@ -305,28 +352,87 @@ func (m *Command) SetupServer() error {
 		coordinatorOpt = pilosa.OptServerIsCoordinator(true)
 	}
 
+	breaker := pilosa.NewCircuitBreaker(m.Config.Cluster.BreakerThreshold, time.Duration(m.Config.Cluster.BreakerCooldown))
+
 	serverOptions := []pilosa.ServerOption{
 		pilosa.OptServerAntiEntropyInterval(time.Duration(m.Config.AntiEntropy.Interval)),
+		pilosa.OptServerAntiEntropyChecksum(m.Config.AntiEntropy.Checksum),
+		pilosa.OptServerAntiEntropyQueue(m.Config.AntiEntropy.QueueSize, m.Config.AntiEntropy.QueuePolicy, time.Duration(m.Config.AntiEntropy.QueueTimeout)),
 		pilosa.OptServerLongQueryTime(time.Duration(m.Config.Cluster.LongQueryTime)),
+		pilosa.OptServerDeadRoutingDelay(time.Duration(m.Config.Gossip.DeadRoutingDelay)),
 		pilosa.OptServerDataDir(m.Config.DataDir),
 		pilosa.OptServerReplicaN(m.Config.Cluster.ReplicaN),
+		pilosa.OptServerSelfHealThreshold(m.Config.Cluster.SelfHealThreshold),
+		pilosa.OptServerClockSkewThreshold(time.Duration(m.Config.Cluster.ClockSkewThreshold)),
+		pilosa.OptServerOwnerChangeRetries(m.Config.Cluster.OwnerChangeRetries),
+		pilosa.OptServerOwnerChangeBackoff(time.Duration(m.Config.Cluster.OwnerChangeBackoff)),
+		pilosa.OptServerOwnerChangeMaxBackoff(time.Duration(m.Config.Cluster.OwnerChangeMaxBackoff)),
+		pilosa.OptServerReplicationUpstream(m.Config.Replication.Upstream, time.Duration(m.Config.Replication.Interval)),
 		pilosa.OptServerMaxWritesPerRequest(m.Config.MaxWritesPerRequest),
 		pilosa.OptServerMetricInterval(time.Duration(m.Config.Metric.PollInterval)),
+		pilosa.OptServerUsageInterval(time.Duration(m.Config.Metric.UsageInterval)),
 		pilosa.OptServerDiagnosticsInterval(diagnosticsInterval),
 		pilosa.OptServerExecutorPoolSize(m.Config.WorkerPoolSize),
-		pilosa.OptServerOpenTranslateStore(boltdb.OpenTranslateStore),
+		pilosa.OptServerOpenTranslateStore(boltdb.OpenTranslateStoreWithPerm(dirPerm, filePerm)),
 		pilosa.OptServerOpenTranslateReader(http.GetOpenTranslateReaderFunc(c)),
 		pilosa.OptServerLogger(m.logger),
-		pilosa.OptServerAttrStoreFunc(boltdb.NewAttrStore),
+		pilosa.OptServerAttrStoreFunc(boltdb.NewAttrStoreWithPerm(filePerm)),
 		pilosa.OptServerSystemInfo(gopsutil.NewSystemInfo()),
 		pilosa.OptServerGCNotifier(gcnotify.NewActiveGCNotifier()),
 		pilosa.OptServerStatsClient(statsClient),
 		pilosa.OptServerURI(advertiseURI),
-		pilosa.OptServerInternalClient(http.NewInternalClientFromURI(uri, c)),
+		pilosa.OptServerZone(m.Config.Node.Zone),
+		pilosa.OptServerInternalClient(http.NewInternalClientFromURI(uri, c, http.OptInternalClientCircuitBreaker(breaker))),
+		pilosa.OptServerCircuitBreaker(breaker),
+		pilosa.OptServerRequireQuorumOnStart(m.Config.Cluster.RequireQuorumOnStart, time.Duration(m.Config.Cluster.QuorumTimeout)),
 		pilosa.OptServerClusterDisabled(m.Config.Cluster.Disabled, m.Config.Cluster.Hosts),
+		pilosa.OptServerSkipCorruptFragments(m.Config.Index.SkipCorruptFragments),
+		pilosa.OptServerFlushInterval(time.Duration(m.Config.Index.FlushInterval)),
+		pilosa.OptServerFsyncOnFlush(m.Config.Index.FsyncOnFlush),
+		pilosa.OptServerTombstoneGracePeriod(time.Duration(m.Config.Index.TombstoneGracePeriod)),
+		pilosa.OptServerTombstoneReapInterval(time.Duration(m.Config.Index.TombstoneReapInterval)),
+		pilosa.OptServerCompactionThreshold(m.Config.Index.CompactionThreshold),
+		pilosa.OptServerCompactionInterval(time.Duration(m.Config.Index.CompactionInterval)),
+		pilosa.OptServerQueryCache(m.Config.Query.Cache.Size, time.Duration(m.Config.Query.Cache.TTL)),
 		pilosa.OptServerSerializer(proto.Serializer{}),
 		coordinatorOpt,
 	}
+	if m.Config.Cluster.BootstrapTopology != "" {
+		topology, err := readClusterTopology(m.Config.Cluster.BootstrapTopology)
+		if err != nil {
+			return errors.Wrap(err, "reading bootstrap topology")
+		}
+		serverOptions = append(serverOptions, pilosa.OptServerBootstrapTopology(topology))
+	}
+	if m.Config.DirPerm != "" {
+		serverOptions = append(serverOptions, pilosa.OptServerDirPerm(dirPerm))
+	}
+	if m.Config.FilePerm != "" {
+		serverOptions = append(serverOptions, pilosa.OptServerFilePerm(filePerm))
+	}
+	if m.Config.Query.SafeMode.Enabled {
+		serverOptions = append(serverOptions, pilosa.OptServerQuerySafeMode(m.Config.Query.SafeMode.MaxShards, m.Config.Query.SafeMode.MaxRows))
+	}
+	if m.Config.ChangeFeed.BufferSize > 0 {
+		serverOptions = append(serverOptions, pilosa.OptServerChangeFeedBufferSize(m.Config.ChangeFeed.BufferSize))
+	}
+	if m.Config.Warmup.Path != "" {
+		serverOptions = append(serverOptions, pilosa.OptServerWarmup(m.Config.Warmup.Path, m.Config.Warmup.SampleRate, m.Config.Warmup.MaxQueries, time.Duration(m.Config.Warmup.Timeout)))
+	}
+	serverOptions = append(serverOptions, pilosa.OptServerQueryLimits(m.Config.Query.MaxDepth, m.Config.Query.MaxOps))
+	if m.Config.Query.PartialResults {
+		serverOptions = append(serverOptions, pilosa.OptServerQueryPartialResults(true))
+	}
+	if m.Config.Query.MaxResultRows > 0 {
+		serverOptions = append(serverOptions, pilosa.OptServerQueryMaxResultRows(m.Config.Query.MaxResultRows))
+	}
+	if m.Config.AutoCreate {
+		serverOptions = append(serverOptions, pilosa.OptServerAutoCreate(m.Config.AutoCreateFieldType))
+	}
+	if m.Config.MaxMemory > 0 {
+		serverOptions = append(serverOptions, pilosa.OptServerMaxMemory(m.Config.MaxMemory, m.Config.Memory.HighWatermark, m.Config.Memory.LowWatermark, time.Duration(m.Config.Memory.CheckInterval)))
+	}
+	serverOptions = append(serverOptions, pilosa.OptServerLimits(m.Config.Limits.MaxIndexes, m.Config.Limits.MaxFieldsPerIndex, m.Config.Limits.MaxOpenFiles))
 
 	serverOptions = append(serverOptions, m.serverOptions...)
 
@@ -344,16 +450,56 @@ func (m *Command) SetupServer() error {
 		return errors.Wrap(err, "new api")
 	}
 
+	// The listener timeout of the config applies unless the command was
+	// given its own.
+	closeTimeout := m.closeTimeout
+	if closeTimeout == 0 {
+		closeTimeout = time.Duration(m.Config.Shutdown.ListenerTimeout)
+	}
 	m.Handler, err = http.NewHandler(
 		http.OptHandlerAllowedOrigins(m.Config.Handler.AllowedOrigins),
 		http.OptHandlerAPI(m.API),
 		http.OptHandlerLogger(m.logger),
-		http.OptHandlerListener(m.ln),
-		http.OptHandlerCloseTimeout(m.closeTimeout),
+		http.OptHandlerListener(m.lns...),
+		http.OptHandlerCloseTimeout(closeTimeout),
+		http.OptHandlerMaxBodyBytes(m.Config.Handler.MaxBodyBytes),
+		http.OptHandlerDefaultIndex(m.Config.DefaultIndex),
+		http.OptHandlerDiagnostics(m.Config.redacted(), m.Config.LogPath),
+		http.OptHandlerLogTail(m.logTail),
+		http.OptHandlerAdminListener(m.adminLn),
 	)
 	return errors.Wrap(err, "new handler")
 }
 
+// adminListener returns the listener of the admin port, on the host of uri.
+// If the admin TLS configuration has a certificate, it serves HTTPS with it
+// whatever the scheme of the main port. Otherwise it uses the scheme and TLS
+// configuration tlsconf of the main port.
+func (m *Command) adminListener(uri pilosa.URI, tlsconf *tls.Config) (net.Listener, error) {
+	if m.Config.Admin.Port < 0 || m.Config.Admin.Port > math.MaxUint16 {
+		return nil, errors.Errorf("invalid admin port: %d", m.Config.Admin.Port)
+	}
+	uri.SetPort(uint16(m.Config.Admin.Port))
+	if m.Config.Admin.TLS.CertificatePath != "" {
+		var err error
+		uri.Scheme = "https"
+		if tlsconf, err = GetTLSConfig(&m.Config.Admin.TLS, m.logger.Logger()); err != nil {
+			return nil, errors.Wrap(err, "get admin tls config")
+		}
+	}
+	return getListener(uri, tlsconf)
+}
+
+// readClusterTopology reads the topology document at path.
+func readClusterTopology(path string) (*pilosa.ClusterTopology, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening file")
+	}
+	defer f.Close()
+	return pilosa.DecodeClusterTopology(f)
+}
+
 // setupNetworking sets up internode communication based on the configuration.
 func (m *Command) setupNetworking() error {
 	if m.Config.Cluster.Disabled {
@@ -375,7 +521,7 @@ func (m *Command) setupNetworking() error {
 	gossipMemberSet, err := gossip.NewMemberSet(
 		m.Config.Gossip,
 		m.API,
-		gossip.WithLogOutput(&filteredWriter{logOutput: m.logOutput, v: m.Config.Verbose}),
+		gossip.WithLogOutput(&filteredWriter{logOutput: m.logWriter(), v: m.Config.Verbose}),
 		gossip.WithPilosaLogger(m.logger),
 		gossip.WithTransport(m.gossipTransport),
 	)
@@ -394,11 +540,17 @@ func (m *Command) GossipTransport() *gossip.Transport {
 	return m.gossipTransport
 }
 
-// Close shuts down the server.
+// Close shuts down the server in stages: it drains the writes, then the
+// queries, flushes the fragments, closes the listeners and finally closes
+// everything else.
 func (m *Command) Close() error {
 	defer close(m.done)
+	m.drain()
+
+	m.logger.Printf("shutdown: closing listeners")
+	errh := m.Handler.Close()
+
 	eg := errgroup.Group{}
-	eg.Go(m.Handler.Close)
 	eg.Go(m.Server.Close)
 	eg.Go(m.API.Close)
 	if m.gossipMemberSet != nil {
@@ -412,9 +564,40 @@ func (m *Command) Close() error {
 	}
 
 	err := eg.Wait()
+	if err == nil {
+		err = errh
+	}
 	return errors.Wrap(err, "closing everything")
 }
 
+// drain runs the first stages of the shutdown. It refuses new writes and
+// waits for those in flight, then does the same for queries, and flushes the
+// fragments, so that no acknowledged write is lost in a buffer. A stage
+// which does not complete within its timeout is logged and forced: queries
+// still running are cancelled, and the shutdown moves on.
+func (m *Command) drain() {
+	stage := func(name string, timeout toml.Duration, fn func(context.Context) error) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout))
+		defer cancel()
+		start := time.Now()
+		if err := fn(ctx); err != nil {
+			m.logger.Printf("shutdown: %s timed out after %s, forcing: %v", name, timeout, err)
+			return
+		}
+		m.logger.Printf("shutdown: %s done in %s", name, time.Since(start))
+	}
+
+	m.logger.Printf("shutdown: refusing new writes")
+	m.API.StopWrites()
+	stage("draining writes", m.Config.Shutdown.WriteTimeout, m.API.DrainWrites)
+
+	m.logger.Printf("shutdown: refusing new queries")
+	m.API.StopQueries()
+	stage("draining queries", m.Config.Shutdown.QueryTimeout, m.API.DrainQueries)
+
+	stage("flushing fragments", m.Config.Shutdown.FlushTimeout, m.API.FlushFragments)
+}
+
 // newStatsClient creates a stats client from the config
 func newStatsClient(name string, host string) (stats.StatsClient, error) {
 	switch name {
@@ -452,6 +635,15 @@ func getListener(uri pilosa.URI, tlsconf *tls.Config) (ln net.Listener, err erro
 	return ln, nil
 }
 
+// logWriter returns the writer to which logs are written: the log output, and
+// the log tail if it is enabled.
+func (m *Command) logWriter() io.Writer {
+	if m.logTail == nil {
+		return m.logOutput
+	}
+	return io.MultiWriter(m.logOutput, m.logTail)
+}
+
 type filteredWriter struct {
 	v         bool
 	logOutput io.Writer
@@ -16,12 +16,15 @@ package server_test
 
 import (
 	"bytes"
+	"crypto/tls"
 	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
+	"net"
+	gohttp "net/http"
 	"reflect"
 	"sort"
 	"strconv"
@@ -985,3 +988,87 @@ func TestClusterExhaustingConnectionsImport(t *testing.T) {
 		t.Fatalf("setting lots of shards: %v", err)
 	}
 }
+
+// freePort returns a port which is free to listen on.
+func freePort(t *testing.T) int {
+	ln, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+// Ensure the admin endpoints are only served on the admin port when one is
+// configured, and that cluster-wide anti-entropy syncs still reach the other
+// nodes through their main port.
+func TestMain_AdminPort(t *testing.T) {
+	cluster := test.MustNewCluster(t, 2)
+	ports := make([]int, len(cluster))
+	for i, c := range cluster {
+		ports[i] = freePort(t)
+		c.Config.Admin.Port = ports[i]
+	}
+	if err := cluster.Start(); err != nil {
+		t.Fatalf("starting cluster: %v", err)
+	}
+	defer cluster.Close()
+	cluster.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+
+	status := func(method, url string) int {
+		req, err := gohttp.NewRequest(method, url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Accept", "application/json")
+		resp, err := gohttp.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	main := cluster[0].URL()
+	admin := fmt.Sprintf("http://localhost:%d", ports[0])
+
+	if code := status("GET", main+"/status"); code != gohttp.StatusOK {
+		t.Fatalf("main /status: %d", code)
+	} else if code := status("GET", main+"/cluster/shard-distribution"); code != gohttp.StatusNotFound {
+		t.Fatalf("main /cluster/shard-distribution: %d", code)
+	} else if code := status("GET", admin+"/status"); code != gohttp.StatusNotFound {
+		t.Fatalf("admin /status: %d", code)
+	} else if code := status("GET", admin+"/cluster/shard-distribution"); code != gohttp.StatusOK {
+		t.Fatalf("admin /cluster/shard-distribution: %d", code)
+	} else if code := status("POST", admin+"/cluster/anti-entropy/sync?index=i"); code != gohttp.StatusOK {
+		t.Fatalf("admin /cluster/anti-entropy/sync: %d", code)
+	}
+}
+
+// Ensure the admin port serves HTTPS with its own TLS configuration when the
+// main port serves HTTP.
+func TestMain_AdminPortTLS(t *testing.T) {
+	cluster := test.MustNewCluster(t, 1)
+	port := freePort(t)
+	c := cluster[0]
+	c.Config.Admin.Port = port
+	c.Config.Admin.TLS.CertificatePath = "./testdata/certs/localhost.crt"
+	c.Config.Admin.TLS.CertificateKeyPath = "./testdata/certs/localhost.key"
+	if err := cluster.Start(); err != nil {
+		t.Fatalf("starting cluster: %v", err)
+	}
+	defer cluster.Close()
+
+	client := &gohttp.Client{Transport: &gohttp.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}}
+	resp, err := client.Get(fmt.Sprintf("https://localhost:%d/cluster/shard-distribution", port))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != gohttp.StatusOK {
+		t.Fatalf("admin /cluster/shard-distribution: %d", resp.StatusCode)
+	} else if !strings.HasPrefix(c.URL(), "http://") {
+		t.Fatalf("unexpected main URL: %s", c.URL())
+	}
+}
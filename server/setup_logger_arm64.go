@@ -27,7 +27,16 @@ func (m *Command) setupLogger() error {
 	if m.Config.LogPath == "" {
 		m.logOutput = m.Stderr
 	} else {
-		f, err := os.OpenFile(m.Config.LogPath, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+		// The log file is only readable by its owner unless a mode is
+		// configured.
+		perm := os.FileMode(0600)
+		if m.Config.FilePerm != "" {
+			var err error
+			if perm, err = parsePerm(m.Config.FilePerm); err != nil {
+				return errors.Wrap(err, "parsing file-perm")
+			}
+		}
+		f, err := os.OpenFile(m.Config.LogPath, os.O_RDWR|os.O_CREATE|os.O_APPEND, perm)
 		if err != nil {
 			return errors.Wrap(err, "opening file")
 		}
@@ -38,10 +47,6 @@ func (m *Command) setupLogger() error {
 		}
 	}
 
-	if m.Config.Verbose {
-		m.logger = logger.NewVerboseLogger(m.logOutput)
-	} else {
-		m.logger = logger.NewStandardLogger(m.logOutput)
-	}
+	m.logger = logger.NewLevelLogger(m.logOutput, m.Config.Verbose)
 	return nil
 }
@@ -0,0 +1,124 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrShuttingDown is returned for the writes, or queries, which a node
+// refuses once it started shutting down.
+var ErrShuttingDown = errors.New("node is shutting down")
+
+// drainGroup counts the requests of a kind in flight, so that a node
+// shutting down can stop accepting new ones and wait for the others to
+// complete.
+type drainGroup struct {
+	mu      sync.Mutex
+	n       int
+	stopped bool
+	idle    chan struct{} // closed once stopped with none in flight
+}
+
+// enter registers a request, returning ErrShuttingDown once the group is
+// stopped. The request must leave the group once it completes.
+func (g *drainGroup) enter() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.stopped {
+		return ErrShuttingDown
+	}
+	g.n++
+	return nil
+}
+
+// leave unregisters a request registered by enter.
+func (g *drainGroup) leave() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.n--
+	if g.stopped && g.n == 0 {
+		close(g.idle)
+	}
+}
+
+// stop makes enter refuse new requests.
+func (g *drainGroup) stop() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.stopped {
+		return
+	}
+	g.stopped = true
+	g.idle = make(chan struct{})
+	if g.n == 0 {
+		close(g.idle)
+	}
+}
+
+// wait waits, once the group is stopped, for the requests in flight to
+// complete or ctx to be done.
+func (g *drainGroup) wait(ctx context.Context) error {
+	g.mu.Lock()
+	idle := g.idle
+	g.mu.Unlock()
+	if idle == nil {
+		return errors.New("drain group not stopped")
+	}
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// StopWrites makes the node refuse new writes with ErrShuttingDown. It is the
+// first stage of the shutdown of a node.
+func (api *API) StopWrites() {
+	api.writes.stop()
+}
+
+// DrainWrites waits for the writes in flight when StopWrites was called to
+// complete, or ctx to be done.
+func (api *API) DrainWrites(ctx context.Context) error {
+	return errors.Wrap(api.writes.wait(ctx), "draining writes")
+}
+
+// StopQueries makes the node refuse new read queries with ErrShuttingDown.
+func (api *API) StopQueries() {
+	api.reads.stop()
+}
+
+// DrainQueries waits for the read queries in flight when StopQueries was
+// called to complete. If ctx is done first, the queries still running are
+// cancelled.
+func (api *API) DrainQueries(ctx context.Context) error {
+	err := api.reads.wait(ctx)
+	if err != nil {
+		api.queries.cancelAll()
+	}
+	return errors.Wrap(err, "draining queries")
+}
+
+// FlushFragments flushes every fragment of the node to disk. If ctx is done
+// first, it returns once the fragment being flushed is, leaving the others
+// to be flushed as they are closed.
+func (api *API) FlushFragments(ctx context.Context) error {
+	return errors.Wrap(api.holder.flushContext(ctx), "flushing fragments")
+}
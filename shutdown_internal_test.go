@@ -0,0 +1,72 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestDrainGroup(t *testing.T) {
+	var g drainGroup
+	if err := g.enter(); err != nil {
+		t.Fatal(err)
+	}
+	g.stop()
+	if err := g.enter(); err != ErrShuttingDown {
+		t.Fatalf("expected shutting down, got %v", err)
+	}
+
+	// The request in flight holds the drain until it leaves, or the wait
+	// times out.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := g.wait(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	g.leave()
+	if err := g.wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAPI_FlushFragments(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+	h.SetBit("i", "f", 1, 10)
+	frag := h.fragment("i", "f", viewStandard, 0)
+	api := &API{holder: h.Holder}
+
+	// Flushing stops, rather than continuing in the background, once ctx
+	// is done.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := api.FlushFragments(ctx); errors.Cause(err) != context.Canceled {
+		t.Fatalf("expected canceled, got %v", err)
+	} else if !frag.dirty {
+		t.Fatal("expected fragment not to be flushed")
+	}
+	if err := api.FlushFragments(context.Background()); err != nil {
+		t.Fatal(err)
+	} else if frag.dirty {
+		t.Fatal("expected fragment to be flushed")
+	}
+}
@@ -218,16 +218,25 @@ func TestStatsCount_APICalls(t *testing.T) {
 	holder := cmd.Server.Holder()
 	hldr := test.Holder{Holder: holder}
 
+	// mock records the stats of the holder with m until the returned
+	// function is called, so that the stats recorded by other operations,
+	// such as the flushes made as the cluster closes, are not checked.
+	mock := func(m *MockStats) func() {
+		prev := hldr.Stats
+		hldr.Stats = m
+		return func() { hldr.Stats = prev }
+	}
+
 	t.Run("create index", func(t *testing.T) {
 		called := false
-		hldr.Stats = &MockStats{
+		defer mock(&MockStats{
 			mockCount: func(name string, value int64, rate float64) {
 				if name != "createIndex" {
 					t.Errorf("Expected createIndex, Results %s", name)
 				}
 				called = true
 			},
-		}
+		})()
 		w := httptest.NewRecorder()
 		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i", strings.NewReader("")))
 		if !called {
@@ -237,7 +246,7 @@ func TestStatsCount_APICalls(t *testing.T) {
 
 	t.Run("create field", func(t *testing.T) {
 		called := false
-		hldr.Stats = &MockStats{
+		defer mock(&MockStats{
 			mockCountWithTags: func(name string, value int64, rate float64, index []string) {
 				if name != "createField" {
 					t.Errorf("Expected createField, Results %s", name)
@@ -248,7 +257,7 @@ func TestStatsCount_APICalls(t *testing.T) {
 
 				called = true
 			},
-		}
+		})()
 		w := httptest.NewRecorder()
 		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i/field/f", strings.NewReader("")))
 		if !called {
@@ -258,7 +267,7 @@ func TestStatsCount_APICalls(t *testing.T) {
 
 	t.Run("delete field", func(t *testing.T) {
 		called := false
-		hldr.Stats = &MockStats{
+		defer mock(&MockStats{
 			mockCountWithTags: func(name string, value int64, rate float64, index []string) {
 				if name != "deleteField" {
 					t.Errorf("Expected deleteField, Results %s", name)
@@ -269,7 +278,7 @@ func TestStatsCount_APICalls(t *testing.T) {
 
 				called = true
 			},
-		}
+		})()
 		w := httptest.NewRecorder()
 		h.ServeHTTP(w, test.MustNewHTTPRequest("DELETE", "/index/i/field/f", strings.NewReader("")))
 		if !called {
@@ -279,7 +288,7 @@ func TestStatsCount_APICalls(t *testing.T) {
 
 	t.Run("delete index", func(t *testing.T) {
 		called := false
-		hldr.Stats = &MockStats{
+		defer mock(&MockStats{
 			mockCount: func(name string, value int64, rate float64) {
 				if name != "deleteIndex" {
 					t.Errorf("Expected deleteIndex, Results %s", name)
@@ -287,14 +296,13 @@ func TestStatsCount_APICalls(t *testing.T) {
 
 				called = true
 			},
-		}
+		})()
 		w := httptest.NewRecorder()
 		h.ServeHTTP(w, test.MustNewHTTPRequest("DELETE", "/index/i", strings.NewReader("")))
 		if !called {
 			t.Error("Count isn't called")
 		}
 	})
-
 }
 
 type MockStats struct {
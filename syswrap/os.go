@@ -48,6 +48,12 @@ func OpenFile(name string, flag int, perm os.FileMode) (file *os.File, mustClose
 	return file, mustClose, err
 }
 
+// FileCount returns the number of files opened with OpenFile which are not
+// yet closed.
+func FileCount() uint64 {
+	return atomic.LoadUint64(&fileCount)
+}
+
 // CloseFile decrements the global count of open files and closes the file.
 func CloseFile(f *os.File) error {
 	atomic.AddUint64(&fileCount, ^uint64(0)) // decrement
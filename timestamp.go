@@ -0,0 +1,148 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/pql"
+	"github.com/pkg/errors"
+)
+
+// Time units of timestamp fields. A timestamp field stores the number of
+// units elapsed since the Unix epoch.
+const (
+	TimeUnitSeconds      = "s"
+	TimeUnitMilliseconds = "ms"
+	TimeUnitMicroseconds = "us"
+	TimeUnitNanoseconds  = "ns"
+)
+
+// DefaultTimeUnit is the time unit of timestamp fields which do not set one.
+const DefaultTimeUnit = TimeUnitSeconds
+
+// timeUnitDuration returns the duration of one unit, or zero if unit is not a
+// valid time unit.
+func timeUnitDuration(unit string) time.Duration {
+	switch unit {
+	case TimeUnitSeconds:
+		return time.Second
+	case TimeUnitMilliseconds:
+		return time.Millisecond
+	case TimeUnitMicroseconds:
+		return time.Microsecond
+	case TimeUnitNanoseconds:
+		return time.Nanosecond
+	}
+	return 0
+}
+
+// ValidTimeUnit returns true if unit is a valid time unit of timestamp fields.
+func ValidTimeUnit(unit string) bool {
+	return timeUnitDuration(unit) != 0
+}
+
+// timestampToValue returns the number of units elapsed between the Unix epoch
+// and t, rounded down to whole units. It returns an error if the number does
+// not fit in an int64.
+func timestampToValue(t time.Time, unit string) (int64, error) {
+	d := timeUnitDuration(unit)
+	if d == 0 {
+		return 0, errors.Errorf("invalid time unit: %q", unit)
+	}
+	perSecond := int64(time.Second / d)
+	sec, frac := t.Unix(), int64(t.Nanosecond())/int64(d)
+	if sec < math.MinInt64/perSecond || sec > (math.MaxInt64-frac)/perSecond {
+		return 0, errors.Errorf("timestamp out of range for time unit %s: %s", unit, t.Format(time.RFC3339Nano))
+	}
+	return sec*perSecond + frac, nil
+}
+
+// valueToTimestamp returns the time v units after the Unix epoch, in UTC.
+func valueToTimestamp(v int64, unit string) time.Time {
+	d := timeUnitDuration(unit)
+	perSecond := int64(time.Second / d)
+	sec, rem := v/perSecond, v%perSecond
+	if rem < 0 {
+		sec, rem = sec-1, rem+perSecond
+	}
+	return time.Unix(sec, rem*int64(d)).UTC()
+}
+
+// ParseTimestamp parses s, either a number of units since the Unix epoch or a
+// time in RFC3339 or TimeFormat layout, to the value a timestamp field with the
+// given time unit stores for it. Times without a zone are in UTC.
+func ParseTimestamp(s string, unit string) (int64, error) {
+	if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return v, nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, TimeFormat} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return timestampToValue(t, unit)
+		}
+	}
+	return 0, errors.Errorf("invalid timestamp, expected epoch %s or RFC3339: %q", unit, s)
+}
+
+// formatTimestamp formats the value v of a timestamp field with the given
+// time unit as an RFC3339 time.
+func formatTimestamp(v int64, unit string) string {
+	return valueToTimestamp(v, unit).Format(time.RFC3339Nano)
+}
+
+// timestampValue converts a PQL value, either an integer number of units
+// since the epoch or a timestamp string, to the stored value of the field,
+// which must be a timestamp field.
+func (f *Field) timestampValue(v interface{}) (int64, error) {
+	switch v := v.(type) {
+	case int64:
+		return v, nil
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, errors.Errorf("timestamp out of range: %d", v)
+		}
+		return int64(v), nil
+	case string:
+		return ParseTimestamp(v, f.TimeUnit())
+	}
+	return 0, errors.Errorf("invalid timestamp type %T: %v", v, v)
+}
+
+// timestampCondition returns cond with its timestamp strings converted to the
+// stored values of the field, which must be a timestamp field.
+func (f *Field) timestampCondition(cond *pql.Condition) (*pql.Condition, error) {
+	switch v := cond.Value.(type) {
+	case nil:
+		return cond, nil
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i := range v {
+			value, err := f.timestampValue(v[i])
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return &pql.Condition{Op: cond.Op, Value: values}, nil
+	default:
+		value, err := f.timestampValue(v)
+		if err != nil {
+			return nil, err
+		}
+		return &pql.Condition{Op: cond.Op, Value: value}, nil
+	}
+}
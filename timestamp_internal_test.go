@@ -0,0 +1,74 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		unit string
+		exp  int64
+		fmt  string
+	}{
+		{"1577836800", TimeUnitSeconds, 1577836800, "2020-01-01T00:00:00Z"},
+		{"2020-01-01T00:00:00Z", TimeUnitSeconds, 1577836800, "2020-01-01T00:00:00Z"},
+		{"2020-01-01T01:00:00+01:00", TimeUnitSeconds, 1577836800, "2020-01-01T00:00:00Z"},
+		{"2020-01-01T00:00", TimeUnitSeconds, 1577836800, "2020-01-01T00:00:00Z"},
+		{"2020-01-01T00:00:00.123456789Z", TimeUnitMilliseconds, 1577836800123, "2020-01-01T00:00:00.123Z"},
+		{"2020-01-01T00:00:00.123456789Z", TimeUnitMicroseconds, 1577836800123456, "2020-01-01T00:00:00.123456Z"},
+		{"2020-01-01T00:00:00.123456789Z", TimeUnitNanoseconds, 1577836800123456789, "2020-01-01T00:00:00.123456789Z"},
+		{"1969-12-31T23:59:59.5Z", TimeUnitSeconds, -1, "1969-12-31T23:59:59Z"},
+		{"1969-12-31T23:59:59.5Z", TimeUnitMilliseconds, -500, "1969-12-31T23:59:59.5Z"},
+	} {
+		if v, err := ParseTimestamp(tt.s, tt.unit); err != nil {
+			t.Fatalf("parsing %q: %v", tt.s, err)
+		} else if v != tt.exp {
+			t.Fatalf("parsing %q in %s: got %d, expected %d", tt.s, tt.unit, v, tt.exp)
+		} else if s := formatTimestamp(v, tt.unit); s != tt.fmt {
+			t.Fatalf("formatting %d in %s: got %s, expected %s", v, tt.unit, s, tt.fmt)
+		}
+	}
+
+	if _, err := ParseTimestamp("tomorrow", TimeUnitSeconds); err == nil {
+		t.Fatal("expected error parsing invalid timestamp")
+	} else if _, err := ParseTimestamp("2300-01-01T00:00:00Z", TimeUnitNanoseconds); err == nil {
+		t.Fatal("expected error parsing out of range nanosecond timestamp")
+	}
+}
+
+// Ensure times whose value overflows an int64 are rejected for every unit.
+func TestTimestampToValue_Overflow(t *testing.T) {
+	for _, unit := range []string{TimeUnitSeconds, TimeUnitMilliseconds, TimeUnitMicroseconds, TimeUnitNanoseconds} {
+		perSecond := int64(time.Second / timeUnitDuration(unit))
+		maxSec := math.MaxInt64 / perSecond
+		if v, err := timestampToValue(time.Unix(maxSec, 0), unit); err != nil {
+			t.Fatalf("unexpected error for the last second in %s: %v", unit, err)
+		} else if v != maxSec*perSecond {
+			t.Fatalf("unexpected value in %s: %d", unit, v)
+		}
+		if unit == TimeUnitSeconds {
+			continue
+		} else if _, err := timestampToValue(time.Unix(maxSec+1, 0), unit); err == nil {
+			t.Fatalf("expected error for a time after the range of %s", unit)
+		} else if _, err := timestampToValue(time.Unix(math.MinInt64/perSecond-1, 0), unit); err == nil {
+			t.Fatalf("expected error for a time before the range of %s", unit)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// tombstonesDir is the name of the directory, inside the holder's data
+	// directory, to which deleted indexes are moved until they are reaped.
+	tombstonesDir = ".tombstones"
+
+	// defaultTombstoneReapInterval is the default interval at which expired
+	// tombstones are removed.
+	defaultTombstoneReapInterval = 1 * time.Minute
+)
+
+// IndexTombstone describes a deleted index whose data is retained on disk
+// until its grace period expires. Until then the index can be undeleted.
+type IndexTombstone struct {
+	Index     string    `json:"index"`
+	DeletedAt time.Time `json:"deletedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	path string
+}
+
+// Tombstones returns the tombstoned indexes, sorted by name.
+func (h *Holder) Tombstones() ([]IndexTombstone, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.tombstones()
+}
+
+// tombstones reads the tombstones from the data directory. The caller must
+// hold h.mu.
+func (h *Holder) tombstones() ([]IndexTombstone, error) {
+	fis, err := ioutil.ReadDir(filepath.Join(h.Path, tombstonesDir))
+	if os.IsNotExist(err) {
+		return []IndexTombstone{}, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "reading directory")
+	}
+
+	tombstones := make([]IndexTombstone, 0, len(fis))
+	for _, fi := range fis {
+		// Tombstone directories are named <index>.<unix nano deletion time>.
+		// Index names cannot contain dots.
+		i := strings.LastIndex(fi.Name(), ".")
+		if !fi.IsDir() || i <= 0 {
+			continue
+		}
+		nano, err := strconv.ParseInt(fi.Name()[i+1:], 10, 64)
+		if err != nil {
+			continue
+		}
+		deletedAt := time.Unix(0, nano).UTC()
+		tombstones = append(tombstones, IndexTombstone{
+			Index:     fi.Name()[:i],
+			DeletedAt: deletedAt,
+			ExpiresAt: deletedAt.Add(h.tombstoneGracePeriod),
+			path:      filepath.Join(h.Path, tombstonesDir, fi.Name()),
+		})
+	}
+	sort.Slice(tombstones, func(i, j int) bool { return tombstones[i].Index < tombstones[j].Index })
+	return tombstones, nil
+}
+
+// tombstone returns the tombstone of the named index. The caller must hold
+// h.mu.
+func (h *Holder) tombstone(name string) (*IndexTombstone, error) {
+	tombstones, err := h.tombstones()
+	if err != nil {
+		return nil, err
+	}
+	for i := range tombstones {
+		if tombstones[i].Index == name {
+			return &tombstones[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// tombstoneIndex moves the directory of the closed index to the tombstones
+// directory. An older tombstone of an index with the same name is removed.
+// The caller must hold h.mu.
+func (h *Holder) tombstoneIndex(name string) error {
+	if t, err := h.tombstone(name); err != nil {
+		return errors.Wrap(err, "reading tombstones")
+	} else if t != nil {
+		if err := os.RemoveAll(t.path); err != nil {
+			return errors.Wrap(err, "removing previous tombstone")
+		}
+	}
+
+	dir := filepath.Join(h.Path, tombstonesDir)
+	if err := os.MkdirAll(dir, h.dirPerm); err != nil {
+		return errors.Wrap(err, "creating tombstones directory")
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s.%d", name, time.Now().UnixNano()))
+	if err := os.Rename(h.IndexPath(name), path); err != nil {
+		return errors.Wrap(err, "moving index directory")
+	}
+	h.Logger.Printf("tombstoned index %s until %s", name, time.Now().Add(h.tombstoneGracePeriod).Format(time.RFC3339))
+	return nil
+}
+
+// UndeleteIndex restores a tombstoned index. Aliases of the index are not
+// restored.
+func (h *Holder) UndeleteIndex(name string) (*Index, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.index(name) != nil {
+		return nil, newConflictError(ErrIndexExists)
+	} else if _, ok := h.aliases[name]; ok {
+		return nil, newConflictError(ErrIndexAliasExists)
+	}
+
+	t, err := h.tombstone(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading tombstones")
+	} else if t == nil {
+		return nil, newNotFoundError(ErrIndexTombstoneNotFound, name)
+	}
+
+	if err := os.Rename(t.path, h.IndexPath(name)); err != nil {
+		return nil, errors.Wrap(err, "moving index directory")
+	}
+
+	// If the index cannot be opened, move it back so that it stays
+	// tombstoned and the undelete can be retried.
+	restore := func() {
+		if err := os.Rename(h.IndexPath(name), t.path); err != nil {
+			h.Logger.Printf("moving index %s back to tombstones: %s", name, err)
+		}
+	}
+	index, err := h.newIndex(h.IndexPath(name), name)
+	if err != nil {
+		restore()
+		return nil, errors.Wrap(err, "creating")
+	}
+	if err := index.Open(); err != nil {
+		if cerr := index.Close(); cerr != nil {
+			h.Logger.Printf("closing index %s after failed open: %s", name, cerr)
+		}
+		restore()
+		return nil, errors.Wrap(err, "opening")
+	}
+	h.indexes[name] = index
+
+	// Restart replication.
+	go h.refreshTranslateStoreReplicator()
+
+	h.Logger.Printf("undeleted index %s", name)
+	return index, nil
+}
+
+// monitorTombstones periodically removes tombstones whose grace period has
+// expired.
+func (h *Holder) monitorTombstones() {
+	ticker := time.NewTicker(h.tombstoneReapInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.closing:
+			return
+		case <-ticker.C:
+			if err := h.reapTombstones(time.Now()); err != nil {
+				h.Logger.Printf("reaping tombstones: %s", err)
+			}
+		}
+	}
+}
+
+// reapTombstones removes the tombstones which expired before now.
+func (h *Holder) reapTombstones(now time.Time) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	tombstones, err := h.tombstones()
+	if err != nil {
+		return errors.Wrap(err, "reading tombstones")
+	}
+	for _, t := range tombstones {
+		if t.ExpiresAt.After(now) {
+			continue
+		}
+		if err := os.RemoveAll(t.path); err != nil {
+			return errors.Wrapf(err, "removing tombstone of index %s", t.Index)
+		}
+		h.Logger.Printf("removed tombstoned index %s", t.Index)
+	}
+	return nil
+}
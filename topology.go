@@ -0,0 +1,211 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ClusterTopologyVersion is the version of the format of ClusterTopology
+// documents. It is incremented whenever the format changes incompatibly.
+const ClusterTopologyVersion = 1
+
+// ClusterTopology is a portable description of the membership and shard
+// placement of a cluster. It is exported from a running cluster and used to
+// bootstrap a new cluster with the same topology.
+type ClusterTopology struct {
+	Version   int             `json:"version"`
+	ClusterID string          `json:"clusterID"`
+	Epoch     uint64          `json:"epoch"`
+	ReplicaN  int             `json:"replicaN"`
+	Nodes     []*TopologyNode `json:"nodes"`
+}
+
+// TopologyNode is a node of a ClusterTopology. Shards are placed by node ID,
+// so a bootstrapped node takes over the ID of the node with its URI.
+type TopologyNode struct {
+	ID            string `json:"id"`
+	URI           URI    `json:"uri"`
+	Zone          string `json:"zone,omitempty"`
+	IsCoordinator bool   `json:"isCoordinator"`
+}
+
+// DecodeClusterTopology reads a ClusterTopology document from r and
+// validates it.
+func DecodeClusterTopology(r io.Reader) (*ClusterTopology, error) {
+	var t ClusterTopology
+	if err := json.NewDecoder(r).Decode(&t); err != nil {
+		return nil, errors.Wrap(err, "decoding topology")
+	}
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (t *ClusterTopology) validate() error {
+	if t.Version != ClusterTopologyVersion {
+		return errors.Errorf("unsupported topology version %d, expected %d", t.Version, ClusterTopologyVersion)
+	} else if t.ClusterID == "" {
+		return errors.New("topology has no cluster ID")
+	} else if len(t.Nodes) == 0 {
+		return errors.New("topology has no nodes")
+	} else if t.ReplicaN < 1 || t.ReplicaN > len(t.Nodes) {
+		return errors.Errorf("invalid topology replica count %d for %d nodes", t.ReplicaN, len(t.Nodes))
+	}
+
+	ids := make(map[string]struct{}, len(t.Nodes))
+	var coordinators int
+	for _, n := range t.Nodes {
+		if n.ID == "" {
+			return errors.New("topology node has no ID")
+		} else if _, ok := ids[n.ID]; ok {
+			return errors.Errorf("duplicate topology node ID: %s", n.ID)
+		}
+		ids[n.ID] = struct{}{}
+		if n.IsCoordinator {
+			coordinators++
+		}
+	}
+	if coordinators != 1 {
+		return errors.Errorf("topology must have exactly one coordinator, has %d", coordinators)
+	}
+	return nil
+}
+
+// node returns the node of t whose ID is id, or, if id is empty, whose URI
+// is uri. It returns nil if there is none.
+func (t *ClusterTopology) node(id string, uri URI) *TopologyNode {
+	for _, n := range t.Nodes {
+		if id != "" && n.ID == id {
+			return n
+		} else if id == "" && n.URI == uri {
+			return n
+		}
+	}
+	return nil
+}
+
+// exportTopology returns the topology of the cluster. The nodes of the
+// topology are listed even if they are currently down; the URI and zone of
+// nodes which the cluster has not heard from are unknown.
+func (c *cluster) exportTopology() *ClusterTopology {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ids := c.Topology.nodeIDs
+	if len(ids) == 0 {
+		for _, n := range c.nodes {
+			ids = append(ids, n.ID)
+		}
+	}
+
+	t := &ClusterTopology{
+		Version:   ClusterTopologyVersion,
+		ClusterID: c.Topology.clusterID,
+		Epoch:     c.unprotectedEpoch(),
+		ReplicaN:  c.ReplicaN,
+		Nodes:     make([]*TopologyNode, 0, len(ids)),
+	}
+	if t.ClusterID == "" {
+		t.ClusterID = c.id
+	}
+	for _, id := range ids {
+		tn := &TopologyNode{ID: id, IsCoordinator: id == c.Coordinator}
+		if n := c.unprotectedNodeByID(id); n != nil {
+			tn.URI = n.URI
+			tn.Zone = n.Zone
+		}
+		t.Nodes = append(t.Nodes, tn)
+	}
+	sort.Slice(t.Nodes, func(i, j int) bool { return t.Nodes[i].ID < t.Nodes[j].ID })
+	return t
+}
+
+// bootstrapTopology applies the bootstrap topology to the node: the node
+// takes over the ID, zone and coordinator role of its node in the topology,
+// and the topology is written to its data directory. The topology is only
+// written once, so that a bootstrapped node can restart with the same
+// options; bootstrapping a node of another cluster fails. Called before the
+// node ID is loaded.
+func (s *Server) bootstrapTopology() error {
+	t := s.bootstrap
+	if err := t.validate(); err != nil {
+		return errors.Wrap(err, "validating topology")
+	}
+
+	// Refuse to bootstrap a node which is already part of a cluster.
+	if err := s.cluster.loadTopology(); err != nil {
+		return errors.Wrap(err, "loading topology")
+	}
+	existing := s.cluster.Topology.clusterID
+	bootstrapped := existing == t.ClusterID
+	if !bootstrapped && (existing != "" || len(s.cluster.Topology.nodeIDs) > 0) {
+		return errors.Errorf("data directory already holds the topology of cluster %s; a topology can only bootstrap new nodes", existing)
+	}
+
+	idPath := filepath.Join(s.holder.Path, ".id")
+	id := s.nodeID
+	if id == "" {
+		if buf, err := ioutil.ReadFile(idPath); err == nil {
+			id = strings.TrimSpace(string(buf))
+		} else if !os.IsNotExist(err) {
+			return errors.Wrap(err, "reading node ID")
+		}
+	}
+	node := t.node(id, s.uri)
+	if node == nil && id != "" {
+		if node = t.node("", s.uri); node != nil {
+			return errors.Errorf("node ID %s does not match ID %s of the topology node with URI %s", id, node.ID, s.uri)
+		}
+	}
+	if node == nil {
+		return errors.Errorf("no topology node with URI %s", s.uri)
+	}
+
+	if err := os.MkdirAll(s.holder.Path, s.holder.dirPerm); err != nil {
+		return errors.Wrap(err, "creating directory")
+	} else if err := ioutil.WriteFile(idPath, []byte(node.ID), s.holder.privateFilePerm); err != nil {
+		return errors.Wrap(err, "writing node ID")
+	}
+	s.nodeID = node.ID
+	s.isCoordinator = node.IsCoordinator
+	if node.Zone != "" {
+		s.zone = node.Zone
+	}
+	s.cluster.ReplicaN = t.ReplicaN
+	if bootstrapped {
+		return nil
+	}
+
+	s.logger.Printf("bootstrapping node %s of cluster %s", node.ID, t.ClusterID)
+	top := newTopology()
+	top.clusterID = t.ClusterID
+	top.epoch = t.Epoch
+	for _, n := range t.Nodes {
+		top.nodeIDs = append(top.nodeIDs, n.ID)
+	}
+	sort.Strings(top.nodeIDs)
+	s.cluster.Topology = top
+	return errors.Wrap(s.cluster.saveTopology(), "saving topology")
+}
@@ -0,0 +1,113 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeClusterTopology(t *testing.T) {
+	for _, tt := range []struct {
+		doc string
+		err string
+	}{
+		{doc: `{"version":1,"clusterID":"c","replicaN":1,"nodes":[{"id":"n0","isCoordinator":true}]}`},
+		{doc: `{"version":2,"clusterID":"c","replicaN":1,"nodes":[{"id":"n0","isCoordinator":true}]}`, err: "unsupported topology version 2"},
+		{doc: `{"version":1,"replicaN":1,"nodes":[{"id":"n0","isCoordinator":true}]}`, err: "no cluster ID"},
+		{doc: `{"version":1,"clusterID":"c","replicaN":2,"nodes":[{"id":"n0","isCoordinator":true}]}`, err: "invalid topology replica count"},
+		{doc: `{"version":1,"clusterID":"c","replicaN":1,"nodes":[{"id":"n0"},{"id":"n0","isCoordinator":true}]}`, err: "duplicate topology node ID"},
+		{doc: `{"version":1,"clusterID":"c","replicaN":1,"nodes":[{"id":"n0"},{"id":"n1"}]}`, err: "exactly one coordinator"},
+	} {
+		_, err := DecodeClusterTopology(strings.NewReader(tt.doc))
+		if tt.err == "" && err != nil {
+			t.Errorf("decoding %s: %v", tt.doc, err)
+		} else if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+			t.Errorf("decoding %s: expected error %q, got %v", tt.doc, tt.err, err)
+		}
+	}
+}
+
+func TestServer_BootstrapTopology(t *testing.T) {
+	topology := &ClusterTopology{
+		Version:   ClusterTopologyVersion,
+		ClusterID: "c0",
+		Epoch:     4,
+		ReplicaN:  2,
+		Nodes: []*TopologyNode{
+			{ID: "node0", URI: URI{Scheme: "http", Host: "host0", Port: 10101}, IsCoordinator: true},
+			{ID: "node1", URI: URI{Scheme: "http", Host: "host1", Port: 10101}, Zone: "z1"},
+		},
+	}
+	uri := &URI{Scheme: "http", Host: "host1", Port: 10101}
+
+	td, err := ioutil.TempDir(*TempDir, "")
+	if err != nil {
+		t.Fatalf("getting temp dir: %v", err)
+	}
+	defer os.RemoveAll(td)
+
+	newServer := func(topology *ClusterTopology, opts ...ServerOption) (*Server, error) {
+		return NewServer(append([]ServerOption{
+			OptServerDataDir(td),
+			OptServerURI(uri),
+			OptServerBootstrapTopology(topology),
+		}, opts...)...)
+	}
+
+	s, err := newServer(topology)
+	if err != nil {
+		t.Fatal(err)
+	} else if s.nodeID != "node1" || s.isCoordinator || s.zone != "z1" || s.cluster.ReplicaN != 2 {
+		t.Fatalf("unexpected node: id=%s coordinator=%v zone=%s replicas=%d", s.nodeID, s.isCoordinator, s.zone, s.cluster.ReplicaN)
+	} else if ids := s.cluster.Topology.nodeIDs; !reflect.DeepEqual(ids, []string{"node0", "node1"}) {
+		t.Fatalf("unexpected topology: %v", ids)
+	} else if s.cluster.Topology.clusterID != "c0" || s.cluster.Epoch() != 4 {
+		t.Fatalf("unexpected cluster: id=%s epoch=%d", s.cluster.Topology.clusterID, s.cluster.Epoch())
+	} else if info, err := os.Stat(filepath.Join(td, ".id")); err != nil {
+		t.Fatal(err)
+	} else if mode := info.Mode().Perm(); mode != defaultPrivateFilePerm {
+		t.Fatalf("unexpected mode of node ID: %#o", mode)
+	}
+
+	// The bootstrapped node restarts with the same topology, and takes its
+	// role from it again.
+	if s, err := newServer(topology, OptServerReplicaN(1)); err != nil {
+		t.Fatalf("restarting: %v", err)
+	} else if s.nodeID != "node1" || s.cluster.ReplicaN != 2 {
+		t.Fatalf("unexpected restarted node: id=%s replicas=%d", s.nodeID, s.cluster.ReplicaN)
+	}
+
+	// A node of another cluster cannot be bootstrapped.
+	other := *topology
+	other.ClusterID = "c1"
+	if _, err := newServer(&other); err == nil || !strings.Contains(err.Error(), "already holds the topology of cluster c0") {
+		t.Fatalf("expected existing topology error, got %v", err)
+	}
+
+	// A node must be listed in the topology.
+	uri = &URI{Scheme: "http", Host: "host2", Port: 10101}
+	if td, err = ioutil.TempDir(*TempDir, ""); err != nil {
+		t.Fatalf("getting temp dir: %v", err)
+	}
+	defer os.RemoveAll(td)
+	if _, err := newServer(topology); err == nil || !strings.Contains(err.Error(), "no topology node with URI") {
+		t.Fatalf("expected missing node error, got %v", err)
+	}
+}
@@ -46,6 +46,10 @@ type TranslateStore interface {
 	TranslateKey(key string) (uint64, error)
 	TranslateKeys(key []string) ([]uint64, error)
 
+	// Returns the ID of a string key without creating it, or zero if the
+	// key does not exist.
+	FindKey(key string) (uint64, error)
+
 	// Converts an integer ID to its associated string key.
 	TranslateID(id uint64) (string, error)
 	TranslateIDs(id []uint64) ([]string, error)
@@ -268,6 +272,14 @@ func (s *InMemTranslateStore) TranslateKeys(keys []string) ([]uint64, error) {
 	return ids, nil
 }
 
+// FindKey returns the ID of a string key without creating it.
+// Returns zero if the key does not exist.
+func (s *InMemTranslateStore) FindKey(key string) (uint64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lookup[key], nil
+}
+
 func (s *InMemTranslateStore) translateKey(key string) uint64 {
 	// Return id if it has been added.
 	if id, ok := s.lookup[key]; ok {
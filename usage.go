@@ -0,0 +1,151 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// StorageUsage reports the storage used by a set of fragments.
+type StorageUsage struct {
+	Fragments int `json:"fragments"`
+
+	// DiskBytes is the size of the data and cache files of the fragments.
+	DiskBytes int64 `json:"diskBytes"`
+
+	// MemoryBytes is the approximate size of the fragments' bitmaps in
+	// memory, including the containers mapped from their data files.
+	MemoryBytes int64 `json:"memoryBytes"`
+
+	// CompressedBytes and UncompressedBytes are the sizes of the bitmaps of
+	// the compressed data files of the fragments, compressed and
+	// uncompressed.
+	CompressedBytes   int64 `json:"compressedBytes"`
+	UncompressedBytes int64 `json:"uncompressedBytes"`
+}
+
+func (u *StorageUsage) add(other StorageUsage) {
+	u.Fragments += other.Fragments
+	u.DiskBytes += other.DiskBytes
+	u.MemoryBytes += other.MemoryBytes
+	u.CompressedBytes += other.CompressedBytes
+	u.UncompressedBytes += other.UncompressedBytes
+}
+
+// compressionRatio returns the ratio of the uncompressed to the compressed
+// size of the compressed data files, or 1 if none are compressed.
+func (u *StorageUsage) compressionRatio() float64 {
+	if u.CompressedBytes == 0 {
+		return 1
+	}
+	return float64(u.UncompressedBytes) / float64(u.CompressedBytes)
+}
+
+// IndexUsage reports the storage used by the fragments of an index, in total
+// and by field.
+type IndexUsage struct {
+	StorageUsage
+	Fields map[string]StorageUsage `json:"fields"`
+
+	// CompressionRatio is the ratio of the uncompressed to the compressed
+	// size of the compressed data files of the index.
+	CompressionRatio float64 `json:"compressionRatio"`
+}
+
+// usage returns the storage used by the fragment.
+func (f *fragment) usage() StorageUsage {
+	u := StorageUsage{Fragments: 1}
+	for _, path := range []string{f.path, f.cachePath()} {
+		if fi, err := os.Stat(path); err == nil {
+			u.DiskBytes += fi.Size()
+		}
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.storage != nil {
+		u.MemoryBytes = int64(f.storage.Size())
+	}
+	u.CompressedBytes, u.UncompressedBytes = f.compressedBytes, f.uncompressedBytes
+	return u
+}
+
+// usage returns the storage used by the fragments of every view of the field.
+func (f *Field) usage() StorageUsage {
+	var u StorageUsage
+	for _, view := range f.views() {
+		for _, frag := range view.allFragments() {
+			u.add(frag.usage())
+		}
+	}
+	return u
+}
+
+// Usage returns the storage used by the fragments of each index, or of the
+// given index only, by index name. It returns a NotFoundError if the given
+// index does not exist.
+func (h *Holder) Usage(index string) (map[string]IndexUsage, error) {
+	indexes := h.Indexes()
+	if index != "" {
+		idx := h.Index(index)
+		if idx == nil {
+			return nil, newNotFoundError(ErrIndexNotFound, index)
+		}
+		indexes = []*Index{idx}
+	}
+
+	m := make(map[string]IndexUsage, len(indexes))
+	for _, idx := range indexes {
+		iu := IndexUsage{Fields: make(map[string]StorageUsage)}
+		for _, field := range idx.Fields() {
+			fu := field.usage()
+			iu.Fields[field.Name()] = fu
+			iu.add(fu)
+		}
+		iu.CompressionRatio = iu.compressionRatio()
+		m[idx.Name()] = iu
+	}
+	return m, nil
+}
+
+// monitorUsage periodically reports the storage used by each field, and the
+// compression ratio of each index, to the stats client.
+func (s *Server) monitorUsage() {
+	ticker := time.NewTicker(s.usageInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.closing:
+			return
+		case <-ticker.C:
+		}
+
+		for _, idx := range s.holder.Indexes() {
+			var iu StorageUsage
+			for _, field := range idx.Fields() {
+				u := field.usage()
+				iu.add(u)
+				stats := field.Stats.WithTags(fmt.Sprintf("field:%s", field.Name()))
+				stats.Gauge("fragments", float64(u.Fragments), 1.0)
+				stats.Gauge("diskBytes", float64(u.DiskBytes), 1.0)
+				stats.Gauge("memoryBytes", float64(u.MemoryBytes), 1.0)
+			}
+			idx.Stats.Gauge("compressionRatio", iu.compressionRatio(), 1.0)
+		}
+	}
+}
@@ -51,6 +51,11 @@ type view struct {
 	fieldType string
 	cacheType string
 	cacheSize uint32
+	base      int64 // base of the values of a BSI view
+
+	// The modes with which directories and files are created.
+	dirPerm  os.FileMode
+	filePerm os.FileMode
 
 	// Fragments by shard.
 	fragments map[uint64]*fragment
@@ -60,6 +65,14 @@ type view struct {
 	rowAttrStore  AttrStore
 	logger        logger.Logger
 	snapshotQueue chan *fragment
+	generation    *generation
+	compression   *fragmentCompression
+	changes       *changeFeed
+	durability    string
+
+	// If non-nil, fragments which fail to open are moved aside and
+	// recorded here instead of failing the open.
+	quarantine *fragmentQuarantine
 }
 
 // newView returns a new instance of View.
@@ -73,9 +86,13 @@ func newView(path, index, field, name string, fieldOptions FieldOptions) *view {
 		fieldType: fieldOptions.Type,
 		cacheType: fieldOptions.CacheType,
 		cacheSize: fieldOptions.CacheSize,
+		base:      fieldOptions.Base,
 
 		fragments: make(map[uint64]*fragment),
 
+		dirPerm:  DefaultDirPerm,
+		filePerm: DefaultFilePerm,
+
 		broadcaster: NopBroadcaster,
 		stats:       stats.NopStatsClient,
 		logger:      logger.NopLogger,
@@ -93,9 +110,9 @@ func (v *view) open() error {
 	if err := func() error {
 		// Ensure the view's path exists.
 		v.logger.Debugf("ensure view path exists: %s", v.path)
-		if err := os.MkdirAll(v.path, 0777); err != nil {
+		if err := os.MkdirAll(v.path, v.dirPerm); err != nil {
 			return errors.Wrap(err, "creating view directory")
-		} else if err := os.MkdirAll(filepath.Join(v.path, "fragments"), 0777); err != nil {
+		} else if err := os.MkdirAll(filepath.Join(v.path, "fragments"), v.dirPerm); err != nil {
 			return errors.Wrap(err, "creating fragments directory")
 		}
 
@@ -161,7 +178,13 @@ fileLoop:
 				}()
 				frag := v.newFragment(v.fragmentPath(shard), shard)
 				if err := frag.Open(); err != nil {
-					return fmt.Errorf("open fragment: shard=%d, err=%s", frag.shard, err)
+					if v.quarantine == nil {
+						return fmt.Errorf("open fragment: shard=%d, err=%s", frag.shard, err)
+					}
+					if qerr := v.quarantineFragment(frag, err); qerr != nil {
+						return fmt.Errorf("quarantine fragment: shard=%d, open err=%s, err=%s", frag.shard, err, qerr)
+					}
+					return nil
 				}
 				frag.RowAttrStore = v.rowAttrStore
 				v.logger.Debugf("add index/field/view/fragment to view.fragments: %s/%s/%s/%d", v.index, v.field, v.name, shard)
@@ -175,6 +198,39 @@ fileLoop:
 	return eg.Wait()
 }
 
+// quarantineFragment moves the files of a fragment which could not be opened
+// into the view's quarantine directory and records it with the holder so that
+// the shard can be restored by anti-entropy.
+func (v *view) quarantineFragment(frag *fragment, openErr error) error {
+	v.logger.Printf("quarantining corrupt fragment: index=%s, field=%s, view=%s, shard=%d, err=%s", v.index, v.field, v.name, frag.shard, openErr)
+
+	dir := filepath.Join(v.path, "fragments", quarantineDir)
+	if err := os.MkdirAll(dir, v.dirPerm); err != nil {
+		return errors.Wrap(err, "creating quarantine directory")
+	}
+
+	// Suffix with a timestamp so a repeatedly corrupted shard doesn't
+	// overwrite an earlier copy.
+	dst := filepath.Join(dir, fmt.Sprintf("%d.%d", frag.shard, time.Now().UnixNano()))
+	if err := os.Rename(frag.path, dst); err != nil {
+		return errors.Wrap(err, "moving fragment")
+	}
+	if err := os.Rename(frag.cachePath(), dst+cacheExt); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "moving fragment cache")
+	}
+
+	v.quarantine.add(QuarantinedFragment{
+		Index: v.index,
+		Field: v.field,
+		View:  v.name,
+		Shard: frag.shard,
+		Path:  dst,
+		Error: openErr.Error(),
+		Time:  time.Now().UTC(),
+	})
+	return nil
+}
+
 // close closes the view and its fragments.
 func (v *view) close() error {
 	v.mu.Lock()
@@ -210,7 +266,7 @@ fragLoop:
 // flags returns a set of flags for the underlying fragments.
 func (v *view) flags() byte {
 	var flag byte
-	if v.fieldType == FieldTypeInt {
+	if v.fieldType == FieldTypeInt || v.fieldType == FieldTypeTimestamp {
 		flag |= roaringFlagBSIv2
 	}
 	return flag
@@ -310,6 +366,12 @@ func (v *view) newFragment(path string, shard uint64) *fragment {
 	frag.Logger = v.logger
 	frag.stats = v.stats
 	frag.snapshotQueue = v.snapshotQueue
+	frag.generation = v.generation
+	frag.compression = v.compression
+	frag.changes = v.changes
+	frag.base = v.base
+	frag.durability = v.durability
+	frag.filePerm = v.filePerm
 	if v.fieldType == FieldTypeMutex {
 		frag.mutexVector = newRowsVector(frag)
 	} else if v.fieldType == FieldTypeBool {
@@ -345,6 +407,7 @@ func (v *view) deleteFragment(shard uint64) error {
 	}
 
 	delete(v.fragments, shard)
+	v.generation.bump()
 
 	return nil
 }
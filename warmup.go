@@ -0,0 +1,241 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/pql"
+	"github.com/pkg/errors"
+)
+
+// warmupQuery is a query recorded for replay on startup. The query is
+// recorded once its keys are translated, so that it can run against the local
+// shards alone.
+type warmupQuery struct {
+	Index  string   `json:"index"`
+	Query  string   `json:"query"`
+	Shards []uint64 `json:"shards,omitempty"`
+}
+
+// warmupLog records a sample of the read queries executed by a node to a
+// file, one JSON object per line, and replays them on startup to warm caches
+// before the node reports ready. Once the file holds twice maxQueries queries
+// it is rewritten with the most recent maxQueries, which bounds its size.
+type warmupLog struct {
+	path       string
+	sampleRate float64
+	maxQueries int
+	timeout    time.Duration
+	filePerm   os.FileMode
+
+	mu     sync.Mutex
+	file   *os.File
+	recent []warmupQuery
+	n      int // number of queries in the file
+	rand   *rand.Rand
+}
+
+func newWarmupLog(path string, sampleRate float64, maxQueries int, timeout time.Duration) *warmupLog {
+	return &warmupLog{
+		path:       path,
+		sampleRate: sampleRate,
+		maxQueries: maxQueries,
+		timeout:    timeout,
+		filePerm:   DefaultFilePerm,
+		rand:       rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// open reads the recorded queries, keeping the most recent maxQueries, and
+// opens the file for recording. It returns the queries to replay. Lines which
+// cannot be decoded are dropped.
+func (l *warmupLog) open() ([]warmupQuery, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.Open(l.path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "opening warmup log")
+	} else if err == nil {
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(nil, 1<<24)
+		for scanner.Scan() {
+			var q warmupQuery
+			if err := json.Unmarshal(scanner.Bytes(), &q); err != nil || q.Index == "" {
+				continue
+			}
+			l.recent = append(l.recent, q)
+			if len(l.recent) > l.maxQueries {
+				l.recent = l.recent[1:]
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, errors.Wrap(err, "reading warmup log")
+		}
+	}
+
+	if err := l.rewrite(); err != nil {
+		return nil, err
+	}
+	return append([]warmupQuery(nil), l.recent...), nil
+}
+
+// rewrite replaces the file with the recent queries and reopens it for
+// appending.
+func (l *warmupLog) rewrite() error {
+	if l.file != nil {
+		if err := l.file.Close(); err != nil {
+			return errors.Wrap(err, "closing warmup log")
+		}
+		l.file = nil
+	}
+
+	tmp := l.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, l.filePerm)
+	if err != nil {
+		return errors.Wrap(err, "creating warmup log")
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, q := range l.recent {
+		if err := enc.Encode(q); err != nil {
+			f.Close()
+			return errors.Wrap(err, "writing warmup log")
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return errors.Wrap(err, "writing warmup log")
+	} else if err := f.Close(); err != nil {
+		return errors.Wrap(err, "closing warmup log")
+	} else if err := os.Rename(tmp, l.path); err != nil {
+		return errors.Wrap(err, "renaming warmup log")
+	}
+
+	if l.file, err = os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND, l.filePerm); err != nil {
+		return errors.Wrap(err, "opening warmup log")
+	}
+	l.n = len(l.recent)
+	return nil
+}
+
+// record samples an executed query. Queries which write are never recorded.
+func (l *warmupLog) record(index string, q *pql.Query, shards []uint64) error {
+	if l == nil || isWriteQuery(q) {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil || l.rand.Float64() >= l.sampleRate {
+		return nil
+	}
+
+	wq := warmupQuery{Index: index, Query: q.String(), Shards: shards}
+	buf, err := json.Marshal(wq)
+	if err != nil {
+		return errors.Wrap(err, "marshalling warmup query")
+	}
+	l.recent = append(l.recent, wq)
+	if len(l.recent) > l.maxQueries {
+		l.recent = l.recent[1:]
+	}
+
+	if l.n+1 >= 2*l.maxQueries {
+		return l.rewrite()
+	}
+	if _, err := l.file.Write(append(buf, '\n')); err != nil {
+		return errors.Wrap(err, "writing warmup log")
+	}
+	l.n++
+	return nil
+}
+
+// close closes the file.
+func (l *warmupLog) close() error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return errors.Wrap(err, "closing warmup log")
+}
+
+// replayWarmup executes the read queries of queries against the shards of
+// the local node, discarding the results. Queries which write, or which
+// cannot be parsed or executed, are skipped. It returns the number of queries
+// replayed and skipped.
+func (e *executor) replayWarmup(ctx context.Context, queries []warmupQuery) (replayed, skipped int) {
+	for _, wq := range queries {
+		if ctx.Err() != nil {
+			return replayed, skipped + len(queries) - replayed - skipped
+		}
+
+		q, err := pql.NewParser(strings.NewReader(wq.Query)).Parse()
+		if err != nil || isWriteQuery(q) {
+			skipped++
+			continue
+		}
+		idx := e.Holder.Index(wq.Index)
+		if idx == nil {
+			skipped++
+			continue
+		}
+		shards := e.Cluster.containsShards(idx.Name(), idx.AvailableShards(), e.Node)
+		if len(wq.Shards) > 0 {
+			shards = intersectShards(shards, wq.Shards)
+		}
+		if len(shards) == 0 {
+			skipped++
+			continue
+		}
+
+		if _, err := e.Execute(ctx, idx.Name(), q, shards, &execOptions{Remote: true}); err != nil {
+			skipped++
+			continue
+		}
+		replayed++
+	}
+	return replayed, skipped
+}
+
+// intersectShards returns the shards of a which are also in b.
+func intersectShards(a, b []uint64) []uint64 {
+	m := make(map[uint64]struct{}, len(b))
+	for _, shard := range b {
+		m[shard] = struct{}{}
+	}
+	var shards []uint64
+	for _, shard := range a {
+		if _, ok := m[shard]; ok {
+			shards = append(shards, shard)
+		}
+	}
+	return shards
+}
@@ -0,0 +1,122 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pilosa/pilosa/v2/pql"
+)
+
+// Ensure the warmup log records read queries only, bounds its file, and
+// returns the most recent queries when reopened.
+func TestWarmupLog(t *testing.T) {
+	td, err := ioutil.TempDir(*TempDir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(td)
+	path := filepath.Join(td, "warmup.log")
+
+	l := newWarmupLog(path, 1, 3, time.Minute)
+	if queries, err := l.open(); err != nil {
+		t.Fatal(err)
+	} else if len(queries) != 0 {
+		t.Fatalf("unexpected queries: %v", queries)
+	}
+	for i := 0; i < 10; i++ {
+		for _, s := range []string{fmt.Sprintf("Count(Row(f=%d))", i), fmt.Sprintf("Set(%d, f=1)", i)} {
+			q, err := pql.ParseString(s)
+			if err != nil {
+				t.Fatal(err)
+			} else if err := l.record("i", q, nil); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if buf, err := ioutil.ReadFile(path); err != nil {
+			t.Fatal(err)
+		} else if n := bytes.Count(buf, []byte("\n")); n > 6 {
+			t.Fatalf("expected at most 6 queries in the file, got %d", n)
+		}
+	}
+	if err := l.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	l = newWarmupLog(path, 1, 3, time.Minute)
+	queries, err := l.open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.close()
+	if got := fmt.Sprint(queries); got != "[{i Count(Row(f=7)) []} {i Count(Row(f=8)) []} {i Count(Row(f=9)) []}]" {
+		t.Fatalf("unexpected queries: %s", got)
+	}
+}
+
+// Ensure recorded queries are replayed against local shards, skipping any
+// which write or fail.
+func TestExecutor_ReplayWarmup(t *testing.T) {
+	cluster := NewTestCluster(1)
+	e := newExecutor()
+	defer e.Close()
+	e.Node = cluster.Node
+	e.Cluster = cluster
+	e.Holder = NewHolder()
+	e.Holder.Path, _ = ioutil.TempDir(*TempDir, "")
+	defer os.RemoveAll(e.Holder.Path)
+	if err := e.Holder.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Holder.Close()
+
+	idx, err := e.Holder.CreateIndex("i", IndexOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := idx.CreateField("f")
+	if err != nil {
+		t.Fatal(err)
+	} else if _, err := f.SetBit(1, 2*ShardWidth, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	replayed, skipped := e.replayWarmup(context.Background(), []warmupQuery{
+		{Index: "i", Query: "Count(Row(f=1))"},
+		{Index: "i", Query: "TopN(f)", Shards: []uint64{2, 5}},
+		{Index: "i", Query: "Set(1, f=1)"},
+		{Index: "i", Query: "Row(f=1)", Shards: []uint64{5}},
+		{Index: "i", Query: "Row(nope=1)"},
+		{Index: "missing", Query: "Row(f=1)"},
+		{Index: "i", Query: "Row("},
+	})
+	if replayed != 2 || skipped != 5 {
+		t.Fatalf("expected 2 replayed and 5 skipped, got %d and %d", replayed, skipped)
+	}
+
+	// Replaying stops with the context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if replayed, skipped := e.replayWarmup(ctx, []warmupQuery{{Index: "i", Query: "Count(Row(f=1))"}}); replayed != 0 || skipped != 1 {
+		t.Fatalf("expected the query to be skipped, got %d replayed and %d skipped", replayed, skipped)
+	}
+}